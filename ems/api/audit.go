@@ -0,0 +1,174 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	oapi "pi-wegrzyn/ems/api/oapi/generated"
+	"pi-wegrzyn/ems/auth"
+	"pi-wegrzyn/ems/storage"
+	"pi-wegrzyn/ems/templates"
+)
+
+const auditPageLimit = 500
+
+func (s *Server) GetAudit(ctx context.Context, request oapi.GetAuditRequestObject) (oapi.GetAuditResponseObject, error) {
+	filter := auditFilter(request.Params.Actor, request.Params.Action, request.Params.Target)
+	filter.Limit = auditPageLimit
+
+	entries, err := s.repository.AuditEntries(ctx, filter)
+	if err != nil {
+		slog.ErrorContext(ctx, "error getting audit log", slog.Any("error", err))
+		return oapi.GetAudit500JSONResponse{
+			PageErrorJSONResponse: oapi.PageErrorJSONResponse{
+				Error:        "error getting audit log",
+				ErrorDetails: ptr(err.Error()),
+			},
+		}, nil
+	}
+
+	page, err := s.templateEx.ExecuteAudit(templates.AuditPageContent(filter, entries))
+	if err != nil {
+		slog.ErrorContext(ctx, "error executing template", slog.Any("error", err))
+		return oapi.GetAudit500JSONResponse{
+			PageErrorJSONResponse: oapi.PageErrorJSONResponse{
+				Error:        "error executing template",
+				ErrorDetails: ptr(err.Error()),
+			},
+		}, nil
+	}
+
+	return oapi.GetAudit200TexthtmlResponse{
+		PageTexthtmlResponse: oapi.PageTexthtmlResponse{
+			Body:          page,
+			ContentLength: int64(page.Len()),
+		},
+	}, nil
+}
+
+func (s *Server) GetAuditExport(ctx context.Context, request oapi.GetAuditExportRequestObject) (oapi.GetAuditExportResponseObject, error) {
+	entries, err := s.repository.AuditEntries(ctx, auditFilter(request.Params.Actor, request.Params.Action, request.Params.Target))
+	if err != nil {
+		slog.ErrorContext(ctx, "error getting audit log", slog.Any("error", err))
+		return oapi.GetAuditExport500JSONResponse{
+			PageErrorJSONResponse: oapi.PageErrorJSONResponse{
+				Error:        "error getting audit log",
+				ErrorDetails: ptr(err.Error()),
+			},
+		}, nil
+	}
+
+	filename := fmt.Sprintf("ems-audit-%s.%s", time.Now().Format("20060102-150405"), request.Params.Format)
+	headers := oapi.GetAuditExport200ResponseHeaders{
+		ContentDisposition: fmt.Sprintf("attachment; filename=%q", filename),
+	}
+
+	if request.Params.Format == oapi.GetAuditExportParamsFormatJson {
+		body := make([]oapi.AuditEntry, 0, len(entries))
+		for _, e := range entries {
+			changes := make([]oapi.AuditChange, 0, len(e.Changes))
+			for _, c := range e.Changes {
+				changes = append(changes, oapi.AuditChange{Field: c.Field, Before: c.Before, After: c.After})
+			}
+
+			body = append(body, oapi.AuditEntry{
+				Id:         e.ID,
+				Time:       e.Created,
+				Actor:      e.Actor,
+				Action:     e.Action,
+				TargetType: e.TargetType,
+				TargetId:   e.TargetID,
+				TargetName: e.TargetName,
+				Changes:    changes,
+			})
+		}
+
+		return oapi.GetAuditExport200JSONResponse{Body: body, Headers: headers}, nil
+	}
+
+	buf, err := auditCSV(entries)
+	if err != nil {
+		slog.ErrorContext(ctx, "error writing csv", slog.Any("error", err))
+		return oapi.GetAuditExport500JSONResponse{
+			PageErrorJSONResponse: oapi.PageErrorJSONResponse{
+				Error:        "error writing csv",
+				ErrorDetails: ptr(err.Error()),
+			},
+		}, nil
+	}
+
+	return oapi.GetAuditExport200TextcsvResponse{
+		Body:          buf,
+		Headers:       headers,
+		ContentLength: int64(buf.Len()),
+	}, nil
+}
+
+// audit records a configuration change made by the signed-in user. Failures
+// are only logged, the change itself has already been applied.
+func (s *Server) audit(ctx context.Context, action string, targetType string, id uint, name string, before, after map[string]string) {
+	user, _ := auth.UserFromContext(ctx)
+
+	if err := s.repository.CreateAuditEntry(ctx, storage.AuditEntry{
+		Actor:      user.Login,
+		Action:     action,
+		TargetType: targetType,
+		TargetID:   id,
+		TargetName: name,
+		Changes:    storage.Diff(before, after),
+	}); err != nil {
+		slog.ErrorContext(ctx, "cannot write audit log", slog.Any("action", action), slog.Any("targetID", id), slog.Any("error", err))
+	}
+}
+
+func auditFilter(actor *string, action *string, target *string) storage.AuditFilter {
+	filter := storage.AuditFilter{Limit: 1 << 20}
+	if actor != nil {
+		filter.Actor = strings.TrimSpace(*actor)
+	}
+	if action != nil {
+		filter.Action = *action
+	}
+	if target != nil {
+		filter.TargetName = strings.TrimSpace(*target)
+	}
+
+	return filter
+}
+
+func auditCSV(entries []storage.AuditEntry) (*bytes.Buffer, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+
+	if err := w.Write([]string{"time", "actor", "action", "target_type", "target_id", "target_name", "changes"}); err != nil {
+		return nil, err
+	}
+
+	for _, e := range entries {
+		changes := make([]string, 0, len(e.Changes))
+		for _, c := range e.Changes {
+			changes = append(changes, c.String())
+		}
+
+		if err := w.Write([]string{
+			e.Created.Format(time.RFC3339),
+			e.Actor,
+			e.Action,
+			e.TargetType,
+			fmt.Sprint(e.TargetID),
+			e.TargetName,
+			strings.Join(changes, "; "),
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	w.Flush()
+
+	return &buf, w.Error()
+}
@@ -0,0 +1,144 @@
+package api
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/url"
+	"time"
+
+	oapi "pi-wegrzyn/ems/api/oapi/generated"
+	"pi-wegrzyn/ems/storage"
+	"pi-wegrzyn/ems/templates"
+)
+
+func (s *Server) GetCaptures(ctx context.Context, request oapi.GetCapturesRequestObject) (oapi.GetCapturesResponseObject, error) {
+	device, err := s.repository.Device(ctx, request.Params.DeviceId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			slog.ErrorContext(ctx, "device not found", slog.Any("error", err))
+			return oapi.PageRedirectResponse{
+				Headers: oapi.PageRedirectResponseHeaders{
+					Location: "/",
+				},
+			}, nil
+		}
+		slog.ErrorContext(ctx, "database error", slog.Any("error", err))
+
+		return oapi.GetCaptures500JSONResponse{
+			PageErrorJSONResponse: oapi.PageErrorJSONResponse{
+				Error:        "database error",
+				ErrorDetails: ptr(err.Error()),
+			},
+		}, nil
+	}
+
+	captures, err := s.repository.Captures(ctx, device.ID)
+	if err != nil {
+		slog.ErrorContext(ctx, "error getting captures", slog.Any("error", err))
+		return oapi.GetCaptures500JSONResponse{
+			PageErrorJSONResponse: oapi.PageErrorJSONResponse{
+				Error:        "error getting captures",
+				ErrorDetails: ptr(err.Error()),
+			},
+		}, nil
+	}
+
+	var buf bytes.Buffer
+	if err := writeCaptures(&buf, captures); err != nil {
+		slog.ErrorContext(ctx, "error writing captures", slog.Any("error", err))
+		return oapi.GetCaptures500JSONResponse{
+			PageErrorJSONResponse: oapi.PageErrorJSONResponse{
+				Error:        "error writing captures",
+				ErrorDetails: ptr(err.Error()),
+			},
+		}, nil
+	}
+
+	filename := fmt.Sprintf("ems-eeprom-%s-%s.zip", device.Hostname, time.Now().Format("20060102-150405"))
+	headers := oapi.GetCaptures200ResponseHeaders{
+		ContentDisposition: fmt.Sprintf("attachment; filename=%q", filename),
+	}
+
+	return oapi.GetCaptures200ApplicationzipResponse{Body: &buf, Headers: headers, ContentLength: int64(buf.Len())}, nil
+}
+
+func (s *Server) GetInspect(ctx context.Context, request oapi.GetInspectRequestObject) (oapi.GetInspectResponseObject, error) {
+	device, err := s.repository.Device(ctx, request.Params.DeviceId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			slog.ErrorContext(ctx, "device not found", slog.Any("error", err))
+			return oapi.PageRedirectResponse{
+				Headers: oapi.PageRedirectResponseHeaders{
+					Location: "/",
+				},
+			}, nil
+		}
+		slog.ErrorContext(ctx, "database error", slog.Any("error", err))
+
+		return oapi.GetInspect500JSONResponse{
+			PageErrorJSONResponse: oapi.PageErrorJSONResponse{
+				Error:        "database error",
+				ErrorDetails: ptr(err.Error()),
+			},
+		}, nil
+	}
+
+	captures, err := s.repository.Captures(ctx, device.ID)
+	if err != nil {
+		slog.ErrorContext(ctx, "error getting captures", slog.Any("error", err))
+		return oapi.GetInspect500JSONResponse{
+			PageErrorJSONResponse: oapi.PageErrorJSONResponse{
+				Error:        "error getting captures",
+				ErrorDetails: ptr(err.Error()),
+			},
+		}, nil
+	}
+
+	var iface string
+	if request.Params.Interface != nil {
+		iface = *request.Params.Interface
+	}
+
+	page, err := s.templateEx.ExecuteInspect(templates.InspectPageContent(device, captures, iface))
+	if err != nil {
+		slog.ErrorContext(ctx, "error executing template", slog.Any("error", err))
+		return oapi.GetInspect500JSONResponse{
+			PageErrorJSONResponse: oapi.PageErrorJSONResponse{
+				Error:        "error executing template",
+				ErrorDetails: ptr(err.Error()),
+			},
+		}, nil
+	}
+
+	return oapi.GetInspect200TexthtmlResponse{
+		PageTexthtmlResponse: oapi.PageTexthtmlResponse{
+			Body:          page,
+			ContentLength: int64(page.Len()),
+		},
+	}, nil
+}
+
+// writeCaptures writes a zip archive with a directory per interface and a
+// dump per capture, named after its time. `ems replay` reads such archives.
+func writeCaptures(w io.Writer, captures []storage.Capture) error {
+	archive := zip.NewWriter(w)
+	for _, c := range captures {
+		name := fmt.Sprintf("%s/%s-%d.eeprom", url.PathEscape(c.Interface), c.Created.UTC().Format("20060102-150405"), c.ID)
+
+		f, err := archive.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Deflate, Modified: c.Created})
+		if err != nil {
+			return err
+		}
+		if _, err := f.Write(c.Data); err != nil {
+			return err
+		}
+	}
+
+	return archive.Close()
+}
@@ -0,0 +1,255 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	oapi "pi-wegrzyn/ems/api/oapi/generated"
+	"pi-wegrzyn/ems/storage"
+	"pi-wegrzyn/ems/templates"
+)
+
+func (s *Server) GetCredentials(ctx context.Context, request oapi.GetCredentialsRequestObject) (oapi.GetCredentialsResponseObject, error) {
+	edit := storage.Credential{}
+	if request.Params.EditId != nil {
+		credential, err := s.repository.Credential(ctx, *request.Params.EditId)
+		switch err {
+		case nil:
+			edit = credential
+		case sql.ErrNoRows:
+			slog.ErrorContext(ctx, "credential profile not found", slog.Any("error", err))
+		default:
+			slog.ErrorContext(ctx, "database error", slog.Any("error", err))
+			return oapi.GetCredentials500JSONResponse{
+				PageErrorJSONResponse: oapi.PageErrorJSONResponse{
+					Error:        "database error",
+					ErrorDetails: ptr(err.Error()),
+				},
+			}, nil
+		}
+	}
+
+	page, err := s.credentialsPage(ctx, edit, "")
+	if err != nil {
+		slog.ErrorContext(ctx, "error rendering credentials page", slog.Any("error", err))
+		return oapi.GetCredentials500JSONResponse{
+			PageErrorJSONResponse: oapi.PageErrorJSONResponse{
+				Error:        "error rendering credentials page",
+				ErrorDetails: ptr(err.Error()),
+			},
+		}, nil
+	}
+
+	return oapi.GetCredentials200TexthtmlResponse{
+		PageTexthtmlResponse: oapi.PageTexthtmlResponse{
+			Body:          page,
+			ContentLength: int64(page.Len()),
+		},
+	}, nil
+}
+
+// PostCredentials creates a profile or updates the one given by edit-id.
+// Secrets left empty on update keep their stored values.
+func (s *Server) PostCredentials(ctx context.Context, request oapi.PostCredentialsRequestObject) (oapi.PostCredentialsResponseObject, error) {
+	form, err := templates.ParseCredentialForm(request.Body)
+	if err != nil {
+		slog.ErrorContext(ctx, "error parsing form", slog.Any("error", err))
+		return oapi.PostCredentials500JSONResponse{
+			PageErrorJSONResponse: oapi.PageErrorJSONResponse{
+				Error:        "error parsing form",
+				ErrorDetails: ptr(err.Error()),
+			},
+		}, nil
+	}
+
+	if err := form.Validate(); err != nil {
+		slog.ErrorContext(ctx, "validation error", slog.Any("error", err))
+		return s.postCredentialsError(ctx, storage.Credential{ID: form.EditId, Name: form.Name, Kind: form.Kind, Login: form.Login}, err), nil
+	}
+
+	credential := storage.Credential{}
+	var before map[string]string
+	if form.EditId != 0 {
+		if credential, err = s.repository.Credential(ctx, form.EditId); err != nil {
+			if err == sql.ErrNoRows {
+				slog.ErrorContext(ctx, "credential profile not found", slog.Any("error", err))
+				return oapi.PageRedirectResponse{
+					Headers: oapi.PageRedirectResponseHeaders{
+						Location: "/credentials",
+					},
+				}, nil
+			}
+			slog.ErrorContext(ctx, "database error", slog.Any("error", err))
+
+			return oapi.PostCredentials500JSONResponse{
+				PageErrorJSONResponse: oapi.PageErrorJSONResponse{
+					Error:        "database error",
+					ErrorDetails: ptr(err.Error()),
+				},
+			}, nil
+		}
+		before = credential.AuditFields()
+	}
+
+	credential.Name = form.Name
+	credential.Kind = form.Kind
+	credential.Login = form.Login
+	if form.Password != "" {
+		credential.Password = form.Password
+	}
+	if len(form.Key) != 0 {
+		credential.Keyfile = form.Key
+		credential.Passphrase = form.Passphrase
+		credential.Certificate = form.Certificate
+	}
+	if form.Passphrase != "" {
+		credential.Passphrase = form.Passphrase
+	}
+	if len(form.Certificate) != 0 {
+		credential.Certificate = form.Certificate
+	}
+
+	// Only the secret of the selected kind is kept.
+	switch credential.Kind {
+	case storage.CredentialPassword:
+		credential.Keyfile, credential.Passphrase, credential.Certificate = nil, "", nil
+	case storage.CredentialKey:
+		credential.Password = ""
+	case storage.CredentialAgent:
+		credential.Password, credential.Keyfile, credential.Passphrase, credential.Certificate = "", nil, "", nil
+	}
+
+	if credential.Kind == storage.CredentialKey && len(credential.Keyfile) == 0 {
+		return s.postCredentialsError(ctx, credential, errors.New("key is required")), nil
+	}
+	if credential.Kind == storage.CredentialPassword && credential.Password == "" {
+		return s.postCredentialsError(ctx, credential, errors.New("password is required")), nil
+	}
+
+	if credential.ID == 0 {
+		if credential.ID, err = s.repository.CreateCredential(ctx, credential); err != nil {
+			slog.ErrorContext(ctx, "database error", slog.Any("error", err))
+			return s.postCredentialsError(ctx, credential, errors.New("cannot save profile, the name may be taken")), nil
+		}
+		s.audit(ctx, storage.AuditActionCreate, storage.AuditTargetCredential, credential.ID, credential.Name, nil, credential.AuditFields())
+	} else {
+		if err = s.repository.UpdateCredential(ctx, credential); err != nil {
+			slog.ErrorContext(ctx, "database error", slog.Any("error", err))
+			return s.postCredentialsError(ctx, credential, errors.New("cannot save profile, the name may be taken")), nil
+		}
+		s.audit(ctx, storage.AuditActionUpdate, storage.AuditTargetCredential, credential.ID, credential.Name, before, credential.AuditFields())
+	}
+
+	return oapi.PostCredentials303Response{
+		Headers: oapi.PageRedirectResponseHeaders{
+			Location: "/credentials",
+		},
+	}, nil
+}
+
+func (s *Server) postCredentialsError(ctx context.Context, edit storage.Credential, err error) oapi.PostCredentialsResponseObject {
+	page, err2 := s.credentialsPage(ctx, edit, err.Error())
+	if err2 != nil {
+		slog.ErrorContext(ctx, "error rendering credentials page", slog.Any("error", err2))
+		return oapi.PostCredentials500JSONResponse{
+			PageErrorJSONResponse: oapi.PageErrorJSONResponse{
+				Error:        "error rendering credentials page",
+				ErrorDetails: ptr(errors.Join(err, err2).Error()),
+			},
+		}
+	}
+
+	return oapi.PostCredentials200TexthtmlResponse{
+		PageTexthtmlResponse: oapi.PageTexthtmlResponse{
+			Body:          page,
+			ContentLength: int64(page.Len()),
+		},
+	}
+}
+
+// PostCredentialsDelete refuses to delete profiles still referenced by
+// devices, they would be left without credentials.
+func (s *Server) PostCredentialsDelete(ctx context.Context, request oapi.PostCredentialsDeleteRequestObject) (oapi.PostCredentialsDeleteResponseObject, error) {
+	credential, err := s.repository.Credential(ctx, request.Body.DeleteId)
+	if err == sql.ErrNoRows {
+		slog.ErrorContext(ctx, "credential profile not found", slog.Any("error", err))
+		return oapi.PageRedirectResponse{
+			Headers: oapi.PageRedirectResponseHeaders{
+				Location: "/credentials",
+			},
+		}, nil
+	}
+
+	var usage int
+	if err == nil {
+		usage, err = s.repository.CredentialUsage(ctx, credential.ID)
+	}
+	if err == nil && usage == 0 {
+		err = s.repository.DeleteCredential(ctx, credential.ID)
+	}
+	if err != nil {
+		slog.ErrorContext(ctx, "database error", slog.Any("error", err))
+		return oapi.PostCredentialsDelete500JSONResponse{
+			PageErrorJSONResponse: oapi.PageErrorJSONResponse{
+				Error:        "database error",
+				ErrorDetails: ptr(err.Error()),
+			},
+		}, nil
+	}
+
+	if usage != 0 {
+		page, err := s.credentialsPage(ctx, storage.Credential{}, fmt.Sprintf("profile %s is used by %d device(s) or jump host(s)", credential.Name, usage))
+		if err != nil {
+			slog.ErrorContext(ctx, "error rendering credentials page", slog.Any("error", err))
+			return oapi.PostCredentialsDelete500JSONResponse{
+				PageErrorJSONResponse: oapi.PageErrorJSONResponse{
+					Error:        "error rendering credentials page",
+					ErrorDetails: ptr(err.Error()),
+				},
+			}, nil
+		}
+
+		return oapi.PostCredentialsDelete200TexthtmlResponse{
+			PageTexthtmlResponse: oapi.PageTexthtmlResponse{
+				Body:          page,
+				ContentLength: int64(page.Len()),
+			},
+		}, nil
+	}
+
+	s.audit(ctx, storage.AuditActionDelete, storage.AuditTargetCredential, credential.ID, credential.Name, credential.AuditFields(), nil)
+
+	return oapi.PostCredentialsDelete303Response{
+		Headers: oapi.PageRedirectResponseHeaders{
+			Location: "/credentials",
+		},
+	}, nil
+}
+
+func (s *Server) credentialsPage(ctx context.Context, edit storage.Credential, errMsg string) (*bytes.Buffer, error) {
+	credentials, err := s.repository.Credentials(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	devices, err := s.repository.Devices(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.templateEx.ExecuteCredentials(templates.CredentialsPageContent(credentials, devices, edit, errMsg))
+}
+
+// credentialOptions lists profiles for the device form picker.
+func (s *Server) credentialOptions(ctx context.Context) []storage.Credential {
+	credentials, err := s.repository.Credentials(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "error getting credentials", slog.Any("error", err))
+	}
+
+	return credentials
+}
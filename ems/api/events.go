@@ -0,0 +1,62 @@
+package api
+
+import (
+	"context"
+	"log/slog"
+
+	oapi "pi-wegrzyn/ems/api/oapi/generated"
+	"pi-wegrzyn/ems/storage"
+	"pi-wegrzyn/ems/templates"
+)
+
+const eventsPageLimit = 500
+
+func (s *Server) GetEvents(ctx context.Context, request oapi.GetEventsRequestObject) (oapi.GetEventsResponseObject, error) {
+	filter := storage.EventFilter{Limit: eventsPageLimit}
+	if request.Params.DeviceId != nil {
+		filter.DeviceID = *request.Params.DeviceId
+	}
+	if request.Params.Kind != nil {
+		filter.Kind = *request.Params.Kind
+	}
+
+	events, err := s.repository.Events(ctx, filter)
+	if err != nil {
+		slog.ErrorContext(ctx, "error getting events", slog.Any("error", err))
+		return oapi.GetEvents500JSONResponse{
+			PageErrorJSONResponse: oapi.PageErrorJSONResponse{
+				Error:        "error getting events",
+				ErrorDetails: ptr(err.Error()),
+			},
+		}, nil
+	}
+
+	devices, err := s.repository.Devices(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "error getting devices", slog.Any("error", err))
+		return oapi.GetEvents500JSONResponse{
+			PageErrorJSONResponse: oapi.PageErrorJSONResponse{
+				Error:        "error getting devices",
+				ErrorDetails: ptr(err.Error()),
+			},
+		}, nil
+	}
+
+	page, err := s.templateEx.ExecuteEvents(templates.EventsPageContent(filter, events, devices))
+	if err != nil {
+		slog.ErrorContext(ctx, "error executing template", slog.Any("error", err))
+		return oapi.GetEvents500JSONResponse{
+			PageErrorJSONResponse: oapi.PageErrorJSONResponse{
+				Error:        "error executing template",
+				ErrorDetails: ptr(err.Error()),
+			},
+		}, nil
+	}
+
+	return oapi.GetEvents200TexthtmlResponse{
+		PageTexthtmlResponse: oapi.PageTexthtmlResponse{
+			Body:          page,
+			ContentLength: int64(page.Len()),
+		},
+	}, nil
+}
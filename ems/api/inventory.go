@@ -0,0 +1,205 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	oapi "pi-wegrzyn/ems/api/oapi/generated"
+	"pi-wegrzyn/ems/inventory"
+	"pi-wegrzyn/ems/storage"
+	"pi-wegrzyn/ems/templates"
+)
+
+func (s *Server) GetImport(ctx context.Context, request oapi.GetImportRequestObject) (oapi.GetImportResponseObject, error) {
+	page, err := s.templateEx.ExecuteImport(templates.ImportPageContent("", "", nil, ""))
+	if err != nil {
+		slog.ErrorContext(ctx, "error executing template", slog.Any("error", err))
+		return oapi.GetImport500JSONResponse{
+			PageErrorJSONResponse: oapi.PageErrorJSONResponse{
+				Error:        "error executing template",
+				ErrorDetails: ptr(err.Error()),
+			},
+		}, nil
+	}
+
+	return oapi.GetImport200TexthtmlResponse{
+		PageTexthtmlResponse: oapi.PageTexthtmlResponse{
+			Body:          page,
+			ContentLength: int64(page.Len()),
+		},
+	}, nil
+}
+
+// PostImport shows a dry-run of the uploaded file. The page sends the same
+// content back with apply set, which writes all changes in one transaction.
+func (s *Server) PostImport(ctx context.Context, request oapi.PostImportRequestObject) (oapi.PostImportResponseObject, error) {
+	form, err := templates.ParseImportForm(request.Body)
+	if err != nil {
+		slog.ErrorContext(ctx, "error parsing form", slog.Any("error", err))
+		return oapi.PostImport500JSONResponse{
+			PageErrorJSONResponse: oapi.PageErrorJSONResponse{
+				Error:        "error parsing form",
+				ErrorDetails: ptr(err.Error()),
+			},
+		}, nil
+	}
+
+	format, content := form.Format, form.Content
+	if len(form.File) != 0 {
+		content = string(form.File)
+		if format, err = inventory.FormatFromFilename(form.Filename); err != nil {
+			return s.postImportError(ctx, "", "", nil, err), nil
+		}
+	}
+
+	if content == "" {
+		return s.postImportError(ctx, "", "", nil, errors.New("select a file to import")), nil
+	}
+
+	records, err := inventory.Parse(format, []byte(content))
+	if err != nil {
+		slog.WarnContext(ctx, "cannot parse import file", slog.Any("error", err))
+		return s.postImportError(ctx, format, content, nil, err), nil
+	}
+
+	devices, err := s.repository.Devices(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "error getting devices", slog.Any("error", err))
+		return oapi.PostImport500JSONResponse{
+			PageErrorJSONResponse: oapi.PageErrorJSONResponse{
+				Error:        "error getting devices",
+				ErrorDetails: ptr(err.Error()),
+			},
+		}, nil
+	}
+
+	credentials, err := s.repository.Credentials(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "error getting credentials", slog.Any("error", err))
+		return oapi.PostImport500JSONResponse{
+			PageErrorJSONResponse: oapi.PageErrorJSONResponse{
+				Error:        "error getting credentials",
+				ErrorDetails: ptr(err.Error()),
+			},
+		}, nil
+	}
+
+	plan := inventory.NewPlan(devices, credentials, records)
+	if !form.Apply || !plan.Valid() {
+		return s.postImportError(ctx, format, content, plan.Rows, nil), nil
+	}
+
+	created, err := s.repository.ImportDevices(ctx, plan.Creates(), plan.Updates())
+	if err != nil {
+		slog.ErrorContext(ctx, "cannot import devices", slog.Any("error", err))
+		return s.postImportError(ctx, format, content, plan.Rows, fmt.Errorf("nothing was imported: %w", err)), nil
+	}
+
+	for _, d := range created {
+		s.audit(ctx, storage.AuditActionCreate, storage.AuditTargetDevice, d.ID, d.Hostname, nil, d.AuditFields())
+	}
+
+	before := make(map[uint]storage.Device, len(devices))
+	for _, d := range devices {
+		before[d.ID] = d
+	}
+	for _, d := range plan.Updates() {
+		s.audit(ctx, storage.AuditActionUpdate, storage.AuditTargetDevice, d.ID, d.Hostname, before[d.ID].AuditFields(), d.AuditFields())
+	}
+
+	slog.InfoContext(ctx, "devices imported", slog.Int("created", len(created)), slog.Int("updated", len(plan.Updates())))
+
+	return oapi.PostImport303Response{
+		Headers: oapi.PageRedirectResponseHeaders{
+			Location: "/",
+		},
+	}, nil
+}
+
+func (s *Server) postImportError(ctx context.Context, format string, content string, rows []inventory.Row, err error) oapi.PostImportResponseObject {
+	errMsg := ""
+	if err != nil {
+		errMsg = err.Error()
+	}
+
+	pageRows := make([]templates.ImportRow, 0, len(rows))
+	for _, r := range rows {
+		row := templates.ImportRow{
+			Line:     r.Line,
+			Action:   r.Action,
+			Hostname: r.Device.Hostname,
+			Changes:  r.Changes,
+		}
+		if r.Err != nil {
+			row.Error = r.Err.Error()
+		}
+		pageRows = append(pageRows, row)
+	}
+
+	page, err2 := s.templateEx.ExecuteImport(templates.ImportPageContent(format, content, pageRows, errMsg))
+	if err2 != nil {
+		slog.ErrorContext(ctx, "error executing template", slog.Any("error", err2))
+		return oapi.PostImport500JSONResponse{
+			PageErrorJSONResponse: oapi.PageErrorJSONResponse{
+				Error:        "error executing template",
+				ErrorDetails: ptr(errors.Join(err, err2).Error()),
+			},
+		}
+	}
+
+	return oapi.PostImport200TexthtmlResponse{
+		PageTexthtmlResponse: oapi.PageTexthtmlResponse{
+			Body:          page,
+			ContentLength: int64(page.Len()),
+		},
+	}
+}
+
+func (s *Server) GetExport(ctx context.Context, request oapi.GetExportRequestObject) (oapi.GetExportResponseObject, error) {
+	devices, err := s.repository.Devices(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "error getting devices", slog.Any("error", err))
+		return oapi.GetExport500JSONResponse{
+			PageErrorJSONResponse: oapi.PageErrorJSONResponse{
+				Error:        "error getting devices",
+				ErrorDetails: ptr(err.Error()),
+			},
+		}, nil
+	}
+
+	credentials, err := s.repository.Credentials(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "error getting credentials", slog.Any("error", err))
+		return oapi.GetExport500JSONResponse{
+			PageErrorJSONResponse: oapi.PageErrorJSONResponse{
+				Error:        "error getting credentials",
+				ErrorDetails: ptr(err.Error()),
+			},
+		}, nil
+	}
+
+	var buf bytes.Buffer
+	if err := inventory.Export(&buf, string(request.Params.Format), devices, credentials); err != nil {
+		slog.ErrorContext(ctx, "cannot export devices", slog.Any("error", err))
+		return oapi.GetExport500JSONResponse{
+			PageErrorJSONResponse: oapi.PageErrorJSONResponse{
+				Error:        "cannot export devices",
+				ErrorDetails: ptr(err.Error()),
+			},
+		}, nil
+	}
+
+	headers := oapi.GetExport200ResponseHeaders{
+		ContentDisposition: fmt.Sprintf("attachment; filename=%q", fmt.Sprintf("ems-devices-%s.%s", time.Now().Format("20060102-150405"), request.Params.Format)),
+	}
+
+	if request.Params.Format == oapi.InventoryFormatYaml {
+		return oapi.GetExport200ApplicationyamlResponse{Body: &buf, Headers: headers, ContentLength: int64(buf.Len())}, nil
+	}
+
+	return oapi.GetExport200TextcsvResponse{Body: &buf, Headers: headers, ContentLength: int64(buf.Len())}, nil
+}
@@ -0,0 +1,282 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	oapi "pi-wegrzyn/ems/api/oapi/generated"
+	"pi-wegrzyn/ems/storage"
+	"pi-wegrzyn/ems/templates"
+)
+
+func (s *Server) GetJumpHosts(ctx context.Context, request oapi.GetJumpHostsRequestObject) (oapi.GetJumpHostsResponseObject, error) {
+	edit := storage.JumpHost{}
+	if request.Params.EditId != nil {
+		host, err := s.repository.JumpHost(ctx, *request.Params.EditId)
+		switch err {
+		case nil:
+			edit = host
+		case sql.ErrNoRows:
+			slog.ErrorContext(ctx, "jump host not found", slog.Any("error", err))
+		default:
+			slog.ErrorContext(ctx, "database error", slog.Any("error", err))
+			return oapi.GetJumpHosts500JSONResponse{
+				PageErrorJSONResponse: oapi.PageErrorJSONResponse{
+					Error:        "database error",
+					ErrorDetails: ptr(err.Error()),
+				},
+			}, nil
+		}
+	}
+
+	page, err := s.jumpHostsPage(ctx, edit, "")
+	if err != nil {
+		slog.ErrorContext(ctx, "error rendering jump hosts page", slog.Any("error", err))
+		return oapi.GetJumpHosts500JSONResponse{
+			PageErrorJSONResponse: oapi.PageErrorJSONResponse{
+				Error:        "error rendering jump hosts page",
+				ErrorDetails: ptr(err.Error()),
+			},
+		}, nil
+	}
+
+	return oapi.GetJumpHosts200TexthtmlResponse{
+		PageTexthtmlResponse: oapi.PageTexthtmlResponse{
+			Body:          page,
+			ContentLength: int64(page.Len()),
+		},
+	}, nil
+}
+
+// PostJumpHosts creates a jump host or updates the one given by edit-id.
+// Jump hosts reached through each other in a loop are rejected.
+func (s *Server) PostJumpHosts(ctx context.Context, request oapi.PostJumpHostsRequestObject) (oapi.PostJumpHostsResponseObject, error) {
+	form := templates.JumpHostForm{
+		Name:         request.Body.Name,
+		Address:      request.Body.Address,
+		CredentialID: request.Body.CredentialId,
+	}
+	if request.Body.Port != nil {
+		form.Port = *request.Body.Port
+	}
+	if request.Body.ViaId != nil {
+		form.ViaID = *request.Body.ViaId
+	}
+	if request.Body.EditId != nil {
+		form.EditId = *request.Body.EditId
+	}
+
+	if err := form.Validate(); err != nil {
+		slog.ErrorContext(ctx, "validation error", slog.Any("error", err))
+		return s.postJumpHostsError(ctx, jumpHostFromForm(form), err), nil
+	}
+
+	var before map[string]string
+	if form.EditId != 0 {
+		host, err := s.repository.JumpHost(ctx, form.EditId)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				slog.ErrorContext(ctx, "jump host not found", slog.Any("error", err))
+				return oapi.PageRedirectResponse{
+					Headers: oapi.PageRedirectResponseHeaders{
+						Location: "/jump-hosts",
+					},
+				}, nil
+			}
+			slog.ErrorContext(ctx, "database error", slog.Any("error", err))
+
+			return oapi.PostJumpHosts500JSONResponse{
+				PageErrorJSONResponse: oapi.PageErrorJSONResponse{
+					Error:        "database error",
+					ErrorDetails: ptr(err.Error()),
+				},
+			}, nil
+		}
+		before = host.AuditFields()
+	}
+
+	host := jumpHostFromForm(form)
+
+	hosts, err := s.repository.JumpHosts(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "database error", slog.Any("error", err))
+		return oapi.PostJumpHosts500JSONResponse{
+			PageErrorJSONResponse: oapi.PageErrorJSONResponse{
+				Error:        "database error",
+				ErrorDetails: ptr(err.Error()),
+			},
+		}, nil
+	}
+
+	if err := checkJumpChain(hosts, host); err != nil {
+		return s.postJumpHostsError(ctx, host, err), nil
+	}
+
+	if host.ID == 0 {
+		if host.ID, err = s.repository.CreateJumpHost(ctx, host); err != nil {
+			slog.ErrorContext(ctx, "database error", slog.Any("error", err))
+			return s.postJumpHostsError(ctx, host, errors.New("cannot save jump host, the name may be taken")), nil
+		}
+		s.audit(ctx, storage.AuditActionCreate, storage.AuditTargetJumpHost, host.ID, host.Name, nil, host.AuditFields())
+	} else {
+		if err = s.repository.UpdateJumpHost(ctx, host); err != nil {
+			slog.ErrorContext(ctx, "database error", slog.Any("error", err))
+			return s.postJumpHostsError(ctx, host, errors.New("cannot save jump host, the name may be taken")), nil
+		}
+		s.audit(ctx, storage.AuditActionUpdate, storage.AuditTargetJumpHost, host.ID, host.Name, before, host.AuditFields())
+	}
+
+	return oapi.PostJumpHosts303Response{
+		Headers: oapi.PageRedirectResponseHeaders{
+			Location: "/jump-hosts",
+		},
+	}, nil
+}
+
+func jumpHostFromForm(form templates.JumpHostForm) storage.JumpHost {
+	return storage.JumpHost{
+		ID:           form.EditId,
+		Name:         form.Name,
+		Address:      form.Address,
+		Port:         uint16(form.Port),
+		CredentialID: form.CredentialID,
+		ViaID:        form.ViaID,
+	}
+}
+
+// checkJumpChain resolves the chain of host as if it was already saved, so
+// that unknown jump hosts and loops are caught before they reach the monitor.
+func checkJumpChain(hosts []storage.JumpHost, host storage.JumpHost) error {
+	updated := make([]storage.JumpHost, 0, len(hosts)+1)
+	for _, h := range hosts {
+		if h.ID != host.ID {
+			updated = append(updated, h)
+		}
+	}
+	if host.ID == 0 {
+		// Any unused ID works for a new jump host, nothing can point to it yet.
+		host.ID = ^uint(0)
+	}
+	updated = append(updated, host)
+
+	if _, err := storage.JumpChain(updated, host.ID); err != nil {
+		if errors.Is(err, storage.ErrJumpLoop) {
+			return errors.New("jump hosts would be reached through each other in a loop")
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (s *Server) postJumpHostsError(ctx context.Context, edit storage.JumpHost, err error) oapi.PostJumpHostsResponseObject {
+	page, err2 := s.jumpHostsPage(ctx, edit, err.Error())
+	if err2 != nil {
+		slog.ErrorContext(ctx, "error rendering jump hosts page", slog.Any("error", err2))
+		return oapi.PostJumpHosts500JSONResponse{
+			PageErrorJSONResponse: oapi.PageErrorJSONResponse{
+				Error:        "error rendering jump hosts page",
+				ErrorDetails: ptr(errors.Join(err, err2).Error()),
+			},
+		}
+	}
+
+	return oapi.PostJumpHosts200TexthtmlResponse{
+		PageTexthtmlResponse: oapi.PageTexthtmlResponse{
+			Body:          page,
+			ContentLength: int64(page.Len()),
+		},
+	}
+}
+
+// PostJumpHostsDelete refuses to delete jump hosts still used by devices or
+// other jump hosts, they would become unreachable.
+func (s *Server) PostJumpHostsDelete(ctx context.Context, request oapi.PostJumpHostsDeleteRequestObject) (oapi.PostJumpHostsDeleteResponseObject, error) {
+	host, err := s.repository.JumpHost(ctx, request.Body.DeleteId)
+	if err == sql.ErrNoRows {
+		slog.ErrorContext(ctx, "jump host not found", slog.Any("error", err))
+		return oapi.PageRedirectResponse{
+			Headers: oapi.PageRedirectResponseHeaders{
+				Location: "/jump-hosts",
+			},
+		}, nil
+	}
+
+	var usage int
+	if err == nil {
+		usage, err = s.repository.JumpHostUsage(ctx, host.ID)
+	}
+	if err == nil && usage == 0 {
+		err = s.repository.DeleteJumpHost(ctx, host.ID)
+	}
+	if err != nil {
+		slog.ErrorContext(ctx, "database error", slog.Any("error", err))
+		return oapi.PostJumpHostsDelete500JSONResponse{
+			PageErrorJSONResponse: oapi.PageErrorJSONResponse{
+				Error:        "database error",
+				ErrorDetails: ptr(err.Error()),
+			},
+		}, nil
+	}
+
+	if usage != 0 {
+		page, err := s.jumpHostsPage(ctx, storage.JumpHost{}, fmt.Sprintf("jump host %s is used by %d device(s) or jump host(s)", host.Name, usage))
+		if err != nil {
+			slog.ErrorContext(ctx, "error rendering jump hosts page", slog.Any("error", err))
+			return oapi.PostJumpHostsDelete500JSONResponse{
+				PageErrorJSONResponse: oapi.PageErrorJSONResponse{
+					Error:        "error rendering jump hosts page",
+					ErrorDetails: ptr(err.Error()),
+				},
+			}, nil
+		}
+
+		return oapi.PostJumpHostsDelete200TexthtmlResponse{
+			PageTexthtmlResponse: oapi.PageTexthtmlResponse{
+				Body:          page,
+				ContentLength: int64(page.Len()),
+			},
+		}, nil
+	}
+
+	s.audit(ctx, storage.AuditActionDelete, storage.AuditTargetJumpHost, host.ID, host.Name, host.AuditFields(), nil)
+
+	return oapi.PostJumpHostsDelete303Response{
+		Headers: oapi.PageRedirectResponseHeaders{
+			Location: "/jump-hosts",
+		},
+	}, nil
+}
+
+func (s *Server) jumpHostsPage(ctx context.Context, edit storage.JumpHost, errMsg string) (*bytes.Buffer, error) {
+	hosts, err := s.repository.JumpHosts(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	credentials, err := s.repository.Credentials(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	devices, err := s.repository.Devices(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.templateEx.ExecuteJumpHosts(templates.JumpHostsPageContent(hosts, credentials, devices, edit, errMsg))
+}
+
+// jumpHostOptions lists jump hosts for the device form picker.
+func (s *Server) jumpHostOptions(ctx context.Context) []storage.JumpHost {
+	hosts, err := s.repository.JumpHosts(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "error getting jump hosts", slog.Any("error", err))
+	}
+
+	return hosts
+}
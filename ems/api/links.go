@@ -0,0 +1,237 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+
+	oapi "pi-wegrzyn/ems/api/oapi/generated"
+	"pi-wegrzyn/ems/storage"
+	"pi-wegrzyn/ems/templates"
+)
+
+func (s *Server) GetLinks(ctx context.Context, request oapi.GetLinksRequestObject) (oapi.GetLinksResponseObject, error) {
+	edit := storage.Link{}
+	if request.Params.EditId != nil {
+		link, err := s.repository.Link(ctx, *request.Params.EditId)
+		switch err {
+		case nil:
+			edit = link
+		case sql.ErrNoRows:
+			slog.ErrorContext(ctx, "link not found", slog.Any("error", err))
+		default:
+			slog.ErrorContext(ctx, "database error", slog.Any("error", err))
+			return oapi.GetLinks500JSONResponse{
+				PageErrorJSONResponse: oapi.PageErrorJSONResponse{
+					Error:        "database error",
+					ErrorDetails: ptr(err.Error()),
+				},
+			}, nil
+		}
+	}
+
+	page, err := s.linksPage(ctx, edit, "")
+	if err != nil {
+		slog.ErrorContext(ctx, "error rendering links page", slog.Any("error", err))
+		return oapi.GetLinks500JSONResponse{
+			PageErrorJSONResponse: oapi.PageErrorJSONResponse{
+				Error:        "error rendering links page",
+				ErrorDetails: ptr(err.Error()),
+			},
+		}, nil
+	}
+
+	return oapi.GetLinks200TexthtmlResponse{
+		PageTexthtmlResponse: oapi.PageTexthtmlResponse{
+			Body:          page,
+			ContentLength: int64(page.Len()),
+		},
+	}, nil
+}
+
+// PostLinks creates a link or updates the one given by edit-id. Moving an
+// end keeps the baseline, it is reset separately once the span is known to
+// be healthy.
+func (s *Server) PostLinks(ctx context.Context, request oapi.PostLinksRequestObject) (oapi.PostLinksResponseObject, error) {
+	form := templates.LinkForm{
+		Name:       request.Body.Name,
+		ADeviceID:  request.Body.ADeviceId,
+		AInterface: request.Body.AInterface,
+		BDeviceID:  request.Body.BDeviceId,
+		BInterface: request.Body.BInterface,
+	}
+	if request.Body.AlarmDelta != nil {
+		form.AlarmDelta = *request.Body.AlarmDelta
+	}
+	if request.Body.EditId != nil {
+		form.EditId = *request.Body.EditId
+	}
+
+	if err := form.Validate(); err != nil {
+		slog.ErrorContext(ctx, "validation error", slog.Any("error", err))
+		return s.postLinksError(ctx, linkFromForm(form), err), nil
+	}
+
+	var before map[string]string
+	if form.EditId != 0 {
+		link, err := s.repository.Link(ctx, form.EditId)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				slog.ErrorContext(ctx, "link not found", slog.Any("error", err))
+				return oapi.PageRedirectResponse{
+					Headers: oapi.PageRedirectResponseHeaders{
+						Location: "/links",
+					},
+				}, nil
+			}
+			slog.ErrorContext(ctx, "database error", slog.Any("error", err))
+
+			return oapi.PostLinks500JSONResponse{
+				PageErrorJSONResponse: oapi.PageErrorJSONResponse{
+					Error:        "database error",
+					ErrorDetails: ptr(err.Error()),
+				},
+			}, nil
+		}
+		before = link.AuditFields()
+	}
+
+	link := linkFromForm(form)
+
+	var err error
+	if link.ID == 0 {
+		if link.ID, err = s.repository.CreateLink(ctx, link); err != nil {
+			slog.ErrorContext(ctx, "database error", slog.Any("error", err))
+			return s.postLinksError(ctx, link, errors.New("cannot save link, the name may be taken")), nil
+		}
+		s.audit(ctx, storage.AuditActionCreate, storage.AuditTargetLink, link.ID, link.Name, nil, link.AuditFields())
+	} else {
+		if err = s.repository.UpdateLink(ctx, link); err != nil {
+			slog.ErrorContext(ctx, "database error", slog.Any("error", err))
+			return s.postLinksError(ctx, link, errors.New("cannot save link, the name may be taken")), nil
+		}
+		s.audit(ctx, storage.AuditActionUpdate, storage.AuditTargetLink, link.ID, link.Name, before, link.AuditFields())
+	}
+
+	return oapi.PostLinks303Response{
+		Headers: oapi.PageRedirectResponseHeaders{
+			Location: "/links",
+		},
+	}, nil
+}
+
+func linkFromForm(form templates.LinkForm) storage.Link {
+	return storage.Link{
+		ID:         form.EditId,
+		Name:       form.Name,
+		ADeviceID:  form.ADeviceID,
+		AInterface: form.AInterface,
+		BDeviceID:  form.BDeviceID,
+		BInterface: form.BInterface,
+		AlarmDelta: form.AlarmDelta,
+	}
+}
+
+func (s *Server) postLinksError(ctx context.Context, edit storage.Link, err error) oapi.PostLinksResponseObject {
+	page, err2 := s.linksPage(ctx, edit, err.Error())
+	if err2 != nil {
+		slog.ErrorContext(ctx, "error rendering links page", slog.Any("error", err2))
+		return oapi.PostLinks500JSONResponse{
+			PageErrorJSONResponse: oapi.PageErrorJSONResponse{
+				Error:        "error rendering links page",
+				ErrorDetails: ptr(errors.Join(err, err2).Error()),
+			},
+		}
+	}
+
+	return oapi.PostLinks200TexthtmlResponse{
+		PageTexthtmlResponse: oapi.PageTexthtmlResponse{
+			Body:          page,
+			ContentLength: int64(page.Len()),
+		},
+	}
+}
+
+// PostLinksBaseline clears the baseline of a link, the monitor takes the next
+// reading as the new one.
+func (s *Server) PostLinksBaseline(ctx context.Context, request oapi.PostLinksBaselineRequestObject) (oapi.PostLinksBaselineResponseObject, error) {
+	link, err := s.repository.Link(ctx, request.Body.LinkId)
+	if err == sql.ErrNoRows {
+		slog.ErrorContext(ctx, "link not found", slog.Any("error", err))
+		return oapi.PageRedirectResponse{
+			Headers: oapi.PageRedirectResponseHeaders{
+				Location: "/links",
+			},
+		}, nil
+	}
+	if err == nil {
+		err = s.repository.ResetLinkBaseline(ctx, link.ID)
+	}
+	if err != nil {
+		slog.ErrorContext(ctx, "database error", slog.Any("error", err))
+		return oapi.PostLinksBaseline500JSONResponse{
+			PageErrorJSONResponse: oapi.PageErrorJSONResponse{
+				Error:        "database error",
+				ErrorDetails: ptr(err.Error()),
+			},
+		}, nil
+	}
+
+	after := link.AuditFields()
+	after["baseline"] = "reset"
+	s.audit(ctx, storage.AuditActionUpdate, storage.AuditTargetLink, link.ID, link.Name, link.AuditFields(), after)
+
+	return oapi.PostLinksBaseline303Response{
+		Headers: oapi.PageRedirectResponseHeaders{
+			Location: "/links",
+		},
+	}, nil
+}
+
+func (s *Server) PostLinksDelete(ctx context.Context, request oapi.PostLinksDeleteRequestObject) (oapi.PostLinksDeleteResponseObject, error) {
+	link, err := s.repository.Link(ctx, request.Body.DeleteId)
+	if err == sql.ErrNoRows {
+		slog.ErrorContext(ctx, "link not found", slog.Any("error", err))
+		return oapi.PageRedirectResponse{
+			Headers: oapi.PageRedirectResponseHeaders{
+				Location: "/links",
+			},
+		}, nil
+	}
+	if err == nil {
+		err = s.repository.DeleteLink(ctx, link.ID)
+	}
+	if err != nil {
+		slog.ErrorContext(ctx, "database error", slog.Any("error", err))
+		return oapi.PostLinksDelete500JSONResponse{
+			PageErrorJSONResponse: oapi.PageErrorJSONResponse{
+				Error:        "database error",
+				ErrorDetails: ptr(err.Error()),
+			},
+		}, nil
+	}
+
+	s.audit(ctx, storage.AuditActionDelete, storage.AuditTargetLink, link.ID, link.Name, link.AuditFields(), nil)
+
+	return oapi.PostLinksDelete303Response{
+		Headers: oapi.PageRedirectResponseHeaders{
+			Location: "/links",
+		},
+	}, nil
+}
+
+func (s *Server) linksPage(ctx context.Context, edit storage.Link, errMsg string) (*bytes.Buffer, error) {
+	links, err := s.repository.Links(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	devices, err := s.repository.Devices(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.templateEx.ExecuteLinks(templates.LinksPageContent(links, devices, edit, errMsg))
+}
@@ -0,0 +1,229 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"time"
+
+	oapi "pi-wegrzyn/ems/api/oapi/generated"
+	"pi-wegrzyn/ems/storage"
+	"pi-wegrzyn/ems/templates"
+)
+
+func (s *Server) GetMaintenance(ctx context.Context, request oapi.GetMaintenanceRequestObject) (oapi.GetMaintenanceResponseObject, error) {
+	edit := storage.MaintenanceWindow{}
+	if request.Params.EditId != nil {
+		window, err := s.repository.MaintenanceWindow(ctx, *request.Params.EditId)
+		switch err {
+		case nil:
+			edit = window
+		case sql.ErrNoRows:
+			slog.ErrorContext(ctx, "maintenance window not found", slog.Any("error", err))
+		default:
+			slog.ErrorContext(ctx, "database error", slog.Any("error", err))
+			return oapi.GetMaintenance500JSONResponse{
+				PageErrorJSONResponse: oapi.PageErrorJSONResponse{
+					Error:        "database error",
+					ErrorDetails: ptr(err.Error()),
+				},
+			}, nil
+		}
+	}
+
+	if request.Params.EditId == nil && request.Params.DeviceId != nil {
+		device, err := s.repository.Device(ctx, *request.Params.DeviceId)
+		switch err {
+		case nil:
+			edit = storage.MaintenanceWindow{Name: "mute-" + device.Hostname, DeviceID: device.ID}
+		case sql.ErrNoRows:
+			slog.ErrorContext(ctx, "device not found", slog.Any("error", err))
+		default:
+			slog.ErrorContext(ctx, "database error", slog.Any("error", err))
+			return oapi.GetMaintenance500JSONResponse{
+				PageErrorJSONResponse: oapi.PageErrorJSONResponse{
+					Error:        "database error",
+					ErrorDetails: ptr(err.Error()),
+				},
+			}, nil
+		}
+	}
+
+	page, err := s.maintenancePage(ctx, edit, "")
+	if err != nil {
+		slog.ErrorContext(ctx, "error rendering maintenance page", slog.Any("error", err))
+		return oapi.GetMaintenance500JSONResponse{
+			PageErrorJSONResponse: oapi.PageErrorJSONResponse{
+				Error:        "error rendering maintenance page",
+				ErrorDetails: ptr(err.Error()),
+			},
+		}, nil
+	}
+
+	return oapi.GetMaintenance200TexthtmlResponse{
+		PageTexthtmlResponse: oapi.PageTexthtmlResponse{
+			Body:          page,
+			ContentLength: int64(page.Len()),
+		},
+	}, nil
+}
+
+// PostMaintenance creates a maintenance window or updates the one given by
+// edit-id. A device is muted by a one-off window of the device starting now.
+func (s *Server) PostMaintenance(ctx context.Context, request oapi.PostMaintenanceRequestObject) (oapi.PostMaintenanceResponseObject, error) {
+	form := templates.MaintenanceForm{
+		Name:            request.Body.Name,
+		Starts:          request.Body.Starts,
+		DurationMinutes: request.Body.DurationMinutes,
+		SkipPolling:     request.Body.SkipPolling != nil && *request.Body.SkipPolling != "",
+	}
+	if request.Body.Site != nil {
+		form.Site = *request.Body.Site
+	}
+	if request.Body.Group != nil {
+		form.Group = *request.Body.Group
+	}
+	if request.Body.DeviceId != nil {
+		form.DeviceID = *request.Body.DeviceId
+	}
+	if request.Body.Schedule != nil {
+		form.Schedule = *request.Body.Schedule
+	}
+	if request.Body.EditId != nil {
+		form.EditId = *request.Body.EditId
+	}
+
+	if err := form.Validate(); err != nil {
+		slog.ErrorContext(ctx, "validation error", slog.Any("error", err))
+		return s.postMaintenanceError(ctx, maintenanceWindowFromForm(form), err), nil
+	}
+
+	var before map[string]string
+	if form.EditId != 0 {
+		window, err := s.repository.MaintenanceWindow(ctx, form.EditId)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				slog.ErrorContext(ctx, "maintenance window not found", slog.Any("error", err))
+				return oapi.PageRedirectResponse{
+					Headers: oapi.PageRedirectResponseHeaders{
+						Location: "/maintenance",
+					},
+				}, nil
+			}
+			slog.ErrorContext(ctx, "database error", slog.Any("error", err))
+
+			return oapi.PostMaintenance500JSONResponse{
+				PageErrorJSONResponse: oapi.PageErrorJSONResponse{
+					Error:        "database error",
+					ErrorDetails: ptr(err.Error()),
+				},
+			}, nil
+		}
+		before = window.AuditFields()
+	}
+
+	window := maintenanceWindowFromForm(form)
+
+	var err error
+	if window.ID == 0 {
+		if window.ID, err = s.repository.CreateMaintenanceWindow(ctx, window); err != nil {
+			slog.ErrorContext(ctx, "database error", slog.Any("error", err))
+			return s.postMaintenanceError(ctx, window, errors.New("cannot save maintenance window, the name may be taken")), nil
+		}
+		s.audit(ctx, storage.AuditActionCreate, storage.AuditTargetMaintenance, window.ID, window.Name, nil, window.AuditFields())
+	} else {
+		if err = s.repository.UpdateMaintenanceWindow(ctx, window); err != nil {
+			slog.ErrorContext(ctx, "database error", slog.Any("error", err))
+			return s.postMaintenanceError(ctx, window, errors.New("cannot save maintenance window, the name may be taken")), nil
+		}
+		s.audit(ctx, storage.AuditActionUpdate, storage.AuditTargetMaintenance, window.ID, window.Name, before, window.AuditFields())
+	}
+
+	return oapi.PostMaintenance303Response{
+		Headers: oapi.PageRedirectResponseHeaders{
+			Location: "/maintenance",
+		},
+	}, nil
+}
+
+func maintenanceWindowFromForm(form templates.MaintenanceForm) storage.MaintenanceWindow {
+	return storage.MaintenanceWindow{
+		ID:          form.EditId,
+		Name:        form.Name,
+		Site:        form.Site,
+		Group:       form.Group,
+		DeviceID:    form.DeviceID,
+		Starts:      form.StartsAt,
+		Duration:    time.Duration(form.DurationMinutes) * time.Minute,
+		Schedule:    form.Schedule,
+		SkipPolling: form.SkipPolling,
+	}
+}
+
+func (s *Server) postMaintenanceError(ctx context.Context, edit storage.MaintenanceWindow, err error) oapi.PostMaintenanceResponseObject {
+	page, err2 := s.maintenancePage(ctx, edit, err.Error())
+	if err2 != nil {
+		slog.ErrorContext(ctx, "error rendering maintenance page", slog.Any("error", err2))
+		return oapi.PostMaintenance500JSONResponse{
+			PageErrorJSONResponse: oapi.PageErrorJSONResponse{
+				Error:        "error rendering maintenance page",
+				ErrorDetails: ptr(errors.Join(err, err2).Error()),
+			},
+		}
+	}
+
+	return oapi.PostMaintenance200TexthtmlResponse{
+		PageTexthtmlResponse: oapi.PageTexthtmlResponse{
+			Body:          page,
+			ContentLength: int64(page.Len()),
+		},
+	}
+}
+
+func (s *Server) PostMaintenanceDelete(ctx context.Context, request oapi.PostMaintenanceDeleteRequestObject) (oapi.PostMaintenanceDeleteResponseObject, error) {
+	window, err := s.repository.MaintenanceWindow(ctx, request.Body.DeleteId)
+	if err == sql.ErrNoRows {
+		slog.ErrorContext(ctx, "maintenance window not found", slog.Any("error", err))
+		return oapi.PageRedirectResponse{
+			Headers: oapi.PageRedirectResponseHeaders{
+				Location: "/maintenance",
+			},
+		}, nil
+	}
+	if err == nil {
+		err = s.repository.DeleteMaintenanceWindow(ctx, window.ID)
+	}
+	if err != nil {
+		slog.ErrorContext(ctx, "database error", slog.Any("error", err))
+		return oapi.PostMaintenanceDelete500JSONResponse{
+			PageErrorJSONResponse: oapi.PageErrorJSONResponse{
+				Error:        "database error",
+				ErrorDetails: ptr(err.Error()),
+			},
+		}, nil
+	}
+
+	s.audit(ctx, storage.AuditActionDelete, storage.AuditTargetMaintenance, window.ID, window.Name, window.AuditFields(), nil)
+
+	return oapi.PostMaintenanceDelete303Response{
+		Headers: oapi.PageRedirectResponseHeaders{
+			Location: "/maintenance",
+		},
+	}, nil
+}
+
+func (s *Server) maintenancePage(ctx context.Context, edit storage.MaintenanceWindow, errMsg string) (*bytes.Buffer, error) {
+	windows, err := s.repository.MaintenanceWindows(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	devices, err := s.repository.Devices(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.templateEx.ExecuteMaintenance(templates.MaintenancePageContent(windows, devices, edit, errMsg, time.Now()))
+}
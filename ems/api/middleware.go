@@ -6,39 +6,66 @@ import (
 	"net/http"
 
 	oapi "pi-wegrzyn/ems/api/oapi/generated"
+	"pi-wegrzyn/ems/auth"
 
 	strictnethttp "github.com/oapi-codegen/runtime/strictmiddleware/nethttp"
 )
 
 const (
-	GetSignInOperation     = "GetSignin"
-	PostSignInOperation    = "PostSignin"
-	GetStyleCSSOperation   = "GetStaticStyleCss"
-	GetFaviconIcoOperation = "GetStaticFaviconIco"
+	GetSignInOperation             = "GetSignin"
+	PostSignInOperation            = "PostSignin"
+	GetSignInOIDCOperation         = "GetSigninOidc"
+	GetSignInOIDCCallbackOperation = "GetSigninOidcCallback"
+	GetStyleCSSOperation           = "GetStaticStyleCss"
+	GetFaviconIcoOperation         = "GetStaticFaviconIco"
 )
 
 type SessionChecker interface {
 	IsSignedIn(r *http.Request) bool
+	Session(r *http.Request) (auth.User, bool)
+}
+
+func isSignInOperation(operationID string) bool {
+	return operationID == GetSignInOperation ||
+		operationID == PostSignInOperation ||
+		operationID == GetSignInOIDCOperation ||
+		operationID == GetSignInOIDCCallbackOperation
 }
 
 func NewAuthMiddleware(cfg Config, cookies SessionChecker) strictnethttp.StrictHTTPMiddlewareFunc {
 	return func(f strictnethttp.StrictHTTPHandlerFunc, operationID string) strictnethttp.StrictHTTPHandlerFunc {
 		return func(ctx context.Context, w http.ResponseWriter, r *http.Request, request any) (response any, err error) {
-			if (operationID == GetSignInOperation || operationID == PostSignInOperation) && cookies.IsSignedIn(r) {
+			if isSignInOperation(operationID) && cookies.IsSignedIn(r) {
 				return oapi.PageRedirectResponse{
 					Headers: oapi.PageRedirectResponseHeaders{
 						Location: "/",
 					},
 				}, nil
 			}
-			if operationID == GetSignInOperation ||
-				operationID == PostSignInOperation ||
+			if isSignInOperation(operationID) ||
 				operationID == GetStyleCSSOperation ||
-				operationID == GetFaviconIcoOperation ||
-				cookies.IsSignedIn(r) {
+				operationID == GetFaviconIcoOperation {
 				return f(ctx, w, r, request)
 			}
 
+			if user, ok := cookies.Session(r); ok {
+				if r.Method != http.MethodGet && !user.CanModify() {
+					slog.WarnContext(ctx, "operation not permitted for role",
+						slog.Any("operationID", operationID),
+						slog.Any("username", user.Login),
+						slog.Any("role", user.Role),
+					)
+
+					return oapi.PageRedirectResponse{
+						Headers: oapi.PageRedirectResponseHeaders{
+							Location: "/",
+						},
+					}, nil
+				}
+
+				return f(auth.WithUser(ctx, user), w, r, request)
+			}
+
 			return oapi.PageRedirectResponse{
 				Headers: oapi.PageRedirectResponseHeaders{
 					Location: "/signin",
@@ -18,6 +18,7 @@ import (
 	"net/url"
 	"path"
 	"strings"
+	"time"
 
 	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/oapi-codegen/runtime"
@@ -29,21 +30,214 @@ const (
 	CookieAuthScopes = "cookieAuth.Scopes"
 )
 
+// Defines values for InventoryFormat.
+const (
+	InventoryFormatCsv  InventoryFormat = "csv"
+	InventoryFormatYaml InventoryFormat = "yaml"
+)
+
 // Defines values for IpType.
 const (
 	N4 IpType = 4
 	N6 IpType = 6
 )
 
+// Defines values for GetParamsStatus.
+const (
+	KeyfileError GetParamsStatus = "keyfile-error"
+	Ok           GetParamsStatus = "ok"
+	SshError     GetParamsStatus = "ssh-error"
+	Undefined    GetParamsStatus = "undefined"
+	Warning      GetParamsStatus = "warning"
+)
+
+// Defines values for GetParamsSort.
+const (
+	Hostname GetParamsSort = "hostname"
+	Site     GetParamsSort = "site"
+	Status   GetParamsSort = "status"
+)
+
+// Defines values for GetParamsOrder.
+const (
+	Asc  GetParamsOrder = "asc"
+	Desc GetParamsOrder = "desc"
+)
+
+// Defines values for GetAuditExportParamsFormat.
+const (
+	GetAuditExportParamsFormatCsv  GetAuditExportParamsFormat = "csv"
+	GetAuditExportParamsFormatJson GetAuditExportParamsFormat = "json"
+)
+
+// Defines values for PostCredentialsMultipartBodyKind.
+const (
+	Agent    PostCredentialsMultipartBodyKind = "agent"
+	Key      PostCredentialsMultipartBodyKind = "key"
+	Password PostCredentialsMultipartBodyKind = "password"
+)
+
+// Defines values for PostEditMultipartBodyCollector.
+const (
+	PostEditMultipartBodyCollectorGnmi    PostEditMultipartBodyCollector = "gnmi"
+	PostEditMultipartBodyCollectorLocal   PostEditMultipartBodyCollector = "local"
+	PostEditMultipartBodyCollectorNetconf PostEditMultipartBodyCollector = "netconf"
+	PostEditMultipartBodyCollectorSnmp    PostEditMultipartBodyCollector = "snmp"
+	PostEditMultipartBodyCollectorSsh     PostEditMultipartBodyCollector = "ssh"
+)
+
+// Defines values for PostEditMultipartBodyGnmiMode.
+const (
+	PostEditMultipartBodyGnmiModeOnChange PostEditMultipartBodyGnmiMode = "on-change"
+	PostEditMultipartBodyGnmiModeSample   PostEditMultipartBodyGnmiMode = "sample"
+)
+
+// Defines values for PostEditMultipartBodySnmpVersion.
+const (
+	PostEditMultipartBodySnmpVersionN2c PostEditMultipartBodySnmpVersion = "2c"
+	PostEditMultipartBodySnmpVersionN3  PostEditMultipartBodySnmpVersion = "3"
+)
+
+// Defines values for PostNewMultipartBodyCollector.
+const (
+	PostNewMultipartBodyCollectorGnmi    PostNewMultipartBodyCollector = "gnmi"
+	PostNewMultipartBodyCollectorLocal   PostNewMultipartBodyCollector = "local"
+	PostNewMultipartBodyCollectorNetconf PostNewMultipartBodyCollector = "netconf"
+	PostNewMultipartBodyCollectorSnmp    PostNewMultipartBodyCollector = "snmp"
+	PostNewMultipartBodyCollectorSsh     PostNewMultipartBodyCollector = "ssh"
+)
+
+// Defines values for PostNewMultipartBodyGnmiMode.
+const (
+	PostNewMultipartBodyGnmiModeOnChange PostNewMultipartBodyGnmiMode = "on-change"
+	PostNewMultipartBodyGnmiModeSample   PostNewMultipartBodyGnmiMode = "sample"
+)
+
+// Defines values for PostNewMultipartBodySnmpVersion.
+const (
+	PostNewMultipartBodySnmpVersionN2c PostNewMultipartBodySnmpVersion = "2c"
+	PostNewMultipartBodySnmpVersionN3  PostNewMultipartBodySnmpVersion = "3"
+)
+
+// Defines values for PostRulesFormdataBodyComparison.
+const (
+	Above PostRulesFormdataBodyComparison = "above"
+	Below PostRulesFormdataBodyComparison = "below"
+	Falls PostRulesFormdataBodyComparison = "falls"
+	Rises PostRulesFormdataBodyComparison = "rises"
+)
+
+// AuditChange defines model for auditChange.
+type AuditChange struct {
+	After  string `json:"after"`
+	Before string `json:"before"`
+	Field  string `json:"field"`
+}
+
+// AuditEntry defines model for auditEntry.
+type AuditEntry struct {
+	Action     string        `json:"action"`
+	Actor      string        `json:"actor"`
+	Changes    []AuditChange `json:"changes"`
+	Id         uint          `json:"id"`
+	TargetId   uint          `json:"targetId"`
+	TargetName string        `json:"targetName"`
+	TargetType string        `json:"targetType"`
+	Time       time.Time     `json:"time"`
+}
+
+// InventoryFormat defines model for inventoryFormat.
+type InventoryFormat string
+
 // IpType defines model for ipType.
 type IpType int
 
+// AuditAction defines model for auditAction.
+type AuditAction = string
+
+// AuditActor defines model for auditActor.
+type AuditActor = string
+
+// AuditTarget defines model for auditTarget.
+type AuditTarget = string
+
 // PageError defines model for PageError.
 type PageError struct {
 	Error        string  `json:"error"`
 	ErrorDetails *string `json:"errorDetails,omitempty"`
 }
 
+// GetParams defines parameters for Get.
+type GetParams struct {
+	// Q Search in hostname, IP, login, site, group and tags
+	Q      *string          `form:"q,omitempty" json:"q,omitempty"`
+	Site   *string          `form:"site,omitempty" json:"site,omitempty"`
+	Group  *string          `form:"group,omitempty" json:"group,omitempty"`
+	Tag    *string          `form:"tag,omitempty" json:"tag,omitempty"`
+	Status *GetParamsStatus `form:"status,omitempty" json:"status,omitempty"`
+	Sort   *GetParamsSort   `form:"sort,omitempty" json:"sort,omitempty"`
+	Order  *GetParamsOrder  `form:"order,omitempty" json:"order,omitempty"`
+	Page   *int             `form:"page,omitempty" json:"page,omitempty"`
+}
+
+// GetParamsStatus defines parameters for Get.
+type GetParamsStatus string
+
+// GetParamsSort defines parameters for Get.
+type GetParamsSort string
+
+// GetParamsOrder defines parameters for Get.
+type GetParamsOrder string
+
+// GetAuditParams defines parameters for GetAudit.
+type GetAuditParams struct {
+	Actor  *AuditActor  `form:"actor,omitempty" json:"actor,omitempty"`
+	Action *AuditAction `form:"action,omitempty" json:"action,omitempty"`
+	Target *AuditTarget `form:"target,omitempty" json:"target,omitempty"`
+}
+
+// GetAuditExportParams defines parameters for GetAuditExport.
+type GetAuditExportParams struct {
+	Actor  *AuditActor                `form:"actor,omitempty" json:"actor,omitempty"`
+	Action *AuditAction               `form:"action,omitempty" json:"action,omitempty"`
+	Target *AuditTarget               `form:"target,omitempty" json:"target,omitempty"`
+	Format GetAuditExportParamsFormat `form:"format" json:"format"`
+}
+
+// GetAuditExportParamsFormat defines parameters for GetAuditExport.
+type GetAuditExportParamsFormat string
+
+// GetCapturesParams defines parameters for GetCaptures.
+type GetCapturesParams struct {
+	DeviceId uint `form:"device-id" json:"device-id"`
+}
+
+// GetCredentialsParams defines parameters for GetCredentials.
+type GetCredentialsParams struct {
+	// EditId Profile loaded into the form
+	EditId *uint `form:"edit-id,omitempty" json:"edit-id,omitempty"`
+}
+
+// PostCredentialsMultipartBody defines parameters for PostCredentials.
+type PostCredentialsMultipartBody struct {
+	Certificate *openapi_types.File              `json:"certificate,omitempty"`
+	EditId      *uint                            `json:"edit-id,omitempty"`
+	Key         *openapi_types.File              `json:"key,omitempty"`
+	Kind        PostCredentialsMultipartBodyKind `json:"kind"`
+	Login       string                           `json:"login"`
+	Name        string                           `json:"name"`
+	Passphrase  *string                          `json:"passphrase,omitempty"`
+	Password    *string                          `json:"password,omitempty"`
+}
+
+// PostCredentialsMultipartBodyKind defines parameters for PostCredentials.
+type PostCredentialsMultipartBodyKind string
+
+// PostCredentialsDeleteFormdataBody defines parameters for PostCredentialsDelete.
+type PostCredentialsDeleteFormdataBody struct {
+	DeleteId uint `form:"delete-id" json:"delete-id"`
+}
+
 // PostDeleteFormdataBody defines parameters for PostDelete.
 type PostDeleteFormdataBody struct {
 	DeleteId uint `form:"delete-id" json:"delete-id"`
@@ -56,15 +250,124 @@ type GetEditParams struct {
 
 // PostEditMultipartBody defines parameters for PostEdit.
 type PostEditMultipartBody struct {
-	EditId        uint                `json:"edit-id"`
-	Hostname      string              `json:"hostname"`
-	Ip            string              `json:"ip"`
-	IpType        IpType              `json:"ip-type"`
-	Key           *openapi_types.File `json:"key,omitempty"`
-	KeyClear      *string             `json:"key-clear,omitempty"`
-	Login         string              `json:"login"`
-	Password      *string             `json:"password,omitempty"`
-	PasswordClear *string             `json:"password-clear,omitempty"`
+	Certificate        *openapi_types.File               `json:"certificate,omitempty"`
+	Collector          *PostEditMultipartBodyCollector   `json:"collector,omitempty"`
+	CredentialId       *uint                             `json:"credential-id,omitempty"`
+	EditId             uint                              `json:"edit-id"`
+	GnmiMode           *PostEditMultipartBodyGnmiMode    `json:"gnmi-mode,omitempty"`
+	GnmiPort           *int                              `json:"gnmi-port,omitempty"`
+	GnmiSampleInterval *int                              `json:"gnmi-sample-interval,omitempty"`
+	GnmiSkipVerify     *string                           `json:"gnmi-skip-verify,omitempty"`
+	GnmiTls            *string                           `json:"gnmi-tls,omitempty"`
+	Group              *string                           `json:"group,omitempty"`
+	Hostname           string                            `json:"hostname"`
+	Ip                 string                            `json:"ip"`
+	IpType             IpType                            `json:"ip-type"`
+	JumpHostId         *uint                             `json:"jump-host-id,omitempty"`
+	Key                *openapi_types.File               `json:"key,omitempty"`
+	KeyClear           *string                           `json:"key-clear,omitempty"`
+	LocalInterfaces    *string                           `json:"local-interfaces,omitempty"`
+	LocalPath          *string                           `json:"local-path,omitempty"`
+	Login              *string                           `json:"login,omitempty"`
+	NetconfFilter      *string                           `json:"netconf-filter,omitempty"`
+	NetconfPlatform    *string                           `json:"netconf-platform,omitempty"`
+	NetconfPort        *int                              `json:"netconf-port,omitempty"`
+	Passphrase         *string                           `json:"passphrase,omitempty"`
+	Password           *string                           `json:"password,omitempty"`
+	PasswordClear      *string                           `json:"password-clear,omitempty"`
+	Port               *int                              `json:"port,omitempty"`
+	Site               *string                           `json:"site,omitempty"`
+	SnmpAuthPassword   *string                           `json:"snmp-auth-password,omitempty"`
+	SnmpAuthProtocol   *string                           `json:"snmp-auth-protocol,omitempty"`
+	SnmpCommunity      *string                           `json:"snmp-community,omitempty"`
+	SnmpPort           *int                              `json:"snmp-port,omitempty"`
+	SnmpPrivPassword   *string                           `json:"snmp-priv-password,omitempty"`
+	SnmpPrivProtocol   *string                           `json:"snmp-priv-protocol,omitempty"`
+	SnmpUser           *string                           `json:"snmp-user,omitempty"`
+	SnmpVersion        *PostEditMultipartBodySnmpVersion `json:"snmp-version,omitempty"`
+	Tags               *string                           `json:"tags,omitempty"`
+}
+
+// PostEditMultipartBodyCollector defines parameters for PostEdit.
+type PostEditMultipartBodyCollector string
+
+// PostEditMultipartBodyGnmiMode defines parameters for PostEdit.
+type PostEditMultipartBodyGnmiMode string
+
+// PostEditMultipartBodySnmpVersion defines parameters for PostEdit.
+type PostEditMultipartBodySnmpVersion string
+
+// GetEventsParams defines parameters for GetEvents.
+type GetEventsParams struct {
+	DeviceId *uint   `form:"device-id,omitempty" json:"device-id,omitempty"`
+	Kind     *string `form:"kind,omitempty" json:"kind,omitempty"`
+}
+
+// GetExportParams defines parameters for GetExport.
+type GetExportParams struct {
+	Format InventoryFormat `form:"format" json:"format"`
+}
+
+// PostImportMultipartBody defines parameters for PostImport.
+type PostImportMultipartBody struct {
+	Apply   *string             `json:"apply,omitempty"`
+	Content *string             `json:"content,omitempty"`
+	File    *openapi_types.File `json:"file,omitempty"`
+	Format  *InventoryFormat    `json:"format,omitempty"`
+}
+
+// GetInspectParams defines parameters for GetInspect.
+type GetInspectParams struct {
+	DeviceId  uint    `form:"device-id" json:"device-id"`
+	Interface *string `form:"interface,omitempty" json:"interface,omitempty"`
+}
+
+// GetJumpHostsParams defines parameters for GetJumpHosts.
+type GetJumpHostsParams struct {
+	// EditId Jump host loaded into the form
+	EditId *uint `form:"edit-id,omitempty" json:"edit-id,omitempty"`
+}
+
+// PostJumpHostsFormdataBody defines parameters for PostJumpHosts.
+type PostJumpHostsFormdataBody struct {
+	Address      string `form:"address" json:"address"`
+	CredentialId uint   `form:"credential-id" json:"credential-id"`
+	EditId       *uint  `form:"edit-id,omitempty" json:"edit-id,omitempty"`
+	Name         string `form:"name" json:"name"`
+	Port         *int   `form:"port,omitempty" json:"port,omitempty"`
+	ViaId        *uint  `form:"via-id,omitempty" json:"via-id,omitempty"`
+}
+
+// PostJumpHostsDeleteFormdataBody defines parameters for PostJumpHostsDelete.
+type PostJumpHostsDeleteFormdataBody struct {
+	DeleteId uint `form:"delete-id" json:"delete-id"`
+}
+
+// GetLinksParams defines parameters for GetLinks.
+type GetLinksParams struct {
+	// EditId Link loaded into the form
+	EditId *uint `form:"edit-id,omitempty" json:"edit-id,omitempty"`
+}
+
+// PostLinksFormdataBody defines parameters for PostLinks.
+type PostLinksFormdataBody struct {
+	ADeviceId  uint     `form:"a-device-id" json:"a-device-id"`
+	AInterface string   `form:"a-interface" json:"a-interface"`
+	AlarmDelta *float64 `form:"alarm-delta,omitempty" json:"alarm-delta,omitempty"`
+	BDeviceId  uint     `form:"b-device-id" json:"b-device-id"`
+	BInterface string   `form:"b-interface" json:"b-interface"`
+	EditId     *uint    `form:"edit-id,omitempty" json:"edit-id,omitempty"`
+	Name       string   `form:"name" json:"name"`
+}
+
+// PostLinksBaselineFormdataBody defines parameters for PostLinksBaseline.
+type PostLinksBaselineFormdataBody struct {
+	LinkId uint `form:"link-id" json:"link-id"`
+}
+
+// PostLinksDeleteFormdataBody defines parameters for PostLinksDelete.
+type PostLinksDeleteFormdataBody struct {
+	DeleteId uint `form:"delete-id" json:"delete-id"`
 }
 
 // GetLogoutParams defines parameters for GetLogout.
@@ -72,14 +375,120 @@ type GetLogoutParams struct {
 	SessionToken *string `form:"session_token,omitempty" json:"session_token,omitempty"`
 }
 
+// GetMaintenanceParams defines parameters for GetMaintenance.
+type GetMaintenanceParams struct {
+	// EditId Maintenance window loaded into the form
+	EditId *uint `form:"edit-id,omitempty" json:"edit-id,omitempty"`
+
+	// DeviceId Device muted by a new window loaded into the form
+	DeviceId *uint `form:"device-id,omitempty" json:"device-id,omitempty"`
+}
+
+// PostMaintenanceFormdataBody defines parameters for PostMaintenance.
+type PostMaintenanceFormdataBody struct {
+	DeviceId        *uint   `form:"device-id,omitempty" json:"device-id,omitempty"`
+	DurationMinutes int     `form:"duration-minutes" json:"duration-minutes"`
+	EditId          *uint   `form:"edit-id,omitempty" json:"edit-id,omitempty"`
+	Group           *string `form:"group,omitempty" json:"group,omitempty"`
+	Name            string  `form:"name" json:"name"`
+
+	// Schedule Cron expression of recurring starts, empty for a one-off window
+	Schedule    *string `form:"schedule,omitempty" json:"schedule,omitempty"`
+	Site        *string `form:"site,omitempty" json:"site,omitempty"`
+	SkipPolling *string `form:"skip-polling,omitempty" json:"skip-polling,omitempty"`
+
+	// Starts Local time of the server, e.g. 2026-10-19T22:00
+	Starts string `form:"starts" json:"starts"`
+}
+
+// PostMaintenanceDeleteFormdataBody defines parameters for PostMaintenanceDelete.
+type PostMaintenanceDeleteFormdataBody struct {
+	DeleteId uint `form:"delete-id" json:"delete-id"`
+}
+
 // PostNewMultipartBody defines parameters for PostNew.
 type PostNewMultipartBody struct {
-	Hostname string              `json:"hostname"`
-	Ip       string              `json:"ip"`
-	IpType   IpType              `json:"ip-type"`
-	Key      *openapi_types.File `json:"key,omitempty"`
-	Login    string              `json:"login"`
-	Password *string             `json:"password,omitempty"`
+	Certificate        *openapi_types.File              `json:"certificate,omitempty"`
+	Collector          *PostNewMultipartBodyCollector   `json:"collector,omitempty"`
+	CredentialId       *uint                            `json:"credential-id,omitempty"`
+	GnmiMode           *PostNewMultipartBodyGnmiMode    `json:"gnmi-mode,omitempty"`
+	GnmiPort           *int                             `json:"gnmi-port,omitempty"`
+	GnmiSampleInterval *int                             `json:"gnmi-sample-interval,omitempty"`
+	GnmiSkipVerify     *string                          `json:"gnmi-skip-verify,omitempty"`
+	GnmiTls            *string                          `json:"gnmi-tls,omitempty"`
+	Group              *string                          `json:"group,omitempty"`
+	Hostname           string                           `json:"hostname"`
+	Ip                 string                           `json:"ip"`
+	IpType             IpType                           `json:"ip-type"`
+	JumpHostId         *uint                            `json:"jump-host-id,omitempty"`
+	Key                *openapi_types.File              `json:"key,omitempty"`
+	LocalInterfaces    *string                          `json:"local-interfaces,omitempty"`
+	LocalPath          *string                          `json:"local-path,omitempty"`
+	Login              *string                          `json:"login,omitempty"`
+	NetconfFilter      *string                          `json:"netconf-filter,omitempty"`
+	NetconfPlatform    *string                          `json:"netconf-platform,omitempty"`
+	NetconfPort        *int                             `json:"netconf-port,omitempty"`
+	Passphrase         *string                          `json:"passphrase,omitempty"`
+	Password           *string                          `json:"password,omitempty"`
+	Port               *int                             `json:"port,omitempty"`
+	Site               *string                          `json:"site,omitempty"`
+	SnmpAuthPassword   *string                          `json:"snmp-auth-password,omitempty"`
+	SnmpAuthProtocol   *string                          `json:"snmp-auth-protocol,omitempty"`
+	SnmpCommunity      *string                          `json:"snmp-community,omitempty"`
+	SnmpPort           *int                             `json:"snmp-port,omitempty"`
+	SnmpPrivPassword   *string                          `json:"snmp-priv-password,omitempty"`
+	SnmpPrivProtocol   *string                          `json:"snmp-priv-protocol,omitempty"`
+	SnmpUser           *string                          `json:"snmp-user,omitempty"`
+	SnmpVersion        *PostNewMultipartBodySnmpVersion `json:"snmp-version,omitempty"`
+	Tags               *string                          `json:"tags,omitempty"`
+}
+
+// PostNewMultipartBodyCollector defines parameters for PostNew.
+type PostNewMultipartBodyCollector string
+
+// PostNewMultipartBodyGnmiMode defines parameters for PostNew.
+type PostNewMultipartBodyGnmiMode string
+
+// PostNewMultipartBodySnmpVersion defines parameters for PostNew.
+type PostNewMultipartBodySnmpVersion string
+
+// GetRulesParams defines parameters for GetRules.
+type GetRulesParams struct {
+	// EditId Rule loaded into the form
+	EditId *uint `form:"edit-id,omitempty" json:"edit-id,omitempty"`
+}
+
+// PostRulesFormdataBody defines parameters for PostRules.
+type PostRulesFormdataBody struct {
+	Comparison    PostRulesFormdataBodyComparison `form:"comparison" json:"comparison"`
+	DeviceId      *uint                           `form:"device-id,omitempty" json:"device-id,omitempty"`
+	EditId        *uint                           `form:"edit-id,omitempty" json:"edit-id,omitempty"`
+	Enabled       *string                         `form:"enabled,omitempty" json:"enabled,omitempty"`
+	ForPolls      *int                            `form:"for-polls,omitempty" json:"for-polls,omitempty"`
+	ForSeconds    *int                            `form:"for-seconds,omitempty" json:"for-seconds,omitempty"`
+	Group         *string                         `form:"group,omitempty" json:"group,omitempty"`
+	Hysteresis    *float64                        `form:"hysteresis,omitempty" json:"hysteresis,omitempty"`
+	Interface     *string                         `form:"interface,omitempty" json:"interface,omitempty"`
+	Metric        string                          `form:"metric" json:"metric"`
+	Name          string                          `form:"name" json:"name"`
+	Site          *string                         `form:"site,omitempty" json:"site,omitempty"`
+	Tag           *string                         `form:"tag,omitempty" json:"tag,omitempty"`
+	Threshold     float64                         `form:"threshold" json:"threshold"`
+	WindowMinutes *int                            `form:"window-minutes,omitempty" json:"window-minutes,omitempty"`
+}
+
+// PostRulesFormdataBodyComparison defines parameters for PostRules.
+type PostRulesFormdataBodyComparison string
+
+// PostRulesDeleteFormdataBody defines parameters for PostRulesDelete.
+type PostRulesDeleteFormdataBody struct {
+	DeleteId uint `form:"delete-id" json:"delete-id"`
+}
+
+// PostRulesSilenceFormdataBody defines parameters for PostRulesSilence.
+type PostRulesSilenceFormdataBody struct {
+	Minutes int  `form:"minutes" json:"minutes"`
+	RuleId  uint `form:"rule-id" json:"rule-id"`
 }
 
 // PostSigninFormdataBody defines parameters for PostSignin.
@@ -88,15 +497,61 @@ type PostSigninFormdataBody struct {
 	Password string              `form:"password" json:"password"`
 }
 
+// GetSigninOidcCallbackParams defines parameters for GetSigninOidcCallback.
+type GetSigninOidcCallbackParams struct {
+	Code  *string `form:"code,omitempty" json:"code,omitempty"`
+	State *string `form:"state,omitempty" json:"state,omitempty"`
+	Error *string `form:"error,omitempty" json:"error,omitempty"`
+}
+
+// PostCredentialsMultipartRequestBody defines body for PostCredentials for multipart/form-data ContentType.
+type PostCredentialsMultipartRequestBody PostCredentialsMultipartBody
+
+// PostCredentialsDeleteFormdataRequestBody defines body for PostCredentialsDelete for application/x-www-form-urlencoded ContentType.
+type PostCredentialsDeleteFormdataRequestBody PostCredentialsDeleteFormdataBody
+
 // PostDeleteFormdataRequestBody defines body for PostDelete for application/x-www-form-urlencoded ContentType.
 type PostDeleteFormdataRequestBody PostDeleteFormdataBody
 
 // PostEditMultipartRequestBody defines body for PostEdit for multipart/form-data ContentType.
 type PostEditMultipartRequestBody PostEditMultipartBody
 
+// PostImportMultipartRequestBody defines body for PostImport for multipart/form-data ContentType.
+type PostImportMultipartRequestBody PostImportMultipartBody
+
+// PostJumpHostsFormdataRequestBody defines body for PostJumpHosts for application/x-www-form-urlencoded ContentType.
+type PostJumpHostsFormdataRequestBody PostJumpHostsFormdataBody
+
+// PostJumpHostsDeleteFormdataRequestBody defines body for PostJumpHostsDelete for application/x-www-form-urlencoded ContentType.
+type PostJumpHostsDeleteFormdataRequestBody PostJumpHostsDeleteFormdataBody
+
+// PostLinksFormdataRequestBody defines body for PostLinks for application/x-www-form-urlencoded ContentType.
+type PostLinksFormdataRequestBody PostLinksFormdataBody
+
+// PostLinksBaselineFormdataRequestBody defines body for PostLinksBaseline for application/x-www-form-urlencoded ContentType.
+type PostLinksBaselineFormdataRequestBody PostLinksBaselineFormdataBody
+
+// PostLinksDeleteFormdataRequestBody defines body for PostLinksDelete for application/x-www-form-urlencoded ContentType.
+type PostLinksDeleteFormdataRequestBody PostLinksDeleteFormdataBody
+
+// PostMaintenanceFormdataRequestBody defines body for PostMaintenance for application/x-www-form-urlencoded ContentType.
+type PostMaintenanceFormdataRequestBody PostMaintenanceFormdataBody
+
+// PostMaintenanceDeleteFormdataRequestBody defines body for PostMaintenanceDelete for application/x-www-form-urlencoded ContentType.
+type PostMaintenanceDeleteFormdataRequestBody PostMaintenanceDeleteFormdataBody
+
 // PostNewMultipartRequestBody defines body for PostNew for multipart/form-data ContentType.
 type PostNewMultipartRequestBody PostNewMultipartBody
 
+// PostRulesFormdataRequestBody defines body for PostRules for application/x-www-form-urlencoded ContentType.
+type PostRulesFormdataRequestBody PostRulesFormdataBody
+
+// PostRulesDeleteFormdataRequestBody defines body for PostRulesDelete for application/x-www-form-urlencoded ContentType.
+type PostRulesDeleteFormdataRequestBody PostRulesDeleteFormdataBody
+
+// PostRulesSilenceFormdataRequestBody defines body for PostRulesSilence for application/x-www-form-urlencoded ContentType.
+type PostRulesSilenceFormdataRequestBody PostRulesSilenceFormdataBody
+
 // PostSigninFormdataRequestBody defines body for PostSignin for application/x-www-form-urlencoded ContentType.
 type PostSigninFormdataRequestBody PostSigninFormdataBody
 
@@ -104,7 +559,25 @@ type PostSigninFormdataRequestBody PostSigninFormdataBody
 type ServerInterface interface {
 	// Main configuration page
 	// (GET /)
-	Get(w http.ResponseWriter, r *http.Request)
+	Get(w http.ResponseWriter, r *http.Request, params GetParams)
+	// Load audit log page
+	// (GET /audit)
+	GetAudit(w http.ResponseWriter, r *http.Request, params GetAuditParams)
+	// Export audit log
+	// (GET /audit/export)
+	GetAuditExport(w http.ResponseWriter, r *http.Request, params GetAuditExportParams)
+	// Download raw EEPROM captures of a device
+	// (GET /captures)
+	GetCaptures(w http.ResponseWriter, r *http.Request, params GetCapturesParams)
+	// Load credential profiles page
+	// (GET /credentials)
+	GetCredentials(w http.ResponseWriter, r *http.Request, params GetCredentialsParams)
+	// Create or update credential profile
+	// (POST /credentials)
+	PostCredentials(w http.ResponseWriter, r *http.Request)
+	// Delete credential profile not used by any device
+	// (POST /credentials/delete)
+	PostCredentialsDelete(w http.ResponseWriter, r *http.Request)
 	// Load Edit device page
 	// (POST /delete)
 	PostDelete(w http.ResponseWriter, r *http.Request)
@@ -114,21 +587,84 @@ type ServerInterface interface {
 	// Update device
 	// (POST /edit)
 	PostEdit(w http.ResponseWriter, r *http.Request)
+	// Load interface events page
+	// (GET /events)
+	GetEvents(w http.ResponseWriter, r *http.Request, params GetEventsParams)
+	// Export devices without credentials
+	// (GET /export)
+	GetExport(w http.ResponseWriter, r *http.Request, params GetExportParams)
+	// Load device import page
+	// (GET /import)
+	GetImport(w http.ResponseWriter, r *http.Request)
+	// Preview or apply device import
+	// (POST /import)
+	PostImport(w http.ResponseWriter, r *http.Request)
+	// Load EEPROM inspector page
+	// (GET /inspect)
+	GetInspect(w http.ResponseWriter, r *http.Request, params GetInspectParams)
+	// Load jump hosts page
+	// (GET /jump-hosts)
+	GetJumpHosts(w http.ResponseWriter, r *http.Request, params GetJumpHostsParams)
+	// Create or update jump host
+	// (POST /jump-hosts)
+	PostJumpHosts(w http.ResponseWriter, r *http.Request)
+	// Delete jump host not used by any device or other jump host
+	// (POST /jump-hosts/delete)
+	PostJumpHostsDelete(w http.ResponseWriter, r *http.Request)
+	// Load fiber links page
+	// (GET /links)
+	GetLinks(w http.ResponseWriter, r *http.Request, params GetLinksParams)
+	// Create or update fiber link
+	// (POST /links)
+	PostLinks(w http.ResponseWriter, r *http.Request)
+	// Reset span loss baseline of fiber link, the next reading becomes the new baseline
+	// (POST /links/baseline)
+	PostLinksBaseline(w http.ResponseWriter, r *http.Request)
+	// Delete fiber link
+	// (POST /links/delete)
+	PostLinksDelete(w http.ResponseWriter, r *http.Request)
 	// Log out
 	// (GET /logout)
 	GetLogout(w http.ResponseWriter, r *http.Request, params GetLogoutParams)
+	// Load maintenance windows page
+	// (GET /maintenance)
+	GetMaintenance(w http.ResponseWriter, r *http.Request, params GetMaintenanceParams)
+	// Create or update maintenance window
+	// (POST /maintenance)
+	PostMaintenance(w http.ResponseWriter, r *http.Request)
+	// Delete maintenance window
+	// (POST /maintenance/delete)
+	PostMaintenanceDelete(w http.ResponseWriter, r *http.Request)
 	// Load New device page
 	// (GET /new)
 	GetNew(w http.ResponseWriter, r *http.Request)
 	// Create new device
 	// (POST /new)
 	PostNew(w http.ResponseWriter, r *http.Request)
+	// Load alert rules page
+	// (GET /rules)
+	GetRules(w http.ResponseWriter, r *http.Request, params GetRulesParams)
+	// Create or update alert rule
+	// (POST /rules)
+	PostRules(w http.ResponseWriter, r *http.Request)
+	// Delete alert rule
+	// (POST /rules/delete)
+	PostRulesDelete(w http.ResponseWriter, r *http.Request)
+	// Silence alerts of rule for a number of minutes, 0 lifts the silence
+	// (POST /rules/silence)
+	PostRulesSilence(w http.ResponseWriter, r *http.Request)
 	// Load signin page
 	// (GET /signin)
 	GetSignin(w http.ResponseWriter, r *http.Request)
 	// Handle login
 	// (POST /signin)
 	PostSignin(w http.ResponseWriter, r *http.Request)
+	// Start single sign-on login
+	// (GET /signin/oidc)
+	GetSigninOidc(w http.ResponseWriter, r *http.Request)
+	// Finish single sign-on login
+	// (GET /signin/oidc/callback)
+	GetSigninOidcCallback(w http.ResponseWriter, r *http.Request, params GetSigninOidcCallbackParams)
 	// Serve the favicon
 	// (GET /static/favicon.ico)
 	GetStaticFaviconIco(w http.ResponseWriter, r *http.Request)
@@ -149,14 +685,83 @@ type MiddlewareFunc func(http.Handler) http.Handler
 // Get operation middleware
 func (siw *ServerInterfaceWrapper) Get(w http.ResponseWriter, r *http.Request) {
 
+	var err error
+
 	ctx := r.Context()
 
 	ctx = context.WithValue(ctx, CookieAuthScopes, []string{})
 
 	r = r.WithContext(ctx)
 
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetParams
+
+	// ------------- Optional query parameter "q" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "q", r.URL.Query(), &params.Q)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "q", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "site" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "site", r.URL.Query(), &params.Site)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "site", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "group" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "group", r.URL.Query(), &params.Group)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "group", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "tag" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "tag", r.URL.Query(), &params.Tag)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "tag", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "status" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "status", r.URL.Query(), &params.Status)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "status", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "sort" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sort", r.URL.Query(), &params.Sort)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "sort", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "order" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "order", r.URL.Query(), &params.Order)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "order", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "page" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "page", r.URL.Query(), &params.Page)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "page", Err: err})
+		return
+	}
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.Get(w, r)
+		siw.Handler.Get(w, r, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -166,8 +771,10 @@ func (siw *ServerInterfaceWrapper) Get(w http.ResponseWriter, r *http.Request) {
 	handler.ServeHTTP(w, r)
 }
 
-// PostDelete operation middleware
-func (siw *ServerInterfaceWrapper) PostDelete(w http.ResponseWriter, r *http.Request) {
+// GetAudit operation middleware
+func (siw *ServerInterfaceWrapper) GetAudit(w http.ResponseWriter, r *http.Request) {
+
+	var err error
 
 	ctx := r.Context()
 
@@ -175,8 +782,35 @@ func (siw *ServerInterfaceWrapper) PostDelete(w http.ResponseWriter, r *http.Req
 
 	r = r.WithContext(ctx)
 
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetAuditParams
+
+	// ------------- Optional query parameter "actor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "actor", r.URL.Query(), &params.Actor)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "actor", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "action" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "action", r.URL.Query(), &params.Action)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "action", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "target" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "target", r.URL.Query(), &params.Target)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "target", Err: err})
+		return
+	}
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.PostDelete(w, r)
+		siw.Handler.GetAudit(w, r, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -186,8 +820,8 @@ func (siw *ServerInterfaceWrapper) PostDelete(w http.ResponseWriter, r *http.Req
 	handler.ServeHTTP(w, r)
 }
 
-// GetEdit operation middleware
-func (siw *ServerInterfaceWrapper) GetEdit(w http.ResponseWriter, r *http.Request) {
+// GetAuditExport operation middleware
+func (siw *ServerInterfaceWrapper) GetAuditExport(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
@@ -198,25 +832,49 @@ func (siw *ServerInterfaceWrapper) GetEdit(w http.ResponseWriter, r *http.Reques
 	r = r.WithContext(ctx)
 
 	// Parameter object where we will unmarshal all parameters from the context
-	var params GetEditParams
+	var params GetAuditExportParams
 
-	// ------------- Required query parameter "edit-id" -------------
+	// ------------- Optional query parameter "actor" -------------
 
-	if paramValue := r.URL.Query().Get("edit-id"); paramValue != "" {
+	err = runtime.BindQueryParameter("form", true, false, "actor", r.URL.Query(), &params.Actor)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "actor", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "action" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "action", r.URL.Query(), &params.Action)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "action", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "target" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "target", r.URL.Query(), &params.Target)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "target", Err: err})
+		return
+	}
+
+	// ------------- Required query parameter "format" -------------
+
+	if paramValue := r.URL.Query().Get("format"); paramValue != "" {
 
 	} else {
-		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "edit-id"})
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "format"})
 		return
 	}
 
-	err = runtime.BindQueryParameter("form", true, true, "edit-id", r.URL.Query(), &params.EditId)
+	err = runtime.BindQueryParameter("form", true, true, "format", r.URL.Query(), &params.Format)
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "edit-id", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "format", Err: err})
 		return
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetEdit(w, r, params)
+		siw.Handler.GetAuditExport(w, r, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -226,8 +884,10 @@ func (siw *ServerInterfaceWrapper) GetEdit(w http.ResponseWriter, r *http.Reques
 	handler.ServeHTTP(w, r)
 }
 
-// PostEdit operation middleware
-func (siw *ServerInterfaceWrapper) PostEdit(w http.ResponseWriter, r *http.Request) {
+// GetCaptures operation middleware
+func (siw *ServerInterfaceWrapper) GetCaptures(w http.ResponseWriter, r *http.Request) {
+
+	var err error
 
 	ctx := r.Context()
 
@@ -235,8 +895,26 @@ func (siw *ServerInterfaceWrapper) PostEdit(w http.ResponseWriter, r *http.Reque
 
 	r = r.WithContext(ctx)
 
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetCapturesParams
+
+	// ------------- Required query parameter "device-id" -------------
+
+	if paramValue := r.URL.Query().Get("device-id"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "device-id"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "device-id", r.URL.Query(), &params.DeviceId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "device-id", Err: err})
+		return
+	}
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.PostEdit(w, r)
+		siw.Handler.GetCaptures(w, r, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -246,8 +924,8 @@ func (siw *ServerInterfaceWrapper) PostEdit(w http.ResponseWriter, r *http.Reque
 	handler.ServeHTTP(w, r)
 }
 
-// GetLogout operation middleware
-func (siw *ServerInterfaceWrapper) GetLogout(w http.ResponseWriter, r *http.Request) {
+// GetCredentials operation middleware
+func (siw *ServerInterfaceWrapper) GetCredentials(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
@@ -258,25 +936,18 @@ func (siw *ServerInterfaceWrapper) GetLogout(w http.ResponseWriter, r *http.Requ
 	r = r.WithContext(ctx)
 
 	// Parameter object where we will unmarshal all parameters from the context
-	var params GetLogoutParams
+	var params GetCredentialsParams
 
-	{
-		var cookie *http.Cookie
-
-		if cookie, err = r.Cookie("session_token"); err == nil {
-			var value string
-			err = runtime.BindStyledParameterWithOptions("simple", "session_token", cookie.Value, &value, runtime.BindStyledParameterOptions{Explode: true, Required: false})
-			if err != nil {
-				siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "session_token", Err: err})
-				return
-			}
-			params.SessionToken = &value
+	// ------------- Optional query parameter "edit-id" -------------
 
-		}
+	err = runtime.BindQueryParameter("form", true, false, "edit-id", r.URL.Query(), &params.EditId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "edit-id", Err: err})
+		return
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetLogout(w, r, params)
+		siw.Handler.GetCredentials(w, r, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -286,8 +957,8 @@ func (siw *ServerInterfaceWrapper) GetLogout(w http.ResponseWriter, r *http.Requ
 	handler.ServeHTTP(w, r)
 }
 
-// GetNew operation middleware
-func (siw *ServerInterfaceWrapper) GetNew(w http.ResponseWriter, r *http.Request) {
+// PostCredentials operation middleware
+func (siw *ServerInterfaceWrapper) PostCredentials(w http.ResponseWriter, r *http.Request) {
 
 	ctx := r.Context()
 
@@ -296,7 +967,7 @@ func (siw *ServerInterfaceWrapper) GetNew(w http.ResponseWriter, r *http.Request
 	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetNew(w, r)
+		siw.Handler.PostCredentials(w, r)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -306,8 +977,8 @@ func (siw *ServerInterfaceWrapper) GetNew(w http.ResponseWriter, r *http.Request
 	handler.ServeHTTP(w, r)
 }
 
-// PostNew operation middleware
-func (siw *ServerInterfaceWrapper) PostNew(w http.ResponseWriter, r *http.Request) {
+// PostCredentialsDelete operation middleware
+func (siw *ServerInterfaceWrapper) PostCredentialsDelete(w http.ResponseWriter, r *http.Request) {
 
 	ctx := r.Context()
 
@@ -316,7 +987,7 @@ func (siw *ServerInterfaceWrapper) PostNew(w http.ResponseWriter, r *http.Reques
 	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.PostNew(w, r)
+		siw.Handler.PostCredentialsDelete(w, r)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -326,11 +997,17 @@ func (siw *ServerInterfaceWrapper) PostNew(w http.ResponseWriter, r *http.Reques
 	handler.ServeHTTP(w, r)
 }
 
-// GetSignin operation middleware
-func (siw *ServerInterfaceWrapper) GetSignin(w http.ResponseWriter, r *http.Request) {
+// PostDelete operation middleware
+func (siw *ServerInterfaceWrapper) PostDelete(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetSignin(w, r)
+		siw.Handler.PostDelete(w, r)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -340,11 +1017,37 @@ func (siw *ServerInterfaceWrapper) GetSignin(w http.ResponseWriter, r *http.Requ
 	handler.ServeHTTP(w, r)
 }
 
-// PostSignin operation middleware
-func (siw *ServerInterfaceWrapper) PostSignin(w http.ResponseWriter, r *http.Request) {
+// GetEdit operation middleware
+func (siw *ServerInterfaceWrapper) GetEdit(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetEditParams
+
+	// ------------- Required query parameter "edit-id" -------------
+
+	if paramValue := r.URL.Query().Get("edit-id"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "edit-id"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "edit-id", r.URL.Query(), &params.EditId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "edit-id", Err: err})
+		return
+	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.PostSignin(w, r)
+		siw.Handler.GetEdit(w, r, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -354,11 +1057,17 @@ func (siw *ServerInterfaceWrapper) PostSignin(w http.ResponseWriter, r *http.Req
 	handler.ServeHTTP(w, r)
 }
 
-// GetStaticFaviconIco operation middleware
-func (siw *ServerInterfaceWrapper) GetStaticFaviconIco(w http.ResponseWriter, r *http.Request) {
+// PostEdit operation middleware
+func (siw *ServerInterfaceWrapper) PostEdit(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetStaticFaviconIco(w, r)
+		siw.Handler.PostEdit(w, r)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -368,11 +1077,38 @@ func (siw *ServerInterfaceWrapper) GetStaticFaviconIco(w http.ResponseWriter, r
 	handler.ServeHTTP(w, r)
 }
 
-// GetStaticStyleCss operation middleware
-func (siw *ServerInterfaceWrapper) GetStaticStyleCss(w http.ResponseWriter, r *http.Request) {
+// GetEvents operation middleware
+func (siw *ServerInterfaceWrapper) GetEvents(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetEventsParams
+
+	// ------------- Optional query parameter "device-id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "device-id", r.URL.Query(), &params.DeviceId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "device-id", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "kind" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "kind", r.URL.Query(), &params.Kind)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "kind", Err: err})
+		return
+	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetStaticStyleCss(w, r)
+		siw.Handler.GetEvents(w, r, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -382,620 +1118,2979 @@ func (siw *ServerInterfaceWrapper) GetStaticStyleCss(w http.ResponseWriter, r *h
 	handler.ServeHTTP(w, r)
 }
 
-type UnescapedCookieParamError struct {
-	ParamName string
-	Err       error
-}
+// GetExport operation middleware
+func (siw *ServerInterfaceWrapper) GetExport(w http.ResponseWriter, r *http.Request) {
 
-func (e *UnescapedCookieParamError) Error() string {
-	return fmt.Sprintf("error unescaping cookie parameter '%s'", e.ParamName)
-}
+	var err error
 
-func (e *UnescapedCookieParamError) Unwrap() error {
-	return e.Err
-}
+	ctx := r.Context()
 
-type UnmarshalingParamError struct {
-	ParamName string
-	Err       error
-}
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{})
 
-func (e *UnmarshalingParamError) Error() string {
-	return fmt.Sprintf("Error unmarshaling parameter %s as JSON: %s", e.ParamName, e.Err.Error())
-}
+	r = r.WithContext(ctx)
 
-func (e *UnmarshalingParamError) Unwrap() error {
-	return e.Err
-}
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetExportParams
 
-type RequiredParamError struct {
-	ParamName string
-}
+	// ------------- Required query parameter "format" -------------
 
-func (e *RequiredParamError) Error() string {
-	return fmt.Sprintf("Query argument %s is required, but not found", e.ParamName)
-}
+	if paramValue := r.URL.Query().Get("format"); paramValue != "" {
 
-type RequiredHeaderError struct {
-	ParamName string
-	Err       error
-}
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "format"})
+		return
+	}
 
-func (e *RequiredHeaderError) Error() string {
-	return fmt.Sprintf("Header parameter %s is required, but not found", e.ParamName)
-}
+	err = runtime.BindQueryParameter("form", true, true, "format", r.URL.Query(), &params.Format)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "format", Err: err})
+		return
+	}
 
-func (e *RequiredHeaderError) Unwrap() error {
-	return e.Err
-}
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetExport(w, r, params)
+	}))
 
-type InvalidParamFormatError struct {
-	ParamName string
-	Err       error
-}
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-func (e *InvalidParamFormatError) Error() string {
-	return fmt.Sprintf("Invalid format for parameter %s: %s", e.ParamName, e.Err.Error())
+	handler.ServeHTTP(w, r)
 }
 
-func (e *InvalidParamFormatError) Unwrap() error {
-	return e.Err
-}
+// GetImport operation middleware
+func (siw *ServerInterfaceWrapper) GetImport(w http.ResponseWriter, r *http.Request) {
 
-type TooManyValuesForParamError struct {
-	ParamName string
-	Count     int
-}
+	ctx := r.Context()
 
-func (e *TooManyValuesForParamError) Error() string {
-	return fmt.Sprintf("Expected one value for %s, got %d", e.ParamName, e.Count)
-}
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{})
 
-// Handler creates http.Handler with routing matching OpenAPI spec.
-func Handler(si ServerInterface) http.Handler {
-	return HandlerWithOptions(si, StdHTTPServerOptions{})
-}
+	r = r.WithContext(ctx)
 
-// ServeMux is an abstraction of http.ServeMux.
-type ServeMux interface {
-	HandleFunc(pattern string, handler func(http.ResponseWriter, *http.Request))
-	ServeHTTP(w http.ResponseWriter, r *http.Request)
-}
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetImport(w, r)
+	}))
 
-type StdHTTPServerOptions struct {
-	BaseURL          string
-	BaseRouter       ServeMux
-	Middlewares      []MiddlewareFunc
-	ErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
-}
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-// HandlerFromMux creates http.Handler with routing matching OpenAPI spec based on the provided mux.
-func HandlerFromMux(si ServerInterface, m ServeMux) http.Handler {
-	return HandlerWithOptions(si, StdHTTPServerOptions{
-		BaseRouter: m,
-	})
+	handler.ServeHTTP(w, r)
 }
 
-func HandlerFromMuxWithBaseURL(si ServerInterface, m ServeMux, baseURL string) http.Handler {
-	return HandlerWithOptions(si, StdHTTPServerOptions{
-		BaseURL:    baseURL,
-		BaseRouter: m,
-	})
-}
+// PostImport operation middleware
+func (siw *ServerInterfaceWrapper) PostImport(w http.ResponseWriter, r *http.Request) {
 
-// HandlerWithOptions creates http.Handler with additional options
-func HandlerWithOptions(si ServerInterface, options StdHTTPServerOptions) http.Handler {
-	m := options.BaseRouter
+	ctx := r.Context()
 
-	if m == nil {
-		m = http.NewServeMux()
-	}
-	if options.ErrorHandlerFunc == nil {
-		options.ErrorHandlerFunc = func(w http.ResponseWriter, r *http.Request, err error) {
-			http.Error(w, err.Error(), http.StatusBadRequest)
-		}
-	}
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{})
 
-	wrapper := ServerInterfaceWrapper{
-		Handler:            si,
-		HandlerMiddlewares: options.Middlewares,
-		ErrorHandlerFunc:   options.ErrorHandlerFunc,
-	}
+	r = r.WithContext(ctx)
 
-	m.HandleFunc("GET "+options.BaseURL+"/", wrapper.Get)
-	m.HandleFunc("POST "+options.BaseURL+"/delete", wrapper.PostDelete)
-	m.HandleFunc("GET "+options.BaseURL+"/edit", wrapper.GetEdit)
-	m.HandleFunc("POST "+options.BaseURL+"/edit", wrapper.PostEdit)
-	m.HandleFunc("GET "+options.BaseURL+"/logout", wrapper.GetLogout)
-	m.HandleFunc("GET "+options.BaseURL+"/new", wrapper.GetNew)
-	m.HandleFunc("POST "+options.BaseURL+"/new", wrapper.PostNew)
-	m.HandleFunc("GET "+options.BaseURL+"/signin", wrapper.GetSignin)
-	m.HandleFunc("POST "+options.BaseURL+"/signin", wrapper.PostSignin)
-	m.HandleFunc("GET "+options.BaseURL+"/static/favicon.ico", wrapper.GetStaticFaviconIco)
-	m.HandleFunc("GET "+options.BaseURL+"/static/style.css", wrapper.GetStaticStyleCss)
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostImport(w, r)
+	}))
 
-	return m
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
 }
 
-type PageTexthtmlResponse struct {
-	Body io.Reader
+// GetInspect operation middleware
+func (siw *ServerInterfaceWrapper) GetInspect(w http.ResponseWriter, r *http.Request) {
 
-	ContentLength int64
-}
+	var err error
 
-type PageErrorJSONResponse struct {
-	Error        string  `json:"error"`
-	ErrorDetails *string `json:"errorDetails,omitempty"`
-}
+	ctx := r.Context()
 
-type PageRedirectResponseHeaders struct {
-	Location string
-}
-type PageRedirectResponse struct {
-	Headers PageRedirectResponseHeaders
-}
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{})
 
-type GetRequestObject struct {
-}
+	r = r.WithContext(ctx)
 
-type GetResponseObject interface {
-	VisitGetResponse(w http.ResponseWriter) error
-}
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetInspectParams
 
-type Get200TexthtmlResponse struct{ PageTexthtmlResponse }
+	// ------------- Required query parameter "device-id" -------------
 
-func (response Get200TexthtmlResponse) VisitGetResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "text/html")
-	if response.ContentLength != 0 {
-		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
-	}
-	w.WriteHeader(200)
+	if paramValue := r.URL.Query().Get("device-id"); paramValue != "" {
 
-	if closer, ok := response.Body.(io.ReadCloser); ok {
-		defer closer.Close()
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "device-id"})
+		return
 	}
-	_, err := io.Copy(w, response.Body)
-	return err
-}
-
-type Get303Response = PageRedirectResponse
 
-func (response Get303Response) VisitGetResponse(w http.ResponseWriter) error {
-	w.Header().Set("Location", fmt.Sprint(response.Headers.Location))
-	w.WriteHeader(303)
-	return nil
-}
+	err = runtime.BindQueryParameter("form", true, true, "device-id", r.URL.Query(), &params.DeviceId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "device-id", Err: err})
+		return
+	}
 
-type Get500JSONResponse struct{ PageErrorJSONResponse }
+	// ------------- Optional query parameter "interface" -------------
 
-func (response Get500JSONResponse) VisitGetResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
+	err = runtime.BindQueryParameter("form", true, false, "interface", r.URL.Query(), &params.Interface)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "interface", Err: err})
+		return
+	}
 
-	return json.NewEncoder(w).Encode(response)
-}
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetInspect(w, r, params)
+	}))
 
-type PostDeleteRequestObject struct {
-	Body *PostDeleteFormdataRequestBody
-}
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-type PostDeleteResponseObject interface {
-	VisitPostDeleteResponse(w http.ResponseWriter) error
+	handler.ServeHTTP(w, r)
 }
 
-type PostDelete303Response = PageRedirectResponse
-
-func (response PostDelete303Response) VisitPostDeleteResponse(w http.ResponseWriter) error {
-	w.Header().Set("Location", fmt.Sprint(response.Headers.Location))
-	w.WriteHeader(303)
-	return nil
-}
+// GetJumpHosts operation middleware
+func (siw *ServerInterfaceWrapper) GetJumpHosts(w http.ResponseWriter, r *http.Request) {
 
-type PostDelete500JSONResponse struct{ PageErrorJSONResponse }
+	var err error
 
-func (response PostDelete500JSONResponse) VisitPostDeleteResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
+	ctx := r.Context()
 
-	return json.NewEncoder(w).Encode(response)
-}
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{})
 
-type GetEditRequestObject struct {
-	Params GetEditParams
-}
+	r = r.WithContext(ctx)
 
-type GetEditResponseObject interface {
-	VisitGetEditResponse(w http.ResponseWriter) error
-}
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetJumpHostsParams
 
-type GetEdit200TexthtmlResponse struct{ PageTexthtmlResponse }
+	// ------------- Optional query parameter "edit-id" -------------
 
-func (response GetEdit200TexthtmlResponse) VisitGetEditResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "text/html")
-	if response.ContentLength != 0 {
-		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	err = runtime.BindQueryParameter("form", true, false, "edit-id", r.URL.Query(), &params.EditId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "edit-id", Err: err})
+		return
 	}
-	w.WriteHeader(200)
 
-	if closer, ok := response.Body.(io.ReadCloser); ok {
-		defer closer.Close()
-	}
-	_, err := io.Copy(w, response.Body)
-	return err
-}
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetJumpHosts(w, r, params)
+	}))
 
-type GetEdit303Response = PageRedirectResponse
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-func (response GetEdit303Response) VisitGetEditResponse(w http.ResponseWriter) error {
-	w.Header().Set("Location", fmt.Sprint(response.Headers.Location))
-	w.WriteHeader(303)
-	return nil
+	handler.ServeHTTP(w, r)
 }
 
-type GetEdit500JSONResponse struct{ PageErrorJSONResponse }
-
-func (response GetEdit500JSONResponse) VisitGetEditResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
+// PostJumpHosts operation middleware
+func (siw *ServerInterfaceWrapper) PostJumpHosts(w http.ResponseWriter, r *http.Request) {
 
-	return json.NewEncoder(w).Encode(response)
-}
+	ctx := r.Context()
 
-type PostEditRequestObject struct {
-	Body *multipart.Reader
-}
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{})
 
-type PostEditResponseObject interface {
-	VisitPostEditResponse(w http.ResponseWriter) error
-}
+	r = r.WithContext(ctx)
 
-type PostEdit200TexthtmlResponse struct{ PageTexthtmlResponse }
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostJumpHosts(w, r)
+	}))
 
-func (response PostEdit200TexthtmlResponse) VisitPostEditResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "text/html")
-	if response.ContentLength != 0 {
-		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
 	}
-	w.WriteHeader(200)
 
-	if closer, ok := response.Body.(io.ReadCloser); ok {
-		defer closer.Close()
-	}
-	_, err := io.Copy(w, response.Body)
-	return err
+	handler.ServeHTTP(w, r)
 }
 
-type PostEdit303Response = PageRedirectResponse
+// PostJumpHostsDelete operation middleware
+func (siw *ServerInterfaceWrapper) PostJumpHostsDelete(w http.ResponseWriter, r *http.Request) {
 
-func (response PostEdit303Response) VisitPostEditResponse(w http.ResponseWriter) error {
-	w.Header().Set("Location", fmt.Sprint(response.Headers.Location))
-	w.WriteHeader(303)
-	return nil
-}
+	ctx := r.Context()
 
-type PostEdit500JSONResponse struct{ PageErrorJSONResponse }
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{})
 
-func (response PostEdit500JSONResponse) VisitPostEditResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
+	r = r.WithContext(ctx)
 
-	return json.NewEncoder(w).Encode(response)
-}
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostJumpHostsDelete(w, r)
+	}))
 
-type GetLogoutRequestObject struct {
-	Params GetLogoutParams
-}
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-type GetLogoutResponseObject interface {
-	VisitGetLogoutResponse(w http.ResponseWriter) error
+	handler.ServeHTTP(w, r)
 }
 
-type GetLogout303Response = PageRedirectResponse
-
-func (response GetLogout303Response) VisitGetLogoutResponse(w http.ResponseWriter) error {
-	w.Header().Set("Location", fmt.Sprint(response.Headers.Location))
-	w.WriteHeader(303)
-	return nil
-}
+// GetLinks operation middleware
+func (siw *ServerInterfaceWrapper) GetLinks(w http.ResponseWriter, r *http.Request) {
 
-type GetLogout500JSONResponse struct{ PageErrorJSONResponse }
+	var err error
 
-func (response GetLogout500JSONResponse) VisitGetLogoutResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
+	ctx := r.Context()
 
-	return json.NewEncoder(w).Encode(response)
-}
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{})
 
-type GetNewRequestObject struct {
-}
+	r = r.WithContext(ctx)
 
-type GetNewResponseObject interface {
-	VisitGetNewResponse(w http.ResponseWriter) error
-}
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetLinksParams
 
-type GetNew200TexthtmlResponse struct{ PageTexthtmlResponse }
+	// ------------- Optional query parameter "edit-id" -------------
 
-func (response GetNew200TexthtmlResponse) VisitGetNewResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "text/html")
-	if response.ContentLength != 0 {
-		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	err = runtime.BindQueryParameter("form", true, false, "edit-id", r.URL.Query(), &params.EditId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "edit-id", Err: err})
+		return
 	}
-	w.WriteHeader(200)
 
-	if closer, ok := response.Body.(io.ReadCloser); ok {
-		defer closer.Close()
-	}
-	_, err := io.Copy(w, response.Body)
-	return err
-}
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetLinks(w, r, params)
+	}))
 
-type GetNew303Response = PageRedirectResponse
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-func (response GetNew303Response) VisitGetNewResponse(w http.ResponseWriter) error {
-	w.Header().Set("Location", fmt.Sprint(response.Headers.Location))
-	w.WriteHeader(303)
-	return nil
+	handler.ServeHTTP(w, r)
 }
 
-type GetNew500JSONResponse struct{ PageErrorJSONResponse }
-
-func (response GetNew500JSONResponse) VisitGetNewResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
+// PostLinks operation middleware
+func (siw *ServerInterfaceWrapper) PostLinks(w http.ResponseWriter, r *http.Request) {
 
-	return json.NewEncoder(w).Encode(response)
-}
+	ctx := r.Context()
 
-type PostNewRequestObject struct {
-	Body *multipart.Reader
-}
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{})
 
-type PostNewResponseObject interface {
-	VisitPostNewResponse(w http.ResponseWriter) error
-}
+	r = r.WithContext(ctx)
 
-type PostNew200TexthtmlResponse struct{ PageTexthtmlResponse }
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostLinks(w, r)
+	}))
 
-func (response PostNew200TexthtmlResponse) VisitPostNewResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "text/html")
-	if response.ContentLength != 0 {
-		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
 	}
-	w.WriteHeader(200)
 
-	if closer, ok := response.Body.(io.ReadCloser); ok {
-		defer closer.Close()
-	}
-	_, err := io.Copy(w, response.Body)
-	return err
+	handler.ServeHTTP(w, r)
 }
 
-type PostNew303Response = PageRedirectResponse
+// PostLinksBaseline operation middleware
+func (siw *ServerInterfaceWrapper) PostLinksBaseline(w http.ResponseWriter, r *http.Request) {
 
-func (response PostNew303Response) VisitPostNewResponse(w http.ResponseWriter) error {
-	w.Header().Set("Location", fmt.Sprint(response.Headers.Location))
-	w.WriteHeader(303)
-	return nil
-}
+	ctx := r.Context()
 
-type PostNew500JSONResponse struct{ PageErrorJSONResponse }
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{})
 
-func (response PostNew500JSONResponse) VisitPostNewResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
+	r = r.WithContext(ctx)
 
-	return json.NewEncoder(w).Encode(response)
-}
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostLinksBaseline(w, r)
+	}))
 
-type GetSigninRequestObject struct {
-}
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-type GetSigninResponseObject interface {
-	VisitGetSigninResponse(w http.ResponseWriter) error
+	handler.ServeHTTP(w, r)
 }
 
-type GetSignin200TexthtmlResponse struct{ PageTexthtmlResponse }
+// PostLinksDelete operation middleware
+func (siw *ServerInterfaceWrapper) PostLinksDelete(w http.ResponseWriter, r *http.Request) {
 
-func (response GetSignin200TexthtmlResponse) VisitGetSigninResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "text/html")
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostLinksDelete(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetLogout operation middleware
+func (siw *ServerInterfaceWrapper) GetLogout(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetLogoutParams
+
+	{
+		var cookie *http.Cookie
+
+		if cookie, err = r.Cookie("session_token"); err == nil {
+			var value string
+			err = runtime.BindStyledParameterWithOptions("simple", "session_token", cookie.Value, &value, runtime.BindStyledParameterOptions{Explode: true, Required: false})
+			if err != nil {
+				siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "session_token", Err: err})
+				return
+			}
+			params.SessionToken = &value
+
+		}
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetLogout(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetMaintenance operation middleware
+func (siw *ServerInterfaceWrapper) GetMaintenance(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetMaintenanceParams
+
+	// ------------- Optional query parameter "edit-id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "edit-id", r.URL.Query(), &params.EditId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "edit-id", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "device-id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "device-id", r.URL.Query(), &params.DeviceId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "device-id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetMaintenance(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostMaintenance operation middleware
+func (siw *ServerInterfaceWrapper) PostMaintenance(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostMaintenance(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostMaintenanceDelete operation middleware
+func (siw *ServerInterfaceWrapper) PostMaintenanceDelete(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostMaintenanceDelete(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetNew operation middleware
+func (siw *ServerInterfaceWrapper) GetNew(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetNew(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostNew operation middleware
+func (siw *ServerInterfaceWrapper) PostNew(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostNew(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetRules operation middleware
+func (siw *ServerInterfaceWrapper) GetRules(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetRulesParams
+
+	// ------------- Optional query parameter "edit-id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "edit-id", r.URL.Query(), &params.EditId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "edit-id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetRules(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostRules operation middleware
+func (siw *ServerInterfaceWrapper) PostRules(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostRules(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostRulesDelete operation middleware
+func (siw *ServerInterfaceWrapper) PostRulesDelete(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostRulesDelete(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostRulesSilence operation middleware
+func (siw *ServerInterfaceWrapper) PostRulesSilence(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostRulesSilence(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetSignin operation middleware
+func (siw *ServerInterfaceWrapper) GetSignin(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetSignin(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostSignin operation middleware
+func (siw *ServerInterfaceWrapper) PostSignin(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostSignin(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetSigninOidc operation middleware
+func (siw *ServerInterfaceWrapper) GetSigninOidc(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetSigninOidc(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetSigninOidcCallback operation middleware
+func (siw *ServerInterfaceWrapper) GetSigninOidcCallback(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetSigninOidcCallbackParams
+
+	// ------------- Optional query parameter "code" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "code", r.URL.Query(), &params.Code)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "code", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "state" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "state", r.URL.Query(), &params.State)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "state", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "error" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "error", r.URL.Query(), &params.Error)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "error", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetSigninOidcCallback(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetStaticFaviconIco operation middleware
+func (siw *ServerInterfaceWrapper) GetStaticFaviconIco(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetStaticFaviconIco(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetStaticStyleCss operation middleware
+func (siw *ServerInterfaceWrapper) GetStaticStyleCss(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetStaticStyleCss(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+type UnescapedCookieParamError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *UnescapedCookieParamError) Error() string {
+	return fmt.Sprintf("error unescaping cookie parameter '%s'", e.ParamName)
+}
+
+func (e *UnescapedCookieParamError) Unwrap() error {
+	return e.Err
+}
+
+type UnmarshalingParamError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *UnmarshalingParamError) Error() string {
+	return fmt.Sprintf("Error unmarshaling parameter %s as JSON: %s", e.ParamName, e.Err.Error())
+}
+
+func (e *UnmarshalingParamError) Unwrap() error {
+	return e.Err
+}
+
+type RequiredParamError struct {
+	ParamName string
+}
+
+func (e *RequiredParamError) Error() string {
+	return fmt.Sprintf("Query argument %s is required, but not found", e.ParamName)
+}
+
+type RequiredHeaderError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *RequiredHeaderError) Error() string {
+	return fmt.Sprintf("Header parameter %s is required, but not found", e.ParamName)
+}
+
+func (e *RequiredHeaderError) Unwrap() error {
+	return e.Err
+}
+
+type InvalidParamFormatError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *InvalidParamFormatError) Error() string {
+	return fmt.Sprintf("Invalid format for parameter %s: %s", e.ParamName, e.Err.Error())
+}
+
+func (e *InvalidParamFormatError) Unwrap() error {
+	return e.Err
+}
+
+type TooManyValuesForParamError struct {
+	ParamName string
+	Count     int
+}
+
+func (e *TooManyValuesForParamError) Error() string {
+	return fmt.Sprintf("Expected one value for %s, got %d", e.ParamName, e.Count)
+}
+
+// Handler creates http.Handler with routing matching OpenAPI spec.
+func Handler(si ServerInterface) http.Handler {
+	return HandlerWithOptions(si, StdHTTPServerOptions{})
+}
+
+// ServeMux is an abstraction of http.ServeMux.
+type ServeMux interface {
+	HandleFunc(pattern string, handler func(http.ResponseWriter, *http.Request))
+	ServeHTTP(w http.ResponseWriter, r *http.Request)
+}
+
+type StdHTTPServerOptions struct {
+	BaseURL          string
+	BaseRouter       ServeMux
+	Middlewares      []MiddlewareFunc
+	ErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+// HandlerFromMux creates http.Handler with routing matching OpenAPI spec based on the provided mux.
+func HandlerFromMux(si ServerInterface, m ServeMux) http.Handler {
+	return HandlerWithOptions(si, StdHTTPServerOptions{
+		BaseRouter: m,
+	})
+}
+
+func HandlerFromMuxWithBaseURL(si ServerInterface, m ServeMux, baseURL string) http.Handler {
+	return HandlerWithOptions(si, StdHTTPServerOptions{
+		BaseURL:    baseURL,
+		BaseRouter: m,
+	})
+}
+
+// HandlerWithOptions creates http.Handler with additional options
+func HandlerWithOptions(si ServerInterface, options StdHTTPServerOptions) http.Handler {
+	m := options.BaseRouter
+
+	if m == nil {
+		m = http.NewServeMux()
+	}
+	if options.ErrorHandlerFunc == nil {
+		options.ErrorHandlerFunc = func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		}
+	}
+
+	wrapper := ServerInterfaceWrapper{
+		Handler:            si,
+		HandlerMiddlewares: options.Middlewares,
+		ErrorHandlerFunc:   options.ErrorHandlerFunc,
+	}
+
+	m.HandleFunc("GET "+options.BaseURL+"/", wrapper.Get)
+	m.HandleFunc("GET "+options.BaseURL+"/audit", wrapper.GetAudit)
+	m.HandleFunc("GET "+options.BaseURL+"/audit/export", wrapper.GetAuditExport)
+	m.HandleFunc("GET "+options.BaseURL+"/captures", wrapper.GetCaptures)
+	m.HandleFunc("GET "+options.BaseURL+"/credentials", wrapper.GetCredentials)
+	m.HandleFunc("POST "+options.BaseURL+"/credentials", wrapper.PostCredentials)
+	m.HandleFunc("POST "+options.BaseURL+"/credentials/delete", wrapper.PostCredentialsDelete)
+	m.HandleFunc("POST "+options.BaseURL+"/delete", wrapper.PostDelete)
+	m.HandleFunc("GET "+options.BaseURL+"/edit", wrapper.GetEdit)
+	m.HandleFunc("POST "+options.BaseURL+"/edit", wrapper.PostEdit)
+	m.HandleFunc("GET "+options.BaseURL+"/events", wrapper.GetEvents)
+	m.HandleFunc("GET "+options.BaseURL+"/export", wrapper.GetExport)
+	m.HandleFunc("GET "+options.BaseURL+"/import", wrapper.GetImport)
+	m.HandleFunc("POST "+options.BaseURL+"/import", wrapper.PostImport)
+	m.HandleFunc("GET "+options.BaseURL+"/inspect", wrapper.GetInspect)
+	m.HandleFunc("GET "+options.BaseURL+"/jump-hosts", wrapper.GetJumpHosts)
+	m.HandleFunc("POST "+options.BaseURL+"/jump-hosts", wrapper.PostJumpHosts)
+	m.HandleFunc("POST "+options.BaseURL+"/jump-hosts/delete", wrapper.PostJumpHostsDelete)
+	m.HandleFunc("GET "+options.BaseURL+"/links", wrapper.GetLinks)
+	m.HandleFunc("POST "+options.BaseURL+"/links", wrapper.PostLinks)
+	m.HandleFunc("POST "+options.BaseURL+"/links/baseline", wrapper.PostLinksBaseline)
+	m.HandleFunc("POST "+options.BaseURL+"/links/delete", wrapper.PostLinksDelete)
+	m.HandleFunc("GET "+options.BaseURL+"/logout", wrapper.GetLogout)
+	m.HandleFunc("GET "+options.BaseURL+"/maintenance", wrapper.GetMaintenance)
+	m.HandleFunc("POST "+options.BaseURL+"/maintenance", wrapper.PostMaintenance)
+	m.HandleFunc("POST "+options.BaseURL+"/maintenance/delete", wrapper.PostMaintenanceDelete)
+	m.HandleFunc("GET "+options.BaseURL+"/new", wrapper.GetNew)
+	m.HandleFunc("POST "+options.BaseURL+"/new", wrapper.PostNew)
+	m.HandleFunc("GET "+options.BaseURL+"/rules", wrapper.GetRules)
+	m.HandleFunc("POST "+options.BaseURL+"/rules", wrapper.PostRules)
+	m.HandleFunc("POST "+options.BaseURL+"/rules/delete", wrapper.PostRulesDelete)
+	m.HandleFunc("POST "+options.BaseURL+"/rules/silence", wrapper.PostRulesSilence)
+	m.HandleFunc("GET "+options.BaseURL+"/signin", wrapper.GetSignin)
+	m.HandleFunc("POST "+options.BaseURL+"/signin", wrapper.PostSignin)
+	m.HandleFunc("GET "+options.BaseURL+"/signin/oidc", wrapper.GetSigninOidc)
+	m.HandleFunc("GET "+options.BaseURL+"/signin/oidc/callback", wrapper.GetSigninOidcCallback)
+	m.HandleFunc("GET "+options.BaseURL+"/static/favicon.ico", wrapper.GetStaticFaviconIco)
+	m.HandleFunc("GET "+options.BaseURL+"/static/style.css", wrapper.GetStaticStyleCss)
+
+	return m
+}
+
+type PageTexthtmlResponse struct {
+	Body io.Reader
+
+	ContentLength int64
+}
+
+type PageErrorJSONResponse struct {
+	Error        string  `json:"error"`
+	ErrorDetails *string `json:"errorDetails,omitempty"`
+}
+
+type PageRedirectResponseHeaders struct {
+	Location string
+}
+type PageRedirectResponse struct {
+	Headers PageRedirectResponseHeaders
+}
+
+type GetRequestObject struct {
+	Params GetParams
+}
+
+type GetResponseObject interface {
+	VisitGetResponse(w http.ResponseWriter) error
+}
+
+type Get200TexthtmlResponse struct{ PageTexthtmlResponse }
+
+func (response Get200TexthtmlResponse) VisitGetResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "text/html")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.WriteHeader(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
+}
+
+type Get303Response = PageRedirectResponse
+
+func (response Get303Response) VisitGetResponse(w http.ResponseWriter) error {
+	w.Header().Set("Location", fmt.Sprint(response.Headers.Location))
+	w.WriteHeader(303)
+	return nil
+}
+
+type Get500JSONResponse struct{ PageErrorJSONResponse }
+
+func (response Get500JSONResponse) VisitGetResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetAuditRequestObject struct {
+	Params GetAuditParams
+}
+
+type GetAuditResponseObject interface {
+	VisitGetAuditResponse(w http.ResponseWriter) error
+}
+
+type GetAudit200TexthtmlResponse struct{ PageTexthtmlResponse }
+
+func (response GetAudit200TexthtmlResponse) VisitGetAuditResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "text/html")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.WriteHeader(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
+}
+
+type GetAudit303Response = PageRedirectResponse
+
+func (response GetAudit303Response) VisitGetAuditResponse(w http.ResponseWriter) error {
+	w.Header().Set("Location", fmt.Sprint(response.Headers.Location))
+	w.WriteHeader(303)
+	return nil
+}
+
+type GetAudit500JSONResponse struct{ PageErrorJSONResponse }
+
+func (response GetAudit500JSONResponse) VisitGetAuditResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetAuditExportRequestObject struct {
+	Params GetAuditExportParams
+}
+
+type GetAuditExportResponseObject interface {
+	VisitGetAuditExportResponse(w http.ResponseWriter) error
+}
+
+type GetAuditExport200ResponseHeaders struct {
+	ContentDisposition string
+}
+
+type GetAuditExport200JSONResponse struct {
+	Body    []AuditEntry
+	Headers GetAuditExport200ResponseHeaders
+}
+
+func (response GetAuditExport200JSONResponse) VisitGetAuditExportResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Disposition", fmt.Sprint(response.Headers.ContentDisposition))
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetAuditExport200TextcsvResponse struct {
+	Body          io.Reader
+	Headers       GetAuditExport200ResponseHeaders
+	ContentLength int64
+}
+
+func (response GetAuditExport200TextcsvResponse) VisitGetAuditExportResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "text/csv")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.Header().Set("Content-Disposition", fmt.Sprint(response.Headers.ContentDisposition))
+	w.WriteHeader(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
+}
+
+type GetAuditExport303Response = PageRedirectResponse
+
+func (response GetAuditExport303Response) VisitGetAuditExportResponse(w http.ResponseWriter) error {
+	w.Header().Set("Location", fmt.Sprint(response.Headers.Location))
+	w.WriteHeader(303)
+	return nil
+}
+
+type GetAuditExport500JSONResponse struct{ PageErrorJSONResponse }
+
+func (response GetAuditExport500JSONResponse) VisitGetAuditExportResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetCapturesRequestObject struct {
+	Params GetCapturesParams
+}
+
+type GetCapturesResponseObject interface {
+	VisitGetCapturesResponse(w http.ResponseWriter) error
+}
+
+type GetCaptures200ResponseHeaders struct {
+	ContentDisposition string
+}
+
+type GetCaptures200ApplicationzipResponse struct {
+	Body          io.Reader
+	Headers       GetCaptures200ResponseHeaders
+	ContentLength int64
+}
+
+func (response GetCaptures200ApplicationzipResponse) VisitGetCapturesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/zip")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.Header().Set("Content-Disposition", fmt.Sprint(response.Headers.ContentDisposition))
+	w.WriteHeader(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
+}
+
+type GetCaptures303Response = PageRedirectResponse
+
+func (response GetCaptures303Response) VisitGetCapturesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Location", fmt.Sprint(response.Headers.Location))
+	w.WriteHeader(303)
+	return nil
+}
+
+type GetCaptures500JSONResponse struct{ PageErrorJSONResponse }
+
+func (response GetCaptures500JSONResponse) VisitGetCapturesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetCredentialsRequestObject struct {
+	Params GetCredentialsParams
+}
+
+type GetCredentialsResponseObject interface {
+	VisitGetCredentialsResponse(w http.ResponseWriter) error
+}
+
+type GetCredentials200TexthtmlResponse struct{ PageTexthtmlResponse }
+
+func (response GetCredentials200TexthtmlResponse) VisitGetCredentialsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "text/html")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.WriteHeader(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
+}
+
+type GetCredentials303Response = PageRedirectResponse
+
+func (response GetCredentials303Response) VisitGetCredentialsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Location", fmt.Sprint(response.Headers.Location))
+	w.WriteHeader(303)
+	return nil
+}
+
+type GetCredentials500JSONResponse struct{ PageErrorJSONResponse }
+
+func (response GetCredentials500JSONResponse) VisitGetCredentialsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostCredentialsRequestObject struct {
+	Body *multipart.Reader
+}
+
+type PostCredentialsResponseObject interface {
+	VisitPostCredentialsResponse(w http.ResponseWriter) error
+}
+
+type PostCredentials200TexthtmlResponse struct{ PageTexthtmlResponse }
+
+func (response PostCredentials200TexthtmlResponse) VisitPostCredentialsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "text/html")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.WriteHeader(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
+}
+
+type PostCredentials303Response = PageRedirectResponse
+
+func (response PostCredentials303Response) VisitPostCredentialsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Location", fmt.Sprint(response.Headers.Location))
+	w.WriteHeader(303)
+	return nil
+}
+
+type PostCredentials500JSONResponse struct{ PageErrorJSONResponse }
+
+func (response PostCredentials500JSONResponse) VisitPostCredentialsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostCredentialsDeleteRequestObject struct {
+	Body *PostCredentialsDeleteFormdataRequestBody
+}
+
+type PostCredentialsDeleteResponseObject interface {
+	VisitPostCredentialsDeleteResponse(w http.ResponseWriter) error
+}
+
+type PostCredentialsDelete200TexthtmlResponse struct{ PageTexthtmlResponse }
+
+func (response PostCredentialsDelete200TexthtmlResponse) VisitPostCredentialsDeleteResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "text/html")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.WriteHeader(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
+}
+
+type PostCredentialsDelete303Response = PageRedirectResponse
+
+func (response PostCredentialsDelete303Response) VisitPostCredentialsDeleteResponse(w http.ResponseWriter) error {
+	w.Header().Set("Location", fmt.Sprint(response.Headers.Location))
+	w.WriteHeader(303)
+	return nil
+}
+
+type PostCredentialsDelete500JSONResponse struct{ PageErrorJSONResponse }
+
+func (response PostCredentialsDelete500JSONResponse) VisitPostCredentialsDeleteResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostDeleteRequestObject struct {
+	Body *PostDeleteFormdataRequestBody
+}
+
+type PostDeleteResponseObject interface {
+	VisitPostDeleteResponse(w http.ResponseWriter) error
+}
+
+type PostDelete303Response = PageRedirectResponse
+
+func (response PostDelete303Response) VisitPostDeleteResponse(w http.ResponseWriter) error {
+	w.Header().Set("Location", fmt.Sprint(response.Headers.Location))
+	w.WriteHeader(303)
+	return nil
+}
+
+type PostDelete500JSONResponse struct{ PageErrorJSONResponse }
+
+func (response PostDelete500JSONResponse) VisitPostDeleteResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetEditRequestObject struct {
+	Params GetEditParams
+}
+
+type GetEditResponseObject interface {
+	VisitGetEditResponse(w http.ResponseWriter) error
+}
+
+type GetEdit200TexthtmlResponse struct{ PageTexthtmlResponse }
+
+func (response GetEdit200TexthtmlResponse) VisitGetEditResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "text/html")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.WriteHeader(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
+}
+
+type GetEdit303Response = PageRedirectResponse
+
+func (response GetEdit303Response) VisitGetEditResponse(w http.ResponseWriter) error {
+	w.Header().Set("Location", fmt.Sprint(response.Headers.Location))
+	w.WriteHeader(303)
+	return nil
+}
+
+type GetEdit500JSONResponse struct{ PageErrorJSONResponse }
+
+func (response GetEdit500JSONResponse) VisitGetEditResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostEditRequestObject struct {
+	Body *multipart.Reader
+}
+
+type PostEditResponseObject interface {
+	VisitPostEditResponse(w http.ResponseWriter) error
+}
+
+type PostEdit200TexthtmlResponse struct{ PageTexthtmlResponse }
+
+func (response PostEdit200TexthtmlResponse) VisitPostEditResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "text/html")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.WriteHeader(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
+}
+
+type PostEdit303Response = PageRedirectResponse
+
+func (response PostEdit303Response) VisitPostEditResponse(w http.ResponseWriter) error {
+	w.Header().Set("Location", fmt.Sprint(response.Headers.Location))
+	w.WriteHeader(303)
+	return nil
+}
+
+type PostEdit500JSONResponse struct{ PageErrorJSONResponse }
+
+func (response PostEdit500JSONResponse) VisitPostEditResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetEventsRequestObject struct {
+	Params GetEventsParams
+}
+
+type GetEventsResponseObject interface {
+	VisitGetEventsResponse(w http.ResponseWriter) error
+}
+
+type GetEvents200TexthtmlResponse struct{ PageTexthtmlResponse }
+
+func (response GetEvents200TexthtmlResponse) VisitGetEventsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "text/html")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.WriteHeader(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
+}
+
+type GetEvents303Response = PageRedirectResponse
+
+func (response GetEvents303Response) VisitGetEventsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Location", fmt.Sprint(response.Headers.Location))
+	w.WriteHeader(303)
+	return nil
+}
+
+type GetEvents500JSONResponse struct{ PageErrorJSONResponse }
+
+func (response GetEvents500JSONResponse) VisitGetEventsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetExportRequestObject struct {
+	Params GetExportParams
+}
+
+type GetExportResponseObject interface {
+	VisitGetExportResponse(w http.ResponseWriter) error
+}
+
+type GetExport200ResponseHeaders struct {
+	ContentDisposition string
+}
+
+type GetExport200ApplicationyamlResponse struct {
+	Body          io.Reader
+	Headers       GetExport200ResponseHeaders
+	ContentLength int64
+}
+
+func (response GetExport200ApplicationyamlResponse) VisitGetExportResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/yaml")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.Header().Set("Content-Disposition", fmt.Sprint(response.Headers.ContentDisposition))
+	w.WriteHeader(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
+}
+
+type GetExport200TextcsvResponse struct {
+	Body          io.Reader
+	Headers       GetExport200ResponseHeaders
+	ContentLength int64
+}
+
+func (response GetExport200TextcsvResponse) VisitGetExportResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "text/csv")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.Header().Set("Content-Disposition", fmt.Sprint(response.Headers.ContentDisposition))
+	w.WriteHeader(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
+}
+
+type GetExport303Response = PageRedirectResponse
+
+func (response GetExport303Response) VisitGetExportResponse(w http.ResponseWriter) error {
+	w.Header().Set("Location", fmt.Sprint(response.Headers.Location))
+	w.WriteHeader(303)
+	return nil
+}
+
+type GetExport500JSONResponse struct{ PageErrorJSONResponse }
+
+func (response GetExport500JSONResponse) VisitGetExportResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetImportRequestObject struct {
+}
+
+type GetImportResponseObject interface {
+	VisitGetImportResponse(w http.ResponseWriter) error
+}
+
+type GetImport200TexthtmlResponse struct{ PageTexthtmlResponse }
+
+func (response GetImport200TexthtmlResponse) VisitGetImportResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "text/html")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.WriteHeader(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
+}
+
+type GetImport303Response = PageRedirectResponse
+
+func (response GetImport303Response) VisitGetImportResponse(w http.ResponseWriter) error {
+	w.Header().Set("Location", fmt.Sprint(response.Headers.Location))
+	w.WriteHeader(303)
+	return nil
+}
+
+type GetImport500JSONResponse struct{ PageErrorJSONResponse }
+
+func (response GetImport500JSONResponse) VisitGetImportResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostImportRequestObject struct {
+	Body *multipart.Reader
+}
+
+type PostImportResponseObject interface {
+	VisitPostImportResponse(w http.ResponseWriter) error
+}
+
+type PostImport200TexthtmlResponse struct{ PageTexthtmlResponse }
+
+func (response PostImport200TexthtmlResponse) VisitPostImportResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "text/html")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.WriteHeader(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
+}
+
+type PostImport303Response = PageRedirectResponse
+
+func (response PostImport303Response) VisitPostImportResponse(w http.ResponseWriter) error {
+	w.Header().Set("Location", fmt.Sprint(response.Headers.Location))
+	w.WriteHeader(303)
+	return nil
+}
+
+type PostImport500JSONResponse struct{ PageErrorJSONResponse }
+
+func (response PostImport500JSONResponse) VisitPostImportResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetInspectRequestObject struct {
+	Params GetInspectParams
+}
+
+type GetInspectResponseObject interface {
+	VisitGetInspectResponse(w http.ResponseWriter) error
+}
+
+type GetInspect200TexthtmlResponse struct{ PageTexthtmlResponse }
+
+func (response GetInspect200TexthtmlResponse) VisitGetInspectResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "text/html")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.WriteHeader(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
+}
+
+type GetInspect303Response = PageRedirectResponse
+
+func (response GetInspect303Response) VisitGetInspectResponse(w http.ResponseWriter) error {
+	w.Header().Set("Location", fmt.Sprint(response.Headers.Location))
+	w.WriteHeader(303)
+	return nil
+}
+
+type GetInspect500JSONResponse struct{ PageErrorJSONResponse }
+
+func (response GetInspect500JSONResponse) VisitGetInspectResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetJumpHostsRequestObject struct {
+	Params GetJumpHostsParams
+}
+
+type GetJumpHostsResponseObject interface {
+	VisitGetJumpHostsResponse(w http.ResponseWriter) error
+}
+
+type GetJumpHosts200TexthtmlResponse struct{ PageTexthtmlResponse }
+
+func (response GetJumpHosts200TexthtmlResponse) VisitGetJumpHostsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "text/html")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.WriteHeader(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
+}
+
+type GetJumpHosts303Response = PageRedirectResponse
+
+func (response GetJumpHosts303Response) VisitGetJumpHostsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Location", fmt.Sprint(response.Headers.Location))
+	w.WriteHeader(303)
+	return nil
+}
+
+type GetJumpHosts500JSONResponse struct{ PageErrorJSONResponse }
+
+func (response GetJumpHosts500JSONResponse) VisitGetJumpHostsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostJumpHostsRequestObject struct {
+	Body *PostJumpHostsFormdataRequestBody
+}
+
+type PostJumpHostsResponseObject interface {
+	VisitPostJumpHostsResponse(w http.ResponseWriter) error
+}
+
+type PostJumpHosts200TexthtmlResponse struct{ PageTexthtmlResponse }
+
+func (response PostJumpHosts200TexthtmlResponse) VisitPostJumpHostsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "text/html")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.WriteHeader(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
+}
+
+type PostJumpHosts303Response = PageRedirectResponse
+
+func (response PostJumpHosts303Response) VisitPostJumpHostsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Location", fmt.Sprint(response.Headers.Location))
+	w.WriteHeader(303)
+	return nil
+}
+
+type PostJumpHosts500JSONResponse struct{ PageErrorJSONResponse }
+
+func (response PostJumpHosts500JSONResponse) VisitPostJumpHostsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostJumpHostsDeleteRequestObject struct {
+	Body *PostJumpHostsDeleteFormdataRequestBody
+}
+
+type PostJumpHostsDeleteResponseObject interface {
+	VisitPostJumpHostsDeleteResponse(w http.ResponseWriter) error
+}
+
+type PostJumpHostsDelete200TexthtmlResponse struct{ PageTexthtmlResponse }
+
+func (response PostJumpHostsDelete200TexthtmlResponse) VisitPostJumpHostsDeleteResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "text/html")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.WriteHeader(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
+}
+
+type PostJumpHostsDelete303Response = PageRedirectResponse
+
+func (response PostJumpHostsDelete303Response) VisitPostJumpHostsDeleteResponse(w http.ResponseWriter) error {
+	w.Header().Set("Location", fmt.Sprint(response.Headers.Location))
+	w.WriteHeader(303)
+	return nil
+}
+
+type PostJumpHostsDelete500JSONResponse struct{ PageErrorJSONResponse }
+
+func (response PostJumpHostsDelete500JSONResponse) VisitPostJumpHostsDeleteResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetLinksRequestObject struct {
+	Params GetLinksParams
+}
+
+type GetLinksResponseObject interface {
+	VisitGetLinksResponse(w http.ResponseWriter) error
+}
+
+type GetLinks200TexthtmlResponse struct{ PageTexthtmlResponse }
+
+func (response GetLinks200TexthtmlResponse) VisitGetLinksResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "text/html")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.WriteHeader(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
+}
+
+type GetLinks303Response = PageRedirectResponse
+
+func (response GetLinks303Response) VisitGetLinksResponse(w http.ResponseWriter) error {
+	w.Header().Set("Location", fmt.Sprint(response.Headers.Location))
+	w.WriteHeader(303)
+	return nil
+}
+
+type GetLinks500JSONResponse struct{ PageErrorJSONResponse }
+
+func (response GetLinks500JSONResponse) VisitGetLinksResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostLinksRequestObject struct {
+	Body *PostLinksFormdataRequestBody
+}
+
+type PostLinksResponseObject interface {
+	VisitPostLinksResponse(w http.ResponseWriter) error
+}
+
+type PostLinks200TexthtmlResponse struct{ PageTexthtmlResponse }
+
+func (response PostLinks200TexthtmlResponse) VisitPostLinksResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "text/html")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.WriteHeader(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
+}
+
+type PostLinks303Response = PageRedirectResponse
+
+func (response PostLinks303Response) VisitPostLinksResponse(w http.ResponseWriter) error {
+	w.Header().Set("Location", fmt.Sprint(response.Headers.Location))
+	w.WriteHeader(303)
+	return nil
+}
+
+type PostLinks500JSONResponse struct{ PageErrorJSONResponse }
+
+func (response PostLinks500JSONResponse) VisitPostLinksResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostLinksBaselineRequestObject struct {
+	Body *PostLinksBaselineFormdataRequestBody
+}
+
+type PostLinksBaselineResponseObject interface {
+	VisitPostLinksBaselineResponse(w http.ResponseWriter) error
+}
+
+type PostLinksBaseline303Response = PageRedirectResponse
+
+func (response PostLinksBaseline303Response) VisitPostLinksBaselineResponse(w http.ResponseWriter) error {
+	w.Header().Set("Location", fmt.Sprint(response.Headers.Location))
+	w.WriteHeader(303)
+	return nil
+}
+
+type PostLinksBaseline500JSONResponse struct{ PageErrorJSONResponse }
+
+func (response PostLinksBaseline500JSONResponse) VisitPostLinksBaselineResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostLinksDeleteRequestObject struct {
+	Body *PostLinksDeleteFormdataRequestBody
+}
+
+type PostLinksDeleteResponseObject interface {
+	VisitPostLinksDeleteResponse(w http.ResponseWriter) error
+}
+
+type PostLinksDelete303Response = PageRedirectResponse
+
+func (response PostLinksDelete303Response) VisitPostLinksDeleteResponse(w http.ResponseWriter) error {
+	w.Header().Set("Location", fmt.Sprint(response.Headers.Location))
+	w.WriteHeader(303)
+	return nil
+}
+
+type PostLinksDelete500JSONResponse struct{ PageErrorJSONResponse }
+
+func (response PostLinksDelete500JSONResponse) VisitPostLinksDeleteResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetLogoutRequestObject struct {
+	Params GetLogoutParams
+}
+
+type GetLogoutResponseObject interface {
+	VisitGetLogoutResponse(w http.ResponseWriter) error
+}
+
+type GetLogout303Response = PageRedirectResponse
+
+func (response GetLogout303Response) VisitGetLogoutResponse(w http.ResponseWriter) error {
+	w.Header().Set("Location", fmt.Sprint(response.Headers.Location))
+	w.WriteHeader(303)
+	return nil
+}
+
+type GetLogout500JSONResponse struct{ PageErrorJSONResponse }
+
+func (response GetLogout500JSONResponse) VisitGetLogoutResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetMaintenanceRequestObject struct {
+	Params GetMaintenanceParams
+}
+
+type GetMaintenanceResponseObject interface {
+	VisitGetMaintenanceResponse(w http.ResponseWriter) error
+}
+
+type GetMaintenance200TexthtmlResponse struct{ PageTexthtmlResponse }
+
+func (response GetMaintenance200TexthtmlResponse) VisitGetMaintenanceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "text/html")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.WriteHeader(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
+}
+
+type GetMaintenance303Response = PageRedirectResponse
+
+func (response GetMaintenance303Response) VisitGetMaintenanceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Location", fmt.Sprint(response.Headers.Location))
+	w.WriteHeader(303)
+	return nil
+}
+
+type GetMaintenance500JSONResponse struct{ PageErrorJSONResponse }
+
+func (response GetMaintenance500JSONResponse) VisitGetMaintenanceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostMaintenanceRequestObject struct {
+	Body *PostMaintenanceFormdataRequestBody
+}
+
+type PostMaintenanceResponseObject interface {
+	VisitPostMaintenanceResponse(w http.ResponseWriter) error
+}
+
+type PostMaintenance200TexthtmlResponse struct{ PageTexthtmlResponse }
+
+func (response PostMaintenance200TexthtmlResponse) VisitPostMaintenanceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "text/html")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.WriteHeader(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
+}
+
+type PostMaintenance303Response = PageRedirectResponse
+
+func (response PostMaintenance303Response) VisitPostMaintenanceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Location", fmt.Sprint(response.Headers.Location))
+	w.WriteHeader(303)
+	return nil
+}
+
+type PostMaintenance500JSONResponse struct{ PageErrorJSONResponse }
+
+func (response PostMaintenance500JSONResponse) VisitPostMaintenanceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostMaintenanceDeleteRequestObject struct {
+	Body *PostMaintenanceDeleteFormdataRequestBody
+}
+
+type PostMaintenanceDeleteResponseObject interface {
+	VisitPostMaintenanceDeleteResponse(w http.ResponseWriter) error
+}
+
+type PostMaintenanceDelete303Response = PageRedirectResponse
+
+func (response PostMaintenanceDelete303Response) VisitPostMaintenanceDeleteResponse(w http.ResponseWriter) error {
+	w.Header().Set("Location", fmt.Sprint(response.Headers.Location))
+	w.WriteHeader(303)
+	return nil
+}
+
+type PostMaintenanceDelete500JSONResponse struct{ PageErrorJSONResponse }
+
+func (response PostMaintenanceDelete500JSONResponse) VisitPostMaintenanceDeleteResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetNewRequestObject struct {
+}
+
+type GetNewResponseObject interface {
+	VisitGetNewResponse(w http.ResponseWriter) error
+}
+
+type GetNew200TexthtmlResponse struct{ PageTexthtmlResponse }
+
+func (response GetNew200TexthtmlResponse) VisitGetNewResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "text/html")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.WriteHeader(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
+}
+
+type GetNew303Response = PageRedirectResponse
+
+func (response GetNew303Response) VisitGetNewResponse(w http.ResponseWriter) error {
+	w.Header().Set("Location", fmt.Sprint(response.Headers.Location))
+	w.WriteHeader(303)
+	return nil
+}
+
+type GetNew500JSONResponse struct{ PageErrorJSONResponse }
+
+func (response GetNew500JSONResponse) VisitGetNewResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostNewRequestObject struct {
+	Body *multipart.Reader
+}
+
+type PostNewResponseObject interface {
+	VisitPostNewResponse(w http.ResponseWriter) error
+}
+
+type PostNew200TexthtmlResponse struct{ PageTexthtmlResponse }
+
+func (response PostNew200TexthtmlResponse) VisitPostNewResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "text/html")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.WriteHeader(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
+}
+
+type PostNew303Response = PageRedirectResponse
+
+func (response PostNew303Response) VisitPostNewResponse(w http.ResponseWriter) error {
+	w.Header().Set("Location", fmt.Sprint(response.Headers.Location))
+	w.WriteHeader(303)
+	return nil
+}
+
+type PostNew500JSONResponse struct{ PageErrorJSONResponse }
+
+func (response PostNew500JSONResponse) VisitPostNewResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetRulesRequestObject struct {
+	Params GetRulesParams
+}
+
+type GetRulesResponseObject interface {
+	VisitGetRulesResponse(w http.ResponseWriter) error
+}
+
+type GetRules200TexthtmlResponse struct{ PageTexthtmlResponse }
+
+func (response GetRules200TexthtmlResponse) VisitGetRulesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "text/html")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.WriteHeader(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
+}
+
+type GetRules303Response = PageRedirectResponse
+
+func (response GetRules303Response) VisitGetRulesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Location", fmt.Sprint(response.Headers.Location))
+	w.WriteHeader(303)
+	return nil
+}
+
+type GetRules500JSONResponse struct{ PageErrorJSONResponse }
+
+func (response GetRules500JSONResponse) VisitGetRulesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostRulesRequestObject struct {
+	Body *PostRulesFormdataRequestBody
+}
+
+type PostRulesResponseObject interface {
+	VisitPostRulesResponse(w http.ResponseWriter) error
+}
+
+type PostRules200TexthtmlResponse struct{ PageTexthtmlResponse }
+
+func (response PostRules200TexthtmlResponse) VisitPostRulesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "text/html")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.WriteHeader(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
+}
+
+type PostRules303Response = PageRedirectResponse
+
+func (response PostRules303Response) VisitPostRulesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Location", fmt.Sprint(response.Headers.Location))
+	w.WriteHeader(303)
+	return nil
+}
+
+type PostRules500JSONResponse struct{ PageErrorJSONResponse }
+
+func (response PostRules500JSONResponse) VisitPostRulesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostRulesDeleteRequestObject struct {
+	Body *PostRulesDeleteFormdataRequestBody
+}
+
+type PostRulesDeleteResponseObject interface {
+	VisitPostRulesDeleteResponse(w http.ResponseWriter) error
+}
+
+type PostRulesDelete303Response = PageRedirectResponse
+
+func (response PostRulesDelete303Response) VisitPostRulesDeleteResponse(w http.ResponseWriter) error {
+	w.Header().Set("Location", fmt.Sprint(response.Headers.Location))
+	w.WriteHeader(303)
+	return nil
+}
+
+type PostRulesDelete500JSONResponse struct{ PageErrorJSONResponse }
+
+func (response PostRulesDelete500JSONResponse) VisitPostRulesDeleteResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostRulesSilenceRequestObject struct {
+	Body *PostRulesSilenceFormdataRequestBody
+}
+
+type PostRulesSilenceResponseObject interface {
+	VisitPostRulesSilenceResponse(w http.ResponseWriter) error
+}
+
+type PostRulesSilence303Response = PageRedirectResponse
+
+func (response PostRulesSilence303Response) VisitPostRulesSilenceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Location", fmt.Sprint(response.Headers.Location))
+	w.WriteHeader(303)
+	return nil
+}
+
+type PostRulesSilence500JSONResponse struct{ PageErrorJSONResponse }
+
+func (response PostRulesSilence500JSONResponse) VisitPostRulesSilenceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetSigninRequestObject struct {
+}
+
+type GetSigninResponseObject interface {
+	VisitGetSigninResponse(w http.ResponseWriter) error
+}
+
+type GetSignin200TexthtmlResponse struct{ PageTexthtmlResponse }
+
+func (response GetSignin200TexthtmlResponse) VisitGetSigninResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "text/html")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.WriteHeader(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
+}
+
+type GetSignin500JSONResponse struct{ PageErrorJSONResponse }
+
+func (response GetSignin500JSONResponse) VisitGetSigninResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostSigninRequestObject struct {
+	Body *PostSigninFormdataRequestBody
+}
+
+type PostSigninResponseObject interface {
+	VisitPostSigninResponse(w http.ResponseWriter) error
+}
+
+type PostSignin200TexthtmlResponse struct{ PageTexthtmlResponse }
+
+func (response PostSignin200TexthtmlResponse) VisitPostSigninResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "text/html")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.WriteHeader(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
+}
+
+type PostSignin303Response = PageRedirectResponse
+
+func (response PostSignin303Response) VisitPostSigninResponse(w http.ResponseWriter) error {
+	w.Header().Set("Location", fmt.Sprint(response.Headers.Location))
+	w.WriteHeader(303)
+	return nil
+}
+
+type PostSignin500JSONResponse struct{ PageErrorJSONResponse }
+
+func (response PostSignin500JSONResponse) VisitPostSigninResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetSigninOidcRequestObject struct {
+}
+
+type GetSigninOidcResponseObject interface {
+	VisitGetSigninOidcResponse(w http.ResponseWriter) error
+}
+
+type GetSigninOidc303Response = PageRedirectResponse
+
+func (response GetSigninOidc303Response) VisitGetSigninOidcResponse(w http.ResponseWriter) error {
+	w.Header().Set("Location", fmt.Sprint(response.Headers.Location))
+	w.WriteHeader(303)
+	return nil
+}
+
+type GetSigninOidc500JSONResponse struct{ PageErrorJSONResponse }
+
+func (response GetSigninOidc500JSONResponse) VisitGetSigninOidcResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetSigninOidcCallbackRequestObject struct {
+	Params GetSigninOidcCallbackParams
+}
+
+type GetSigninOidcCallbackResponseObject interface {
+	VisitGetSigninOidcCallbackResponse(w http.ResponseWriter) error
+}
+
+type GetSigninOidcCallback200TexthtmlResponse struct{ PageTexthtmlResponse }
+
+func (response GetSigninOidcCallback200TexthtmlResponse) VisitGetSigninOidcCallbackResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "text/html")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.WriteHeader(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
+}
+
+type GetSigninOidcCallback303Response = PageRedirectResponse
+
+func (response GetSigninOidcCallback303Response) VisitGetSigninOidcCallbackResponse(w http.ResponseWriter) error {
+	w.Header().Set("Location", fmt.Sprint(response.Headers.Location))
+	w.WriteHeader(303)
+	return nil
+}
+
+type GetSigninOidcCallback500JSONResponse struct{ PageErrorJSONResponse }
+
+func (response GetSigninOidcCallback500JSONResponse) VisitGetSigninOidcCallbackResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetStaticFaviconIcoRequestObject struct {
+}
+
+type GetStaticFaviconIcoResponseObject interface {
+	VisitGetStaticFaviconIcoResponse(w http.ResponseWriter) error
+}
+
+type GetStaticFaviconIco200ImagexIconResponse struct {
+	Body          io.Reader
+	ContentLength int64
+}
+
+func (response GetStaticFaviconIco200ImagexIconResponse) VisitGetStaticFaviconIcoResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "image/x-icon")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.WriteHeader(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
+}
+
+type GetStaticStyleCssRequestObject struct {
+}
+
+type GetStaticStyleCssResponseObject interface {
+	VisitGetStaticStyleCssResponse(w http.ResponseWriter) error
+}
+
+type GetStaticStyleCss200TextcssResponse struct {
+	Body          io.Reader
+	ContentLength int64
+}
+
+func (response GetStaticStyleCss200TextcssResponse) VisitGetStaticStyleCssResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "text/css")
 	if response.ContentLength != 0 {
 		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
 	}
-	w.WriteHeader(200)
+	w.WriteHeader(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
+}
+
+// StrictServerInterface represents all server handlers.
+type StrictServerInterface interface {
+	// Main configuration page
+	// (GET /)
+	Get(ctx context.Context, request GetRequestObject) (GetResponseObject, error)
+	// Load audit log page
+	// (GET /audit)
+	GetAudit(ctx context.Context, request GetAuditRequestObject) (GetAuditResponseObject, error)
+	// Export audit log
+	// (GET /audit/export)
+	GetAuditExport(ctx context.Context, request GetAuditExportRequestObject) (GetAuditExportResponseObject, error)
+	// Download raw EEPROM captures of a device
+	// (GET /captures)
+	GetCaptures(ctx context.Context, request GetCapturesRequestObject) (GetCapturesResponseObject, error)
+	// Load credential profiles page
+	// (GET /credentials)
+	GetCredentials(ctx context.Context, request GetCredentialsRequestObject) (GetCredentialsResponseObject, error)
+	// Create or update credential profile
+	// (POST /credentials)
+	PostCredentials(ctx context.Context, request PostCredentialsRequestObject) (PostCredentialsResponseObject, error)
+	// Delete credential profile not used by any device
+	// (POST /credentials/delete)
+	PostCredentialsDelete(ctx context.Context, request PostCredentialsDeleteRequestObject) (PostCredentialsDeleteResponseObject, error)
+	// Load Edit device page
+	// (POST /delete)
+	PostDelete(ctx context.Context, request PostDeleteRequestObject) (PostDeleteResponseObject, error)
+	// Load Edit device page
+	// (GET /edit)
+	GetEdit(ctx context.Context, request GetEditRequestObject) (GetEditResponseObject, error)
+	// Update device
+	// (POST /edit)
+	PostEdit(ctx context.Context, request PostEditRequestObject) (PostEditResponseObject, error)
+	// Load interface events page
+	// (GET /events)
+	GetEvents(ctx context.Context, request GetEventsRequestObject) (GetEventsResponseObject, error)
+	// Export devices without credentials
+	// (GET /export)
+	GetExport(ctx context.Context, request GetExportRequestObject) (GetExportResponseObject, error)
+	// Load device import page
+	// (GET /import)
+	GetImport(ctx context.Context, request GetImportRequestObject) (GetImportResponseObject, error)
+	// Preview or apply device import
+	// (POST /import)
+	PostImport(ctx context.Context, request PostImportRequestObject) (PostImportResponseObject, error)
+	// Load EEPROM inspector page
+	// (GET /inspect)
+	GetInspect(ctx context.Context, request GetInspectRequestObject) (GetInspectResponseObject, error)
+	// Load jump hosts page
+	// (GET /jump-hosts)
+	GetJumpHosts(ctx context.Context, request GetJumpHostsRequestObject) (GetJumpHostsResponseObject, error)
+	// Create or update jump host
+	// (POST /jump-hosts)
+	PostJumpHosts(ctx context.Context, request PostJumpHostsRequestObject) (PostJumpHostsResponseObject, error)
+	// Delete jump host not used by any device or other jump host
+	// (POST /jump-hosts/delete)
+	PostJumpHostsDelete(ctx context.Context, request PostJumpHostsDeleteRequestObject) (PostJumpHostsDeleteResponseObject, error)
+	// Load fiber links page
+	// (GET /links)
+	GetLinks(ctx context.Context, request GetLinksRequestObject) (GetLinksResponseObject, error)
+	// Create or update fiber link
+	// (POST /links)
+	PostLinks(ctx context.Context, request PostLinksRequestObject) (PostLinksResponseObject, error)
+	// Reset span loss baseline of fiber link, the next reading becomes the new baseline
+	// (POST /links/baseline)
+	PostLinksBaseline(ctx context.Context, request PostLinksBaselineRequestObject) (PostLinksBaselineResponseObject, error)
+	// Delete fiber link
+	// (POST /links/delete)
+	PostLinksDelete(ctx context.Context, request PostLinksDeleteRequestObject) (PostLinksDeleteResponseObject, error)
+	// Log out
+	// (GET /logout)
+	GetLogout(ctx context.Context, request GetLogoutRequestObject) (GetLogoutResponseObject, error)
+	// Load maintenance windows page
+	// (GET /maintenance)
+	GetMaintenance(ctx context.Context, request GetMaintenanceRequestObject) (GetMaintenanceResponseObject, error)
+	// Create or update maintenance window
+	// (POST /maintenance)
+	PostMaintenance(ctx context.Context, request PostMaintenanceRequestObject) (PostMaintenanceResponseObject, error)
+	// Delete maintenance window
+	// (POST /maintenance/delete)
+	PostMaintenanceDelete(ctx context.Context, request PostMaintenanceDeleteRequestObject) (PostMaintenanceDeleteResponseObject, error)
+	// Load New device page
+	// (GET /new)
+	GetNew(ctx context.Context, request GetNewRequestObject) (GetNewResponseObject, error)
+	// Create new device
+	// (POST /new)
+	PostNew(ctx context.Context, request PostNewRequestObject) (PostNewResponseObject, error)
+	// Load alert rules page
+	// (GET /rules)
+	GetRules(ctx context.Context, request GetRulesRequestObject) (GetRulesResponseObject, error)
+	// Create or update alert rule
+	// (POST /rules)
+	PostRules(ctx context.Context, request PostRulesRequestObject) (PostRulesResponseObject, error)
+	// Delete alert rule
+	// (POST /rules/delete)
+	PostRulesDelete(ctx context.Context, request PostRulesDeleteRequestObject) (PostRulesDeleteResponseObject, error)
+	// Silence alerts of rule for a number of minutes, 0 lifts the silence
+	// (POST /rules/silence)
+	PostRulesSilence(ctx context.Context, request PostRulesSilenceRequestObject) (PostRulesSilenceResponseObject, error)
+	// Load signin page
+	// (GET /signin)
+	GetSignin(ctx context.Context, request GetSigninRequestObject) (GetSigninResponseObject, error)
+	// Handle login
+	// (POST /signin)
+	PostSignin(ctx context.Context, request PostSigninRequestObject) (PostSigninResponseObject, error)
+	// Start single sign-on login
+	// (GET /signin/oidc)
+	GetSigninOidc(ctx context.Context, request GetSigninOidcRequestObject) (GetSigninOidcResponseObject, error)
+	// Finish single sign-on login
+	// (GET /signin/oidc/callback)
+	GetSigninOidcCallback(ctx context.Context, request GetSigninOidcCallbackRequestObject) (GetSigninOidcCallbackResponseObject, error)
+	// Serve the favicon
+	// (GET /static/favicon.ico)
+	GetStaticFaviconIco(ctx context.Context, request GetStaticFaviconIcoRequestObject) (GetStaticFaviconIcoResponseObject, error)
+	// Serve the CSS stylesheet
+	// (GET /static/style.css)
+	GetStaticStyleCss(ctx context.Context, request GetStaticStyleCssRequestObject) (GetStaticStyleCssResponseObject, error)
+}
+
+type StrictHandlerFunc = strictnethttp.StrictHTTPHandlerFunc
+type StrictMiddlewareFunc = strictnethttp.StrictHTTPMiddlewareFunc
+
+type StrictHTTPServerOptions struct {
+	RequestErrorHandlerFunc  func(w http.ResponseWriter, r *http.Request, err error)
+	ResponseErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+func NewStrictHandler(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc) ServerInterface {
+	return &strictHandler{ssi: ssi, middlewares: middlewares, options: StrictHTTPServerOptions{
+		RequestErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		},
+		ResponseErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		},
+	}}
+}
+
+func NewStrictHandlerWithOptions(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc, options StrictHTTPServerOptions) ServerInterface {
+	return &strictHandler{ssi: ssi, middlewares: middlewares, options: options}
+}
+
+type strictHandler struct {
+	ssi         StrictServerInterface
+	middlewares []StrictMiddlewareFunc
+	options     StrictHTTPServerOptions
+}
+
+// Get operation middleware
+func (sh *strictHandler) Get(w http.ResponseWriter, r *http.Request, params GetParams) {
+	var request GetRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.Get(ctx, request.(GetRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "Get")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetResponseObject); ok {
+		if err := validResponse.VisitGetResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetAudit operation middleware
+func (sh *strictHandler) GetAudit(w http.ResponseWriter, r *http.Request, params GetAuditParams) {
+	var request GetAuditRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetAudit(ctx, request.(GetAuditRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetAudit")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetAuditResponseObject); ok {
+		if err := validResponse.VisitGetAuditResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetAuditExport operation middleware
+func (sh *strictHandler) GetAuditExport(w http.ResponseWriter, r *http.Request, params GetAuditExportParams) {
+	var request GetAuditExportRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetAuditExport(ctx, request.(GetAuditExportRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetAuditExport")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetAuditExportResponseObject); ok {
+		if err := validResponse.VisitGetAuditExportResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetCaptures operation middleware
+func (sh *strictHandler) GetCaptures(w http.ResponseWriter, r *http.Request, params GetCapturesParams) {
+	var request GetCapturesRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetCaptures(ctx, request.(GetCapturesRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetCaptures")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetCapturesResponseObject); ok {
+		if err := validResponse.VisitGetCapturesResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetCredentials operation middleware
+func (sh *strictHandler) GetCredentials(w http.ResponseWriter, r *http.Request, params GetCredentialsParams) {
+	var request GetCredentialsRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetCredentials(ctx, request.(GetCredentialsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetCredentials")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetCredentialsResponseObject); ok {
+		if err := validResponse.VisitGetCredentialsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PostCredentials operation middleware
+func (sh *strictHandler) PostCredentials(w http.ResponseWriter, r *http.Request) {
+	var request PostCredentialsRequestObject
+
+	if reader, err := r.MultipartReader(); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode multipart body: %w", err))
+		return
+	} else {
+		request.Body = reader
+	}
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.PostCredentials(ctx, request.(PostCredentialsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostCredentials")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(PostCredentialsResponseObject); ok {
+		if err := validResponse.VisitPostCredentialsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PostCredentialsDelete operation middleware
+func (sh *strictHandler) PostCredentialsDelete(w http.ResponseWriter, r *http.Request) {
+	var request PostCredentialsDeleteRequestObject
+
+	if err := r.ParseForm(); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode formdata: %w", err))
+		return
+	}
+	var body PostCredentialsDeleteFormdataRequestBody
+	if err := runtime.BindForm(&body, r.Form, nil, nil); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't bind formdata: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.PostCredentialsDelete(ctx, request.(PostCredentialsDeleteRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostCredentialsDelete")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(PostCredentialsDeleteResponseObject); ok {
+		if err := validResponse.VisitPostCredentialsDeleteResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PostDelete operation middleware
+func (sh *strictHandler) PostDelete(w http.ResponseWriter, r *http.Request) {
+	var request PostDeleteRequestObject
+
+	if err := r.ParseForm(); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode formdata: %w", err))
+		return
+	}
+	var body PostDeleteFormdataRequestBody
+	if err := runtime.BindForm(&body, r.Form, nil, nil); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't bind formdata: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.PostDelete(ctx, request.(PostDeleteRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostDelete")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(PostDeleteResponseObject); ok {
+		if err := validResponse.VisitPostDeleteResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetEdit operation middleware
+func (sh *strictHandler) GetEdit(w http.ResponseWriter, r *http.Request, params GetEditParams) {
+	var request GetEditRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetEdit(ctx, request.(GetEditRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetEdit")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetEditResponseObject); ok {
+		if err := validResponse.VisitGetEditResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PostEdit operation middleware
+func (sh *strictHandler) PostEdit(w http.ResponseWriter, r *http.Request) {
+	var request PostEditRequestObject
+
+	if reader, err := r.MultipartReader(); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode multipart body: %w", err))
+		return
+	} else {
+		request.Body = reader
+	}
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.PostEdit(ctx, request.(PostEditRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostEdit")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(PostEditResponseObject); ok {
+		if err := validResponse.VisitPostEditResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetEvents operation middleware
+func (sh *strictHandler) GetEvents(w http.ResponseWriter, r *http.Request, params GetEventsParams) {
+	var request GetEventsRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetEvents(ctx, request.(GetEventsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetEvents")
+	}
 
-	if closer, ok := response.Body.(io.ReadCloser); ok {
-		defer closer.Close()
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetEventsResponseObject); ok {
+		if err := validResponse.VisitGetEventsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
 	}
-	_, err := io.Copy(w, response.Body)
-	return err
 }
 
-type GetSignin500JSONResponse struct{ PageErrorJSONResponse }
+// GetExport operation middleware
+func (sh *strictHandler) GetExport(w http.ResponseWriter, r *http.Request, params GetExportParams) {
+	var request GetExportRequestObject
 
-func (response GetSignin500JSONResponse) VisitGetSigninResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
+	request.Params = params
 
-	return json.NewEncoder(w).Encode(response)
-}
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetExport(ctx, request.(GetExportRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetExport")
+	}
 
-type PostSigninRequestObject struct {
-	Body *PostSigninFormdataRequestBody
-}
+	response, err := handler(r.Context(), w, r, request)
 
-type PostSigninResponseObject interface {
-	VisitPostSigninResponse(w http.ResponseWriter) error
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetExportResponseObject); ok {
+		if err := validResponse.VisitGetExportResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type PostSignin200TexthtmlResponse struct{ PageTexthtmlResponse }
+// GetImport operation middleware
+func (sh *strictHandler) GetImport(w http.ResponseWriter, r *http.Request) {
+	var request GetImportRequestObject
 
-func (response PostSignin200TexthtmlResponse) VisitPostSigninResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "text/html")
-	if response.ContentLength != 0 {
-		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetImport(ctx, request.(GetImportRequestObject))
 	}
-	w.WriteHeader(200)
-
-	if closer, ok := response.Body.(io.ReadCloser); ok {
-		defer closer.Close()
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetImport")
 	}
-	_, err := io.Copy(w, response.Body)
-	return err
-}
 
-type PostSignin303Response = PageRedirectResponse
+	response, err := handler(r.Context(), w, r, request)
 
-func (response PostSignin303Response) VisitPostSigninResponse(w http.ResponseWriter) error {
-	w.Header().Set("Location", fmt.Sprint(response.Headers.Location))
-	w.WriteHeader(303)
-	return nil
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetImportResponseObject); ok {
+		if err := validResponse.VisitGetImportResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type PostSignin500JSONResponse struct{ PageErrorJSONResponse }
+// PostImport operation middleware
+func (sh *strictHandler) PostImport(w http.ResponseWriter, r *http.Request) {
+	var request PostImportRequestObject
 
-func (response PostSignin500JSONResponse) VisitPostSigninResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
+	if reader, err := r.MultipartReader(); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode multipart body: %w", err))
+		return
+	} else {
+		request.Body = reader
+	}
 
-	return json.NewEncoder(w).Encode(response)
-}
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.PostImport(ctx, request.(PostImportRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostImport")
+	}
 
-type GetStaticFaviconIcoRequestObject struct {
-}
+	response, err := handler(r.Context(), w, r, request)
 
-type GetStaticFaviconIcoResponseObject interface {
-	VisitGetStaticFaviconIcoResponse(w http.ResponseWriter) error
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(PostImportResponseObject); ok {
+		if err := validResponse.VisitPostImportResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type GetStaticFaviconIco200ImagexIconResponse struct {
-	Body          io.Reader
-	ContentLength int64
-}
+// GetInspect operation middleware
+func (sh *strictHandler) GetInspect(w http.ResponseWriter, r *http.Request, params GetInspectParams) {
+	var request GetInspectRequestObject
 
-func (response GetStaticFaviconIco200ImagexIconResponse) VisitGetStaticFaviconIcoResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "image/x-icon")
-	if response.ContentLength != 0 {
-		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetInspect(ctx, request.(GetInspectRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetInspect")
 	}
-	w.WriteHeader(200)
 
-	if closer, ok := response.Body.(io.ReadCloser); ok {
-		defer closer.Close()
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetInspectResponseObject); ok {
+		if err := validResponse.VisitGetInspectResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
 	}
-	_, err := io.Copy(w, response.Body)
-	return err
 }
 
-type GetStaticStyleCssRequestObject struct {
-}
+// GetJumpHosts operation middleware
+func (sh *strictHandler) GetJumpHosts(w http.ResponseWriter, r *http.Request, params GetJumpHostsParams) {
+	var request GetJumpHostsRequestObject
 
-type GetStaticStyleCssResponseObject interface {
-	VisitGetStaticStyleCssResponse(w http.ResponseWriter) error
-}
+	request.Params = params
 
-type GetStaticStyleCss200TextcssResponse struct {
-	Body          io.Reader
-	ContentLength int64
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetJumpHosts(ctx, request.(GetJumpHostsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetJumpHosts")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetJumpHostsResponseObject); ok {
+		if err := validResponse.VisitGetJumpHostsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-func (response GetStaticStyleCss200TextcssResponse) VisitGetStaticStyleCssResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "text/css")
-	if response.ContentLength != 0 {
-		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+// PostJumpHosts operation middleware
+func (sh *strictHandler) PostJumpHosts(w http.ResponseWriter, r *http.Request) {
+	var request PostJumpHostsRequestObject
+
+	if err := r.ParseForm(); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode formdata: %w", err))
+		return
 	}
-	w.WriteHeader(200)
+	var body PostJumpHostsFormdataRequestBody
+	if err := runtime.BindForm(&body, r.Form, nil, nil); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't bind formdata: %w", err))
+		return
+	}
+	request.Body = &body
 
-	if closer, ok := response.Body.(io.ReadCloser); ok {
-		defer closer.Close()
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.PostJumpHosts(ctx, request.(PostJumpHostsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostJumpHosts")
 	}
-	_, err := io.Copy(w, response.Body)
-	return err
-}
 
-// StrictServerInterface represents all server handlers.
-type StrictServerInterface interface {
-	// Main configuration page
-	// (GET /)
-	Get(ctx context.Context, request GetRequestObject) (GetResponseObject, error)
-	// Load Edit device page
-	// (POST /delete)
-	PostDelete(ctx context.Context, request PostDeleteRequestObject) (PostDeleteResponseObject, error)
-	// Load Edit device page
-	// (GET /edit)
-	GetEdit(ctx context.Context, request GetEditRequestObject) (GetEditResponseObject, error)
-	// Update device
-	// (POST /edit)
-	PostEdit(ctx context.Context, request PostEditRequestObject) (PostEditResponseObject, error)
-	// Log out
-	// (GET /logout)
-	GetLogout(ctx context.Context, request GetLogoutRequestObject) (GetLogoutResponseObject, error)
-	// Load New device page
-	// (GET /new)
-	GetNew(ctx context.Context, request GetNewRequestObject) (GetNewResponseObject, error)
-	// Create new device
-	// (POST /new)
-	PostNew(ctx context.Context, request PostNewRequestObject) (PostNewResponseObject, error)
-	// Load signin page
-	// (GET /signin)
-	GetSignin(ctx context.Context, request GetSigninRequestObject) (GetSigninResponseObject, error)
-	// Handle login
-	// (POST /signin)
-	PostSignin(ctx context.Context, request PostSigninRequestObject) (PostSigninResponseObject, error)
-	// Serve the favicon
-	// (GET /static/favicon.ico)
-	GetStaticFaviconIco(ctx context.Context, request GetStaticFaviconIcoRequestObject) (GetStaticFaviconIcoResponseObject, error)
-	// Serve the CSS stylesheet
-	// (GET /static/style.css)
-	GetStaticStyleCss(ctx context.Context, request GetStaticStyleCssRequestObject) (GetStaticStyleCssResponseObject, error)
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(PostJumpHostsResponseObject); ok {
+		if err := validResponse.VisitPostJumpHostsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type StrictHandlerFunc = strictnethttp.StrictHTTPHandlerFunc
-type StrictMiddlewareFunc = strictnethttp.StrictHTTPMiddlewareFunc
+// PostJumpHostsDelete operation middleware
+func (sh *strictHandler) PostJumpHostsDelete(w http.ResponseWriter, r *http.Request) {
+	var request PostJumpHostsDeleteRequestObject
 
-type StrictHTTPServerOptions struct {
-	RequestErrorHandlerFunc  func(w http.ResponseWriter, r *http.Request, err error)
-	ResponseErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
-}
+	if err := r.ParseForm(); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode formdata: %w", err))
+		return
+	}
+	var body PostJumpHostsDeleteFormdataRequestBody
+	if err := runtime.BindForm(&body, r.Form, nil, nil); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't bind formdata: %w", err))
+		return
+	}
+	request.Body = &body
 
-func NewStrictHandler(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc) ServerInterface {
-	return &strictHandler{ssi: ssi, middlewares: middlewares, options: StrictHTTPServerOptions{
-		RequestErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
-			http.Error(w, err.Error(), http.StatusBadRequest)
-		},
-		ResponseErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
-			http.Error(w, err.Error(), http.StatusInternalServerError)
-		},
-	}}
-}
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.PostJumpHostsDelete(ctx, request.(PostJumpHostsDeleteRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostJumpHostsDelete")
+	}
 
-func NewStrictHandlerWithOptions(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc, options StrictHTTPServerOptions) ServerInterface {
-	return &strictHandler{ssi: ssi, middlewares: middlewares, options: options}
-}
+	response, err := handler(r.Context(), w, r, request)
 
-type strictHandler struct {
-	ssi         StrictServerInterface
-	middlewares []StrictMiddlewareFunc
-	options     StrictHTTPServerOptions
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(PostJumpHostsDeleteResponseObject); ok {
+		if err := validResponse.VisitPostJumpHostsDeleteResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-// Get operation middleware
-func (sh *strictHandler) Get(w http.ResponseWriter, r *http.Request) {
-	var request GetRequestObject
+// GetLinks operation middleware
+func (sh *strictHandler) GetLinks(w http.ResponseWriter, r *http.Request, params GetLinksParams) {
+	var request GetLinksRequestObject
+
+	request.Params = params
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.Get(ctx, request.(GetRequestObject))
+		return sh.ssi.GetLinks(ctx, request.(GetLinksRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "Get")
+		handler = middleware(handler, "GetLinks")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(GetResponseObject); ok {
-		if err := validResponse.VisitGetResponse(w); err != nil {
+	} else if validResponse, ok := response.(GetLinksResponseObject); ok {
+		if err := validResponse.VisitGetLinksResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -1003,15 +4098,15 @@ func (sh *strictHandler) Get(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-// PostDelete operation middleware
-func (sh *strictHandler) PostDelete(w http.ResponseWriter, r *http.Request) {
-	var request PostDeleteRequestObject
+// PostLinks operation middleware
+func (sh *strictHandler) PostLinks(w http.ResponseWriter, r *http.Request) {
+	var request PostLinksRequestObject
 
 	if err := r.ParseForm(); err != nil {
 		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode formdata: %w", err))
 		return
 	}
-	var body PostDeleteFormdataRequestBody
+	var body PostLinksFormdataRequestBody
 	if err := runtime.BindForm(&body, r.Form, nil, nil); err != nil {
 		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't bind formdata: %w", err))
 		return
@@ -1019,18 +4114,18 @@ func (sh *strictHandler) PostDelete(w http.ResponseWriter, r *http.Request) {
 	request.Body = &body
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.PostDelete(ctx, request.(PostDeleteRequestObject))
+		return sh.ssi.PostLinks(ctx, request.(PostLinksRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "PostDelete")
+		handler = middleware(handler, "PostLinks")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(PostDeleteResponseObject); ok {
-		if err := validResponse.VisitPostDeleteResponse(w); err != nil {
+	} else if validResponse, ok := response.(PostLinksResponseObject); ok {
+		if err := validResponse.VisitPostLinksResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -1038,25 +4133,34 @@ func (sh *strictHandler) PostDelete(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-// GetEdit operation middleware
-func (sh *strictHandler) GetEdit(w http.ResponseWriter, r *http.Request, params GetEditParams) {
-	var request GetEditRequestObject
+// PostLinksBaseline operation middleware
+func (sh *strictHandler) PostLinksBaseline(w http.ResponseWriter, r *http.Request) {
+	var request PostLinksBaselineRequestObject
 
-	request.Params = params
+	if err := r.ParseForm(); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode formdata: %w", err))
+		return
+	}
+	var body PostLinksBaselineFormdataRequestBody
+	if err := runtime.BindForm(&body, r.Form, nil, nil); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't bind formdata: %w", err))
+		return
+	}
+	request.Body = &body
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.GetEdit(ctx, request.(GetEditRequestObject))
+		return sh.ssi.PostLinksBaseline(ctx, request.(PostLinksBaselineRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "GetEdit")
+		handler = middleware(handler, "PostLinksBaseline")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(GetEditResponseObject); ok {
-		if err := validResponse.VisitGetEditResponse(w); err != nil {
+	} else if validResponse, ok := response.(PostLinksBaselineResponseObject); ok {
+		if err := validResponse.VisitPostLinksBaselineResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -1064,30 +4168,34 @@ func (sh *strictHandler) GetEdit(w http.ResponseWriter, r *http.Request, params
 	}
 }
 
-// PostEdit operation middleware
-func (sh *strictHandler) PostEdit(w http.ResponseWriter, r *http.Request) {
-	var request PostEditRequestObject
+// PostLinksDelete operation middleware
+func (sh *strictHandler) PostLinksDelete(w http.ResponseWriter, r *http.Request) {
+	var request PostLinksDeleteRequestObject
 
-	if reader, err := r.MultipartReader(); err != nil {
-		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode multipart body: %w", err))
+	if err := r.ParseForm(); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode formdata: %w", err))
+		return
+	}
+	var body PostLinksDeleteFormdataRequestBody
+	if err := runtime.BindForm(&body, r.Form, nil, nil); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't bind formdata: %w", err))
 		return
-	} else {
-		request.Body = reader
 	}
+	request.Body = &body
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.PostEdit(ctx, request.(PostEditRequestObject))
+		return sh.ssi.PostLinksDelete(ctx, request.(PostLinksDeleteRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "PostEdit")
+		handler = middleware(handler, "PostLinksDelete")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(PostEditResponseObject); ok {
-		if err := validResponse.VisitPostEditResponse(w); err != nil {
+	} else if validResponse, ok := response.(PostLinksDeleteResponseObject); ok {
+		if err := validResponse.VisitPostLinksDeleteResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -1121,6 +4229,102 @@ func (sh *strictHandler) GetLogout(w http.ResponseWriter, r *http.Request, param
 	}
 }
 
+// GetMaintenance operation middleware
+func (sh *strictHandler) GetMaintenance(w http.ResponseWriter, r *http.Request, params GetMaintenanceParams) {
+	var request GetMaintenanceRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetMaintenance(ctx, request.(GetMaintenanceRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetMaintenance")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetMaintenanceResponseObject); ok {
+		if err := validResponse.VisitGetMaintenanceResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PostMaintenance operation middleware
+func (sh *strictHandler) PostMaintenance(w http.ResponseWriter, r *http.Request) {
+	var request PostMaintenanceRequestObject
+
+	if err := r.ParseForm(); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode formdata: %w", err))
+		return
+	}
+	var body PostMaintenanceFormdataRequestBody
+	if err := runtime.BindForm(&body, r.Form, nil, nil); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't bind formdata: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.PostMaintenance(ctx, request.(PostMaintenanceRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostMaintenance")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(PostMaintenanceResponseObject); ok {
+		if err := validResponse.VisitPostMaintenanceResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PostMaintenanceDelete operation middleware
+func (sh *strictHandler) PostMaintenanceDelete(w http.ResponseWriter, r *http.Request) {
+	var request PostMaintenanceDeleteRequestObject
+
+	if err := r.ParseForm(); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode formdata: %w", err))
+		return
+	}
+	var body PostMaintenanceDeleteFormdataRequestBody
+	if err := runtime.BindForm(&body, r.Form, nil, nil); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't bind formdata: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.PostMaintenanceDelete(ctx, request.(PostMaintenanceDeleteRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostMaintenanceDelete")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(PostMaintenanceDeleteResponseObject); ok {
+		if err := validResponse.VisitPostMaintenanceDeleteResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
 // GetNew operation middleware
 func (sh *strictHandler) GetNew(w http.ResponseWriter, r *http.Request) {
 	var request GetNewRequestObject
@@ -1176,6 +4380,137 @@ func (sh *strictHandler) PostNew(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// GetRules operation middleware
+func (sh *strictHandler) GetRules(w http.ResponseWriter, r *http.Request, params GetRulesParams) {
+	var request GetRulesRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetRules(ctx, request.(GetRulesRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetRules")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetRulesResponseObject); ok {
+		if err := validResponse.VisitGetRulesResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PostRules operation middleware
+func (sh *strictHandler) PostRules(w http.ResponseWriter, r *http.Request) {
+	var request PostRulesRequestObject
+
+	if err := r.ParseForm(); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode formdata: %w", err))
+		return
+	}
+	var body PostRulesFormdataRequestBody
+	if err := runtime.BindForm(&body, r.Form, nil, nil); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't bind formdata: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.PostRules(ctx, request.(PostRulesRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostRules")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(PostRulesResponseObject); ok {
+		if err := validResponse.VisitPostRulesResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PostRulesDelete operation middleware
+func (sh *strictHandler) PostRulesDelete(w http.ResponseWriter, r *http.Request) {
+	var request PostRulesDeleteRequestObject
+
+	if err := r.ParseForm(); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode formdata: %w", err))
+		return
+	}
+	var body PostRulesDeleteFormdataRequestBody
+	if err := runtime.BindForm(&body, r.Form, nil, nil); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't bind formdata: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.PostRulesDelete(ctx, request.(PostRulesDeleteRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostRulesDelete")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(PostRulesDeleteResponseObject); ok {
+		if err := validResponse.VisitPostRulesDeleteResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PostRulesSilence operation middleware
+func (sh *strictHandler) PostRulesSilence(w http.ResponseWriter, r *http.Request) {
+	var request PostRulesSilenceRequestObject
+
+	if err := r.ParseForm(); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode formdata: %w", err))
+		return
+	}
+	var body PostRulesSilenceFormdataRequestBody
+	if err := runtime.BindForm(&body, r.Form, nil, nil); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't bind formdata: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.PostRulesSilence(ctx, request.(PostRulesSilenceRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostRulesSilence")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(PostRulesSilenceResponseObject); ok {
+		if err := validResponse.VisitPostRulesSilenceResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
 // GetSignin operation middleware
 func (sh *strictHandler) GetSignin(w http.ResponseWriter, r *http.Request) {
 	var request GetSigninRequestObject
@@ -1235,6 +4570,56 @@ func (sh *strictHandler) PostSignin(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// GetSigninOidc operation middleware
+func (sh *strictHandler) GetSigninOidc(w http.ResponseWriter, r *http.Request) {
+	var request GetSigninOidcRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetSigninOidc(ctx, request.(GetSigninOidcRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetSigninOidc")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetSigninOidcResponseObject); ok {
+		if err := validResponse.VisitGetSigninOidcResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetSigninOidcCallback operation middleware
+func (sh *strictHandler) GetSigninOidcCallback(w http.ResponseWriter, r *http.Request, params GetSigninOidcCallbackParams) {
+	var request GetSigninOidcCallbackRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetSigninOidcCallback(ctx, request.(GetSigninOidcCallbackRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetSigninOidcCallback")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetSigninOidcCallbackResponseObject); ok {
+		if err := validResponse.VisitGetSigninOidcCallbackResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
 // GetStaticFaviconIco operation middleware
 func (sh *strictHandler) GetStaticFaviconIco(w http.ResponseWriter, r *http.Request) {
 	var request GetStaticFaviconIcoRequestObject
@@ -1286,26 +4671,57 @@ func (sh *strictHandler) GetStaticStyleCss(w http.ResponseWriter, r *http.Reques
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/+xY32/bNhD+V4hbH7aBstx6Gzq9ZW3WBUs6o25eZrgBI51lNhKpkqc4XqD/fSAp//4R",
-	"r1uxLNiLLcl3x7vv7vso8x5SXVZaoSILyT0YtJVWFv1NX+TovlOtCBW5S8I7iidUFu7GphMshX88qxAS",
-	"sGSkyqFpGg4Z2tTIiqRWkMC5Fhkz+KlGS5ixykVuuF/h1BhtNpYRVVXIVDjn+KN1EVZXq4yu0JAMWeLc",
-	"H+9EWRUuDx+SlWitW4dvpseDz2skIQu7yzXzP2G2P0bDwZUjDWaQDNskRgszff0RUzoSCTaVNGEhRAvK",
-	"O8ykcRGS+w3/+S+MdICRwwRFhsYXcq4Datt+r72X1IrpMTPz8HwFVlR16WqJgUNsZa6kchUtsZk/3AbD",
-	"1xki+TRk9d5bLIJ+x39YgiMVYY7Gg2gxrY2k2cA5h4amWt9IPKlp4kO55MMj4KBE6RdGa6VWV6RvcCUf",
-	"UclfcRZQl2qst1E4Ueykf8bG2jCRpi6Kylmq1VjmtfHIhY7oMTs97b/77YJdaCVJuzrZAM0tGuBQyBSV",
-	"9fW1Cb15e8neoEIjCtavrwuZsvNgxG7RuFxZj2nDCkHom0ySwrxdDNjY+MnPXGrAoXWABLqd552us9YV",
-	"KlFJSKDX6XZ6wKESNPFoxe4jRz8pjha+iLPM5YSuv2uMftHtuq9nBseQwFfxkvzxwi7ut+TsdXvHGS+G",
-	"teHw/bErBNqvjgAkw/XmD0fNiIOty1KYGSRwIaTa0SwfJM6wQPItqbTdgUZfW3odbAJ10dJPOpsdUJ67",
-	"aDqdRmNtyqg2BapUZ47t+6Uo5BBJb+T8BEECtVQEO4d/VUGWvrtVZGlLpsZmo7GPrlde5k4zSSzDW5ni",
-	"Sqcwk3RoaJ2Xn3AjSiQvbMNWBz7VaGZLGXCRHGKb6Kyq2oNtGD0pjuzBnR9gRYv3fk6UdUGyEoZiz4VM",
-	"kDi4IbddOQJ8DhNtKXTzfnubltWexxG128suDNt9KG43oYbDDc7WsrmWSvg52gp9g7MoLVCYNfsPWj3b",
-	"ZV3oXPqtthJEaNyEfhiK6I+T6PfRMOpchctu9OPo2+HyemeoSlg71WYdtcXDAw5Hp7v5xrLgzqIFHvAl",
-	"vPP6PkeP/tskuqwyQdgyKIhWoXNdH5St82CxW7geeoHZ/yo9evxKnzNXuIdJ4fQQRm9xCk9Pbt/i9Hi1",
-	"nUPwD4ntI9PPf08RN+Ttf1HbHNVXBp2oqcWwBsa2/+kOkHYw/9f3+WD8vbq2GReSPoJtK7l/kTf+xbwv",
-	"JhRLIQvgUIq7c1S560TvBYdSqvntC76DHY4GXw+jq87y9pv757z3vPkC5AhZr0R5imxYm5pfhMoKZKHw",
-	"MPckSKbxWNzKVKuOTPVBDnjrn4PxWar3sGFlrGQpcozvIuewPkEPyuj2odH7CbI204Nl+hMKRhvW81ot",
-	"zQrspNY+XOnAmb6y9uE6/YlgG/MvHAi6il4NBsznZCeIdGRhW05HaKA/twlvY+tZnF4M2OUZcKhNAQlM",
-	"iKokjgudisJtIMnL7ssuNKPmzwAAAP//ZJHCkicVAAA=",
+	"H4sIAAAAAAAC/+wc23LbNvZXMNg+7O6QlmxvMq3eXNttveuknjh9qcftQOSRhBgEGACUrGb07zsAeJME",
+	"UpQSJ66sl8QSD4Bzv4L6hCORpIID1woPPuGUSJKABmk/kSym+izSVHDzkXI8wB8zkHMcYE4SwANM3NMA",
+	"q2gCCTFgep6aJ0pLysd4sQjKfYRs2UbILru8J3IMumkb7Z627bMIsASVCq7A0nhDxmD+jwTXwO3OGh51",
+	"b6ITZj607hSDiiRNHYPwtSAxkvAxA6UhRqnZeRHYEy6lFHLlGJKmjEbELO59UIIvn5ZKkYLU1GEJxXp4",
+	"JEnKDB52S5SAUuacYBW9wK25AE0oU76lsX0EcfMellcfMyohxoO7HIn7EkwMP0CkO3ICzaieILdFzpR3",
+	"EFNpdhh8WllfPEFa2MU4wBMgca6W18JxbX3dhV1FBUdihGSxfV0dgGeJoaWHA9xTdMwpNxRVvCm+XGeG",
+	"pdPtVFnH+YTwMawLjIw0SI/WBHgIIyHB+2hEgcV+5a/LwYGVOwX5YeuCyW3mkms596BYGvYaIqSw1bUn",
+	"kaXXrqcaEvvHdxJGeID/0at8SS/nU6/OpEWJIJGSzM1naskdCZkQjQc4o1xXnKdcwxisujjDvtoK+q31",
+	"CR4a3OP387ThMU1g6ZyYaAjtt5sshBqx5JCFSys9ZO3YGkFL2Fb89QmT8ilwLeT8pxyzSp0jNcUBnpOE",
+	"4fs1JANM04LafMF/gtf365wz+g1RJqme3xr5OTlHQjxQOMv0pHS77qvK7ypQigr+pxYPUDMdktL/wdw5",
+	"CMpHYt1gzzg6u7lCIyERiSKzCx+jSPARHWfSGrlzHmKELi9v3v36Br0RnGphCEO3IKdgWMxoBFxZ+nKE",
+	"fn77G/oZOEjC0E02ZDRC1w4ITUEaXNEpEhIxonOVodq5xje3aCStk44NajjA+QI8wP2j46O+gRYpcJJS",
+	"PMCnR/2jUxzglOiJ5VbP/JOHKGNtlgijuPhnG5nqEfZulR23QGQ0QZSjiVDa0BKgq5sAMTGmPECKagjQ",
+	"WIosRYTHSJOxwoE3En5sD6b+8Gn232WdxWiXhZqMd8JTE50pr1/PeAwjysEYlnjAAZ4Ryc1mAVZqErr4",
+	"E+AHmI8og3A1pG08WUh/PCnkhYMadoad3fcWMgbp3ZyoCLsQu8V2edysdksop4nZ8Nhj+fcradFJv9/k",
+	"2ku43k2e4Zz2T7sBlxF/EeBXXU9wuVPdOVnLqbulu3tDgMqShJhIh98Qyj1uxG7iQlKblZ5ZgDVT9WFa",
+	"gfRqSe4i6Axt7L4reJ777pmsbK5o6TNublVOPXhMhdwsrksH9nyF1mCleZJRTyO0zMDnBVyMt5WCxws0",
+	"KEXneqN7NufSybVkbhG4wsmguV3ddFYKH7iWFNRyun/uSAgvqEqFokXS2nLAc1Rzp6CVojsdj0iqM+kk",
+	"1qTf5wXMmnL7FCqGKY0gpHGrTm3KobdUp79ouiyScv8h5UTOqxOateB3miKT/dCpTfdyzsQozpJ0LzXi",
+	"Qsw4s4UymRXZbaEPhgMEOVnmmiIhBq4pYe3KUgPbkGzeSGFyIGRwgBhRrgXSEzDJeNKQVUJMtVOtz1el",
+	"v3O4qoSBUsdFVbZ7UqE8orkRakU2eXvkRxHPVywryZimKZG6Z3gbxkSTtt5QZP4cGVOETqYXlGLsVEw/",
+	"wLzjtg+Ux/WAlRKlZkLGLt/GASZjQ6GvQLUFjiWNaA2S4wH+446Ef52Fv9/fhUd/uj/74Q/3/76r/v7O",
+	"hwVvKvsNOulEErXMphqW3iX2WbcFK92AvB6wbClI9HfPlh31Yq8M5lwC0WCK7Sw1nRSP8ax5uF4MDJw6",
+	"dzKnCwfeZlT1cPUYzmaz0BpXJhnwSMQQt5mYQ6ejyaxoQbX25cneycUjccSFRpmCGA3niPD5UqjrIvz9",
+	"lfjzjHiXJm11QqqVaLChkr70FtLtmcWXT1r/zpnGOt/bMoyc398wtYgEY1BMD4pEQKkJDrDiSYoDPOYJ",
+	"NXIHbRo0NjBGxN+2rvxG52xlq9TGoBImIoYlZN0kKMCCh64b78XNri1aEwl5dK21169enb4KWltt+VJ3",
+	"TGi+llPClnY5fd3vd9vkgabhFCQdzb0pjwXSTPkf2q6t70nZzPQ9pGnD16H7rr2DkE8iFgH+kCVpaE56",
+	"ikwU5mHEgMgl+D8E92aMVgGdJEYkAj+3HJBp8zc8/nIJrLOMcERZ0wyxAEkZ0Ya8dqCdlPTps+VqwRay",
+	"2o0Y24n38cj4pJBkehJujXxtqRRaRII1nxCJJMm4dfrb7L4jsXappNMdaXJLN9KUKZDNT8uhWeVYTyIc",
+	"4FOvM7WDrI1z7ypHqE1baIor5/PyMuzfXE1VT59hWtzmaUzLHMTW/cQtUrGGdndeCzd36vYwhSsDC3KS",
+	"qefPG4cbTXONHWcJraF55XrBtk1ge/ugtQ2784TgwiXAjCq9x7MBZ2rK3pQSmUb1lq9VF5psUperJFeX",
+	"fTOivARyHOhQCdUY8YVqIaPq8045Su0MzxUv1rWUKkC2tdoXFwNvJEwpzEyL0QppWVly0+EqhajddnKQ",
+	"rzlmawiTZch4abEyH4Pl0hKyFivLerE1tflvlqS/WKAN0y8DaG9ZHeZf24vpQ8G8LmOvukieqFtL4liC",
+	"8hfuT91Eap437VS9TSnZreuc10MFK1YJPwyeKq1ddSidJg6lGh+GTc962FRKuWHGZBRC6AnIVX1glD+0",
+	"xpZrC7AhrhigQ0jZPqSM6BAksjLoEFMKUTxVPAmr/K5TECBV+9j/QgUjpsQAppcFHItsyGrvFfAsGbod",
+	"h9uiMNyAwpcIag0hJ6xnw3VWLJOxjOMhINV0vuaBekOigFG+IRxZC/ixAH0ySzAo7RaKipV7MQN/Bwo0",
+	"UinhiAmlUCEjc0+vkmJgnT2HR40kkNi8ozKESCSg8gezcmFd4F2yDyvuw6WHr51MrFmoGIustYtw7SD8",
+	"TYRN7011r/WfYRAfI0O4ZVNCjJpwwiNo49WbGtiGtKoGimaUx2L25ElW8MnfhU4yneeU1qK3x2bHkcoe",
+	"Jn3JmlS7JH/LavNkvnCr7CvO3zMKE8ozvXSHYOfbKY33MxpbDoaYOHMd5mXdPZeCI3hMpfM47v3oKJNm",
+	"JVKaSK0CBEmq5+5lTCQ4hGI0ysXiHRY3DtXNrZRUMGY+ewHseetImhe7GdI0sXHVWJGyr3gGCI7GR+ik",
+	"f/I6PO6Hxz+8PzkZ9PtdL+Dmx3mEdMhBPSa45sI7JSg1ozykKV87TWkSIodZW/x9C7M9HBW+hVn3G5MF",
+	"C17shcnDHcg9vQN5uNX4rW41Hq4ovtgrioeLiSuJJi+DsV3ekxlrf9v5nQXY0A0wQIchyw4/s8BAamRl",
+	"0CE3KkTxRGm8oY5IqpZNcAjMlptkKKa2hqDKIjEijCmvZW5ZqW9VgwMnQwax/yqXkLbKbaj1zWMFkeBx",
+	"A0BL7jFXGiQoqjoOi9onPwloSaMtOwlN4UgTf02vJxLURLC4I8quTGlrl/iL+ZyYoK4+9dMPJX3Nyms+",
+	"t1MRby3+UL5/7fLdLzBFDTe7SOw2h3wykbU2NQ22u0mzWBngz2nIPTup5uJwYrU/5WEIzfuqzgOaL3Oa",
+	"A9RHjI60mxEWQrd6kP8UZUu2dOsgPseffR4n1nMMh3SH9KKG+9OMrovitdRKSAhlRtvI4zXwsZHd6YlV",
+	"vuLjSeApdU1N+8+78M+j6uO/Ph0Hp8eL7778j0Y4rGu77GNAW9KaXwiPbTpvCK/pfU/QONqs/L8aqG/v",
+	"EpY9gCZSI0X5mIE1iFDwBgJ7EWFsSKKHbpSeF9CdbqkbG9n1BxZ3Wlj8vuL+XFpfkutPlFM1aROsJppG",
+	"vRGZ0kjwIxqJVrFa6J8c8FUk8Mb3rWhCxtB7DM2Cz/7FrfemaHaHt2uzGcIhvQJd0Kr0nMFRpNRmSm8N",
+	"6LlSm+nM3xNTW74nZig6v71FFic1AdAdCVtb1CHem8Xe7oT5DdnfrnCAM8nwAE+0Tge9nm21mv7Q4Pv+",
+	"9328uF/8fwCQgLkLfV0AAA==",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
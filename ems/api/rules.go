@@ -0,0 +1,282 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"time"
+
+	oapi "pi-wegrzyn/ems/api/oapi/generated"
+	"pi-wegrzyn/ems/storage"
+	"pi-wegrzyn/ems/templates"
+)
+
+func (s *Server) GetRules(ctx context.Context, request oapi.GetRulesRequestObject) (oapi.GetRulesResponseObject, error) {
+	edit := storage.Rule{}
+	if request.Params.EditId != nil {
+		rule, err := s.repository.Rule(ctx, *request.Params.EditId)
+		switch err {
+		case nil:
+			edit = rule
+		case sql.ErrNoRows:
+			slog.ErrorContext(ctx, "rule not found", slog.Any("error", err))
+		default:
+			slog.ErrorContext(ctx, "database error", slog.Any("error", err))
+			return oapi.GetRules500JSONResponse{
+				PageErrorJSONResponse: oapi.PageErrorJSONResponse{
+					Error:        "database error",
+					ErrorDetails: ptr(err.Error()),
+				},
+			}, nil
+		}
+	}
+
+	page, err := s.rulesPage(ctx, edit, "")
+	if err != nil {
+		slog.ErrorContext(ctx, "error rendering rules page", slog.Any("error", err))
+		return oapi.GetRules500JSONResponse{
+			PageErrorJSONResponse: oapi.PageErrorJSONResponse{
+				Error:        "error rendering rules page",
+				ErrorDetails: ptr(err.Error()),
+			},
+		}, nil
+	}
+
+	return oapi.GetRules200TexthtmlResponse{
+		PageTexthtmlResponse: oapi.PageTexthtmlResponse{
+			Body:          page,
+			ContentLength: int64(page.Len()),
+		},
+	}, nil
+}
+
+// PostRules creates a rule or updates the one given by edit-id. Silence of
+// the rule is kept, it is set separately.
+func (s *Server) PostRules(ctx context.Context, request oapi.PostRulesRequestObject) (oapi.PostRulesResponseObject, error) {
+	form := templates.RuleForm{
+		Name:       request.Body.Name,
+		Enabled:    request.Body.Enabled != nil && *request.Body.Enabled != "",
+		Metric:     request.Body.Metric,
+		Comparison: string(request.Body.Comparison),
+		Threshold:  request.Body.Threshold,
+	}
+	if request.Body.Hysteresis != nil {
+		form.Hysteresis = *request.Body.Hysteresis
+	}
+	if request.Body.WindowMinutes != nil {
+		form.WindowMinutes = *request.Body.WindowMinutes
+	}
+	if request.Body.ForPolls != nil {
+		form.ForPolls = *request.Body.ForPolls
+	}
+	if request.Body.ForSeconds != nil {
+		form.ForSeconds = *request.Body.ForSeconds
+	}
+	if request.Body.Site != nil {
+		form.Site = *request.Body.Site
+	}
+	if request.Body.Group != nil {
+		form.Group = *request.Body.Group
+	}
+	if request.Body.Tag != nil {
+		form.Tag = *request.Body.Tag
+	}
+	if request.Body.DeviceId != nil {
+		form.DeviceID = *request.Body.DeviceId
+	}
+	if request.Body.Interface != nil {
+		form.Interface = *request.Body.Interface
+	}
+	if request.Body.EditId != nil {
+		form.EditId = *request.Body.EditId
+	}
+
+	if err := form.Validate(); err != nil {
+		slog.ErrorContext(ctx, "validation error", slog.Any("error", err))
+		return s.postRulesError(ctx, ruleFromForm(form), err), nil
+	}
+
+	var before map[string]string
+	if form.EditId != 0 {
+		rule, err := s.repository.Rule(ctx, form.EditId)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				slog.ErrorContext(ctx, "rule not found", slog.Any("error", err))
+				return oapi.PageRedirectResponse{
+					Headers: oapi.PageRedirectResponseHeaders{
+						Location: "/rules",
+					},
+				}, nil
+			}
+			slog.ErrorContext(ctx, "database error", slog.Any("error", err))
+
+			return oapi.PostRules500JSONResponse{
+				PageErrorJSONResponse: oapi.PageErrorJSONResponse{
+					Error:        "database error",
+					ErrorDetails: ptr(err.Error()),
+				},
+			}, nil
+		}
+		before = rule.AuditFields()
+	}
+
+	rule := ruleFromForm(form)
+
+	var err error
+	if rule.ID == 0 {
+		if rule.ID, err = s.repository.CreateRule(ctx, rule); err != nil {
+			slog.ErrorContext(ctx, "database error", slog.Any("error", err))
+			return s.postRulesError(ctx, rule, errors.New("cannot save rule, the name may be taken")), nil
+		}
+		s.audit(ctx, storage.AuditActionCreate, storage.AuditTargetRule, rule.ID, rule.Name, nil, rule.AuditFields())
+	} else {
+		if err = s.repository.UpdateRule(ctx, rule); err != nil {
+			slog.ErrorContext(ctx, "database error", slog.Any("error", err))
+			return s.postRulesError(ctx, rule, errors.New("cannot save rule, the name may be taken")), nil
+		}
+		s.audit(ctx, storage.AuditActionUpdate, storage.AuditTargetRule, rule.ID, rule.Name, before, rule.AuditFields())
+	}
+
+	return oapi.PostRules303Response{
+		Headers: oapi.PageRedirectResponseHeaders{
+			Location: "/rules",
+		},
+	}, nil
+}
+
+func ruleFromForm(form templates.RuleForm) storage.Rule {
+	return storage.Rule{
+		ID:         form.EditId,
+		Name:       form.Name,
+		Enabled:    form.Enabled,
+		Metric:     form.Metric,
+		Comparison: form.Comparison,
+		Threshold:  form.Threshold,
+		Hysteresis: form.Hysteresis,
+		Window:     time.Duration(form.WindowMinutes) * time.Minute,
+		ForPolls:   form.ForPolls,
+		ForSeconds: form.ForSeconds,
+		Site:       form.Site,
+		Group:      form.Group,
+		Tag:        form.Tag,
+		DeviceID:   form.DeviceID,
+		Interface:  form.Interface,
+	}
+}
+
+func (s *Server) postRulesError(ctx context.Context, edit storage.Rule, err error) oapi.PostRulesResponseObject {
+	page, err2 := s.rulesPage(ctx, edit, err.Error())
+	if err2 != nil {
+		slog.ErrorContext(ctx, "error rendering rules page", slog.Any("error", err2))
+		return oapi.PostRules500JSONResponse{
+			PageErrorJSONResponse: oapi.PageErrorJSONResponse{
+				Error:        "error rendering rules page",
+				ErrorDetails: ptr(errors.Join(err, err2).Error()),
+			},
+		}
+	}
+
+	return oapi.PostRules200TexthtmlResponse{
+		PageTexthtmlResponse: oapi.PageTexthtmlResponse{
+			Body:          page,
+			ContentLength: int64(page.Len()),
+		},
+	}
+}
+
+// PostRulesSilence keeps alerts of a rule quiet for the given minutes, 0 lifts
+// the silence. State of the alerts is still followed.
+func (s *Server) PostRulesSilence(ctx context.Context, request oapi.PostRulesSilenceRequestObject) (oapi.PostRulesSilenceResponseObject, error) {
+	rule, err := s.repository.Rule(ctx, request.Body.RuleId)
+	if err == sql.ErrNoRows {
+		slog.ErrorContext(ctx, "rule not found", slog.Any("error", err))
+		return oapi.PageRedirectResponse{
+			Headers: oapi.PageRedirectResponseHeaders{
+				Location: "/rules",
+			},
+		}, nil
+	}
+
+	var until time.Time
+	if request.Body.Minutes > 0 {
+		until = time.Now().Add(time.Duration(request.Body.Minutes) * time.Minute)
+	}
+	if err == nil {
+		err = s.repository.SilenceRule(ctx, rule.ID, until)
+	}
+	if err != nil {
+		slog.ErrorContext(ctx, "database error", slog.Any("error", err))
+		return oapi.PostRulesSilence500JSONResponse{
+			PageErrorJSONResponse: oapi.PageErrorJSONResponse{
+				Error:        "database error",
+				ErrorDetails: ptr(err.Error()),
+			},
+		}, nil
+	}
+
+	after := rule.AuditFields()
+	after["silenced-until"] = "lifted"
+	if !until.IsZero() {
+		after["silenced-until"] = until.Format(time.DateTime)
+	}
+	s.audit(ctx, storage.AuditActionUpdate, storage.AuditTargetRule, rule.ID, rule.Name, rule.AuditFields(), after)
+
+	return oapi.PostRulesSilence303Response{
+		Headers: oapi.PageRedirectResponseHeaders{
+			Location: "/rules",
+		},
+	}, nil
+}
+
+func (s *Server) PostRulesDelete(ctx context.Context, request oapi.PostRulesDeleteRequestObject) (oapi.PostRulesDeleteResponseObject, error) {
+	rule, err := s.repository.Rule(ctx, request.Body.DeleteId)
+	if err == sql.ErrNoRows {
+		slog.ErrorContext(ctx, "rule not found", slog.Any("error", err))
+		return oapi.PageRedirectResponse{
+			Headers: oapi.PageRedirectResponseHeaders{
+				Location: "/rules",
+			},
+		}, nil
+	}
+	if err == nil {
+		err = s.repository.DeleteRule(ctx, rule.ID)
+	}
+	if err != nil {
+		slog.ErrorContext(ctx, "database error", slog.Any("error", err))
+		return oapi.PostRulesDelete500JSONResponse{
+			PageErrorJSONResponse: oapi.PageErrorJSONResponse{
+				Error:        "database error",
+				ErrorDetails: ptr(err.Error()),
+			},
+		}, nil
+	}
+
+	s.audit(ctx, storage.AuditActionDelete, storage.AuditTargetRule, rule.ID, rule.Name, rule.AuditFields(), nil)
+
+	return oapi.PostRulesDelete303Response{
+		Headers: oapi.PageRedirectResponseHeaders{
+			Location: "/rules",
+		},
+	}, nil
+}
+
+func (s *Server) rulesPage(ctx context.Context, edit storage.Rule, errMsg string) (*bytes.Buffer, error) {
+	rules, err := s.repository.Rules(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	alerts, err := s.repository.FiringAlerts(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	devices, err := s.repository.Devices(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.templateEx.ExecuteRules(templates.RulesPageContent(rules, alerts, devices, edit, errMsg, time.Now()))
+}
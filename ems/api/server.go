@@ -9,23 +9,67 @@ import (
 	"errors"
 	"log/slog"
 	"net/http"
+	"strings"
+	"time"
 
 	oapi "pi-wegrzyn/ems/api/oapi/generated"
+	"pi-wegrzyn/ems/auth"
 	"pi-wegrzyn/ems/cookies"
 	"pi-wegrzyn/ems/storage"
 	"pi-wegrzyn/ems/templates"
 )
 
 type Repository interface {
-	CreateDevice(ctx context.Context, device storage.Device) error
+	CreateDevice(ctx context.Context, device storage.Device) (uint, error)
 	Device(ctx context.Context, id uint) (storage.Device, error)
 	Devices(ctx context.Context) ([]storage.Device, error)
 	UpdateDevice(ctx context.Context, device storage.Device) error
 	DeleteDevice(ctx context.Context, id uint) error
+	ImportDevices(ctx context.Context, creates []storage.Device, updates []storage.Device) ([]storage.Device, error)
+
+	CreateCredential(ctx context.Context, credential storage.Credential) (uint, error)
+	Credential(ctx context.Context, id uint) (storage.Credential, error)
+	Credentials(ctx context.Context) ([]storage.Credential, error)
+	UpdateCredential(ctx context.Context, credential storage.Credential) error
+	DeleteCredential(ctx context.Context, id uint) error
+	CredentialUsage(ctx context.Context, id uint) (int, error)
+	CreateJumpHost(ctx context.Context, host storage.JumpHost) (uint, error)
+	JumpHost(ctx context.Context, id uint) (storage.JumpHost, error)
+	JumpHosts(ctx context.Context) ([]storage.JumpHost, error)
+	UpdateJumpHost(ctx context.Context, host storage.JumpHost) error
+	DeleteJumpHost(ctx context.Context, id uint) error
+	JumpHostUsage(ctx context.Context, id uint) (int, error)
+	FlaggedTrends(ctx context.Context) ([]storage.Trend, error)
+	CreateLink(ctx context.Context, link storage.Link) (uint, error)
+	Link(ctx context.Context, id uint) (storage.Link, error)
+	Links(ctx context.Context) ([]storage.Link, error)
+	UpdateLink(ctx context.Context, link storage.Link) error
+	ResetLinkBaseline(ctx context.Context, id uint) error
+	DeleteLink(ctx context.Context, id uint) error
+	CreateRule(ctx context.Context, rule storage.Rule) (uint, error)
+	Rule(ctx context.Context, id uint) (storage.Rule, error)
+	Rules(ctx context.Context) ([]storage.Rule, error)
+	UpdateRule(ctx context.Context, rule storage.Rule) error
+	SilenceRule(ctx context.Context, id uint, until time.Time) error
+	DeleteRule(ctx context.Context, id uint) error
+	FiringAlerts(ctx context.Context) ([]storage.Alert, error)
+	CreateMaintenanceWindow(ctx context.Context, window storage.MaintenanceWindow) (uint, error)
+	MaintenanceWindow(ctx context.Context, id uint) (storage.MaintenanceWindow, error)
+	MaintenanceWindows(ctx context.Context) ([]storage.MaintenanceWindow, error)
+	UpdateMaintenanceWindow(ctx context.Context, window storage.MaintenanceWindow) error
+	DeleteMaintenanceWindow(ctx context.Context, id uint) error
+
+	CreateAuditEntry(ctx context.Context, entry storage.AuditEntry) error
+	AuditEntries(ctx context.Context, filter storage.AuditFilter) ([]storage.AuditEntry, error)
+
+	Captures(ctx context.Context, deviceID uint) ([]storage.Capture, error)
+
+	Interfaces(ctx context.Context) ([]storage.Interface, error)
+	Events(ctx context.Context, filter storage.EventFilter) ([]storage.Event, error)
 }
 
 type Cookies interface {
-	Create(ctx context.Context, w http.ResponseWriter, username string)
+	Create(ctx context.Context, w http.ResponseWriter, user auth.User)
 	Delete(ctx context.Context, w http.ResponseWriter, token *string)
 }
 
@@ -40,9 +84,11 @@ type StaticFiles struct {
 }
 
 type Server struct {
-	config     Config
-	repository Repository
-	cookies    Cookies
+	config        Config
+	repository    Repository
+	cookies       Cookies
+	authenticator auth.Authenticator
+	oidc          *auth.OIDC
 
 	templateEx  *templates.Executor
 	staticFiles *StaticFiles
@@ -52,15 +98,19 @@ func NewHandler(
 	config Config,
 	repository Repository,
 	cookieStore *cookies.Store,
+	authenticator auth.Authenticator,
+	oidc *auth.OIDC,
 	executor *templates.Executor,
 	staticFiles *StaticFiles,
 ) http.Handler {
 	return oapi.Handler(oapi.NewStrictHandler(&Server{
-		config:      config,
-		repository:  repository,
-		cookies:     cookieStore,
-		templateEx:  executor,
-		staticFiles: staticFiles,
+		config:        config,
+		repository:    repository,
+		cookies:       cookieStore,
+		authenticator: authenticator,
+		oidc:          oidc,
+		templateEx:    executor,
+		staticFiles:   staticFiles,
 	}, []oapi.StrictMiddlewareFunc{
 		NewLoggerMiddleware(),
 		NewAuthMiddleware(config, cookieStore),
@@ -79,7 +129,66 @@ func (s *Server) Get(ctx context.Context, request oapi.GetRequestObject) (oapi.G
 		}, nil
 	}
 
-	page, err := s.templateEx.ExecuteIndex(devices)
+	interfaces, err := s.repository.Interfaces(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "error getting interfaces", slog.Any("error", err))
+		return oapi.Get500JSONResponse{
+			PageErrorJSONResponse: oapi.PageErrorJSONResponse{
+				Error:        "error getting interfaces",
+				ErrorDetails: ptr(err.Error()),
+			},
+		}, nil
+	}
+
+	trends, err := s.repository.FlaggedTrends(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "error getting trends", slog.Any("error", err))
+		return oapi.Get500JSONResponse{
+			PageErrorJSONResponse: oapi.PageErrorJSONResponse{
+				Error:        "error getting trends",
+				ErrorDetails: ptr(err.Error()),
+			},
+		}, nil
+	}
+	index := templates.IndexPageContent(devices, interfaces, deviceQuery(request.Params)).WithTrends(trends)
+
+	rules, err := s.repository.Rules(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "error getting rules", slog.Any("error", err))
+		return oapi.Get500JSONResponse{
+			PageErrorJSONResponse: oapi.PageErrorJSONResponse{
+				Error:        "error getting rules",
+				ErrorDetails: ptr(err.Error()),
+			},
+		}, nil
+	}
+
+	alerts, err := s.repository.FiringAlerts(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "error getting alerts", slog.Any("error", err))
+		return oapi.Get500JSONResponse{
+			PageErrorJSONResponse: oapi.PageErrorJSONResponse{
+				Error:        "error getting alerts",
+				ErrorDetails: ptr(err.Error()),
+			},
+		}, nil
+	}
+
+	windows, err := s.repository.MaintenanceWindows(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "error getting maintenance windows", slog.Any("error", err))
+		return oapi.Get500JSONResponse{
+			PageErrorJSONResponse: oapi.PageErrorJSONResponse{
+				Error:        "error getting maintenance windows",
+				ErrorDetails: ptr(err.Error()),
+			},
+		}, nil
+	}
+
+	now := time.Now()
+	index = index.WithAlerts(rules, alerts, now).WithMaintenance(windows, now)
+
+	page, err := s.templateEx.ExecuteIndex(index)
 	if err != nil {
 		slog.ErrorContext(ctx, "error executing template", slog.Any("error", err))
 		return oapi.Get500JSONResponse{
@@ -119,7 +228,7 @@ func (s *Server) GetEdit(ctx context.Context, request oapi.GetEditRequestObject)
 		}, nil
 	}
 
-	page, err := s.templateEx.ExecuteNewEdit(templates.EditPageContent(device, ""))
+	page, err := s.templateEx.ExecuteNewEdit(templates.EditPageContent(device, "").WithCredentials(s.credentialOptions(ctx)).WithJumpHosts(s.jumpHostOptions(ctx)))
 	if err != nil {
 		slog.ErrorContext(ctx, "error executing template", slog.Any("error", err))
 		return oapi.GetEdit500JSONResponse{
@@ -156,10 +265,21 @@ func (s *Server) PostEdit(ctx context.Context, request oapi.PostEditRequestObjec
 		return s.postEditError(
 			ctx,
 			storage.Device{
-				ID:        form.EditId,
-				Hostname:  form.Hostname,
-				IPAddress: form.Ip,
-				Login:     form.Login,
+				ID:           form.EditId,
+				Hostname:     form.Hostname,
+				IPAddress:    form.Ip,
+				Port:         uint16(form.Port),
+				Login:        form.Login,
+				CredentialID: form.CredentialID,
+				JumpHostID:   form.JumpHostID,
+				Collector:    form.Collector,
+				SNMP:         storage.SNMP{Version: form.SNMP.Version, Port: form.SNMP.Port, User: form.SNMP.User, AuthProtocol: form.SNMP.AuthProtocol, PrivProtocol: form.SNMP.PrivProtocol},
+				GNMI:         form.GNMI,
+				NETCONF:      form.NETCONF,
+				Local:        form.Local,
+				Site:         form.Site,
+				Group:        form.Group,
+				Tags:         form.Tags,
 			},
 			err,
 		), nil
@@ -185,9 +305,17 @@ func (s *Server) PostEdit(ctx context.Context, request oapi.PostEditRequestObjec
 		}, nil
 	}
 
+	before := device.AuditFields()
+
 	device.Hostname = form.Hostname
 	device.IPAddress = form.Ip
+	device.Port = uint16(form.Port)
 	device.Login = form.Login
+	device.CredentialID = form.CredentialID
+	device.JumpHostID = form.JumpHostID
+	device.Site = form.Site
+	device.Group = form.Group
+	device.Tags = form.Tags
 	if form.PasswordClear != nil {
 		device.Password = *form.Password
 	}
@@ -195,13 +323,48 @@ func (s *Server) PostEdit(ctx context.Context, request oapi.PostEditRequestObjec
 	switch {
 	case form.KeyClear != nil:
 		device.Keyfile = []byte{}
+		device.Passphrase = ""
+		device.Certificate = []byte{}
 	case len(form.Key) != 0:
 		device.Keyfile = form.Key
+		device.Passphrase = form.Passphrase
+		device.Certificate = form.Certificate
 	default:
+		if form.Passphrase != "" {
+			device.Passphrase = form.Passphrase
+		}
+		if len(form.Certificate) != 0 {
+			device.Certificate = form.Certificate
+		}
+	}
+
+	device.Collector = form.Collector
+	device.GNMI = form.GNMI
+	device.NETCONF = form.NETCONF
+	device.Local = form.Local
+	device.SNMP.Version = form.SNMP.Version
+	device.SNMP.Port = form.SNMP.Port
+	device.SNMP.User = form.SNMP.User
+	device.SNMP.AuthProtocol = form.SNMP.AuthProtocol
+	device.SNMP.PrivProtocol = form.SNMP.PrivProtocol
+	if form.SNMP.Community != "" {
+		device.SNMP.Community = form.SNMP.Community
+	}
+	if form.SNMP.AuthPassword != "" {
+		device.SNMP.AuthPassword = form.SNMP.AuthPassword
+	}
+	if form.SNMP.PrivPassword != "" {
+		device.SNMP.PrivPassword = form.SNMP.PrivPassword
+	}
+
+	if device.Collector == storage.CollectorSNMP && device.SNMP.Version == storage.SNMPVersion2c && device.SNMP.Community == "" {
+		return s.postEditError(ctx, device, errors.New("SNMP community is required")), nil
 	}
 
 	if err = s.repository.UpdateDevice(ctx, device); err != nil {
 		slog.ErrorContext(ctx, "database error", slog.Any("error", err))
+	} else {
+		s.audit(ctx, storage.AuditActionUpdate, storage.AuditTargetDevice, device.ID, device.Hostname, before, device.AuditFields())
 	}
 
 	return oapi.PostEdit303Response{
@@ -212,7 +375,7 @@ func (s *Server) PostEdit(ctx context.Context, request oapi.PostEditRequestObjec
 }
 
 func (s *Server) postEditError(ctx context.Context, device storage.Device, err error) oapi.PostEditResponseObject {
-	page, err2 := s.templateEx.ExecuteNewEdit(templates.EditPageContent(device, err.Error()))
+	page, err2 := s.templateEx.ExecuteNewEdit(templates.EditPageContent(device, err.Error()).WithCredentials(s.credentialOptions(ctx)).WithJumpHosts(s.jumpHostOptions(ctx)))
 	if err2 != nil {
 		slog.ErrorContext(ctx, "error executing template", slog.Any("error", err2))
 
@@ -233,7 +396,7 @@ func (s *Server) postEditError(ctx context.Context, device storage.Device, err e
 }
 
 func (s *Server) GetNew(ctx context.Context, request oapi.GetNewRequestObject) (oapi.GetNewResponseObject, error) {
-	page, err := s.templateEx.ExecuteNewEdit(templates.NewPageContent())
+	page, err := s.templateEx.ExecuteNewEdit(templates.NewPageContent().WithCredentials(s.credentialOptions(ctx)).WithJumpHosts(s.jumpHostOptions(ctx)))
 	if err != nil {
 		slog.ErrorContext(ctx, "error executing template", slog.Any("error", err))
 		return oapi.GetNew500JSONResponse{
@@ -270,22 +433,50 @@ func (s *Server) PostNew(ctx context.Context, request oapi.PostNewRequestObject)
 		return s.postNewError(
 			ctx,
 			storage.Device{
-				Hostname:  form.Hostname,
-				IPAddress: form.Ip,
-				Login:     form.Login,
+				Hostname:     form.Hostname,
+				IPAddress:    form.Ip,
+				Port:         uint16(form.Port),
+				Login:        form.Login,
+				CredentialID: form.CredentialID,
+				JumpHostID:   form.JumpHostID,
+				Collector:    form.Collector,
+				SNMP:         storage.SNMP{Version: form.SNMP.Version, Port: form.SNMP.Port, User: form.SNMP.User, AuthProtocol: form.SNMP.AuthProtocol, PrivProtocol: form.SNMP.PrivProtocol},
+				GNMI:         form.GNMI,
+				NETCONF:      form.NETCONF,
+				Local:        form.Local,
+				Site:         form.Site,
+				Group:        form.Group,
+				Tags:         form.Tags,
 			},
 			err,
 		), nil
 	}
 
-	if err = s.repository.CreateDevice(ctx, storage.Device{
-		Hostname:  form.Hostname,
-		IPAddress: form.Ip,
-		Login:     form.Login,
-		Password:  *form.Password,
-		Keyfile:   form.Key,
-	}); err != nil {
+	device := storage.Device{
+		Hostname:     form.Hostname,
+		IPAddress:    form.Ip,
+		Port:         uint16(form.Port),
+		Login:        form.Login,
+		Password:     *form.Password,
+		Keyfile:      form.Key,
+		Passphrase:   form.Passphrase,
+		Certificate:  form.Certificate,
+		CredentialID: form.CredentialID,
+		JumpHostID:   form.JumpHostID,
+		Collector:    form.Collector,
+		SNMP:         form.SNMP,
+		GNMI:         form.GNMI,
+		NETCONF:      form.NETCONF,
+		Local:        form.Local,
+		Site:         form.Site,
+		Group:        form.Group,
+		Tags:         form.Tags,
+	}
+
+	if device.ID, err = s.repository.CreateDevice(ctx, device); err != nil {
 		slog.ErrorContext(ctx, "database error", slog.Any("error", err))
+	} else {
+		s.audit(ctx, storage.AuditActionCreate, storage.AuditTargetDevice, device.ID, device.Hostname, nil, device.AuditFields())
 	}
 
 	return oapi.PostNew303Response{
@@ -296,7 +487,7 @@ func (s *Server) PostNew(ctx context.Context, request oapi.PostNewRequestObject)
 }
 
 func (s *Server) postNewError(ctx context.Context, device storage.Device, err error) oapi.PostNewResponseObject {
-	page, err2 := s.templateEx.ExecuteNewEdit(templates.NewPageContentWithError(device, err.Error()))
+	page, err2 := s.templateEx.ExecuteNewEdit(templates.NewPageContentWithError(device, err.Error()).WithCredentials(s.credentialOptions(ctx)).WithJumpHosts(s.jumpHostOptions(ctx)))
 	if err2 != nil {
 		slog.ErrorContext(ctx, "error executing template", slog.Any("error", err2))
 		return oapi.PostNew500JSONResponse{
@@ -316,9 +507,14 @@ func (s *Server) postNewError(ctx context.Context, device storage.Device, err er
 }
 
 func (s *Server) PostDelete(ctx context.Context, request oapi.PostDeleteRequestObject) (oapi.PostDeleteResponseObject, error) {
-	err := s.repository.DeleteDevice(ctx, request.Body.DeleteId)
+	device, err := s.repository.Device(ctx, request.Body.DeleteId)
+	if err == nil {
+		err = s.repository.DeleteDevice(ctx, device.ID)
+	}
+
 	switch err {
 	case nil:
+		s.audit(ctx, storage.AuditActionDelete, storage.AuditTargetDevice, device.ID, device.Hostname, device.AuditFields(), nil)
 	case sql.ErrNoRows:
 		slog.ErrorContext(ctx, "device not found", slog.Any("error", err))
 	default:
@@ -339,7 +535,7 @@ func (s *Server) PostDelete(ctx context.Context, request oapi.PostDeleteRequestO
 }
 
 func (s *Server) GetSignin(ctx context.Context, request oapi.GetSigninRequestObject) (oapi.GetSigninResponseObject, error) {
-	page, err := s.templateEx.ExecuteSignIn("")
+	page, err := s.templateEx.ExecuteSignIn(templates.SignIn{SSOEnabled: s.oidc != nil})
 	if err != nil {
 		slog.ErrorContext(ctx, "error executing template", slog.Any("error", err))
 		return oapi.GetSignin500JSONResponse{
@@ -362,13 +558,14 @@ func (s *Server) PostSignin(ctx context.Context, request oapi.PostSigninRequestO
 	login := string(request.Body.Login)
 	password := request.Body.Password
 
-	if s.config.User != login ||
-		s.config.Password != password {
-		return s.postSigninError(ctx, errors.New("wrong credentials, try again")), nil
+	user, err := s.authenticator.Authenticate(ctx, login, password)
+	if err != nil {
+		slog.WarnContext(ctx, "sign in failed", slog.Any("username", login), slog.Any("error", err))
+		return s.postSigninError(ctx, auth.ErrInvalidCredentials), nil
 	}
 
 	v := PostSignInVisiter(func(w http.ResponseWriter) error {
-		s.cookies.Create(ctx, w, string(login))
+		s.cookies.Create(ctx, w, user)
 		w.Header().Add("Location", "/")
 		w.WriteHeader(http.StatusSeeOther)
 
@@ -379,7 +576,10 @@ func (s *Server) PostSignin(ctx context.Context, request oapi.PostSigninRequestO
 }
 
 func (s *Server) postSigninError(ctx context.Context, err error) oapi.PostSigninResponseObject {
-	page, err2 := s.templateEx.ExecuteSignIn(err.Error())
+	page, err2 := s.templateEx.ExecuteSignIn(templates.SignIn{
+		ErrorMessage: err.Error(),
+		SSOEnabled:   s.oidc != nil,
+	})
 	if err2 != nil {
 		slog.ErrorContext(ctx, "error executing template", slog.Any("error", err2))
 		return oapi.PostSignin500JSONResponse{
@@ -398,6 +598,91 @@ func (s *Server) postSigninError(ctx context.Context, err error) oapi.PostSignin
 	}
 }
 
+func (s *Server) GetSigninOidc(ctx context.Context, request oapi.GetSigninOidcRequestObject) (oapi.GetSigninOidcResponseObject, error) {
+	if s.oidc == nil {
+		return oapi.PageRedirectResponse{
+			Headers: oapi.PageRedirectResponseHeaders{
+				Location: "/signin",
+			},
+		}, nil
+	}
+
+	authURL, err := s.oidc.AuthCodeURL()
+	if err != nil {
+		slog.ErrorContext(ctx, "cannot start single sign-on", slog.Any("error", err))
+		return oapi.GetSigninOidc500JSONResponse{
+			PageErrorJSONResponse: oapi.PageErrorJSONResponse{
+				Error:        "cannot start single sign-on",
+				ErrorDetails: ptr(err.Error()),
+			},
+		}, nil
+	}
+
+	return oapi.PageRedirectResponse{
+		Headers: oapi.PageRedirectResponseHeaders{
+			Location: authURL,
+		},
+	}, nil
+}
+
+func (s *Server) GetSigninOidcCallback(ctx context.Context, request oapi.GetSigninOidcCallbackRequestObject) (oapi.GetSigninOidcCallbackResponseObject, error) {
+	if s.oidc == nil {
+		return oapi.PageRedirectResponse{
+			Headers: oapi.PageRedirectResponseHeaders{
+				Location: "/signin",
+			},
+		}, nil
+	}
+
+	if request.Params.Error != nil {
+		slog.WarnContext(ctx, "identity provider returned error", slog.Any("error", *request.Params.Error))
+		return s.oidcCallbackError(ctx, errors.New("single sign-on failed: "+*request.Params.Error)), nil
+	}
+
+	if request.Params.Code == nil || request.Params.State == nil {
+		return s.oidcCallbackError(ctx, errors.New("single sign-on failed: missing code or state")), nil
+	}
+
+	user, err := s.oidc.Exchange(ctx, *request.Params.State, *request.Params.Code)
+	if err != nil {
+		slog.WarnContext(ctx, "single sign-on failed", slog.Any("error", err))
+		return s.oidcCallbackError(ctx, errors.New("single sign-on failed, try again")), nil
+	}
+
+	v := OIDCCallbackVisiter(func(w http.ResponseWriter) error {
+		s.cookies.Create(ctx, w, user)
+		w.Header().Add("Location", "/")
+		w.WriteHeader(http.StatusSeeOther)
+
+		return nil
+	})
+
+	return v, nil
+}
+
+func (s *Server) oidcCallbackError(ctx context.Context, err error) oapi.GetSigninOidcCallbackResponseObject {
+	page, err2 := s.templateEx.ExecuteSignIn(templates.SignIn{
+		ErrorMessage: err.Error(),
+		SSOEnabled:   true,
+	})
+	if err2 != nil {
+		slog.ErrorContext(ctx, "error executing template", slog.Any("error", err2))
+		return oapi.GetSigninOidcCallback500JSONResponse{
+			PageErrorJSONResponse: oapi.PageErrorJSONResponse{
+				Error:        "error executing template",
+				ErrorDetails: ptr(errors.Join(err, err2).Error()),
+			},
+		}
+	}
+
+	return oapi.GetSigninOidcCallback200TexthtmlResponse{
+		PageTexthtmlResponse: oapi.PageTexthtmlResponse{
+			Body:          page,
+			ContentLength: int64(page.Len()),
+		},
+	}
+}
+
 func (s *Server) GetLogout(ctx context.Context, request oapi.GetLogoutRequestObject) (oapi.GetLogoutResponseObject, error) {
 	v := LogoutVisiter(func(w http.ResponseWriter) error {
 		s.cookies.Delete(ctx, w, request.Params.SessionToken)
@@ -418,6 +703,36 @@ func (s *Server) GetStaticFaviconIco(ctx context.Context, request oapi.GetStatic
 	return oapi.GetStaticFaviconIco200ImagexIconResponse{Body: bytes.NewReader(s.staticFiles.Favicon)}, nil
 }
 
+func deviceQuery(params oapi.GetParams) storage.DeviceQuery {
+	query := storage.DeviceQuery{PageSize: storage.DefaultPageSize}
+	if params.Q != nil {
+		query.Search = strings.TrimSpace(*params.Q)
+	}
+	if params.Site != nil {
+		query.Site = *params.Site
+	}
+	if params.Group != nil {
+		query.Group = *params.Group
+	}
+	if params.Tag != nil {
+		query.Tag = *params.Tag
+	}
+	if params.Status != nil {
+		query.Status = string(*params.Status)
+	}
+	if params.Sort != nil {
+		query.Sort = string(*params.Sort)
+	}
+	if params.Order != nil {
+		query.Desc = *params.Order == oapi.Desc
+	}
+	if params.Page != nil {
+		query.Page = *params.Page
+	}
+
+	return query
+}
+
 func ptr[T any](v T) *T {
 	return &v
 }
@@ -428,6 +743,12 @@ func (v PostSignInVisiter) VisitPostSigninResponse(w http.ResponseWriter) error
 	return v(w)
 }
 
+type OIDCCallbackVisiter func(w http.ResponseWriter) error
+
+func (v OIDCCallbackVisiter) VisitGetSigninOidcCallbackResponse(w http.ResponseWriter) error {
+	return v(w)
+}
+
 type LogoutVisiter func(w http.ResponseWriter) error
 
 func (v LogoutVisiter) VisitGetLogoutResponse(w http.ResponseWriter) error {
@@ -0,0 +1,54 @@
+package auth
+
+import (
+	"context"
+	"slices"
+)
+
+type Role string
+
+const (
+	RoleViewer Role = "viewer"
+	RoleAdmin  Role = "admin"
+)
+
+type User struct {
+	Login string
+	Role  Role
+}
+
+func (u User) CanModify() bool {
+	return u.Role == RoleAdmin
+}
+
+type RoleMapping struct {
+	AdminGroups  []string `envconfig:"ADMIN_GROUPS"`
+	ViewerGroups []string `envconfig:"VIEWER_GROUPS"`
+}
+
+func (m RoleMapping) Role(groups []string) (Role, bool) {
+	for _, g := range groups {
+		if slices.Contains(m.AdminGroups, g) {
+			return RoleAdmin, true
+		}
+	}
+
+	for _, g := range groups {
+		if slices.Contains(m.ViewerGroups, g) {
+			return RoleViewer, true
+		}
+	}
+
+	return "", false
+}
+
+type ctxKey struct{}
+
+func WithUser(ctx context.Context, user User) context.Context {
+	return context.WithValue(ctx, ctxKey{}, user)
+}
+
+func UserFromContext(ctx context.Context) (User, bool) {
+	user, ok := ctx.Value(ctxKey{}).(User)
+	return user, ok
+}
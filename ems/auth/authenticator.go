@@ -0,0 +1,50 @@
+package auth
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"log/slog"
+)
+
+var ErrInvalidCredentials = errors.New("wrong credentials, try again")
+
+type Authenticator interface {
+	Authenticate(ctx context.Context, login string, password string) (User, error)
+}
+
+// Local authenticates the single administrator configured for EMS.
+type Local struct {
+	User     string
+	Password string
+}
+
+func (l Local) Authenticate(ctx context.Context, login string, password string) (User, error) {
+	if l.User == "" ||
+		subtle.ConstantTimeCompare([]byte(l.User), []byte(login)) != 1 ||
+		subtle.ConstantTimeCompare([]byte(l.Password), []byte(password)) != 1 {
+		return User{}, ErrInvalidCredentials
+	}
+
+	return User{Login: login, Role: RoleAdmin}, nil
+}
+
+// Chain tries authenticators in order and returns the first successful result.
+// Backend failures (e.g. an unreachable directory) are logged and the next
+// authenticator is tried, so local users keep working when LDAP is down.
+type Chain []Authenticator
+
+func (c Chain) Authenticate(ctx context.Context, login string, password string) (User, error) {
+	for _, a := range c {
+		user, err := a.Authenticate(ctx, login, password)
+		if err == nil {
+			return user, nil
+		}
+
+		if !errors.Is(err, ErrInvalidCredentials) {
+			slog.WarnContext(ctx, "authentication backend failed", slog.Any("username", login), slog.Any("error", err))
+		}
+	}
+
+	return User{}, ErrInvalidCredentials
+}
@@ -0,0 +1,150 @@
+package auth
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"time"
+
+	"github.com/go-ldap/ldap/v3"
+)
+
+type LDAPConfig struct {
+	URL                string `envconfig:"URL"`
+	StartTLS           bool   `envconfig:"START_TLS" default:"false"`
+	InsecureSkipVerify bool   `envconfig:"INSECURE_SKIP_VERIFY" default:"false"`
+	Timeout            int    `envconfig:"TIMEOUT_SECONDS" default:"10"`
+	BindDN             string `envconfig:"BIND_DN"`
+	BindPassword       string `envconfig:"BIND_PASSWORD"`
+	BaseDN             string `envconfig:"BASE_DN"`
+	UserFilter         string `envconfig:"USER_FILTER" default:"(&(objectClass=user)(sAMAccountName=%s))"`
+	GroupAttribute     string `envconfig:"GROUP_ATTRIBUTE" default:"memberOf"`
+
+	RoleMapping
+}
+
+func (c LDAPConfig) Enabled() bool {
+	return c.URL != ""
+}
+
+type LDAP struct {
+	config LDAPConfig
+}
+
+func NewLDAP(cfg LDAPConfig) *LDAP {
+	return &LDAP{config: cfg}
+}
+
+// Authenticate looks the user up with the service account, then binds as the
+// found entry to check the password. Groups from GroupAttribute decide the role.
+func (l *LDAP) Authenticate(ctx context.Context, login string, password string) (User, error) {
+	if password == "" {
+		// An empty password would make it an unauthenticated bind, which most
+		// servers accept.
+		return User{}, ErrInvalidCredentials
+	}
+
+	conn, err := l.connect()
+	if err != nil {
+		return User{}, err
+	}
+	defer func() {
+		if err := conn.Close(); err != nil {
+			slog.ErrorContext(ctx, "cannot close LDAP connection", slog.Any("error", err))
+		}
+	}()
+
+	if l.config.BindDN != "" {
+		if err := conn.Bind(l.config.BindDN, l.config.BindPassword); err != nil {
+			return User{}, fmt.Errorf("service account bind failed: %w", err)
+		}
+	}
+
+	result, err := conn.Search(ldap.NewSearchRequest(
+		l.config.BaseDN,
+		ldap.ScopeWholeSubtree,
+		ldap.NeverDerefAliases,
+		2,
+		l.config.Timeout,
+		false,
+		fmt.Sprintf(l.config.UserFilter, ldap.EscapeFilter(login)),
+		[]string{"dn", l.config.GroupAttribute},
+		nil,
+	))
+	if err != nil {
+		if ldap.IsErrorWithCode(err, ldap.LDAPResultSizeLimitExceeded) {
+			return User{}, fmt.Errorf("user filter matches more than one entry for %s", login)
+		}
+		return User{}, fmt.Errorf("user search failed: %w", err)
+	}
+
+	switch len(result.Entries) {
+	case 0:
+		return User{}, ErrInvalidCredentials
+	case 1:
+	default:
+		return User{}, fmt.Errorf("user filter matches more than one entry for %s", login)
+	}
+
+	entry := result.Entries[0]
+
+	if err := conn.Bind(entry.DN, password); err != nil {
+		if ldap.IsErrorWithCode(err, ldap.LDAPResultInvalidCredentials) {
+			return User{}, ErrInvalidCredentials
+		}
+		return User{}, fmt.Errorf("user bind failed: %w", err)
+	}
+
+	role, ok := l.config.Role(groupNames(entry.GetAttributeValues(l.config.GroupAttribute)))
+	if !ok {
+		return User{}, fmt.Errorf("user %s has no EMS role assigned", login)
+	}
+
+	return User{Login: login, Role: role}, nil
+}
+
+func (l *LDAP) connect() (*ldap.Conn, error) {
+	u, err := url.Parse(l.config.URL)
+	if err != nil {
+		return nil, err
+	}
+
+	tlsConfig := &tls.Config{
+		ServerName:         u.Hostname(),
+		InsecureSkipVerify: l.config.InsecureSkipVerify,
+	}
+
+	conn, err := ldap.DialURL(l.config.URL, ldap.DialWithTLSConfig(tlsConfig))
+	if err != nil {
+		return nil, err
+	}
+	conn.SetTimeout(time.Duration(l.config.Timeout) * time.Second)
+
+	if l.config.StartTLS {
+		if err := conn.StartTLS(tlsConfig); err != nil {
+			return nil, errors.Join(fmt.Errorf("StartTLS failed: %w", err), conn.Close())
+		}
+	}
+
+	return conn, nil
+}
+
+// groupNames returns both full DNs and their common names, because DNs cannot
+// be listed in comma-separated configuration values.
+func groupNames(dns []string) []string {
+	names := make([]string, 0, 2*len(dns))
+	for _, dn := range dns {
+		names = append(names, dn)
+
+		parsed, err := ldap.ParseDN(dn)
+		if err != nil || len(parsed.RDNs) == 0 || len(parsed.RDNs[0].Attributes) == 0 {
+			continue
+		}
+		names = append(names, parsed.RDNs[0].Attributes[0].Value)
+	}
+
+	return names
+}
@@ -0,0 +1,312 @@
+package auth
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"math/big"
+	"net"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	ber "github.com/go-asn1-ber/asn1-ber"
+	"github.com/go-ldap/ldap/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testBindDN       = "cn=ems,ou=services,dc=noc,dc=local"
+	testBindPassword = "service-secret"
+	testAdminsGroup  = "cn=ems-admins,ou=groups,dc=noc,dc=local"
+	testViewersGroup = "cn=ems-viewers,ou=groups,dc=noc,dc=local"
+)
+
+func TestLDAP_Authenticate(t *testing.T) {
+	directory := map[string]ldapEntry{
+		"alice": {dn: "cn=alice,ou=noc,dc=noc,dc=local", password: "alice-pw", groups: []string{testAdminsGroup}},
+		"bob":   {dn: "cn=bob,ou=noc,dc=noc,dc=local", password: "bob-pw", groups: []string{"cn=other", testViewersGroup}},
+		"carol": {dn: "cn=carol,ou=noc,dc=noc,dc=local", password: "carol-pw", groups: []string{"cn=finance"}},
+	}
+
+	tcs := []struct {
+		name     string
+		startTLS bool
+		login    string
+		password string
+		want     User
+		wantErr  error
+	}{
+		{
+			name:     "admin",
+			login:    "alice",
+			password: "alice-pw",
+			want:     User{Login: "alice", Role: RoleAdmin},
+		},
+		{
+			name:     "viewer over StartTLS",
+			startTLS: true,
+			login:    "bob",
+			password: "bob-pw",
+			want:     User{Login: "bob", Role: RoleViewer},
+		},
+		{
+			name:     "wrong password",
+			login:    "alice",
+			password: "nope",
+			wantErr:  ErrInvalidCredentials,
+		},
+		{
+			name:     "empty password",
+			login:    "alice",
+			password: "",
+			wantErr:  ErrInvalidCredentials,
+		},
+		{
+			name:     "unknown user",
+			login:    "mallory",
+			password: "x",
+			wantErr:  ErrInvalidCredentials,
+		},
+		{
+			name:     "filter injection is escaped",
+			login:    "*",
+			password: "alice-pw",
+			wantErr:  ErrInvalidCredentials,
+		},
+		{
+			name:     "no mapped group",
+			login:    "carol",
+			password: "carol-pw",
+			wantErr:  errors.New("user carol has no EMS role assigned"),
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			server := newLDAPServer(t, directory)
+
+			l := NewLDAP(LDAPConfig{
+				URL:                "ldap://" + server.addr,
+				StartTLS:           tc.startTLS,
+				InsecureSkipVerify: true,
+				Timeout:            5,
+				BindDN:             testBindDN,
+				BindPassword:       testBindPassword,
+				BaseDN:             "dc=noc,dc=local",
+				UserFilter:         "(&(objectClass=user)(sAMAccountName=%s))",
+				GroupAttribute:     "memberOf",
+				RoleMapping: RoleMapping{
+					AdminGroups:  []string{testAdminsGroup},
+					ViewerGroups: []string{"ems-viewers"},
+				},
+			})
+
+			got, err := l.Authenticate(context.Background(), tc.login, tc.password)
+
+			if tc.wantErr != nil {
+				require.Error(t, err)
+				assert.EqualError(t, err, tc.wantErr.Error())
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+			assert.Equal(t, tc.startTLS, server.tlsUsed.Load())
+		})
+	}
+}
+
+func TestChain_Authenticate(t *testing.T) {
+	local := Local{User: "admin", Password: "P@55w0Rd"}
+	unreachable := NewLDAP(LDAPConfig{URL: "ldap://127.0.0.1:1", Timeout: 1})
+
+	t.Run("falls back to local user when LDAP is down", func(t *testing.T) {
+		got, err := Chain{unreachable, local}.Authenticate(context.Background(), "admin", "P@55w0Rd")
+
+		require.NoError(t, err)
+		assert.Equal(t, User{Login: "admin", Role: RoleAdmin}, got)
+	})
+
+	t.Run("rejects when no authenticator accepts", func(t *testing.T) {
+		_, err := Chain{unreachable, local}.Authenticate(context.Background(), "admin", "wrong")
+
+		assert.ErrorIs(t, err, ErrInvalidCredentials)
+	})
+}
+
+type ldapEntry struct {
+	dn       string
+	password string
+	groups   []string
+}
+
+// ldapServer is a minimal LDAPv3 stand-in: simple bind, subtree search by
+// sAMAccountName, StartTLS and unbind.
+type ldapServer struct {
+	addr      string
+	directory map[string]ldapEntry
+	tlsConfig *tls.Config
+	tlsUsed   atomic.Bool
+}
+
+func newLDAPServer(t *testing.T, directory map[string]ldapEntry) *ldapServer {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = listener.Close() })
+
+	s := &ldapServer{
+		addr:      listener.Addr().String(),
+		directory: directory,
+		tlsConfig: &tls.Config{Certificates: []tls.Certificate{selfSignedCertificate(t)}},
+	}
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go s.serve(conn)
+		}
+	}()
+
+	return s
+}
+
+func (s *ldapServer) serve(conn net.Conn) {
+	defer func() { _ = conn.Close() }()
+
+	boundDN := ""
+	for {
+		packet, err := ber.ReadPacket(conn)
+		if err != nil || len(packet.Children) < 2 {
+			return
+		}
+
+		messageID := packet.Children[0].Value.(int64)
+		op := packet.Children[1]
+
+		switch op.Tag {
+		case ldap.ApplicationBindRequest:
+			dn := op.Children[1].Data.String()
+			password := op.Children[2].Data.String()
+
+			code := uint16(ldap.LDAPResultInvalidCredentials)
+			if s.checkPassword(dn, password) {
+				code = ldap.LDAPResultSuccess
+				boundDN = dn
+			}
+			s.write(conn, messageID, ldapResult(ldap.ApplicationBindResponse, code))
+		case ldap.ApplicationSearchRequest:
+			if boundDN != testBindDN {
+				s.write(conn, messageID, ldapResult(ldap.ApplicationSearchResultDone, ldap.LDAPResultInsufficientAccessRights))
+				continue
+			}
+
+			filter, err := ldap.DecompileFilter(op.Children[6])
+			if err != nil {
+				s.write(conn, messageID, ldapResult(ldap.ApplicationSearchResultDone, ldap.LDAPResultProtocolError))
+				continue
+			}
+
+			for login, entry := range s.directory {
+				if strings.Contains(filter, "(sAMAccountName="+login+")") {
+					s.write(conn, messageID, searchEntry(entry))
+				}
+			}
+			s.write(conn, messageID, ldapResult(ldap.ApplicationSearchResultDone, ldap.LDAPResultSuccess))
+		case ldap.ApplicationExtendedRequest:
+			s.write(conn, messageID, ldapResult(ldap.ApplicationExtendedResponse, ldap.LDAPResultSuccess))
+
+			tlsConn := tls.Server(conn, s.tlsConfig)
+			if err := tlsConn.Handshake(); err != nil {
+				return
+			}
+			conn = tlsConn
+			s.tlsUsed.Store(true)
+		case ldap.ApplicationUnbindRequest:
+			return
+		default:
+			return
+		}
+	}
+}
+
+func (s *ldapServer) checkPassword(dn string, password string) bool {
+	if dn == testBindDN {
+		return password == testBindPassword
+	}
+
+	for _, entry := range s.directory {
+		if entry.dn == dn {
+			return password != "" && password == entry.password
+		}
+	}
+
+	return false
+}
+
+func (s *ldapServer) write(conn net.Conn, messageID int64, op *ber.Packet) {
+	packet := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "LDAP Response")
+	packet.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagInteger, messageID, "MessageID"))
+	packet.AppendChild(op)
+
+	_, _ = conn.Write(packet.Bytes())
+}
+
+func ldapResult(tag ber.Tag, code uint16) *ber.Packet {
+	p := ber.Encode(ber.ClassApplication, ber.TypeConstructed, tag, nil, "Result")
+	p.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagEnumerated, int64(code), "resultCode"))
+	p.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", "matchedDN"))
+	p.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", "diagnosticMessage"))
+
+	return p
+}
+
+func searchEntry(entry ldapEntry) *ber.Packet {
+	p := ber.Encode(ber.ClassApplication, ber.TypeConstructed, ldap.ApplicationSearchResultEntry, nil, "Entry")
+	p.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, entry.dn, "objectName"))
+
+	attrs := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "attributes")
+	attr := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "attribute")
+	attr.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "memberOf", "type"))
+	values := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSet, nil, "vals")
+	for _, g := range entry.groups {
+		values.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, g, "val"))
+	}
+	attr.AppendChild(values)
+	attrs.AppendChild(attr)
+	p.AppendChild(attrs)
+
+	return p
+}
+
+func selfSignedCertificate(t *testing.T) tls.Certificate {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "ldap.test"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}
+}
@@ -0,0 +1,179 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+	"golang.org/x/oauth2"
+)
+
+const pendingLoginExpiration = 5 * time.Minute
+
+type OIDCConfig struct {
+	Issuer       string   `envconfig:"ISSUER"`
+	ClientID     string   `envconfig:"CLIENT_ID"`
+	ClientSecret string   `envconfig:"CLIENT_SECRET"`
+	RedirectURL  string   `envconfig:"REDIRECT_URL"`
+	Scopes       []string `envconfig:"SCOPES" default:"profile,email"`
+	GroupsClaim  string   `envconfig:"GROUPS_CLAIM" default:"groups"`
+
+	RoleMapping
+}
+
+func (c OIDCConfig) Enabled() bool {
+	return c.Issuer != ""
+}
+
+type OIDC struct {
+	config   OIDCConfig
+	oauth2   oauth2.Config
+	verifier *oidc.IDTokenVerifier
+
+	mu      sync.Mutex
+	pending map[string]pendingLogin
+}
+
+type pendingLogin struct {
+	verifier string
+	nonce    string
+	expires  time.Time
+}
+
+// NewOIDC performs the provider discovery, so the issuer has to be reachable
+// at startup.
+func NewOIDC(ctx context.Context, cfg OIDCConfig) (*OIDC, error) {
+	provider, err := oidc.NewProvider(ctx, cfg.Issuer)
+	if err != nil {
+		return nil, fmt.Errorf("discovery failed: %w", err)
+	}
+
+	return &OIDC{
+		config: cfg,
+		oauth2: oauth2.Config{
+			ClientID:     cfg.ClientID,
+			ClientSecret: cfg.ClientSecret,
+			RedirectURL:  cfg.RedirectURL,
+			Endpoint:     provider.Endpoint(),
+			Scopes:       append([]string{oidc.ScopeOpenID}, cfg.Scopes...),
+		},
+		verifier: provider.Verifier(&oidc.Config{ClientID: cfg.ClientID}),
+		pending:  make(map[string]pendingLogin),
+	}, nil
+}
+
+// AuthCodeURL starts a new login and returns the provider's authorization URL
+// with state, nonce and PKCE challenge attached.
+func (o *OIDC) AuthCodeURL() (string, error) {
+	state, err := randomString()
+	if err != nil {
+		return "", err
+	}
+
+	nonce, err := randomString()
+	if err != nil {
+		return "", err
+	}
+
+	verifier := oauth2.GenerateVerifier()
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	o.pruneExpired()
+	o.pending[state] = pendingLogin{
+		verifier: verifier,
+		nonce:    nonce,
+		expires:  time.Now().Add(pendingLoginExpiration),
+	}
+
+	return o.oauth2.AuthCodeURL(state, oidc.Nonce(nonce), oauth2.S256ChallengeOption(verifier)), nil
+}
+
+// Exchange finishes the login started by AuthCodeURL. The ID token is verified
+// and its groups claim is mapped to an EMS role.
+func (o *OIDC) Exchange(ctx context.Context, state string, code string) (User, error) {
+	o.mu.Lock()
+	login, exists := o.pending[state]
+	delete(o.pending, state)
+	o.mu.Unlock()
+
+	if !exists || login.expires.Before(time.Now()) {
+		return User{}, errors.New("unknown or expired login state")
+	}
+
+	token, err := o.oauth2.Exchange(ctx, code, oauth2.VerifierOption(login.verifier))
+	if err != nil {
+		return User{}, fmt.Errorf("code exchange failed: %w", err)
+	}
+
+	rawIDToken, ok := token.Extra("id_token").(string)
+	if !ok {
+		return User{}, errors.New("no id_token in token response")
+	}
+
+	idToken, err := o.verifier.Verify(ctx, rawIDToken)
+	if err != nil {
+		return User{}, fmt.Errorf("id_token verification failed: %w", err)
+	}
+
+	if idToken.Nonce != login.nonce {
+		return User{}, errors.New("id_token nonce mismatch")
+	}
+
+	var claims map[string]any
+	if err := idToken.Claims(&claims); err != nil {
+		return User{}, err
+	}
+
+	username := idToken.Subject
+	if preferred, ok := claims["preferred_username"].(string); ok && preferred != "" {
+		username = preferred
+	}
+
+	role, ok := o.config.Role(stringSlice(claims[o.config.GroupsClaim]))
+	if !ok {
+		return User{}, fmt.Errorf("user %s has no EMS role assigned", username)
+	}
+
+	return User{Login: username, Role: role}, nil
+}
+
+func (o *OIDC) pruneExpired() {
+	for k, v := range o.pending {
+		if v.expires.Before(time.Now()) {
+			delete(o.pending, k)
+		}
+	}
+}
+
+func randomString() (string, error) {
+	b := make([]byte, 24)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func stringSlice(v any) []string {
+	switch values := v.(type) {
+	case []any:
+		out := make([]string, 0, len(values))
+		for _, value := range values {
+			if s, ok := value.(string); ok {
+				out = append(out, s)
+			}
+		}
+		return out
+	case string:
+		return []string{values}
+	default:
+		return nil
+	}
+}
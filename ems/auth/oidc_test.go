@@ -0,0 +1,219 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-jose/go-jose/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOIDC_Flow(t *testing.T) {
+	tcs := []struct {
+		name      string
+		groups    []string
+		verifier  func(string) string
+		wantUser  User
+		wantError bool
+	}{
+		{
+			name:     "admin group",
+			groups:   []string{"noc", "ems-admins"},
+			wantUser: User{Login: "jdoe", Role: RoleAdmin},
+		},
+		{
+			name:     "viewer group",
+			groups:   []string{"ems-viewers"},
+			wantUser: User{Login: "jdoe", Role: RoleViewer},
+		},
+		{
+			name:      "no mapped group",
+			groups:    []string{"finance"},
+			wantError: true,
+		},
+		{
+			name:      "wrong PKCE verifier",
+			groups:    []string{"ems-admins"},
+			verifier:  func(string) string { return "tampered" },
+			wantError: true,
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			provider := newMockProvider(t, tc.groups)
+			if tc.verifier != nil {
+				provider.challengeOverride = tc.verifier
+			}
+
+			o, err := NewOIDC(context.Background(), OIDCConfig{
+				Issuer:      provider.URL,
+				ClientID:    "ems",
+				RedirectURL: "http://ems.local/signin/oidc/callback",
+				GroupsClaim: "groups",
+				RoleMapping: RoleMapping{
+					AdminGroups:  []string{"ems-admins"},
+					ViewerGroups: []string{"ems-viewers"},
+				},
+			})
+			require.NoError(t, err)
+
+			authURL, err := o.AuthCodeURL()
+			require.NoError(t, err)
+
+			state, code := provider.authorize(t, authURL)
+
+			user, err := o.Exchange(context.Background(), state, code)
+			if tc.wantError {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantUser, user)
+
+			_, err = o.Exchange(context.Background(), state, code)
+			assert.Error(t, err, "state must not be reusable")
+		})
+	}
+}
+
+type mockProvider struct {
+	*httptest.Server
+
+	key               *rsa.PrivateKey
+	groups            []string
+	challengeOverride func(string) string
+
+	mu    sync.Mutex
+	codes map[string]mockCode
+}
+
+type mockCode struct {
+	challenge string
+	nonce     string
+}
+
+func newMockProvider(t *testing.T, groups []string) *mockProvider {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	p := &mockProvider{key: key, groups: groups, codes: make(map[string]mockCode)}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"issuer":                                p.URL,
+			"authorization_endpoint":                p.URL + "/authorize",
+			"token_endpoint":                        p.URL + "/token",
+			"jwks_uri":                              p.URL + "/keys",
+			"id_token_signing_alg_values_supported": []string{"RS256"},
+		})
+	})
+	mux.HandleFunc("/keys", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(jose.JSONWebKeySet{Keys: []jose.JSONWebKey{
+			{Key: &p.key.PublicKey, KeyID: "test", Algorithm: "RS256", Use: "sig"},
+		}})
+	})
+	mux.HandleFunc("/token", p.token)
+
+	p.Server = httptest.NewServer(mux)
+	t.Cleanup(p.Close)
+
+	return p
+}
+
+// authorize plays the user-agent part: it reads the authorization request and
+// issues a code as if the user signed in successfully.
+func (p *mockProvider) authorize(t *testing.T, authURL string) (state string, code string) {
+	t.Helper()
+
+	u, err := url.Parse(authURL)
+	require.NoError(t, err)
+
+	q := u.Query()
+	require.Equal(t, "S256", q.Get("code_challenge_method"))
+	require.NotEmpty(t, q.Get("code_challenge"))
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	code = "code-" + q.Get("state")
+	p.codes[code] = mockCode{challenge: q.Get("code_challenge"), nonce: q.Get("nonce")}
+
+	return q.Get("state"), code
+}
+
+func (p *mockProvider) token(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	p.mu.Lock()
+	c, ok := p.codes[r.PostForm.Get("code")]
+	delete(p.codes, r.PostForm.Get("code"))
+	p.mu.Unlock()
+
+	verifier := r.PostForm.Get("code_verifier")
+	if p.challengeOverride != nil {
+		verifier = p.challengeOverride(verifier)
+	}
+	sum := sha256.Sum256([]byte(verifier))
+
+	if !ok || base64.RawURLEncoding.EncodeToString(sum[:]) != c.challenge {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant"})
+		return
+	}
+
+	signer, err := jose.NewSigner(
+		jose.SigningKey{Algorithm: jose.RS256, Key: p.key},
+		(&jose.SignerOptions{}).WithHeader("kid", "test"),
+	)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	claims, _ := json.Marshal(map[string]any{
+		"iss":                p.URL,
+		"aud":                "ems",
+		"sub":                "0001",
+		"preferred_username": "jdoe",
+		"groups":             p.groups,
+		"nonce":              c.nonce,
+		"iat":                time.Now().Unix(),
+		"exp":                time.Now().Add(time.Hour).Unix(),
+	})
+
+	jws, err := signer.Sign(claims)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	idToken, _ := jws.CompactSerialize()
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]any{
+		"access_token": "access",
+		"token_type":   "Bearer",
+		"expires_in":   3600,
+		"id_token":     idToken,
+	})
+}
@@ -7,6 +7,8 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+
+	"pi-wegrzyn/ems/auth"
 )
 
 type Store struct {
@@ -21,14 +23,15 @@ func NewStore(expiration time.Duration) *Store {
 	}
 }
 
-func (cs *Store) Create(ctx context.Context, w http.ResponseWriter, username string) {
+func (cs *Store) Create(ctx context.Context, w http.ResponseWriter, user auth.User) {
 	cs.pruneExpired()
 
 	token := uuid.NewString()
 	expiration := time.Now().Add(cs.expiration)
 
 	cs.cookies[token] = cookie{
-		Login:   username,
+		Login:   user.Login,
+		Role:    user.Role,
 		Expires: expiration,
 	}
 
@@ -38,7 +41,7 @@ func (cs *Store) Create(ctx context.Context, w http.ResponseWriter, username str
 		Expires: expiration,
 	})
 
-	slog.InfoContext(ctx, "created cookie for user", slog.Any("username", username), slog.Any("expiresAt", expiration))
+	slog.InfoContext(ctx, "created cookie for user", slog.Any("username", user.Login), slog.Any("role", user.Role), slog.Any("expiresAt", expiration))
 }
 
 func (cs *Store) Delete(ctx context.Context, w http.ResponseWriter, token *string) {
@@ -61,20 +64,25 @@ func (cs *Store) Delete(ctx context.Context, w http.ResponseWriter, token *strin
 }
 
 func (cs *Store) IsSignedIn(r *http.Request) bool {
+	_, ok := cs.Session(r)
+	return ok
+}
+
+func (cs *Store) Session(r *http.Request) (auth.User, bool) {
 	cs.pruneExpired()
 
 	cookie, err := r.Cookie("session_token")
 	if err != nil {
-		return false
+		return auth.User{}, false
 	}
 
 	session, exists := cs.cookies[cookie.Value]
 	if !exists || session.isExpired() {
 		delete(cs.cookies, cookie.Value)
-		return false
+		return auth.User{}, false
 	}
 
-	return true
+	return auth.User{Login: session.Login, Role: session.Role}, true
 }
 
 func (cs *Store) pruneExpired() {
@@ -87,6 +95,7 @@ func (cs *Store) pruneExpired() {
 
 type cookie struct {
 	Login   string
+	Role    auth.Role
 	Expires time.Time
 }
 
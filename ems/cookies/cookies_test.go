@@ -6,13 +6,15 @@ import (
 	"net/http/httptest"
 	"testing"
 	"time"
+
+	"pi-wegrzyn/ems/auth"
 )
 
 func TestStore_Create(t *testing.T) {
 	store := NewStore(time.Hour)
 	testWriter := httptest.NewRecorder()
 
-	store.Create(context.Background(), testWriter, "test")
+	store.Create(context.Background(), testWriter, auth.User{Login: "test", Role: auth.RoleAdmin})
 
 	if len(store.cookies) != 1 {
 		t.Errorf("expected 1 cookie, got %d", len(store.cookies))
@@ -29,7 +31,7 @@ func TestStore_Delete(t *testing.T) {
 	store := NewStore(time.Hour)
 	testWriter := httptest.NewRecorder()
 
-	store.Create(context.Background(), testWriter, "test")
+	store.Create(context.Background(), testWriter, auth.User{Login: "test", Role: auth.RoleAdmin})
 
 	cookie := testWriter.Result().Cookies()[0]
 
@@ -45,7 +47,7 @@ func TestStore_IsSignedIn(t *testing.T) {
 		store := NewStore(time.Hour)
 		testWriter := httptest.NewRecorder()
 
-		store.Create(context.Background(), testWriter, "test")
+		store.Create(context.Background(), testWriter, auth.User{Login: "test", Role: auth.RoleAdmin})
 
 		request := httptest.NewRequest(http.MethodGet, "/", nil)
 		request.AddCookie(testWriter.Result().Cookies()[0])
@@ -57,6 +59,22 @@ func TestStore_IsSignedIn(t *testing.T) {
 		}
 	})
 
+	t.Run("keeps role", func(t *testing.T) {
+		store := NewStore(time.Hour)
+		testWriter := httptest.NewRecorder()
+
+		store.Create(context.Background(), testWriter, auth.User{Login: "test", Role: auth.RoleViewer})
+
+		request := httptest.NewRequest(http.MethodGet, "/", nil)
+		request.AddCookie(testWriter.Result().Cookies()[0])
+
+		user, ok := store.Session(request)
+
+		if !ok || user.Role != auth.RoleViewer {
+			t.Errorf("expected viewer session, got %v (%v)", user, ok)
+		}
+	})
+
 	t.Run("no cookie set", func(t *testing.T) {
 		store := NewStore(time.Hour)
 
@@ -2,6 +2,7 @@ package influx
 
 import (
 	"math"
+	"strconv"
 	"time"
 
 	influxdb2 "github.com/influxdata/influxdb-client-go/v2"
@@ -30,23 +31,183 @@ type Measurement struct {
 	TxPower     float64
 	RxPower     float64
 	OSNR        float64
+	// TxPowerLowAlarm and RxPowerLowAlarm are power low alarm thresholds in
+	// dBm, 0 when not known.
+	TxPowerLowAlarm float64
+	RxPowerLowAlarm float64
+	// Laser is set for tunable DWDM optics only.
+	Laser *Laser
+	// VDM are observables advertised by the module, by field name.
+	VDM map[string]float64
+	// ModuleState and DataPathStates of host lanes are CMIS state codes,
+	// 0 when not known.
+	ModuleState    uint8
+	DataPathStates []uint8
 }
 
-func (c *Client) InsertMeasurements(hostname string, interfaceName string, data Measurement) {
+// Laser is the state of a tunable laser, frequency in THz, wavelength in nm,
+// grid spacing in GHz and powers in dBm.
+type Laser struct {
+	Frequency   float64
+	Wavelength  float64
+	Channel     int
+	GridSpacing float64
+	TargetPower float64
+	MinPower    float64
+	MaxPower    float64
+}
+
+// Labels describe where the device belongs. They are written as Influx tags,
+// every free-form tag as its own tag_<name>=true key.
+type Labels struct {
+	Site  string
+	Group string
+	Tags  []string
+}
+
+func (l Labels) influxTags(interfaceName string) map[string]string {
+	tags := l.deviceTags()
+	tags["iface"] = interfaceName
+
+	return tags
+}
+
+func (l Labels) deviceTags() map[string]string {
+	tags := map[string]string{}
+	if l.Site != "" {
+		tags["site"] = l.Site
+	}
+	if l.Group != "" {
+		tags["group"] = l.Group
+	}
+	for _, tag := range l.Tags {
+		tags["tag_"+tag] = "true"
+	}
+
+	return tags
+}
+
+func (c *Client) InsertMeasurements(hostname string, labels Labels, interfaceName string, data Measurement) {
 	writeAPI := c.influxClient.WriteAPI(c.config.Org, c.config.Bucket)
 
 	p := influxdb2.NewPoint(
 		hostname,
-		map[string]string{"iface": interfaceName},
-		map[string]interface{}{
-			"temp":   math.Round(data.Temperature*100) / 100,
-			"vcc":    math.Round(data.Voltage*100) / 100,
-			"tx_pwr": math.Round(data.TxPower*100) / 100,
-			"rx_pwr": math.Round(data.RxPower*100) / 100,
-			"osnr":   math.Round(data.OSNR*100) / 100,
-		},
+		labels.influxTags(interfaceName),
+		data.fields(),
 		time.Now(),
 	)
 
 	writeAPI.WritePoint(p)
 }
+
+// InsertAvailability writes the result of polling the device to the
+// "availability" measurement, up is 1 for a successful poll and 0 otherwise.
+func (c *Client) InsertAvailability(hostname string, labels Labels, up bool) {
+	writeAPI := c.influxClient.WriteAPI(c.config.Org, c.config.Bucket)
+
+	tags := labels.deviceTags()
+	tags["host"] = hostname
+
+	value := 0
+	if up {
+		value = 1
+	}
+
+	writeAPI.WritePoint(influxdb2.NewPoint("availability", tags, map[string]interface{}{"up": value}, time.Now()))
+}
+
+// Span is a reading of a fiber link, in dB. Margin is over the Rx low alarm
+// threshold of B, nil when the threshold is not known.
+type Span struct {
+	Link     string
+	AHost    string
+	AIface   string
+	BHost    string
+	BIface   string
+	Loss     float64
+	Baseline float64
+	Margin   *float64
+}
+
+// InsertSpan writes the reading of a link to the "span" measurement.
+func (c *Client) InsertSpan(span Span) {
+	writeAPI := c.influxClient.WriteAPI(c.config.Org, c.config.Bucket)
+
+	tags := map[string]string{
+		"link":    span.Link,
+		"a_host":  span.AHost,
+		"a_iface": span.AIface,
+		"b_host":  span.BHost,
+		"b_iface": span.BIface,
+	}
+	fields := map[string]interface{}{
+		"loss":     math.Round(span.Loss*100) / 100,
+		"baseline": math.Round(span.Baseline*100) / 100,
+	}
+	if span.Margin != nil {
+		fields["margin"] = math.Round(*span.Margin*100) / 100
+	}
+
+	writeAPI.WritePoint(influxdb2.NewPoint("span", tags, fields, time.Now()))
+}
+
+// Field returns the value written as the named Influx field, e.g. rx_pwr or
+// vdm_osnr, false when the measurement has no such field.
+func (m Measurement) Field(name string) (float64, bool) {
+	switch value := m.fields()[name].(type) {
+	case float64:
+		return value, true
+	case int:
+		return float64(value), true
+	default:
+		return 0, false
+	}
+}
+
+func (m Measurement) fields() map[string]interface{} {
+	fields := map[string]interface{}{
+		"temp":   math.Round(m.Temperature*100) / 100,
+		"vcc":    math.Round(m.Voltage*100) / 100,
+		"tx_pwr": math.Round(m.TxPower*100) / 100,
+		"rx_pwr": math.Round(m.RxPower*100) / 100,
+		"osnr":   math.Round(m.OSNR*100) / 100,
+	}
+
+	if m.TxPowerLowAlarm != 0 {
+		fields["tx_pwr_low_alarm"] = math.Round(m.TxPowerLowAlarm*100) / 100
+	}
+	if m.RxPowerLowAlarm != 0 {
+		fields["rx_pwr_low_alarm"] = math.Round(m.RxPowerLowAlarm*100) / 100
+	}
+
+	if m.Laser != nil {
+		fields["laser_freq"] = math.Round(m.Laser.Frequency*1e6) / 1e6
+		fields["laser_wavelength"] = math.Round(m.Laser.Wavelength*1000) / 1000
+		fields["laser_channel"] = m.Laser.Channel
+		fields["laser_grid"] = m.Laser.GridSpacing
+		fields["target_pwr"] = math.Round(m.Laser.TargetPower*100) / 100
+		fields["prog_pwr_min"] = math.Round(m.Laser.MinPower*100) / 100
+		fields["prog_pwr_max"] = math.Round(m.Laser.MaxPower*100) / 100
+	}
+
+	if m.ModuleState != 0 {
+		fields["module_state"] = int(m.ModuleState)
+	}
+	for lane, state := range m.DataPathStates {
+		if state == 0 {
+			continue
+		}
+		if lane == 0 {
+			fields["dp_state"] = int(state)
+			continue
+		}
+		fields["dp_state_l"+strconv.Itoa(lane+1)] = int(state)
+	}
+
+	// VDM values are not rounded, error ratios are far below 0.01.
+	for name, value := range m.VDM {
+		fields[name] = value
+	}
+
+	return fields
+}
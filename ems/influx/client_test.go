@@ -22,10 +22,16 @@ func Test_InsertMeasurements(t *testing.T) {
 		influxClient: mock,
 	}
 
-	client.InsertMeasurements(testHostname, "test-interface", Measurement{Temperature: 123})
+	client.InsertMeasurements(testHostname, Labels{Site: "WAW", Tags: []string{"lab", "core"}}, "test-interface", Measurement{Temperature: 123})
 
 	require.NotNil(t, mock.point)
 	assert.Contains(t, fmt.Sprintf("%v", *mock.point), testHostname)
+
+	tags := map[string]string{}
+	for _, tag := range mock.point.TagList() {
+		tags[tag.Key] = tag.Value
+	}
+	assert.Equal(t, map[string]string{"iface": "test-interface", "site": "WAW", "tag_core": "true", "tag_lab": "true"}, tags)
 }
 
 type influxMock struct {
@@ -49,3 +55,100 @@ func (w writeAPIMock) WritePoint(p *write.Point)                      { *w.point
 func (w writeAPIMock) Flush()                                         {}
 func (w writeAPIMock) Errors() <-chan error                           { return make(<-chan error) }
 func (w writeAPIMock) SetWriteFailedCallback(api.WriteFailedCallback) {}
+
+func Test_InsertMeasurements_laser(t *testing.T) {
+	mock := &influxMock{}
+	client := Client{config: Config{Bucket: "test-bucket", Org: "test-org"}, influxClient: mock}
+
+	client.InsertMeasurements("test-hostname", Labels{}, "test-interface", Measurement{Temperature: 123})
+	require.NotNil(t, mock.point)
+	for _, field := range mock.point.FieldList() {
+		assert.NotEqual(t, "laser_freq", field.Key)
+	}
+
+	laser := &Laser{Frequency: 193.1, Wavelength: 1552.5244, Channel: 0, GridSpacing: 100, TargetPower: -12.5, MinPower: -22.9, MaxPower: 4}
+	client.InsertMeasurements("test-hostname", Labels{}, "test-interface", Measurement{Temperature: 123, Laser: laser})
+
+	fields := map[string]interface{}{}
+	for _, field := range mock.point.FieldList() {
+		fields[field.Key] = field.Value
+	}
+	assert.Equal(t, 193.1, fields["laser_freq"])
+	assert.Equal(t, 1552.524, fields["laser_wavelength"])
+	assert.Equal(t, int64(0), fields["laser_channel"])
+	assert.Equal(t, -12.5, fields["target_pwr"])
+	assert.Equal(t, 4.0, fields["prog_pwr_max"])
+}
+
+func Test_InsertMeasurements_vdm(t *testing.T) {
+	mock := &influxMock{}
+	client := Client{config: Config{Bucket: "test-bucket", Org: "test-org"}, influxClient: mock}
+
+	client.InsertMeasurements("test-hostname", Labels{}, "test-interface", Measurement{
+		Temperature: 123,
+		VDM:         map[string]float64{"vdm_pre_fec_ber_media": 1.5e-3, "vdm_cd_short": -12},
+	})
+	require.NotNil(t, mock.point)
+
+	fields := map[string]interface{}{}
+	for _, field := range mock.point.FieldList() {
+		fields[field.Key] = field.Value
+	}
+	assert.Equal(t, 1.5e-3, fields["vdm_pre_fec_ber_media"])
+	assert.Equal(t, -12.0, fields["vdm_cd_short"])
+	assert.Equal(t, 123.0, fields["temp"])
+}
+
+func Test_InsertSpan(t *testing.T) {
+	mock := &influxMock{}
+	client := Client{config: Config{Bucket: "test-bucket", Org: "test-org"}, influxClient: mock}
+
+	margin := 6.254
+	client.InsertSpan(Span{Link: "a-b", AHost: "ep-site-a", AIface: "eth1", BHost: "ep-site-b", BIface: "eth1", Loss: 4.501, Baseline: 4.5, Margin: &margin})
+	require.NotNil(t, mock.point)
+	assert.Equal(t, "span", mock.point.Name())
+
+	tags := map[string]string{}
+	for _, tag := range mock.point.TagList() {
+		tags[tag.Key] = tag.Value
+	}
+	assert.Equal(t, map[string]string{"link": "a-b", "a_host": "ep-site-a", "a_iface": "eth1", "b_host": "ep-site-b", "b_iface": "eth1"}, tags)
+
+	fields := map[string]interface{}{}
+	for _, field := range mock.point.FieldList() {
+		fields[field.Key] = field.Value
+	}
+	assert.Equal(t, map[string]interface{}{"loss": 4.5, "baseline": 4.5, "margin": 6.25}, fields)
+}
+
+func Test_InsertAvailability(t *testing.T) {
+	mock := &influxMock{}
+	client := Client{config: Config{Bucket: "test-bucket", Org: "test-org"}, influxClient: mock}
+
+	client.InsertAvailability("test-hostname", Labels{Site: "WAW", Group: "core"}, false)
+	require.NotNil(t, mock.point)
+	assert.Equal(t, "availability", mock.point.Name())
+
+	tags := map[string]string{}
+	for _, tag := range mock.point.TagList() {
+		tags[tag.Key] = tag.Value
+	}
+	assert.Equal(t, map[string]string{"host": "test-hostname", "site": "WAW", "group": "core"}, tags)
+
+	require.Len(t, mock.point.FieldList(), 1)
+	assert.Equal(t, "up", mock.point.FieldList()[0].Key)
+	assert.EqualValues(t, 0, mock.point.FieldList()[0].Value)
+}
+
+func TestMeasurement_Field(t *testing.T) {
+	m := Measurement{RxPower: -18.504, ModuleState: 3, VDM: map[string]float64{"vdm_osnr": 23.5}}
+
+	for name, want := range map[string]float64{"rx_pwr": -18.5, "module_state": 3, "vdm_osnr": 23.5} {
+		got, ok := m.Field(name)
+		assert.True(t, ok, name)
+		assert.Equal(t, want, got, name)
+	}
+
+	_, ok := m.Field("laser_freq")
+	assert.False(t, ok)
+}
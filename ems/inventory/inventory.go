@@ -0,0 +1,247 @@
+// Package inventory imports and exports the device list as CSV or YAML.
+package inventory
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+
+	"pi-wegrzyn/ems/storage"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	FormatCSV  = "csv"
+	FormatYAML = "yaml"
+
+	MaxFileSize = 1 << 20
+)
+
+var (
+	columns         = []string{"hostname", "ip", "port", "login", "credential", "site", "group", "tags"}
+	requiredColumns = []string{"hostname", "ip", "login"}
+)
+
+// Record is a single device entry of an import or export file. Credential
+// is the name of a credential profile, secrets are never exported.
+type Record struct {
+	Line       int      `yaml:"-"`
+	Hostname   string   `yaml:"hostname"`
+	IP         string   `yaml:"ip"`
+	Port       int      `yaml:"port,omitempty"`
+	Login      string   `yaml:"login,omitempty"`
+	Credential string   `yaml:"credential,omitempty"`
+	Site       string   `yaml:"site,omitempty"`
+	Group      string   `yaml:"group,omitempty"`
+	Tags       []string `yaml:"tags,omitempty,flow"`
+}
+
+// FormatFromFilename guesses the file format from its extension.
+func FormatFromFilename(name string) (string, error) {
+	switch {
+	case strings.HasSuffix(strings.ToLower(name), ".csv"):
+		return FormatCSV, nil
+	case strings.HasSuffix(strings.ToLower(name), ".yaml"), strings.HasSuffix(strings.ToLower(name), ".yml"):
+		return FormatYAML, nil
+	default:
+		return "", fmt.Errorf("unsupported file type of %s, use .csv or .yaml", name)
+	}
+}
+
+func Parse(format string, data []byte) ([]Record, error) {
+	if len(data) > MaxFileSize {
+		return nil, fmt.Errorf("file is larger than %d KB", MaxFileSize/1024)
+	}
+
+	switch format {
+	case FormatCSV:
+		return parseCSV(data)
+	case FormatYAML:
+		return parseYAML(data)
+	default:
+		return nil, fmt.Errorf("unsupported format %q", format)
+	}
+}
+
+func parseCSV(data []byte) ([]Record, error) {
+	r := csv.NewReader(bytes.NewReader(data))
+	r.TrimLeadingSpace = true
+	r.FieldsPerRecord = -1
+
+	header, err := r.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, errors.New("file is empty")
+		}
+		return nil, err
+	}
+
+	index := make(map[string]int, len(header))
+	for i, h := range header {
+		h = strings.ToLower(strings.TrimSpace(h))
+		if !slices.Contains(columns, h) {
+			return nil, fmt.Errorf("line 1: unknown column %q", h)
+		}
+		index[h] = i
+	}
+
+	for _, c := range requiredColumns {
+		if _, ok := index[c]; !ok {
+			return nil, fmt.Errorf("line 1: missing column %q", c)
+		}
+	}
+
+	var records []Record
+	for {
+		row, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		line, _ := r.FieldPos(0)
+		if len(row) != len(header) {
+			return nil, fmt.Errorf("line %d: expected %d fields, got %d", line, len(header), len(row))
+		}
+
+		field := func(name string) string {
+			i, ok := index[name]
+			if !ok {
+				return ""
+			}
+			return strings.TrimSpace(row[i])
+		}
+
+		record := Record{
+			Line:       line,
+			Hostname:   field("hostname"),
+			IP:         field("ip"),
+			Login:      field("login"),
+			Credential: field("credential"),
+			Site:       field("site"),
+			Group:      field("group"),
+			Tags:       storage.ParseTags(field("tags")),
+		}
+
+		if port := field("port"); port != "" {
+			if record.Port, err = strconv.Atoi(port); err != nil {
+				return nil, fmt.Errorf("line %d: wrong port %q", line, port)
+			}
+		}
+
+		records = append(records, record)
+	}
+
+	return records, nil
+}
+
+func parseYAML(data []byte) ([]Record, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+
+	if len(doc.Content) == 0 {
+		return nil, errors.New("file is empty")
+	}
+
+	list := doc.Content[0]
+	if list.Kind != yaml.SequenceNode {
+		return nil, fmt.Errorf("line %d: expected a list of devices", list.Line)
+	}
+
+	records := make([]Record, 0, len(list.Content))
+	for _, item := range list.Content {
+		if item.Kind != yaml.MappingNode {
+			return nil, fmt.Errorf("line %d: expected a device mapping", item.Line)
+		}
+
+		for i := 0; i < len(item.Content); i += 2 {
+			if key := item.Content[i]; !slices.Contains(columns, key.Value) {
+				return nil, fmt.Errorf("line %d: unknown field %q", key.Line, key.Value)
+			}
+		}
+
+		var record Record
+		if err := item.Decode(&record); err != nil {
+			return nil, fmt.Errorf("line %d: %w", item.Line, err)
+		}
+		record.Line = item.Line
+		record.Tags = storage.ParseTags(strings.Join(record.Tags, ","))
+
+		records = append(records, record)
+	}
+
+	return records, nil
+}
+
+// Export writes devices without any secrets, only credential profile names.
+func Export(w io.Writer, format string, devices []storage.Device, profiles []storage.Credential) error {
+	names := make(map[uint]string, len(profiles))
+	for _, p := range profiles {
+		names[p.ID] = p.Name
+	}
+
+	records := make([]Record, 0, len(devices))
+	for _, d := range devices {
+		records = append(records, Record{
+			Hostname:   d.Hostname,
+			IP:         d.IPAddress,
+			Port:       int(d.Port),
+			Login:      d.Login,
+			Credential: names[d.CredentialID],
+			Site:       d.Site,
+			Group:      d.Group,
+			Tags:       d.Tags,
+		})
+	}
+
+	switch format {
+	case FormatCSV:
+		return exportCSV(w, records)
+	case FormatYAML:
+		enc := yaml.NewEncoder(w)
+		enc.SetIndent(2)
+		if err := enc.Encode(records); err != nil {
+			return err
+		}
+		return enc.Close()
+	default:
+		return fmt.Errorf("unsupported format %q", format)
+	}
+}
+
+func exportCSV(w io.Writer, records []Record) error {
+	cw := csv.NewWriter(w)
+
+	if err := cw.Write(columns); err != nil {
+		return err
+	}
+
+	for _, r := range records {
+		if err := cw.Write([]string{
+			r.Hostname,
+			r.IP,
+			strconv.Itoa(r.Port),
+			r.Login,
+			r.Credential,
+			r.Site,
+			r.Group,
+			strings.Join(r.Tags, ","),
+		}); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+
+	return cw.Error()
+}
@@ -0,0 +1,166 @@
+package inventory
+
+import (
+	"bytes"
+	"testing"
+
+	"pi-wegrzyn/ems/storage"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	tcs := []struct {
+		name    string
+		format  string
+		data    string
+		want    []Record
+		wantErr string
+	}{
+		{
+			name:   "csv",
+			format: FormatCSV,
+			data: `hostname,ip,port,login,credential,site,group,tags
+r1,10.0.0.1,22,noc,,WAW,core,"core,waw"
+r2, 2001:db8::2,,noc,noc-key,,,
+`,
+			want: []Record{
+				{Line: 2, Hostname: "r1", IP: "10.0.0.1", Port: 22, Login: "noc", Site: "WAW", Group: "core", Tags: []string{"core", "waw"}},
+				{Line: 3, Hostname: "r2", IP: "2001:db8::2", Login: "noc", Credential: "noc-key", Tags: []string{}},
+			},
+		},
+		{
+			name:   "csv with subset of columns in any order",
+			format: FormatCSV,
+			data:   "Login,Hostname,IP\nnoc,r1,10.0.0.1\n",
+			want:   []Record{{Line: 2, Hostname: "r1", IP: "10.0.0.1", Login: "noc", Tags: []string{}}},
+		},
+		{
+			name:    "csv unknown column",
+			format:  FormatCSV,
+			data:    "hostname,ip,login,password\nr1,10.0.0.1,noc,secret\n",
+			wantErr: `line 1: unknown column "password"`,
+		},
+		{
+			name:    "csv missing column",
+			format:  FormatCSV,
+			data:    "hostname,login\nr1,noc\n",
+			wantErr: `line 1: missing column "ip"`,
+		},
+		{
+			name:    "csv wrong port",
+			format:  FormatCSV,
+			data:    "hostname,ip,login,port\nr1,10.0.0.1,noc,22\nr2,10.0.0.2,noc,ssh\n",
+			wantErr: `line 3: wrong port "ssh"`,
+		},
+		{
+			name:   "yaml",
+			format: FormatYAML,
+			data: `- hostname: r1
+  ip: 10.0.0.1
+  login: noc
+  tags: [core, waw]
+- hostname: r2
+  ip: 10.0.0.2
+  port: 2222
+  login: noc
+  credential: noc-key
+`,
+			want: []Record{
+				{Line: 1, Hostname: "r1", IP: "10.0.0.1", Login: "noc", Tags: []string{"core", "waw"}},
+				{Line: 5, Hostname: "r2", IP: "10.0.0.2", Port: 2222, Login: "noc", Credential: "noc-key", Tags: []string{}},
+			},
+		},
+		{
+			name:    "yaml unknown field",
+			format:  FormatYAML,
+			data:    "- hostname: r1\n  ip: 10.0.0.1\n  login: noc\n  password: secret\n",
+			wantErr: `line 4: unknown field "password"`,
+		},
+		{
+			name:    "yaml not a list",
+			format:  FormatYAML,
+			data:    "hostname: r1\n",
+			wantErr: "line 1: expected a list of devices",
+		},
+		{
+			name:    "empty file",
+			format:  FormatCSV,
+			data:    "",
+			wantErr: "file is empty",
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := Parse(tc.format, []byte(tc.data))
+
+			if tc.wantErr != "" {
+				assert.EqualError(t, err, tc.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestExport(t *testing.T) {
+	devices := []storage.Device{
+		{ID: 1, Hostname: "r1", IPAddress: "10.0.0.1", Port: 22, Login: "noc", Password: "secret", Site: "WAW", Group: "core", Tags: []string{"core", "waw"}},
+		{ID: 2, Hostname: "r2", IPAddress: "10.0.0.2", Port: 2222, Login: "noc", Keyfile: []byte("key"), Tags: []string{}},
+		{ID: 3, Hostname: "r3", IPAddress: "10.0.0.3", Port: 22, CredentialID: 7, Tags: []string{}},
+	}
+	profiles := []storage.Credential{{ID: 7, Name: "noc-key", Kind: storage.CredentialKey, Login: "noc", Keyfile: []byte("key")}}
+
+	t.Run("csv", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, Export(&buf, FormatCSV, devices, profiles))
+
+		assert.Equal(t, `hostname,ip,port,login,credential,site,group,tags
+r1,10.0.0.1,22,noc,,WAW,core,"core,waw"
+r2,10.0.0.2,2222,noc,,,,
+r3,10.0.0.3,22,,noc-key,,,
+`, buf.String())
+	})
+
+	t.Run("yaml", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, Export(&buf, FormatYAML, devices, profiles))
+
+		assert.Equal(t, `- hostname: r1
+  ip: 10.0.0.1
+  port: 22
+  login: noc
+  site: WAW
+  group: core
+  tags: [core, waw]
+- hostname: r2
+  ip: 10.0.0.2
+  port: 2222
+  login: noc
+- hostname: r3
+  ip: 10.0.0.3
+  port: 22
+  credential: noc-key
+`, buf.String())
+	})
+
+	t.Run("round trip", func(t *testing.T) {
+		for _, format := range []string{FormatCSV, FormatYAML} {
+			var buf bytes.Buffer
+			require.NoError(t, Export(&buf, format, devices, profiles))
+
+			records, err := Parse(format, buf.Bytes())
+			require.NoError(t, err)
+
+			plan := NewPlan(devices, profiles, records)
+			require.True(t, plan.Valid())
+			for _, row := range plan.Rows {
+				assert.Equal(t, ActionUnchanged, row.Action, format)
+			}
+		}
+	})
+}
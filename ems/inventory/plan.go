@@ -0,0 +1,164 @@
+package inventory
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	"pi-wegrzyn/ems/storage"
+	"pi-wegrzyn/ems/templates"
+)
+
+const (
+	ActionCreate    = storage.AuditActionCreate
+	ActionUpdate    = storage.AuditActionUpdate
+	ActionUnchanged = "unchanged"
+)
+
+// Row is the outcome of a single record: what would happen to the device or
+// why the record was rejected.
+type Row struct {
+	Line    int
+	Action  string
+	Device  storage.Device
+	Changes []storage.Change
+	Err     error
+}
+
+type Plan struct {
+	Rows []Row
+}
+
+// NewPlan matches records with existing devices by hostname and validates
+// them the same way as the device form. A blank credential keeps the profile
+// of an existing device. Nothing is written.
+func NewPlan(existing []storage.Device, profiles []storage.Credential, records []Record) Plan {
+	byHostname := make(map[string][]storage.Device, len(existing))
+	for _, d := range existing {
+		byHostname[d.Hostname] = append(byHostname[d.Hostname], d)
+	}
+
+	profileIDs := make(map[string]uint, len(profiles))
+	for _, p := range profiles {
+		profileIDs[p.Name] = p.ID
+	}
+
+	seen := make(map[string]int, len(records))
+	plan := Plan{Rows: make([]Row, 0, len(records))}
+
+	for _, r := range records {
+		row := Row{Line: r.Line}
+
+		if line, ok := seen[r.Hostname]; ok && r.Hostname != "" {
+			row.Err = fmt.Errorf("duplicate hostname, already used on line %d", line)
+			plan.Rows = append(plan.Rows, row)
+			continue
+		}
+		seen[r.Hostname] = r.Line
+
+		credentialID := uint(0)
+		if r.Credential != "" {
+			id, ok := profileIDs[r.Credential]
+			if !ok {
+				row.Err = fmt.Errorf("unknown credential profile %q", r.Credential)
+				plan.Rows = append(plan.Rows, row)
+				continue
+			}
+			credentialID = id
+		}
+
+		action, before := ActionCreate, storage.Device{}
+		switch matches := byHostname[r.Hostname]; len(matches) {
+		case 0:
+		case 1:
+			action, before = ActionUpdate, matches[0]
+		default:
+			row.Err = fmt.Errorf("hostname matches %d existing devices", len(matches))
+			plan.Rows = append(plan.Rows, row)
+			continue
+		}
+
+		if credentialID == 0 {
+			credentialID = before.CredentialID
+		}
+
+		form := templates.Form{
+			Hostname:     r.Hostname,
+			Ip:           r.IP,
+			IPType:       ipType(r.IP),
+			Port:         r.Port,
+			Login:        r.Login,
+			CredentialID: credentialID,
+			Site:         r.Site,
+			Group:        r.Group,
+			Tags:         r.Tags,
+		}
+		if err := form.Validate(); err != nil {
+			row.Err = err
+			plan.Rows = append(plan.Rows, row)
+			continue
+		}
+
+		device := before
+		device.Hostname = form.Hostname
+		device.IPAddress = form.Ip
+		device.Port = uint16(form.Port)
+		device.Login = form.Login
+		device.CredentialID = form.CredentialID
+		device.Site = form.Site
+		device.Group = form.Group
+		device.Tags = form.Tags
+
+		if action == ActionCreate {
+			row.Changes = storage.Diff(nil, device.AuditFields())
+		} else {
+			row.Changes = storage.Diff(before.AuditFields(), device.AuditFields())
+			if len(row.Changes) == 0 {
+				action = ActionUnchanged
+			}
+		}
+
+		row.Action = action
+		row.Device = device
+		plan.Rows = append(plan.Rows, row)
+	}
+
+	return plan
+}
+
+func (p Plan) Valid() bool {
+	for _, r := range p.Rows {
+		if r.Err != nil {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (p Plan) Creates() []storage.Device {
+	return p.devices(ActionCreate)
+}
+
+func (p Plan) Updates() []storage.Device {
+	return p.devices(ActionUpdate)
+}
+
+func (p Plan) devices(action string) []storage.Device {
+	var devices []storage.Device
+	for _, r := range p.Rows {
+		if r.Err == nil && r.Action == action {
+			devices = append(devices, r.Device)
+		}
+	}
+
+	return devices
+}
+
+func ipType(ip string) int {
+	if strings.Contains(ip, ":") && net.ParseIP(ip) != nil {
+		return 6
+	}
+
+	return 4
+}
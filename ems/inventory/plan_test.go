@@ -0,0 +1,66 @@
+package inventory
+
+import (
+	"errors"
+	"testing"
+
+	"pi-wegrzyn/ems/storage"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewPlan(t *testing.T) {
+	existing := []storage.Device{
+		{ID: 1, Hostname: "r1", IPAddress: "10.0.0.1", Port: 22, Login: "noc", Password: "secret", Tags: []string{}},
+		{ID: 2, Hostname: "r2", IPAddress: "10.0.0.2", Port: 22, Login: "noc", Keyfile: []byte("key"), Tags: []string{}},
+	}
+
+	profiles := []storage.Credential{{ID: 7, Name: "noc-key", Kind: storage.CredentialKey, Login: "noc"}}
+
+	plan := NewPlan(existing, profiles, []Record{
+		{Line: 2, Hostname: "r1", IP: "10.0.0.1", Login: "noc", Tags: []string{}},
+		{Line: 3, Hostname: "r2", IP: "10.0.0.20", Port: 2222, Login: "noc", Tags: []string{"core"}},
+		{Line: 4, Hostname: "r3", IP: "10.0.0.3", Credential: "noc-key", Tags: []string{}},
+		{Line: 5, Hostname: "r4", IP: "10.0.0", Login: "noc"},
+		{Line: 6, Hostname: "r3", IP: "10.0.0.3", Login: "noc"},
+		{Line: 7, Hostname: "r5", IP: "10.0.0.5", Login: "noc", Credential: "r9"},
+	})
+
+	want := []Row{
+		{
+			Line:    2,
+			Action:  ActionUnchanged,
+			Device:  existing[0],
+			Changes: []storage.Change{},
+		},
+		{
+			Line:   3,
+			Action: ActionUpdate,
+			Device: storage.Device{ID: 2, Hostname: "r2", IPAddress: "10.0.0.20", Port: 2222, Login: "noc", Keyfile: []byte("key"), Tags: []string{"core"}},
+			Changes: []storage.Change{
+				{Field: "ip", Before: "10.0.0.2", After: "10.0.0.20"},
+				{Field: "port", Before: "22", After: "2222"},
+				{Field: "tags", Before: "", After: "core"},
+			},
+		},
+		{
+			Line:   4,
+			Action: ActionCreate,
+			Device: storage.Device{Hostname: "r3", IPAddress: "10.0.0.3", Port: 22, CredentialID: 7, Tags: []string{}},
+			Changes: []storage.Change{
+				{Field: "hostname", After: "r3"},
+				{Field: "ip", After: "10.0.0.3"},
+				{Field: "port", After: "22"},
+				{Field: "profile", After: "#7"},
+			},
+		},
+		{Line: 5, Err: errors.New("wrong IP address")},
+		{Line: 6, Err: errors.New("duplicate hostname, already used on line 4")},
+		{Line: 7, Err: errors.New(`unknown credential profile "r9"`)},
+	}
+
+	assert.Equal(t, want, plan.Rows)
+	assert.False(t, plan.Valid())
+	assert.Len(t, plan.Creates(), 1)
+	assert.Len(t, plan.Updates(), 1)
+}
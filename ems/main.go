@@ -14,6 +14,7 @@ import (
 	"github.com/kelseyhightower/envconfig"
 
 	"pi-wegrzyn/ems/api"
+	"pi-wegrzyn/ems/auth"
 	"pi-wegrzyn/ems/cookies"
 	"pi-wegrzyn/ems/influx"
 	"pi-wegrzyn/ems/monitor"
@@ -31,6 +32,8 @@ type config struct {
 	StartupDelay float64 `envconfig:"STARTUP_DELAY_SECONDS" default:"10"`
 
 	apiConfig     api.Config
+	oidcConfig    auth.OIDCConfig
+	ldapConfig    auth.LDAPConfig
 	dbConfig      storage.Config
 	influxConfig  influx.Config
 	monitorConfig monitor.Config
@@ -44,6 +47,10 @@ type assetsConfig struct {
 }
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "replay" {
+		os.Exit(replay(os.Stdout, os.Stderr, os.Args[2:]))
+	}
+
 	appCtx := context.WithValue(context.Background(), appNameAttr, "ems")
 
 	var config config
@@ -55,6 +62,14 @@ func main() {
 		slog.ErrorContext(appCtx, "cannot read configuration", slog.Any("error", err))
 		os.Exit(1)
 	}
+	if err := envconfig.Process("OIDC", &config.oidcConfig); err != nil {
+		slog.ErrorContext(appCtx, "cannot read oidc configuration", slog.Any("error", err))
+		os.Exit(1)
+	}
+	if err := envconfig.Process("LDAP", &config.ldapConfig); err != nil {
+		slog.ErrorContext(appCtx, "cannot read ldap configuration", slog.Any("error", err))
+		os.Exit(1)
+	}
 	if err := envconfig.Process("DB", &config.dbConfig); err != nil {
 		slog.ErrorContext(appCtx, "cannot read database configuration", slog.Any("error", err))
 		os.Exit(1)
@@ -117,12 +132,31 @@ func main() {
 	}
 	defer influxClient.Close()
 
+	var authenticator auth.Authenticator = auth.Local{
+		User:     config.apiConfig.User,
+		Password: config.apiConfig.Password,
+	}
+	if config.ldapConfig.Enabled() {
+		authenticator = auth.Chain{auth.NewLDAP(config.ldapConfig), authenticator}
+	}
+
+	var oidc *auth.OIDC
+	if config.oidcConfig.Enabled() {
+		oidc, err = auth.NewOIDC(appCtx, config.oidcConfig)
+		if err != nil {
+			slog.ErrorContext(appCtx, "cannot initialize single sign-on", slog.Any("error", err))
+			os.Exit(1)
+		}
+	}
+
 	apiServer := &http.Server{
 		Addr: ":" + config.Port,
 		Handler: api.NewHandler(
 			config.apiConfig,
 			storage.New(conn),
 			cookies.NewStore(15*time.Minute),
+			authenticator,
+			oidc,
 			tmplExecutor,
 			&api.StaticFiles{
 				CSS:     css,
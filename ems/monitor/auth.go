@@ -0,0 +1,138 @@
+package monitor
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"time"
+
+	"pi-wegrzyn/ems/storage"
+
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+)
+
+// authMethods returns SSH auth methods in the order they are offered: agent,
+// key (or its certificate), password and keyboard-interactive. The password
+// is also offered when nothing else is configured. The returned function
+// releases the agent connection, if one was opened.
+func authMethods(credentials storage.Auth, agentSocket string) ([]ssh.AuthMethod, func(), error) {
+	var methods []ssh.AuthMethod
+	closeAgent := func() {}
+
+	if credentials.Agent {
+		if agentSocket == "" {
+			return nil, closeAgent, errors.New("SSH agent socket is not configured")
+		}
+
+		conn, err := net.Dial("unix", agentSocket)
+		if err != nil {
+			return nil, closeAgent, err
+		}
+
+		closeAgent = func() {
+			if err := conn.Close(); err != nil {
+				slog.Error("cannot close SSH agent connection", slog.Any("error", err))
+			}
+		}
+
+		methods = append(methods, ssh.PublicKeysCallback(agent.NewClient(conn).Signers))
+	}
+
+	if len(credentials.Keyfile) != 0 {
+		signer, err := newSigner(credentials.Keyfile, credentials.Passphrase, credentials.Certificate)
+		if err != nil {
+			closeAgent()
+			return nil, func() {}, err
+		}
+
+		methods = append(methods, ssh.PublicKeys(signer))
+	}
+
+	if credentials.Password != "" || len(methods) == 0 {
+		methods = append(methods, ssh.Password(credentials.Password), keyboardInteractive(credentials.Password))
+	}
+
+	return methods, closeAgent, nil
+}
+
+func clientConfig(login string, auth []ssh.AuthMethod, timeout int) *ssh.ClientConfig {
+	return &ssh.ClientConfig{
+		Auth:            auth,
+		User:            login,
+		HostKeyCallback: ssh.InsecureIgnoreHostKey(),
+		Timeout:         time.Duration(timeout) * time.Second,
+	}
+}
+
+// dialSSH connects to addr directly or, when via is set, through a tunnel
+// opened on via like ProxyJump does.
+func dialSSH(via *ssh.Client, addr string, config *ssh.ClientConfig) (*ssh.Client, error) {
+	if via == nil {
+		return ssh.Dial("tcp", addr, config)
+	}
+
+	conn, err := via.Dial("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+
+	c, chans, reqs, err := ssh.NewClientConn(conn, addr, config)
+	if err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+
+	return ssh.NewClient(c, chans, reqs), nil
+}
+
+// newSigner parses a private key, decrypting it with passphrase if it is
+// encrypted. When certificate is set, the signer presents it instead of the
+// bare public key.
+func newSigner(key []byte, passphrase string, certificate []byte) (ssh.Signer, error) {
+	var signer ssh.Signer
+	var err error
+	if passphrase != "" {
+		signer, err = ssh.ParsePrivateKeyWithPassphrase(key, []byte(passphrase))
+	} else {
+		signer, err = ssh.ParsePrivateKey(key)
+	}
+
+	var missing *ssh.PassphraseMissingError
+	if errors.As(err, &missing) {
+		return nil, errors.New("key is encrypted, passphrase is missing")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse key: %w", err)
+	}
+
+	if len(certificate) == 0 {
+		return signer, nil
+	}
+
+	pub, _, _, _, err := ssh.ParseAuthorizedKey(certificate)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse certificate: %w", err)
+	}
+
+	cert, ok := pub.(*ssh.Certificate)
+	if !ok {
+		return nil, errors.New("not an OpenSSH certificate")
+	}
+
+	return ssh.NewCertSigner(cert, signer)
+}
+
+// keyboardInteractive answers every prompt with the password, which is what
+// devices asking for a password over keyboard-interactive expect.
+func keyboardInteractive(password string) ssh.AuthMethod {
+	return ssh.KeyboardInteractive(func(name, instruction string, questions []string, echos []bool) ([]string, error) {
+		answers := make([]string, len(questions))
+		for i := range answers {
+			answers[i] = password
+		}
+
+		return answers, nil
+	})
+}
@@ -0,0 +1,164 @@
+package monitor
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/pem"
+	"errors"
+	"testing"
+
+	"pi-wegrzyn/ems/storage"
+
+	"golang.org/x/crypto/ssh"
+)
+
+func TestRemoteDevice_auth(t *testing.T) {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("cannot generate key: %v", err)
+	}
+	signer, err := ssh.NewSignerFromKey(key)
+	if err != nil {
+		t.Fatalf("cannot create signer: %v", err)
+	}
+
+	plainKey, err := ssh.MarshalPrivateKey(key, "")
+	if err != nil {
+		t.Fatalf("cannot marshal key: %v", err)
+	}
+	encryptedKey, err := ssh.MarshalPrivateKeyWithPassphrase(key, "", []byte("phrase"))
+	if err != nil {
+		t.Fatalf("cannot marshal key: %v", err)
+	}
+
+	ca := newTestSigner(t)
+	cert := &ssh.Certificate{
+		Key:             signer.PublicKey(),
+		KeyId:           "noc",
+		CertType:        ssh.UserCert,
+		ValidPrincipals: []string{"noc"},
+		ValidBefore:     ssh.CertTimeInfinity,
+	}
+	if err := cert.SignCert(rand.Reader, ca); err != nil {
+		t.Fatalf("cannot sign certificate: %v", err)
+	}
+
+	acceptKey := func(conn ssh.ConnMetadata, pub ssh.PublicKey) (*ssh.Permissions, error) {
+		if bytes.Equal(pub.Marshal(), signer.PublicKey().Marshal()) {
+			return nil, nil
+		}
+		return nil, errors.New("unknown key")
+	}
+	acceptPassword := func(conn ssh.ConnMetadata, password []byte) (*ssh.Permissions, error) {
+		if string(password) == "secret" {
+			return nil, nil
+		}
+		return nil, errors.New("wrong password")
+	}
+	certChecker := &ssh.CertChecker{
+		IsUserAuthority: func(auth ssh.PublicKey) bool {
+			return bytes.Equal(auth.Marshal(), ca.PublicKey().Marshal())
+		},
+	}
+
+	tests := []struct {
+		name        string
+		credentials storage.Auth
+		server      *ssh.ServerConfig
+		wantErr     string
+	}{
+		{
+			name:        "plain key",
+			credentials: storage.Auth{Login: "noc", Keyfile: pem.EncodeToMemory(plainKey)},
+			server:      &ssh.ServerConfig{PublicKeyCallback: acceptKey},
+		},
+		{
+			name:        "encrypted key with passphrase",
+			credentials: storage.Auth{Login: "noc", Keyfile: pem.EncodeToMemory(encryptedKey), Passphrase: "phrase"},
+			server:      &ssh.ServerConfig{PublicKeyCallback: acceptKey},
+		},
+		{
+			name:        "encrypted key without passphrase",
+			credentials: storage.Auth{Login: "noc", Keyfile: pem.EncodeToMemory(encryptedKey)},
+			wantErr:     "key is encrypted, passphrase is missing",
+		},
+		{
+			name:        "encrypted key with wrong passphrase",
+			credentials: storage.Auth{Login: "noc", Keyfile: pem.EncodeToMemory(encryptedKey), Passphrase: "wrong"},
+			wantErr:     "cannot parse key: x509: decryption password incorrect",
+		},
+		{
+			name:        "certificate",
+			credentials: storage.Auth{Login: "noc", Keyfile: pem.EncodeToMemory(plainKey), Certificate: ssh.MarshalAuthorizedKey(cert)},
+			server:      &ssh.ServerConfig{PublicKeyCallback: certChecker.Authenticate},
+		},
+		{
+			name:        "public key instead of certificate",
+			credentials: storage.Auth{Login: "noc", Keyfile: pem.EncodeToMemory(plainKey), Certificate: ssh.MarshalAuthorizedKey(signer.PublicKey())},
+			wantErr:     "not an OpenSSH certificate",
+		},
+		{
+			name:        "falls back to password",
+			credentials: storage.Auth{Login: "noc", Keyfile: pem.EncodeToMemory(plainKey), Password: "secret"},
+			server: &ssh.ServerConfig{
+				PublicKeyCallback: func(ssh.ConnMetadata, ssh.PublicKey) (*ssh.Permissions, error) {
+					return nil, errors.New("keys are not accepted")
+				},
+				PasswordCallback: acceptPassword,
+			},
+		},
+		{
+			name:        "password followed by keyboard-interactive",
+			credentials: storage.Auth{Login: "noc", Password: "secret"},
+			server: &ssh.ServerConfig{
+				PasswordCallback: func(conn ssh.ConnMetadata, password []byte) (*ssh.Permissions, error) {
+					if _, err := acceptPassword(conn, password); err != nil {
+						return nil, err
+					}
+					return nil, &ssh.PartialSuccessError{Next: ssh.ServerAuthCallbacks{
+						KeyboardInteractiveCallback: func(conn ssh.ConnMetadata, client ssh.KeyboardInteractiveChallenge) (*ssh.Permissions, error) {
+							answers, err := client("", "", []string{"Password: "}, []bool{false})
+							if err != nil {
+								return nil, err
+							}
+							return acceptPassword(conn, []byte(answers[0]))
+						},
+					}}
+				},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			d := newRemoteDevice(storage.Device{}, tc.credentials, DefaultDecoder())
+
+			auth, closeAgent, err := d.auth("")
+			if tc.wantErr != "" {
+				if err == nil || err.Error() != tc.wantErr {
+					t.Fatalf("expected error %q, got %v", tc.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			defer closeAgent()
+
+			server := newTestServer(t, tc.server)
+			d.IPAddress, d.Port = server.host, server.port
+
+			client, err := d.sshClient(nil, auth, 5)
+			if err != nil {
+				t.Fatalf("cannot connect: %v", err)
+			}
+			defer func() { _ = client.Close() }()
+
+			interfaces, err := d.getInterfaces(client)
+			if err != nil || len(interfaces) != 1 || interfaces[0] != "eth0" {
+				t.Errorf("expected [eth0], got %v (error: %v)", interfaces, err)
+			}
+		})
+	}
+}
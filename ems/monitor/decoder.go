@@ -10,7 +10,7 @@ func DefaultDecoder() Decoder {
 	return func(input []byte) (Eeprom, error) {
 		var temp []byte
 		for i := 0; i < len(input); i += 33 {
-			temp = append(temp, input[i:i+32]...)
+			temp = append(temp, input[i:min(i+32, len(input))]...)
 		}
 
 		return hex.DecodeString(string(temp))
@@ -17,3 +17,10 @@ func TestDefaultDecoder(t *testing.T) {
 		t.Errorf("Expected -10.0, but got %.2f", result.Temperature())
 	}
 }
+
+func TestDefaultDecoder_truncated(t *testing.T) {
+	// Captured output may end mid-line, it must not crash the monitor.
+	if _, err := DefaultDecoder()([]byte("1234567890abcdef1234567890abff00\n12345")); err == nil {
+		t.Error("expected error")
+	}
+}
@@ -4,14 +4,34 @@ import "math"
 
 const (
 	PageLength int = 128
+	// EepromLength covers the lower page and upper pages 00h, 01h, 02h, 04h,
+	// 11h, 12h and 25h.
+	EepromLength int = 8 * PageLength
+
+	PageLowModuleState int = 0*PageLength + 0x03
+	Page11hDPState     int = 5*PageLength + 0x00
 
 	PageLowTemp  int = 0*PageLength + 0x0E
 	PageLowVcc   int = 0*PageLength + 0x10
 	Page11hTxPwr int = 5*PageLength + 0x1A
 	Page11hRxPwr int = 5*PageLength + 0x3A
 	Page25hOsnr  int = 7*PageLength + 0x16
+
+	Page02hTxPwrLowAlarm int = 3*PageLength + 0x32
+	Page02hRxPwrLowAlarm int = 3*PageLength + 0x42
+
+	Page01hTxTunable    int = 2*PageLength + 0x1B
+	Page04hProgPwrMin   int = 4*PageLength + 0x46
+	Page04hProgPwrMax   int = 4*PageLength + 0x48
+	Page12hGridSpacing  int = 6*PageLength + 0x00
+	Page12hChannel      int = 6*PageLength + 0x08
+	Page12hLaserFreq    int = 6*PageLength + 0x28
+	Page12hTargetOutPwr int = 6*PageLength + 0x48
 )
 
+// speedOfLight in m/s converts laser frequencies to wavelengths.
+const speedOfLight float64 = 299792458
+
 type Eeprom []byte
 
 func (e Eeprom) Temperature() float64 {
@@ -38,12 +58,114 @@ func (e Eeprom) RxPower() float64 {
 	return microWatt01ToDbm(rxPower01microW)
 }
 
+// TxPowerLowAlarm and RxPowerLowAlarm are power low alarm thresholds of
+// page 02h in dBm, 0 when the module leaves them unset.
+func (e Eeprom) TxPowerLowAlarm() float64 {
+	return thresholdDbm(e[Page02hTxPwrLowAlarm:])
+}
+
+func (e Eeprom) RxPowerLowAlarm() float64 {
+	return thresholdDbm(e[Page02hRxPwrLowAlarm:])
+}
+
+func thresholdDbm(b []byte) float64 {
+	threshold01microW := uint16(b[0])<<8 | uint16(b[1])
+	if threshold01microW == 0 {
+		return 0
+	}
+
+	return microWatt01ToDbm(threshold01microW)
+}
+
+// Osnr is the OSNR of lane 1 advertised through VDM. Layouts without VDM
+// pages have it at a fixed offset of page 25h.
 func (e Eeprom) Osnr() float64 {
+	if e.VdmSupported() {
+		for _, o := range e.Vdm() {
+			if o.Type == vdmTypeOSNR && o.Lane == 1 {
+				return o.Value
+			}
+		}
+
+		return 0
+	}
+
 	osnr := uint16(e[Page25hOsnr])<<8 | uint16(e[Page25hOsnr+1])
 
 	return float64(osnr) / 10
 }
 
+// ModuleState is the state of the module state machine.
+func (e Eeprom) ModuleState() ModuleState {
+	return ModuleState(e[PageLowModuleState]>>1) & 0x07
+}
+
+// DataPathStates are data path states of host lanes 1-8, two lanes a byte.
+func (e Eeprom) DataPathStates() []DataPathState {
+	states := make([]DataPathState, 0, 8)
+	for _, b := range e[Page11hDPState : Page11hDPState+4] {
+		states = append(states, DataPathState(b&0x0F), DataPathState(b>>4))
+	}
+
+	return states
+}
+
+// Tunable tells that the transmitter is tunable, pages 04h and 12h describe
+// its laser.
+func (e Eeprom) Tunable() bool {
+	return e[Page01hTxTunable]&0x40 != 0
+}
+
+// GridSpacing is the grid of lane 1 in GHz, 0 when unknown.
+func (e Eeprom) GridSpacing() float64 {
+	return gridSpacingGHz[e[Page12hGridSpacing]>>4]
+}
+
+// Channel is the channel number of lane 1 on its grid.
+func (e Eeprom) Channel() int {
+	return int(int16(e[Page12hChannel])<<8 | int16(e[Page12hChannel+1]))
+}
+
+// LaserFrequency is the current laser frequency of lane 1 in THz.
+func (e Eeprom) LaserFrequency() float64 {
+	mhz := uint32(e[Page12hLaserFreq])<<24 | uint32(e[Page12hLaserFreq+1])<<16 | uint32(e[Page12hLaserFreq+2])<<8 | uint32(e[Page12hLaserFreq+3])
+
+	return float64(mhz) / 1e6
+}
+
+// Wavelength is the wavelength of the laser frequency in nm, 0 without one.
+func (e Eeprom) Wavelength() float64 {
+	if e.LaserFrequency() == 0 {
+		return 0
+	}
+
+	return speedOfLight / e.LaserFrequency() / 1e3
+}
+
+// TargetOutputPower is the target output power of lane 1 in dBm.
+func (e Eeprom) TargetOutputPower() float64 {
+	return hundredthDbm(e[Page12hTargetOutPwr:])
+}
+
+// ProgOutputPowerMin is the lowest programmable output power in dBm.
+func (e Eeprom) ProgOutputPowerMin() float64 {
+	return hundredthDbm(e[Page04hProgPwrMin:])
+}
+
+// ProgOutputPowerMax is the highest programmable output power in dBm.
+func (e Eeprom) ProgOutputPowerMax() float64 {
+	return hundredthDbm(e[Page04hProgPwrMax:])
+}
+
+// gridSpacingGHz maps grid spacing codes of page 12h.
+var gridSpacingGHz = map[byte]float64{
+	0: 3.125, 1: 6.25, 2: 12.5, 3: 25, 4: 50, 5: 100, 6: 33, 7: 75,
+}
+
+func hundredthDbm(b []byte) float64 {
+	return float64(int16(b[0])<<8|int16(b[1])) / 100
+}
+
 func microWatt01ToDbm(mw01 uint16) float64 {
 	return 10 * math.Log10(float64(mw01)/10000)
 }
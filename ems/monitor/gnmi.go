@@ -0,0 +1,578 @@
+package monitor
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"maps"
+	"math"
+	"net"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"pi-wegrzyn/ems/influx"
+	"pi-wegrzyn/ems/storage"
+
+	"github.com/openconfig/gnmi/proto/gnmi"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+)
+
+// OpenConfig paths subscribed by the gNMI collector. Components are matched
+// with interfaces through the transceiver leaf of interface state.
+var gnmiPaths = []string{
+	"/components/component/state/temperature/instant",
+	"/components/component/transceiver/state",
+	"/components/component/transceiver/physical-channels/channel/state",
+	"/components/component/optical-channel/state/osnr/instant",
+	"/interfaces/interface/state/transceiver",
+}
+
+// Reconnect backoff of gNMI subscriptions, reset after every sync.
+const (
+	gnmiMinBackoff = time.Second
+	gnmiMaxBackoff = time.Minute
+)
+
+// gnmiDevice streams DOM values from OpenConfig paths, as an alternative to
+// polling on devices exposing gNMI.
+type gnmiDevice struct {
+	storage.Device
+	credentials storage.Auth
+}
+
+func newGNMIDevice(dev storage.Device, auth storage.Auth) gnmiDevice {
+	return gnmiDevice{Device: dev, credentials: auth}
+}
+
+func (d gnmiDevice) labels() influx.Labels {
+	return influx.Labels{Site: d.Site, Group: d.Group, Tags: d.Tags}
+}
+
+// same tells whether a running subscription of d can be kept for other.
+func (d gnmiDevice) same(other gnmiDevice) bool {
+	return d.Hostname == other.Hostname &&
+		d.IPAddress == other.IPAddress &&
+		d.GNMI == other.GNMI &&
+		d.credentials.Login == other.credentials.Login &&
+		d.credentials.Password == other.credentials.Password &&
+		d.Site == other.Site &&
+		d.Group == other.Group &&
+		strings.Join(d.Tags, ",") == strings.Join(other.Tags, ",")
+}
+
+func (d gnmiDevice) dial() (*grpc.ClientConn, error) {
+	creds := insecure.NewCredentials()
+	if d.GNMI.TLS {
+		creds = credentials.NewTLS(&tls.Config{InsecureSkipVerify: d.GNMI.SkipVerify})
+	}
+
+	address := net.JoinHostPort(d.IPAddress, strconv.Itoa(int(d.GNMI.Port)))
+
+	return grpc.NewClient(address, grpc.WithTransportCredentials(creds))
+}
+
+func (d gnmiDevice) subscribeRequest() *gnmi.SubscribeRequest {
+	mode := gnmi.SubscriptionMode_SAMPLE
+	if d.GNMI.Mode == storage.GNMIModeOnChange {
+		mode = gnmi.SubscriptionMode_ON_CHANGE
+	}
+
+	list := &gnmi.SubscriptionList{Mode: gnmi.SubscriptionList_STREAM, Encoding: gnmi.Encoding_JSON_IETF}
+	for _, p := range gnmiPaths {
+		subscription := &gnmi.Subscription{Path: gnmiPath(p), Mode: mode}
+		if mode == gnmi.SubscriptionMode_SAMPLE {
+			subscription.SampleInterval = uint64(time.Duration(d.GNMI.SampleInterval) * time.Second)
+		}
+		list.Subscription = append(list.Subscription, subscription)
+	}
+
+	return &gnmi.SubscribeRequest{Request: &gnmi.SubscribeRequest_Subscribe{Subscribe: list}}
+}
+
+// subscribe streams until the session breaks. Measurements are written
+// after the initial sync, then for components changed by each notification.
+func (d gnmiDevice) subscribe(ctx context.Context, synced func(), write func(interfaceMeasurement)) error {
+	conn, err := d.dial()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = conn.Close() }()
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	if d.credentials.Login != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, "username", d.credentials.Login, "password", d.credentials.Password)
+	}
+
+	stream, err := gnmi.NewGNMIClient(conn).Subscribe(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := stream.Send(d.subscribeRequest()); err != nil {
+		return err
+	}
+
+	state := newGNMIState()
+	isSynced := false
+	for {
+		response, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+
+		switch r := response.Response.(type) {
+		case *gnmi.SubscribeResponse_Update:
+			state.apply(r.Update)
+		case *gnmi.SubscribeResponse_SyncResponse:
+			isSynced = true
+			synced()
+		case *gnmi.SubscribeResponse_Error:
+			return fmt.Errorf("gNMI error: %s", r.Error.GetMessage())
+		}
+
+		if isSynced {
+			for _, measurement := range state.flush() {
+				write(measurement)
+			}
+		}
+	}
+}
+
+// gnmiComponent is the last known state of a transceiver component. Lane
+// values of the lowest channel index take precedence over module totals.
+type gnmiComponent struct {
+	measurement    influx.Measurement
+	transceiver    bool
+	rxChannel      int
+	txChannel      int
+	opticalChannel string
+	dirty          bool
+}
+
+type gnmiState struct {
+	components map[string]*gnmiComponent
+	// interfaces maps transceiver components to interface names.
+	interfaces map[string]string
+	osnr       map[string]float64
+}
+
+func newGNMIState() *gnmiState {
+	return &gnmiState{
+		components: make(map[string]*gnmiComponent),
+		interfaces: make(map[string]string),
+		osnr:       make(map[string]float64),
+	}
+}
+
+func (s *gnmiState) component(name string) *gnmiComponent {
+	c, ok := s.components[name]
+	if !ok {
+		c = &gnmiComponent{rxChannel: -1, txChannel: -1}
+		s.components[name] = c
+	}
+
+	return c
+}
+
+func (s *gnmiState) apply(n *gnmi.Notification) {
+	for _, p := range n.Delete {
+		elems := slices.Concat(pathElems(n.Prefix), pathElems(p))
+		if len(elems) >= 2 && elems[0].Name == "components" && (len(elems) == 2 || elems[2].Name == "transceiver") {
+			delete(s.components, elems[1].Key["name"])
+		}
+	}
+
+	for _, u := range n.Update {
+		elems := slices.Concat(pathElems(n.Prefix), pathElems(u.Path))
+		s.update(elems, u.Val)
+	}
+}
+
+func (s *gnmiState) update(elems []*gnmi.PathElem, val *gnmi.TypedValue) {
+	if len(elems) < 2 {
+		return
+	}
+
+	key := elems[1].Key["name"]
+	leaf := joinElems(elems[2:])
+
+	if elems[0].Name == "interfaces" {
+		if leaf == "state/transceiver" {
+			if component, err := gnmiString(val); err == nil && component != "" {
+				s.interfaces[component] = key
+				s.component(component).dirty = true
+			}
+		}
+		return
+	}
+
+	if elems[0].Name != "components" {
+		return
+	}
+
+	if leaf == "transceiver/physical-channels/channel/state/associated-optical-channel" {
+		if channel, err := gnmiString(val); err == nil {
+			c := s.component(key)
+			c.opticalChannel = channel
+			c.dirty = true
+		}
+		return
+	}
+
+	value, err := gnmiFloat(val)
+	if err != nil {
+		slog.Debug("skipping gNMI value", slog.String("path", leaf), slog.Any("error", err))
+		return
+	}
+
+	switch leaf {
+	case "state/temperature/instant":
+		c := s.component(key)
+		c.measurement.Temperature = value
+		c.dirty = true
+	case "transceiver/state/supply-voltage/instant", "transceiver/state/supply-voltage":
+		c := s.component(key)
+		c.transceiver = true
+		c.measurement.Voltage = value
+		c.dirty = true
+	case "transceiver/state/input-power/instant":
+		c := s.component(key)
+		c.transceiver = true
+		if c.rxChannel < 0 {
+			c.measurement.RxPower = value
+		}
+		c.dirty = true
+	case "transceiver/state/output-power/instant":
+		c := s.component(key)
+		c.transceiver = true
+		if c.txChannel < 0 {
+			c.measurement.TxPower = value
+		}
+		c.dirty = true
+	case "transceiver/physical-channels/channel/state/input-power/instant":
+		c := s.component(key)
+		c.transceiver = true
+		if index := channelIndex(elems); c.rxChannel < 0 || index <= c.rxChannel {
+			c.rxChannel = index
+			c.measurement.RxPower = value
+		}
+		c.dirty = true
+	case "transceiver/physical-channels/channel/state/output-power/instant":
+		c := s.component(key)
+		c.transceiver = true
+		if index := channelIndex(elems); c.txChannel < 0 || index <= c.txChannel {
+			c.txChannel = index
+			c.measurement.TxPower = value
+		}
+		c.dirty = true
+	case "optical-channel/state/osnr/instant":
+		s.osnr[key] = value
+		for _, c := range s.components {
+			if c.opticalChannel == key {
+				c.dirty = true
+			}
+		}
+	}
+}
+
+// flush returns measurements of changed transceiver components, other
+// components such as line cards only report temperature.
+func (s *gnmiState) flush() []interfaceMeasurement {
+	var measurements []interfaceMeasurement
+	for name, c := range s.components {
+		if !c.dirty || !c.transceiver {
+			continue
+		}
+		c.dirty = false
+
+		iface, ok := s.interfaces[name]
+		if !ok {
+			iface = name
+		}
+
+		measurement := c.measurement
+		if c.opticalChannel != "" {
+			measurement.OSNR = s.osnr[c.opticalChannel]
+		}
+
+		measurements = append(measurements, interfaceMeasurement{Interface: iface, Measurement: measurement})
+	}
+
+	return measurements
+}
+
+func pathElems(p *gnmi.Path) []*gnmi.PathElem {
+	if p == nil {
+		return nil
+	}
+
+	return p.Elem
+}
+
+func joinElems(elems []*gnmi.PathElem) string {
+	names := make([]string, 0, len(elems))
+	for _, e := range elems {
+		names = append(names, e.Name)
+	}
+
+	return strings.Join(names, "/")
+}
+
+// channelIndex returns the index key of the physical channel, channels
+// without a valid index sort last.
+func channelIndex(elems []*gnmi.PathElem) int {
+	for _, e := range elems {
+		if e.Name == "channel" {
+			if index, err := strconv.Atoi(e.Key["index"]); err == nil {
+				return index
+			}
+		}
+	}
+
+	return math.MaxInt
+}
+
+// gnmiPath parses a path without keys, every element is a wildcard.
+func gnmiPath(p string) *gnmi.Path {
+	path := &gnmi.Path{}
+	for _, name := range strings.Split(strings.Trim(p, "/"), "/") {
+		path.Elem = append(path.Elem, &gnmi.PathElem{Name: name})
+	}
+
+	return path
+}
+
+func gnmiFloat(val *gnmi.TypedValue) (float64, error) {
+	switch v := val.GetValue().(type) {
+	case *gnmi.TypedValue_FloatVal:
+		return float64(v.FloatVal), nil
+	case *gnmi.TypedValue_DecimalVal:
+		return float64(v.DecimalVal.Digits) / math.Pow10(int(v.DecimalVal.Precision)), nil
+	case *gnmi.TypedValue_IntVal:
+		return float64(v.IntVal), nil
+	case *gnmi.TypedValue_UintVal:
+		return float64(v.UintVal), nil
+	case *gnmi.TypedValue_StringVal:
+		return strconv.ParseFloat(v.StringVal, 64)
+	case *gnmi.TypedValue_JsonVal:
+		return jsonFloat(v.JsonVal)
+	case *gnmi.TypedValue_JsonIetfVal:
+		return jsonFloat(v.JsonIetfVal)
+	default:
+		return 0, fmt.Errorf("unsupported value type %T", v)
+	}
+}
+
+// jsonFloat decodes numbers, also those encoded as strings like 64-bit
+// decimals in JSON_IETF.
+func jsonFloat(data []byte) (float64, error) {
+	var value any
+	if err := json.Unmarshal(data, &value); err != nil {
+		return 0, err
+	}
+
+	switch v := value.(type) {
+	case float64:
+		return v, nil
+	case string:
+		return strconv.ParseFloat(v, 64)
+	default:
+		return 0, fmt.Errorf("unsupported JSON value %s", data)
+	}
+}
+
+func gnmiString(val *gnmi.TypedValue) (string, error) {
+	switch v := val.GetValue().(type) {
+	case *gnmi.TypedValue_StringVal:
+		return v.StringVal, nil
+	case *gnmi.TypedValue_AsciiVal:
+		return v.AsciiVal, nil
+	case *gnmi.TypedValue_JsonVal:
+		var s string
+		err := json.Unmarshal(v.JsonVal, &s)
+		return s, err
+	case *gnmi.TypedValue_JsonIetfVal:
+		var s string
+		err := json.Unmarshal(v.JsonIetfVal, &s)
+		return s, err
+	default:
+		return "", fmt.Errorf("unsupported value type %T", v)
+	}
+}
+
+// gnmiSubscription keeps a device subscribed between monitoring runs and
+// reconnects it with backoff.
+type gnmiSubscription struct {
+	device gnmiDevice
+	cancel context.CancelFunc
+	done   chan struct{}
+
+	// ready is closed after the first sync or failure.
+	ready     chan struct{}
+	readyOnce sync.Once
+
+	mu     sync.Mutex
+	synced bool
+	err    error
+	// measurements are the latest measurements of interfaces written since
+	// the previous call of latest.
+	measurements map[string]interfaceMeasurement
+}
+
+func startGNMISubscription(ctx context.Context, d gnmiDevice, minBackoff, maxBackoff time.Duration, write func(interfaceMeasurement)) *gnmiSubscription {
+	ctx, cancel := context.WithCancel(ctx)
+	s := &gnmiSubscription{device: d, cancel: cancel, done: make(chan struct{}), ready: make(chan struct{}), measurements: make(map[string]interfaceMeasurement)}
+
+	go func() {
+		defer close(s.done)
+
+		backoff := minBackoff
+		for {
+			err := d.subscribe(ctx, func() {
+				s.setState(true, nil)
+				backoff = minBackoff
+			}, func(measurement interfaceMeasurement) {
+				write(measurement)
+				s.keep(measurement)
+			})
+			if ctx.Err() != nil {
+				return
+			}
+
+			if err == nil {
+				err = errors.New("gNMI stream closed")
+			}
+			s.setState(false, err)
+			slog.WarnContext(ctx, "gNMI subscription broken", slog.Any("deviceID", d.ID), slog.Any("error", err), slog.Duration("retry", backoff))
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(backoff):
+			}
+			backoff = min(backoff*2, maxBackoff)
+		}
+	}()
+
+	return s
+}
+
+func (s *gnmiSubscription) setState(synced bool, err error) {
+	s.mu.Lock()
+	s.synced, s.err = synced, err
+	s.mu.Unlock()
+
+	s.readyOnce.Do(func() { close(s.ready) })
+}
+
+func (s *gnmiSubscription) keep(measurement interfaceMeasurement) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.measurements[measurement.Interface] = measurement
+}
+
+// latest returns the latest measurement of every interface streamed since
+// the previous call, so readings are evaluated once per run rather than on
+// every update.
+func (s *gnmiSubscription) latest() []interfaceMeasurement {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	measurements := make([]interfaceMeasurement, 0, len(s.measurements))
+	for _, name := range slices.Sorted(maps.Keys(s.measurements)) {
+		measurements = append(measurements, s.measurements[name])
+	}
+	clear(s.measurements)
+
+	return measurements
+}
+
+// status waits up to timeout for a new subscription to settle. It is OK
+// while the subscription is synced.
+func (s *gnmiSubscription) status(timeout time.Duration) (int8, error) {
+	select {
+	case <-s.ready:
+	case <-time.After(timeout):
+		return storage.StatusErrorCollector, errors.New("gNMI subscription not synced in time")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.synced {
+		return storage.StatusErrorCollector, s.err
+	}
+
+	return storage.StatusOK, nil
+}
+
+func (s *gnmiSubscription) stop() {
+	s.cancel()
+	<-s.done
+}
+
+// gnmiPool holds subscriptions of gNMI devices. Subscriptions of removed
+// or changed devices are stopped.
+type gnmiPool struct {
+	mu            sync.Mutex
+	subscriptions map[uint]*gnmiSubscription
+
+	minBackoff, maxBackoff time.Duration
+}
+
+func newGNMIPool() *gnmiPool {
+	return &gnmiPool{
+		subscriptions: make(map[uint]*gnmiSubscription),
+		minBackoff:    gnmiMinBackoff,
+		maxBackoff:    gnmiMaxBackoff,
+	}
+}
+
+// subscription returns the running subscription of d, started or restarted
+// when its settings changed.
+func (p *gnmiPool) subscription(ctx context.Context, d gnmiDevice, write func(interfaceMeasurement)) *gnmiSubscription {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	s, ok := p.subscriptions[d.ID]
+	if ok && s.device.same(d) {
+		return s
+	}
+	if ok {
+		s.stop()
+	}
+
+	s = startGNMISubscription(ctx, d, p.minBackoff, p.maxBackoff, write)
+	p.subscriptions[d.ID] = s
+
+	return s
+}
+
+// retain stops subscriptions of devices not in ids.
+func (p *gnmiPool) retain(ids map[uint]bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for id, s := range p.subscriptions {
+		if !ids[id] {
+			s.stop()
+			delete(p.subscriptions, id)
+		}
+	}
+}
+
+func (p *gnmiPool) close() {
+	p.retain(nil)
+}
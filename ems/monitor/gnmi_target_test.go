@@ -0,0 +1,154 @@
+package monitor
+
+import (
+	"context"
+	"net"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/openconfig/gnmi/proto/gnmi"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// testSession is what the target sends on a subscription. The stream is
+// dropped afterwards unless hold is set.
+type testSession struct {
+	responses []*gnmi.SubscribeResponse
+	hold      bool
+}
+
+type testTarget struct {
+	host string
+	port uint16
+
+	sessions []testSession
+	count    atomic.Int32
+	requests chan *gnmi.SubscribeRequest
+	metadata chan metadata.MD
+}
+
+// newTestTarget starts a plain-text gNMI target on localhost. Its n-th
+// subscription gets sessions[n], the last session is repeated.
+func newTestTarget(t *testing.T, sessions ...testSession) *testTarget {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("cannot listen: %v", err)
+	}
+
+	target := &testTarget{
+		sessions: sessions,
+		requests: make(chan *gnmi.SubscribeRequest, 16),
+		metadata: make(chan metadata.MD, 16),
+	}
+
+	server := grpc.NewServer()
+	gnmi.RegisterGNMIServer(server, target)
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(server.Stop)
+
+	host, port, err := net.SplitHostPort(listener.Addr().String())
+	if err != nil {
+		t.Fatalf("cannot split address: %v", err)
+	}
+
+	p, err := strconv.ParseUint(port, 10, 16)
+	if err != nil {
+		t.Fatalf("cannot parse port: %v", err)
+	}
+
+	target.host, target.port = host, uint16(p)
+
+	return target
+}
+
+func (t *testTarget) Capabilities(context.Context, *gnmi.CapabilityRequest) (*gnmi.CapabilityResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "not supported")
+}
+
+func (t *testTarget) Get(context.Context, *gnmi.GetRequest) (*gnmi.GetResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "not supported")
+}
+
+func (t *testTarget) Set(context.Context, *gnmi.SetRequest) (*gnmi.SetResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "not supported")
+}
+
+func (t *testTarget) Subscribe(stream gnmi.GNMI_SubscribeServer) error {
+	request, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+
+	md, _ := metadata.FromIncomingContext(stream.Context())
+	t.requests <- request
+	t.metadata <- md
+
+	n := int(t.count.Add(1)) - 1
+	session := t.sessions[min(n, len(t.sessions)-1)]
+
+	for _, response := range session.responses {
+		if err := stream.Send(response); err != nil {
+			return err
+		}
+	}
+
+	if !session.hold {
+		return status.Error(codes.Unavailable, "target restarting")
+	}
+
+	<-stream.Context().Done()
+
+	return nil
+}
+
+func syncResponse() *gnmi.SubscribeResponse {
+	return &gnmi.SubscribeResponse{Response: &gnmi.SubscribeResponse_SyncResponse{SyncResponse: true}}
+}
+
+// notification returns updates of paths under prefix, a path element may
+// carry one key as name[key=value].
+func notification(prefix string, updates map[string]*gnmi.TypedValue) *gnmi.SubscribeResponse {
+	n := &gnmi.Notification{Prefix: testPath(prefix)}
+	for p, val := range updates {
+		n.Update = append(n.Update, &gnmi.Update{Path: testPath(p), Val: val})
+	}
+
+	return &gnmi.SubscribeResponse{Response: &gnmi.SubscribeResponse_Update{Update: n}}
+}
+
+// testPath parses paths like /interfaces/interface[name=Ethernet1/1]/state,
+// slashes inside keys do not split elements.
+func testPath(p string) *gnmi.Path {
+	path := &gnmi.Path{}
+	if strings.Trim(p, "/") == "" {
+		return path
+	}
+
+	start, depth := 0, 0
+	p = strings.Trim(p, "/") + "/"
+	for i, r := range p {
+		switch {
+		case r == '[':
+			depth++
+		case r == ']':
+			depth--
+		case r == '/' && depth == 0:
+			elem := &gnmi.PathElem{Name: p[start:i]}
+			if name, key, ok := strings.Cut(elem.Name, "["); ok {
+				k, v, _ := strings.Cut(strings.TrimSuffix(key, "]"), "=")
+				elem.Name, elem.Key = name, map[string]string{k: v}
+			}
+			path.Elem = append(path.Elem, elem)
+			start = i + 1
+		}
+	}
+
+	return path
+}
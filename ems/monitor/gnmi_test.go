@@ -0,0 +1,237 @@
+package monitor
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"pi-wegrzyn/ems/influx"
+	"pi-wegrzyn/ems/storage"
+
+	gocmp "github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/openconfig/gnmi/proto/gnmi"
+)
+
+func float(v float32) *gnmi.TypedValue {
+	return &gnmi.TypedValue{Value: &gnmi.TypedValue_FloatVal{FloatVal: v}}
+}
+
+func decimal(digits int64, precision uint32) *gnmi.TypedValue {
+	return &gnmi.TypedValue{Value: &gnmi.TypedValue_DecimalVal{DecimalVal: &gnmi.Decimal64{Digits: digits, Precision: precision}}}
+}
+
+func jsonIETF(v string) *gnmi.TypedValue {
+	return &gnmi.TypedValue{Value: &gnmi.TypedValue_JsonIetfVal{JsonIetfVal: []byte(v)}}
+}
+
+func str(v string) *gnmi.TypedValue {
+	return &gnmi.TypedValue{Value: &gnmi.TypedValue_StringVal{StringVal: v}}
+}
+
+func testGNMIDevice(target *testTarget, mode string) gnmiDevice {
+	return newGNMIDevice(storage.Device{
+		ID:        1,
+		Hostname:  "router",
+		IPAddress: target.host,
+		Collector: storage.CollectorGNMI,
+		GNMI:      storage.GNMI{Port: target.port, Mode: mode, SampleInterval: 10},
+	}, storage.Auth{Login: "noc", Password: "secret"})
+}
+
+func TestGNMIDevice_subscribe(t *testing.T) {
+	target := newTestTarget(t, testSession{
+		hold: true,
+		responses: []*gnmi.SubscribeResponse{
+			notification("/components/component[name=Transceiver1]", map[string]*gnmi.TypedValue{
+				"state/temperature/instant":                                                       decimal(354, 1),
+				"transceiver/state/supply-voltage/instant":                                        float(3.3),
+				"transceiver/state/input-power/instant":                                           float(-1),
+				"transceiver/physical-channels/channel[index=2]/state/input-power/instant":        float(-5),
+				"transceiver/physical-channels/channel[index=1]/state/input-power/instant":        jsonIETF(`"-3.12"`),
+				"transceiver/state/output-power/instant":                                          float(1.5),
+				"transceiver/physical-channels/channel[index=1]/state/associated-optical-channel": str("OpticalChannel1"),
+			}),
+			notification("", map[string]*gnmi.TypedValue{
+				"/components/component[name=OpticalChannel1]/optical-channel/state/osnr/instant": float(22.5),
+				"/components/component[name=Transceiver2]/transceiver/state/input-power/instant": jsonIETF(`-7`),
+				"/interfaces/interface[name=Ethernet1/1]/state/transceiver":                      jsonIETF(`"Transceiver1"`),
+				// Components without transceiver data are not written.
+				"/components/component[name=Linecard1]/state/temperature/instant": float(50),
+			}),
+			syncResponse(),
+			notification("/components/component[name=Transceiver1]", map[string]*gnmi.TypedValue{
+				"state/temperature/instant": float(36),
+			}),
+		},
+	})
+
+	writes := make(chan interfaceMeasurement, 16)
+	synced := make(chan struct{})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errs := make(chan error, 1)
+	go func() {
+		errs <- testGNMIDevice(target, storage.GNMIModeSample).subscribe(ctx, func() { close(synced) }, func(m interfaceMeasurement) { writes <- m })
+	}()
+
+	var got []interfaceMeasurement
+	for range 3 {
+		select {
+		case m := <-writes:
+			got = append(got, m)
+		case err := <-errs:
+			t.Fatalf("subscription ended: %v", err)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timeout waiting for measurements, got %v", got)
+		}
+	}
+	cancel()
+	<-errs
+
+	select {
+	case <-synced:
+	default:
+		t.Error("expected sync callback")
+	}
+
+	// The first two writes follow the sync, their order is not defined.
+	slices.SortFunc(got[:2], func(a, b interfaceMeasurement) int { return strings.Compare(a.Interface, b.Interface) })
+
+	ethernet := influx.Measurement{Temperature: 35.4, Voltage: 3.3, RxPower: -3.12, TxPower: 1.5, OSNR: 22.5}
+	want := []interfaceMeasurement{
+		{Interface: "Ethernet1/1", Measurement: ethernet},
+		{Interface: "Transceiver2", Measurement: influx.Measurement{RxPower: -7}},
+		{Interface: "Ethernet1/1", Measurement: influx.Measurement{Temperature: 36, Voltage: 3.3, RxPower: -3.12, TxPower: 1.5, OSNR: 22.5}},
+	}
+	if diff := gocmp.Diff(got, want, cmpopts.EquateApprox(0, 1e-6)); diff != "" {
+		t.Errorf("measurements mismatch (-got +want):\n%s", diff)
+	}
+
+	request := (<-target.requests).GetSubscribe()
+	if len(request.Subscription) != len(gnmiPaths) {
+		t.Fatalf("expected %d subscriptions, got %d", len(gnmiPaths), len(request.Subscription))
+	}
+	for _, s := range request.Subscription {
+		if s.Mode != gnmi.SubscriptionMode_SAMPLE || s.SampleInterval != uint64(10*time.Second) {
+			t.Errorf("expected sample every 10s, got %v every %d", s.Mode, s.SampleInterval)
+		}
+	}
+
+	md := <-target.metadata
+	if gocmp.Diff(md.Get("username"), []string{"noc"}) != "" || gocmp.Diff(md.Get("password"), []string{"secret"}) != "" {
+		t.Errorf("expected credentials in metadata, got %v", md)
+	}
+}
+
+func TestGNMISubscription_reconnect(t *testing.T) {
+	target := newTestTarget(t,
+		// The first session is dropped right after sync.
+		testSession{responses: []*gnmi.SubscribeResponse{
+			notification("/components/component[name=Transceiver1]", map[string]*gnmi.TypedValue{
+				"transceiver/state/input-power/instant": float(-2),
+			}),
+			syncResponse(),
+		}},
+		testSession{hold: true, responses: []*gnmi.SubscribeResponse{
+			notification("/components/component[name=Transceiver1]", map[string]*gnmi.TypedValue{
+				"transceiver/state/input-power/instant": float(-3),
+			}),
+			syncResponse(),
+		}},
+	)
+
+	writes := make(chan interfaceMeasurement, 16)
+	subscription := startGNMISubscription(context.Background(), testGNMIDevice(target, storage.GNMIModeOnChange), 10*time.Millisecond, 20*time.Millisecond, func(m interfaceMeasurement) { writes <- m })
+	defer subscription.stop()
+
+	for _, want := range []float64{-2, -3} {
+		select {
+		case m := <-writes:
+			if m.RxPower != want {
+				t.Errorf("expected rx power %v, got %v", want, m.RxPower)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timeout waiting for rx power %v", want)
+		}
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		status, err := subscription.status(time.Second)
+		if status == storage.StatusOK {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("expected synced subscription, got status %d: %v", status, err)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	if got := target.count.Load(); got != 2 {
+		t.Errorf("expected 2 subscriptions, got %d", got)
+	}
+
+	request := (<-target.requests).GetSubscribe()
+	if mode := request.Subscription[0].Mode; mode != gnmi.SubscriptionMode_ON_CHANGE {
+		t.Errorf("expected on change mode, got %v", mode)
+	}
+}
+
+func TestGNMISubscription_latest(t *testing.T) {
+	s := &gnmiSubscription{measurements: make(map[string]interfaceMeasurement)}
+	s.keep(interfaceMeasurement{Interface: "Ethernet2", Measurement: influx.Measurement{RxPower: -2}})
+	s.keep(interfaceMeasurement{Interface: "Ethernet1", Measurement: influx.Measurement{RxPower: -5}})
+	s.keep(interfaceMeasurement{Interface: "Ethernet2", Measurement: influx.Measurement{RxPower: -3}})
+
+	want := []interfaceMeasurement{
+		{Interface: "Ethernet1", Measurement: influx.Measurement{RxPower: -5}},
+		{Interface: "Ethernet2", Measurement: influx.Measurement{RxPower: -3}},
+	}
+	if diff := gocmp.Diff(s.latest(), want); diff != "" {
+		t.Errorf("latest measurements mismatch (-got +want):\n%s", diff)
+	}
+
+	if got := s.latest(); len(got) != 0 {
+		t.Errorf("expected no measurements since the previous call, got %v", got)
+	}
+}
+
+func TestGNMISubscription_unreachable(t *testing.T) {
+	target := newTestTarget(t, testSession{})
+	d := testGNMIDevice(target, storage.GNMIModeSample)
+	d.GNMI.Port = 1
+
+	subscription := startGNMISubscription(context.Background(), d, time.Minute, time.Minute, func(interfaceMeasurement) {})
+	defer subscription.stop()
+
+	status, err := subscription.status(5 * time.Second)
+	if status != storage.StatusErrorCollector || err == nil {
+		t.Errorf("expected collector error, got status %d: %v", status, err)
+	}
+}
+
+func TestGNMIPool_subscription(t *testing.T) {
+	target := newTestTarget(t, testSession{hold: true, responses: []*gnmi.SubscribeResponse{syncResponse()}})
+
+	pool := newGNMIPool()
+	defer pool.close()
+
+	d := testGNMIDevice(target, storage.GNMIModeSample)
+	first := pool.subscription(context.Background(), d, func(interfaceMeasurement) {})
+	if pool.subscription(context.Background(), d, func(interfaceMeasurement) {}) != first {
+		t.Error("expected the running subscription to be kept")
+	}
+
+	d.GNMI.SampleInterval = 30
+	if pool.subscription(context.Background(), d, func(interfaceMeasurement) {}) == first {
+		t.Error("expected a new subscription after settings changed")
+	}
+
+	pool.retain(map[uint]bool{})
+	if len(pool.subscriptions) != 0 {
+		t.Errorf("expected no subscriptions, got %d", len(pool.subscriptions))
+	}
+}
@@ -0,0 +1,645 @@
+package monitor
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// InspectedField is a field of an EEPROM page, addressed like in CMIS (0-127
+// in the lower page, 128-255 in upper pages). Bytes no known field covers
+// are listed as unknown, with their hex only.
+type InspectedField struct {
+	Address int
+	Length  int
+	Name    string
+	Value   string
+	Hex     string
+	Unknown bool
+}
+
+// Addresses returns the address range of the field, e.g. "138-139".
+func (f InspectedField) Addresses() string {
+	if f.Length == 1 {
+		return fmt.Sprint(f.Address)
+	}
+
+	return fmt.Sprintf("%d-%d", f.Address, f.Address+f.Length-1)
+}
+
+// PageChecksum is the low byte of the sum of the page bytes it covers.
+type PageChecksum struct {
+	Address  int
+	Stored   byte
+	Computed byte
+}
+
+func (c PageChecksum) Valid() bool {
+	return c.Stored == c.Computed
+}
+
+type InspectedPage struct {
+	Name     string
+	Fields   []InspectedField
+	Checksum *PageChecksum
+}
+
+// unknownRowLength splits unknown bytes into rows of the inspector.
+const unknownRowLength = 16
+
+type fieldSpec struct {
+	address int
+	length  int
+	name    string
+	format  func(b []byte) string
+}
+
+type checksumSpec struct {
+	from, to, at int
+}
+
+type pageSpec struct {
+	name string
+	// offset of the page in the Eeprom layout.
+	offset   int
+	fields   []fieldSpec
+	checksum *checksumSpec
+}
+
+// Inspect decodes the EEPROM layout field by field, per page.
+func Inspect(e Eeprom) []InspectedPage {
+	specs := eepromSpecs()
+	if e.VdmSupported() {
+		// Page 25h, the last one, is decoded after its descriptors.
+		specs = append(specs[:len(specs)-1], vdmSpecs(e)...)
+	}
+
+	pages := make([]InspectedPage, 0, len(specs))
+	for _, spec := range specs {
+		pages = append(pages, spec.inspect(e))
+	}
+
+	return pages
+}
+
+// InspectDump decodes a captured dump and inspects it.
+func InspectDump(decode Decoder, input []byte) ([]InspectedPage, error) {
+	decoded, err := decode(input)
+	if err != nil {
+		return nil, err
+	}
+	if len(decoded) < EepromLength {
+		return nil, fmt.Errorf("expected %d bytes of EEPROM, got %d", EepromLength, len(decoded))
+	}
+
+	return Inspect(decoded), nil
+}
+
+func (s pageSpec) inspect(e Eeprom) InspectedPage {
+	base := PageLength
+	if s.offset == 0 {
+		base = 0
+	}
+	data := e[s.offset : s.offset+PageLength]
+
+	page := InspectedPage{Name: s.name}
+
+	address := base
+	unknown := func(to int) {
+		for ; address < to; address += unknownRowLength {
+			length := min(unknownRowLength, to-address)
+			raw := data[address-base : address-base+length]
+			page.Fields = append(page.Fields, InspectedField{Address: address, Length: length, Name: "Reserved / unknown", Hex: hexBytes(raw), Unknown: true})
+		}
+		address = to
+	}
+
+	for _, f := range s.fields {
+		unknown(f.address)
+
+		raw := data[f.address-base : f.address-base+f.length]
+		page.Fields = append(page.Fields, InspectedField{Address: f.address, Length: f.length, Name: f.name, Value: f.format(raw), Hex: hexBytes(raw)})
+		address = f.address + f.length
+	}
+	unknown(base + PageLength)
+
+	if s.checksum != nil {
+		var sum byte
+		for _, b := range data[s.checksum.from-base : s.checksum.to-base+1] {
+			sum += b
+		}
+
+		page.Checksum = &PageChecksum{Address: s.checksum.at, Stored: data[s.checksum.at-base], Computed: sum}
+	}
+
+	return page
+}
+
+// eepromSpecs lists known fields of the pages in the base Eeprom layout,
+// after CMIS 5.2. Fields of a page are sorted by address.
+func eepromSpecs() []pageSpec {
+	return []pageSpec{
+		{
+			name:   "Lower page",
+			offset: 0,
+			fields: concat(
+				[]fieldSpec{
+					{0, 1, "SFF-8024 identifier", formatIdentifier},
+					{1, 1, "CMIS revision", formatRevision},
+					{2, 1, "Memory model", formatMemoryModel},
+					{3, 1, "Module state", formatModuleState},
+					{4, 4, "Flags summary of banks 0-3", hexBytes},
+					{8, 1, "Module state changed flag", formatFlags(map[int]string{0: "module state changed"})},
+					{9, 1, "Module flags (temperature, Vcc)", formatFlags(map[int]string{
+						7: "Vcc low warning", 6: "Vcc high warning", 5: "Vcc low alarm", 4: "Vcc high alarm",
+						3: "temperature low warning", 2: "temperature high warning", 1: "temperature low alarm", 0: "temperature high alarm",
+					})},
+					{10, 2, "Aux monitor flags", hexBytes},
+					{12, 1, "Custom monitor flags", hexBytes},
+					{14, 2, "Temperature monitor", formatTemperatureMonitor},
+					{16, 2, "Supply voltage monitor", formatVoltage},
+					{18, 6, "Aux 1-3 monitors", hexBytes},
+					{26, 1, "Module global controls", formatFlags(map[int]string{6: "software reset", 4: "low power requested by software", 3: "squelch method select"})},
+					{31, 1, "Module level masks (temperature, Vcc)", hexBytes},
+					{37, 2, "CDB status", hexBytes},
+					{39, 2, "Active firmware version", formatVersion},
+					{85, 1, "Media type", formatMediaType},
+				},
+				appDescriptors(),
+				[]fieldSpec{
+					{126, 1, "Bank select", formatUint},
+					{127, 1, "Page select", formatHexByte},
+				},
+			),
+		},
+		{
+			name:   "Page 00h (administrative information)",
+			offset: 1 * PageLength,
+			fields: []fieldSpec{
+				{128, 1, "SFF-8024 identifier", formatIdentifier},
+				{129, 16, "Vendor name", formatASCII},
+				{145, 3, "Vendor OUI", formatOUI},
+				{148, 16, "Vendor part number", formatASCII},
+				{164, 2, "Vendor revision", formatASCII},
+				{166, 16, "Vendor serial number", formatASCII},
+				{182, 8, "Date code", formatDateCode},
+				{190, 10, "CLEI code", formatASCII},
+				{200, 1, "Module power class", func(b []byte) string { return fmt.Sprintf("class %d", b[0]>>5+1) }},
+				{201, 1, "Maximum power", func(b []byte) string { return fmt.Sprintf("%.2f W", float64(b[0])*0.25) }},
+				{202, 1, "Cable assembly length", formatUint},
+				{203, 1, "Connector type", formatConnector},
+				{204, 6, "Copper cable attenuation", hexBytes},
+				{210, 1, "Media lane information", hexBytes},
+				{211, 1, "Cable assembly information", hexBytes},
+				{212, 1, "Media interface technology", formatHexByte},
+			},
+			checksum: &checksumSpec{from: 128, to: 221, at: 222},
+		},
+		{
+			name:   "Page 01h (advertising)",
+			offset: 2 * PageLength,
+			fields: []fieldSpec{
+				{128, 2, "Inactive firmware version", formatVersion},
+				{130, 2, "Hardware version", formatVersion},
+				{132, 1, "Length (SMF)", formatLengthSMF},
+				{133, 1, "Length (OM5)", formatUint},
+				{134, 1, "Length (OM4)", formatUint},
+				{135, 1, "Length (OM3)", formatUint},
+				{136, 1, "Length (OM2)", formatUint},
+				{138, 2, "Nominal wavelength", func(b []byte) string { return fmt.Sprintf("%.2f nm", float64(u16(b))*0.05) }},
+				{140, 2, "Wavelength tolerance", func(b []byte) string { return fmt.Sprintf("%.3f nm", float64(u16(b))*0.005) }},
+				{142, 1, "Supported pages", formatFlags(map[int]string{6: "VDM pages 20h-2Fh", 5: "diagnostic pages 13h-14h", 1: "banks 0-3", 0: "banks 0-1"})},
+				{143, 2, "Durations", hexBytes},
+				{145, 1, "Module characteristics", hexBytes},
+				{146, 1, "Maximum module temperature", formatInt8Celsius},
+				{147, 1, "Minimum module temperature", formatInt8Celsius},
+				{148, 2, "Propagation delay", func(b []byte) string { return fmt.Sprintf("%d ns", u16(b)*10) }},
+				{150, 1, "Minimum operating voltage", func(b []byte) string { return fmt.Sprintf("%.2f V", float64(b[0])*0.02) }},
+				{155, 1, "Transmitter characteristics", formatFlags(map[int]string{6: "tunable", 0: "cooled"})},
+				{159, 1, "Supported monitors", formatFlags(map[int]string{1: "Vcc", 0: "temperature"})},
+				{160, 1, "Supported lane monitors", formatFlags(map[int]string{2: "Rx optical power", 1: "Tx optical power", 0: "Tx bias"})},
+				{167, 1, "Maximum module power up duration", hexBytes},
+				{168, 1, "Maximum data path Tx turn on duration", hexBytes},
+			},
+			checksum: &checksumSpec{from: 130, to: 254, at: 255},
+		},
+		{
+			name:   "Page 02h (thresholds)",
+			offset: 3 * PageLength,
+			fields: concat(
+				thresholds(128, "Temperature", formatCelsius),
+				thresholds(136, "Supply voltage", formatVoltage),
+				[]fieldSpec{{144, 32, "Aux 1-3 and custom thresholds", hexBytes}},
+				thresholds(176, "Tx optical power", formatPower),
+				thresholds(184, "Tx bias", formatBias),
+				thresholds(192, "Rx optical power", formatPower),
+			),
+			checksum: &checksumSpec{from: 128, to: 254, at: 255},
+		},
+		{
+			name:   "Page 04h (laser capabilities)",
+			offset: 4 * PageLength,
+			fields: concat(
+				[]fieldSpec{
+					{128, 1, "Supported grids", formatFlags(map[int]string{7: "75 GHz", 6: "33 GHz", 5: "100 GHz", 4: "50 GHz", 3: "25 GHz", 2: "12.5 GHz", 1: "6.25 GHz", 0: "3.125 GHz"})},
+					{129, 1, "Fine tuning support", formatFlags(map[int]string{0: "fine tuning"})},
+					{130, 2, "Fine tuning resolution", func(b []byte) string { return fmt.Sprintf("%.3f GHz", float64(u16(b))*0.001) }},
+					{132, 2, "Fine tuning low offset", formatFineTuning},
+					{134, 2, "Fine tuning high offset", formatFineTuning},
+				},
+				gridChannels(138, "3.125 GHz"),
+				gridChannels(142, "75 GHz"),
+				gridChannels(146, "33 GHz"),
+				gridChannels(150, "100 GHz"),
+				gridChannels(154, "50 GHz"),
+				gridChannels(158, "25 GHz"),
+				gridChannels(162, "12.5 GHz"),
+				gridChannels(166, "6.25 GHz"),
+				[]fieldSpec{
+					{198, 2, "Minimum programmable output power", formatHundredthDBm},
+					{200, 2, "Maximum programmable output power", formatHundredthDBm},
+				},
+			),
+			checksum: &checksumSpec{from: 128, to: 254, at: 255},
+		},
+		{
+			name:   "Page 11h (lane status)",
+			offset: 5 * PageLength,
+			fields: concat(
+				dataPathStateFields(),
+				[]fieldSpec{
+					{132, 1, "Rx output status (lanes 1-8)", formatLanes},
+					{133, 1, "Tx output status (lanes 1-8)", formatLanes},
+					{134, 1, "Data path state changed (lanes 1-8)", formatLanes},
+					{135, 1, "Tx fault (lanes 1-8)", formatLanes},
+					{136, 1, "Tx LOS (lanes 1-8)", formatLanes},
+					{137, 1, "Tx CDR LOL (lanes 1-8)", formatLanes},
+					{138, 1, "Tx adaptive equalization fault (lanes 1-8)", formatLanes},
+					{139, 1, "Tx power high alarm (lanes 1-8)", formatLanes},
+					{140, 1, "Tx power low alarm (lanes 1-8)", formatLanes},
+					{141, 1, "Tx power high warning (lanes 1-8)", formatLanes},
+					{142, 1, "Tx power low warning (lanes 1-8)", formatLanes},
+					{143, 1, "Tx bias high alarm (lanes 1-8)", formatLanes},
+					{144, 1, "Tx bias low alarm (lanes 1-8)", formatLanes},
+					{145, 1, "Tx bias high warning (lanes 1-8)", formatLanes},
+					{146, 1, "Tx bias low warning (lanes 1-8)", formatLanes},
+					{147, 1, "Rx LOS (lanes 1-8)", formatLanes},
+					{148, 1, "Rx CDR LOL (lanes 1-8)", formatLanes},
+					{149, 1, "Rx power high alarm (lanes 1-8)", formatLanes},
+					{150, 1, "Rx power low alarm (lanes 1-8)", formatLanes},
+					{151, 1, "Rx power high warning (lanes 1-8)", formatLanes},
+					{152, 1, "Rx power low warning (lanes 1-8)", formatLanes},
+					{153, 1, "Rx output status changed (lanes 1-8)", formatLanes},
+				},
+				lanes(154, 2, "Tx optical power", formatPower),
+				lanes(170, 2, "Tx bias", formatBias),
+				lanes(186, 2, "Rx optical power", formatPower),
+			),
+		},
+		{
+			name:   "Page 12h (tunable laser)",
+			offset: 6 * PageLength,
+			fields: concat(
+				lanes(128, 1, "Grid spacing", formatGridSpacing),
+				lanes(136, 2, "Channel number", formatInt16),
+				lanes(152, 2, "Fine tuning offset", formatFineTuning),
+				lanes(168, 4, "Current laser frequency", formatFrequency),
+				lanes(200, 2, "Target output power", formatHundredthDBm),
+				lanes(222, 1, "Tuning status", formatFlags(map[int]string{1: "tuning in progress", 0: "wavelength unlocked"})),
+			),
+		},
+		{
+			name:   "Page 25h (VDM samples)",
+			offset: 7 * PageLength,
+			fields: []fieldSpec{
+				{150, 2, "OSNR", func(b []byte) string { return fmt.Sprintf("%.1f dB", float64(u16(b))/10) }},
+			},
+		},
+	}
+}
+
+func concat(fields ...[]fieldSpec) []fieldSpec {
+	var all []fieldSpec
+	for _, f := range fields {
+		all = append(all, f...)
+	}
+
+	return all
+}
+
+// lanes returns a field per lane of media lanes 1-8.
+func lanes(address, length int, name string, format func([]byte) string) []fieldSpec {
+	fields := make([]fieldSpec, 0, 8)
+	for lane := range 8 {
+		fields = append(fields, fieldSpec{address + lane*length, length, fmt.Sprintf("%s (lane %d)", name, lane+1), format})
+	}
+
+	return fields
+}
+
+// thresholds returns high/low alarm/warning thresholds of a monitor.
+func thresholds(address int, name string, format func([]byte) string) []fieldSpec {
+	return []fieldSpec{
+		{address, 2, name + " high alarm", format},
+		{address + 2, 2, name + " low alarm", format},
+		{address + 4, 2, name + " high warning", format},
+		{address + 6, 2, name + " low warning", format},
+	}
+}
+
+func gridChannels(address int, grid string) []fieldSpec {
+	return []fieldSpec{
+		{address, 2, "Lowest channel of " + grid + " grid", formatInt16},
+		{address + 2, 2, "Highest channel of " + grid + " grid", formatInt16},
+	}
+}
+
+// appDescriptors returns the 8 application descriptors of the lower page.
+func appDescriptors() []fieldSpec {
+	fields := make([]fieldSpec, 0, 8)
+	for i := range 8 {
+		fields = append(fields, fieldSpec{86 + i*4, 4, fmt.Sprintf("Application descriptor %d", i+1), formatAppDescriptor})
+	}
+
+	return fields
+}
+
+// dataPathStateFields returns data path states of host lanes, two lanes per
+// byte.
+func dataPathStateFields() []fieldSpec {
+	fields := make([]fieldSpec, 0, 4)
+	for i := range 4 {
+		fields = append(fields, fieldSpec{128 + i, 1, fmt.Sprintf("Data path state (lanes %d, %d)", 2*i+1, 2*i+2), func(b []byte) string {
+			return fmt.Sprintf("%s, %s", DataPathState(b[0]&0x0F), DataPathState(b[0]>>4))
+		}})
+	}
+
+	return fields
+}
+
+func u16(b []byte) uint16 {
+	return binary.BigEndian.Uint16(b)
+}
+
+func hexBytes(b []byte) string {
+	return strings.ToUpper(hex.EncodeToString(b))
+}
+
+func formatUint(b []byte) string {
+	return fmt.Sprint(b[0])
+}
+
+func formatHexByte(b []byte) string {
+	return fmt.Sprintf("%02Xh", b[0])
+}
+
+func formatInt16(b []byte) string {
+	return fmt.Sprint(int16(u16(b)))
+}
+
+func formatASCII(b []byte) string {
+	return strings.TrimRight(strings.Map(func(r rune) rune {
+		if r < 0x20 || r > 0x7E {
+			return -1
+		}
+		return r
+	}, string(b)), " ")
+}
+
+func formatOUI(b []byte) string {
+	return fmt.Sprintf("%02X-%02X-%02X", b[0], b[1], b[2])
+}
+
+func formatRevision(b []byte) string {
+	return fmt.Sprintf("%d.%d", b[0]>>4, b[0]&0x0F)
+}
+
+func formatVersion(b []byte) string {
+	return fmt.Sprintf("%d.%d", b[0], b[1])
+}
+
+// formatDateCode formats YYMMDD and an optional lot code.
+func formatDateCode(b []byte) string {
+	date := formatASCII(b[0:6])
+	if len(date) != 6 {
+		return formatASCII(b)
+	}
+
+	s := fmt.Sprintf("20%s-%s-%s", date[0:2], date[2:4], date[4:6])
+	if lot := formatASCII(b[6:8]); lot != "" {
+		s += " lot " + lot
+	}
+
+	return s
+}
+
+// formatFlags lists names of set bits, highest first.
+func formatFlags(names map[int]string) func([]byte) string {
+	return func(b []byte) string {
+		var set []string
+		for bit := 7; bit >= 0; bit-- {
+			if b[0]&(1<<bit) == 0 {
+				continue
+			}
+			if name, ok := names[bit]; ok {
+				set = append(set, name)
+			} else {
+				set = append(set, fmt.Sprintf("bit %d", bit))
+			}
+		}
+
+		if len(set) == 0 {
+			return "none"
+		}
+
+		return strings.Join(set, ", ")
+	}
+}
+
+// formatLanes lists lanes with set bits, lane 1 in bit 0.
+func formatLanes(b []byte) string {
+	var set []string
+	for lane := range 8 {
+		if b[0]&(1<<lane) != 0 {
+			set = append(set, fmt.Sprint(lane+1))
+		}
+	}
+
+	if len(set) == 0 {
+		return "none"
+	}
+
+	return "lanes " + strings.Join(set, ", ")
+}
+
+// formatTemperatureMonitor scales the monitor like Eeprom.Temperature.
+func formatTemperatureMonitor(b []byte) string {
+	return fmt.Sprintf("%.2f °C", float64(int16(u16(b)))*10/256)
+}
+
+func formatCelsius(b []byte) string {
+	return fmt.Sprintf("%.2f °C", float64(int16(u16(b)))/256)
+}
+
+func formatInt8Celsius(b []byte) string {
+	return fmt.Sprintf("%d °C", int8(b[0]))
+}
+
+func formatVoltage(b []byte) string {
+	return fmt.Sprintf("%.4f V", float64(u16(b))/10000)
+}
+
+func formatPower(b []byte) string {
+	if u16(b) == 0 {
+		return "0.0 µW"
+	}
+
+	return fmt.Sprintf("%.2f dBm (%.1f µW)", microWatt01ToDbm(u16(b)), float64(u16(b))/10)
+}
+
+func formatBias(b []byte) string {
+	return fmt.Sprintf("%.3f mA", float64(u16(b))*0.002)
+}
+
+func formatHundredthDBm(b []byte) string {
+	return fmt.Sprintf("%.2f dBm", float64(int16(u16(b)))/100)
+}
+
+func formatFineTuning(b []byte) string {
+	return fmt.Sprintf("%.3f GHz", float64(int16(u16(b)))*0.001)
+}
+
+func formatFrequency(b []byte) string {
+	mhz := binary.BigEndian.Uint32(b)
+	if mhz == 0 {
+		return "0"
+	}
+
+	return fmt.Sprintf("%.4f THz (%.2f nm)", float64(mhz)/1e6, speedOfLight/float64(mhz)*1e3)
+}
+
+func formatLengthSMF(b []byte) string {
+	length := float64(b[0] & 0x3F)
+	if b[0]>>6 == 0 {
+		length *= 0.1
+	}
+
+	return fmt.Sprintf("%.1f km", length)
+}
+
+// gridSpacings are grid spacings of Page 12h, bits 7-4.
+var gridSpacings = map[byte]string{
+	0: "3.125 GHz", 1: "6.25 GHz", 2: "12.5 GHz", 3: "25 GHz",
+	4: "50 GHz", 5: "100 GHz", 6: "33 GHz", 7: "75 GHz",
+}
+
+func formatGridSpacing(b []byte) string {
+	spacing, ok := gridSpacings[b[0]>>4]
+	if !ok {
+		spacing = fmt.Sprintf("unknown (%d)", b[0]>>4)
+	}
+	if b[0]&0x01 != 0 {
+		spacing += ", fine tuning enabled"
+	}
+
+	return spacing
+}
+
+var identifiers = map[byte]string{
+	0x03: "SFP/SFP+/SFP28",
+	0x0C: "QSFP",
+	0x0D: "QSFP+",
+	0x11: "QSFP28",
+	0x18: "QSFP-DD",
+	0x19: "OSFP",
+	0x1A: "SFP-DD",
+	0x1B: "DSFP",
+	0x1E: "QSFP+ or later with CMIS",
+	0x1F: "SFP-DD with CMIS",
+	0x20: "SFP+ or later with CMIS",
+}
+
+func formatIdentifier(b []byte) string {
+	if name, ok := identifiers[b[0]]; ok {
+		return name
+	}
+
+	return fmt.Sprintf("unknown (%02Xh)", b[0])
+}
+
+var mediaTypes = map[byte]string{
+	0x00: "undefined",
+	0x01: "optical MMF",
+	0x02: "optical SMF",
+	0x03: "passive copper",
+	0x04: "active cable",
+	0x05: "BASE-T",
+}
+
+func formatMediaType(b []byte) string {
+	if name, ok := mediaTypes[b[0]]; ok {
+		return name
+	}
+
+	return fmt.Sprintf("unknown (%02Xh)", b[0])
+}
+
+var connectors = map[byte]string{
+	0x01: "SC",
+	0x07: "LC",
+	0x0C: "MPO 1x12",
+	0x0D: "MPO 2x16",
+	0x21: "copper pigtail",
+	0x22: "RJ45",
+	0x23: "no separable connector",
+	0x24: "MXC 2x16",
+	0x25: "CS",
+	0x26: "SN",
+	0x27: "MPO 2x12",
+	0x28: "MPO 1x16",
+}
+
+func formatConnector(b []byte) string {
+	if name, ok := connectors[b[0]]; ok {
+		return name
+	}
+
+	return fmt.Sprintf("unknown (%02Xh)", b[0])
+}
+
+func formatAppDescriptor(b []byte) string {
+	if b[0] == 0xFF {
+		return "end of list"
+	}
+
+	return fmt.Sprintf("host interface %02Xh, media interface %02Xh, lanes %d/%d, host lanes %08b", b[0], b[1], b[2]>>4, b[2]&0x0F, b[3])
+}
+
+func formatMemoryModel(b []byte) string {
+	s := "paged"
+	if b[0]&0x80 != 0 {
+		s = "flat"
+	}
+	if b[0]&0x40 != 0 {
+		s += ", stepped config only"
+	}
+
+	switch (b[0] >> 2) & 0x03 {
+	case 0:
+		return s + ", management interface up to 400 kHz"
+	case 1:
+		return s + ", management interface up to 1 MHz"
+	default:
+		return s + ", management interface speed reserved"
+	}
+}
+
+func formatModuleState(b []byte) string {
+	s := ModuleState((b[0] >> 1) & 0x07).String()
+	if b[0]&0x01 == 0 {
+		s += ", interrupt asserted"
+	}
+
+	return s
+}
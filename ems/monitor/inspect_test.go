@@ -0,0 +1,91 @@
+package monitor
+
+import (
+	"testing"
+
+	gocmp "github.com/google/go-cmp/cmp"
+)
+
+func TestEepromSpecs(t *testing.T) {
+	for _, page := range eepromSpecs() {
+		base := PageLength
+		if page.offset == 0 {
+			base = 0
+		}
+
+		address := base
+		for _, f := range page.fields {
+			if f.address < address || f.address+f.length > base+PageLength {
+				t.Errorf("%s: field %q at %d overlaps or is out of order", page.name, f.name, f.address)
+			}
+			address = f.address + f.length
+		}
+	}
+}
+
+func TestInspect(t *testing.T) {
+	eeprom := testEEPROM()
+	eeprom[0] = 0x18
+	copy(eeprom[PageLength+1:], "FibreFiberLtd   ")
+	eeprom[6*PageLength+40], eeprom[6*PageLength+41], eeprom[6*PageLength+42], eeprom[6*PageLength+43] = 0x0B, 0x82, 0x78, 0xE0
+
+	// Page 00h checksum covers bytes 128-221 and is stored in byte 222.
+	var sum byte
+	for _, b := range eeprom[PageLength : PageLength+94] {
+		sum += b
+	}
+	eeprom[PageLength+94] = sum
+	// Page 02h checksum is wrong.
+	eeprom[3*PageLength+127] = 0x01
+
+	pages := Inspect(eeprom)
+	if len(pages) != len(eepromPages)+1 {
+		t.Fatalf("expected %d pages, got %d", len(eepromPages)+1, len(pages))
+	}
+
+	fields := make(map[string]InspectedField)
+	for _, p := range pages {
+		covered := 0
+		for _, f := range p.Fields {
+			fields[p.Name+"/"+f.Name] = f
+			covered += f.Length
+		}
+		if covered != PageLength {
+			t.Errorf("%s: expected fields to cover %d bytes, got %d", p.Name, PageLength, covered)
+		}
+	}
+
+	for name, want := range map[string]string{
+		"Lower page/SFF-8024 identifier":                            "QSFP-DD",
+		"Lower page/Temperature monitor":                            "35.47 °C",
+		"Lower page/Supply voltage monitor":                         "3.3000 V",
+		"Page 00h (administrative information)/Vendor name":         "FibreFiberLtd",
+		"Page 11h (lane status)/Tx optical power (lane 1)":          "0.00 dBm (1000.0 µW)",
+		"Page 12h (tunable laser)/Current laser frequency (lane 1)": "193.1000 THz (1552.52 nm)",
+		"Page 25h (VDM samples)/OSNR":                               "21.5 dB",
+	} {
+		if got := fields[name].Value; got != want {
+			t.Errorf("%s: expected %q, got %q", name, want, got)
+		}
+	}
+
+	if diff := gocmp.Diff(fields["Lower page/Reserved / unknown"], InspectedField{Address: 118, Length: 8, Name: "Reserved / unknown", Hex: "0000000000000000", Unknown: true}); diff != "" {
+		t.Errorf("unknown bytes mismatch (-got +want):\n%s", diff)
+	}
+
+	checksums := make(map[string]bool)
+	for _, p := range pages {
+		if p.Checksum != nil {
+			checksums[p.Name] = p.Checksum.Valid()
+		}
+	}
+	want := map[string]bool{
+		"Page 00h (administrative information)": true,
+		"Page 01h (advertising)":                true,
+		"Page 02h (thresholds)":                 false,
+		"Page 04h (laser capabilities)":         true,
+	}
+	if diff := gocmp.Diff(checksums, want); diff != "" {
+		t.Errorf("checksums mismatch (-got +want):\n%s", diff)
+	}
+}
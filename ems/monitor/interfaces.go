@@ -0,0 +1,133 @@
+package monitor
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+	"strings"
+
+	"pi-wegrzyn/ems/storage"
+)
+
+// updateInterfaces stores the decoded state of interfaces and records events
+// for changes since the previous poll, unless the device is in maintenance.
+func (m Monitor) updateInterfaces(ctx context.Context, run monitoringRun, device storage.Device, data []interfaceMeasurement) {
+	stored, err := m.db.DeviceInterfaces(ctx, device.ID)
+	if err != nil {
+		slog.ErrorContext(ctx, "cannot get interfaces", slog.Any("deviceID", device.ID), slog.Any("error", err))
+
+		return
+	}
+
+	previous := make(map[string]storage.Interface, len(stored))
+	for _, i := range stored {
+		previous[i.Name] = i
+	}
+
+	for _, measurement := range data {
+		current := interfaceState(device.ID, measurement)
+
+		var events []storage.Event
+		if !run.inMaintenance(device.ID) {
+			events = interfaceChanges(previous[current.Name], current)
+		}
+
+		for _, event := range events {
+			event.Hostname = device.Hostname
+			if err := m.db.CreateEvent(ctx, event); err != nil {
+				slog.ErrorContext(ctx, "cannot create event", slog.Any("deviceID", device.ID), slog.String("interface", current.Name), slog.Any("error", err))
+			}
+		}
+
+		if err := m.db.UpdateInterface(ctx, current); err != nil {
+			slog.ErrorContext(ctx, "cannot update interface", slog.Any("deviceID", device.ID), slog.String("interface", current.Name), slog.Any("error", err))
+		}
+	}
+}
+
+func interfaceState(deviceID uint, measurement interfaceMeasurement) storage.Interface {
+	state := storage.Interface{
+		DeviceID:       deviceID,
+		Name:           measurement.Interface,
+		ModuleState:    measurement.ModuleState,
+		DataPathStates: measurement.DataPathStates,
+		TxPower:        measuredPower(measurement.TxPower),
+		RxPower:        measuredPower(measurement.RxPower),
+		RxLowAlarm:     measurement.RxPowerLowAlarm,
+	}
+	if laser := measurement.Laser; laser != nil {
+		state.Frequency = laser.Frequency
+		state.Channel = laser.Channel
+		state.GridSpacing = laser.GridSpacing
+		state.TargetPower = laser.TargetPower
+	}
+
+	return state
+}
+
+// minPower is the lowest power in dBm monitors of 0.1 µW resolve.
+const minPower = -40
+
+// measuredPower clamps no light, decoded as -Inf, to minPower so a dark
+// fiber still has a finite span loss.
+func measuredPower(dBm float64) float64 {
+	if math.IsNaN(dBm) || dBm < minPower {
+		return minPower
+	}
+
+	return dBm
+}
+
+// interfaceChanges returns events of an interface going from previous to
+// current. A zero previous state is an interface seen for the first time.
+func interfaceChanges(previous, current storage.Interface) []storage.Event {
+	var events []storage.Event
+
+	// Frequencies are advertised in MHz.
+	if previous.Frequency != 0 && current.Frequency != 0 && math.Round(previous.Frequency*1e6) != math.Round(current.Frequency*1e6) {
+		events = append(events, storage.Event{
+			DeviceID:  current.DeviceID,
+			Interface: current.Name,
+			Kind:      storage.EventLaserFrequency,
+			Message: fmt.Sprintf("laser frequency changed from %.4f THz (channel %d) to %.4f THz (channel %d)",
+				previous.Frequency, previous.Channel, current.Frequency, current.Channel),
+		})
+	}
+
+	// A module already in fault when first seen has not transitioned.
+	before, after := ModuleState(previous.ModuleState), ModuleState(current.ModuleState)
+	if before != 0 && before != ModuleFault && after == ModuleFault {
+		events = append(events, storage.Event{
+			DeviceID:  current.DeviceID,
+			Interface: current.Name,
+			Kind:      storage.EventModuleFault,
+			Message:   fmt.Sprintf("module state changed from %s to %s", before, after),
+		})
+	}
+
+	var lanes []string
+	for lane, state := range previous.DataPathStates {
+		if DataPathState(state) != DPActivated {
+			continue
+		}
+
+		after := DataPathState(0)
+		if lane < len(current.DataPathStates) {
+			after = DataPathState(current.DataPathStates[lane])
+		}
+		if after != DPActivated {
+			lanes = append(lanes, fmt.Sprintf("lane %d (%s)", lane+1, after))
+		}
+	}
+	if len(lanes) != 0 {
+		events = append(events, storage.Event{
+			DeviceID:  current.DeviceID,
+			Interface: current.Name,
+			Kind:      storage.EventDataPathDeactivated,
+			Message:   "data path left DPActivated on " + strings.Join(lanes, ", "),
+		})
+	}
+
+	return events
+}
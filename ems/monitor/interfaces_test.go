@@ -0,0 +1,108 @@
+package monitor
+
+import (
+	"testing"
+
+	gocmp "github.com/google/go-cmp/cmp"
+
+	"pi-wegrzyn/ems/influx"
+	"pi-wegrzyn/ems/storage"
+)
+
+func TestInterfaceState(t *testing.T) {
+	got := interfaceState(1, interfaceMeasurement{
+		Interface:   "eth0",
+		Measurement: influx.Measurement{Laser: &influx.Laser{Frequency: 193.1, Channel: 2, GridSpacing: 50, TargetPower: -10}},
+	})
+
+	want := storage.Interface{DeviceID: 1, Name: "eth0", Frequency: 193.1, Channel: 2, GridSpacing: 50, TargetPower: -10}
+	if diff := gocmp.Diff(got, want); diff != "" {
+		t.Errorf("state mismatch (-got +want):\n%s", diff)
+	}
+
+	if got := interfaceState(1, interfaceMeasurement{Interface: "eth1"}); got.Frequency != 0 {
+		t.Errorf("expected no frequency without laser, got %v", got.Frequency)
+	}
+}
+
+func TestEeprom_states(t *testing.T) {
+	eeprom := Eeprom(testEEPROM())
+
+	if got := eeprom.ModuleState(); got != ModuleReady {
+		t.Errorf("expected %s, got %s", ModuleReady, got)
+	}
+
+	want := []DataPathState{DPActivated, DPActivated, DPActivated, DPActivated, 0, 0, 0, 0}
+	if diff := gocmp.Diff(eeprom.DataPathStates(), want); diff != "" {
+		t.Errorf("data path states mismatch (-got +want):\n%s", diff)
+	}
+
+	if got := DataPathState(9).String(); got != "reserved (9)" {
+		t.Errorf("unexpected name of unknown state: %s", got)
+	}
+}
+
+func TestInterfaceChanges(t *testing.T) {
+	tuned := storage.Interface{DeviceID: 1, Name: "eth0", Frequency: 193.1, Channel: 0}
+	retuned := storage.Interface{DeviceID: 1, Name: "eth0", Frequency: 193.15, Channel: 1}
+
+	tests := []struct {
+		name     string
+		previous storage.Interface
+		current  storage.Interface
+		want     []storage.Event
+	}{
+		{name: "first poll", previous: storage.Interface{}, current: tuned},
+		{name: "unchanged", previous: tuned, current: tuned},
+		{name: "not tunable", previous: storage.Interface{Name: "eth0"}, current: storage.Interface{Name: "eth0"}},
+		{name: "module removed", previous: tuned, current: storage.Interface{DeviceID: 1, Name: "eth0"}},
+		{
+			name:     "module fault",
+			previous: storage.Interface{DeviceID: 1, Name: "eth0", ModuleState: uint8(ModuleReady)},
+			current:  storage.Interface{DeviceID: 1, Name: "eth0", ModuleState: uint8(ModuleFault)},
+			want: []storage.Event{{
+				DeviceID:  1,
+				Interface: "eth0",
+				Kind:      storage.EventModuleFault,
+				Message:   "module state changed from ModuleReady to ModuleFault",
+			}},
+		},
+		{name: "fault when first seen", previous: storage.Interface{}, current: storage.Interface{ModuleState: uint8(ModuleFault)}},
+		{name: "still in fault", previous: storage.Interface{ModuleState: uint8(ModuleFault)}, current: storage.Interface{ModuleState: uint8(ModuleFault)}},
+		{
+			name:     "data path deactivated",
+			previous: storage.Interface{DeviceID: 1, Name: "eth0", DataPathStates: []uint8{4, 4, 1, 0}},
+			current:  storage.Interface{DeviceID: 1, Name: "eth0", DataPathStates: []uint8{3, 4, 4, 0}},
+			want: []storage.Event{{
+				DeviceID:  1,
+				Interface: "eth0",
+				Kind:      storage.EventDataPathDeactivated,
+				Message:   "data path left DPActivated on lane 1 (DPDeinit)",
+			}},
+		},
+		{
+			name:     "data path activated",
+			previous: storage.Interface{DataPathStates: []uint8{2, 1}},
+			current:  storage.Interface{DataPathStates: []uint8{4, 4}},
+		},
+		{
+			name:     "retuned",
+			previous: tuned,
+			current:  retuned,
+			want: []storage.Event{{
+				DeviceID:  1,
+				Interface: "eth0",
+				Kind:      storage.EventLaserFrequency,
+				Message:   "laser frequency changed from 193.1000 THz (channel 0) to 193.1500 THz (channel 1)",
+			}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if diff := gocmp.Diff(interfaceChanges(tt.previous, tt.current), tt.want); diff != "" {
+				t.Errorf("events mismatch (-got +want):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,145 @@
+package monitor
+
+import (
+	"fmt"
+	"log/slog"
+	"net"
+	"strconv"
+	"sync"
+
+	"pi-wegrzyn/ems/storage"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// jumpPool keeps connections to jump hosts open during a monitoring run, so
+// devices behind the same bastion share a single SSH connection.
+type jumpPool struct {
+	hosts       []storage.JumpHost
+	profiles    map[uint]*storage.Credential
+	timeout     int
+	agentSocket string
+
+	mu      sync.Mutex
+	clients map[uint]*jumpClient
+}
+
+// jumpClient is a connection to a jump host, done is closed once the dial
+// finished, so devices waiting for the same hop do not block the pool.
+type jumpClient struct {
+	done   chan struct{}
+	client *ssh.Client
+	err    error
+}
+
+func newJumpPool(hosts []storage.JumpHost, profiles map[uint]*storage.Credential, timeout int, agentSocket string) *jumpPool {
+	return &jumpPool{
+		hosts:       hosts,
+		profiles:    profiles,
+		timeout:     timeout,
+		agentSocket: agentSocket,
+		clients:     make(map[uint]*jumpClient),
+	}
+}
+
+// via returns the connection to the innermost jump host of the chain ending
+// with jump host id, connecting the missing hops. It returns nil for id 0.
+func (p *jumpPool) via(id uint) (*ssh.Client, error) {
+	chain, err := storage.JumpChain(p.hosts, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var via *ssh.Client
+	for _, host := range chain {
+		client, err := p.client(host, via)
+		if err != nil {
+			return nil, fmt.Errorf("jump host %s: %w", host.Name, err)
+		}
+		via = client
+	}
+
+	return via, nil
+}
+
+// client returns the connection to the jump host, dialing it through via
+// unless another device already did. The pool is not locked while dialing.
+func (p *jumpPool) client(host storage.JumpHost, via *ssh.Client) (*ssh.Client, error) {
+	p.mu.Lock()
+	c, ok := p.clients[host.ID]
+	if !ok {
+		c = &jumpClient{done: make(chan struct{})}
+		p.clients[host.ID] = c
+	}
+	p.mu.Unlock()
+
+	if ok {
+		<-c.done
+		return c.client, c.err
+	}
+
+	c.client, c.err = p.connect(host, via)
+	close(c.done)
+
+	if c.err != nil {
+		p.remove(host.ID, c)
+		return nil, c.err
+	}
+
+	go p.forget(host.ID, c)
+
+	return c.client, nil
+}
+
+func (p *jumpPool) connect(host storage.JumpHost, via *ssh.Client) (*ssh.Client, error) {
+	profile, ok := p.profiles[host.CredentialID]
+	if !ok {
+		return nil, fmt.Errorf("unknown credential profile #%d", host.CredentialID)
+	}
+
+	credentials := profile.Auth()
+	auth, closeAgent, err := authMethods(credentials, p.agentSocket)
+	if err != nil {
+		return nil, err
+	}
+	// The agent is only asked for signatures during the handshake.
+	defer closeAgent()
+
+	addr := net.JoinHostPort(host.Address, strconv.Itoa(int(host.Port)))
+
+	return dialSSH(via, addr, clientConfig(credentials.Login, auth, p.timeout))
+}
+
+// forget removes the connection once it is closed, e.g. by the bastion, so
+// that the next device reconnects instead of using a broken tunnel.
+func (p *jumpPool) forget(id uint, c *jumpClient) {
+	_ = c.client.Wait()
+
+	p.remove(id, c)
+}
+
+func (p *jumpPool) remove(id uint, c *jumpClient) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.clients[id] == c {
+		delete(p.clients, id)
+	}
+}
+
+func (p *jumpPool) close() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for id, c := range p.clients {
+		<-c.done
+		if c.client == nil {
+			delete(p.clients, id)
+			continue
+		}
+		if err := c.client.Close(); err != nil {
+			slog.Debug("cannot close jump host connection", slog.Any("jumpHostID", id), slog.Any("error", err))
+		}
+		delete(p.clients, id)
+	}
+}
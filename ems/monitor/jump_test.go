@@ -0,0 +1,86 @@
+package monitor
+
+import (
+	"errors"
+	"testing"
+
+	"pi-wegrzyn/ems/storage"
+
+	"golang.org/x/crypto/ssh"
+)
+
+func TestJumpPool_via(t *testing.T) {
+	passwordConfig := func(password string) *ssh.ServerConfig {
+		return &ssh.ServerConfig{
+			PasswordCallback: func(conn ssh.ConnMetadata, p []byte) (*ssh.Permissions, error) {
+				if string(p) == password {
+					return nil, nil
+				}
+				return nil, errors.New("wrong password")
+			},
+		}
+	}
+
+	outer := newTestServer(t, passwordConfig("outer"))
+	inner := newTestServer(t, passwordConfig("inner"))
+
+	profiles := map[uint]*storage.Credential{
+		1: {ID: 1, Name: "outer", Kind: storage.CredentialPassword, Login: "jump", Password: "outer"},
+		2: {ID: 2, Name: "inner", Kind: storage.CredentialPassword, Login: "jump", Password: "inner"},
+	}
+	hosts := []storage.JumpHost{
+		{ID: 1, Name: "outer", Address: outer.host, Port: outer.port, CredentialID: 1},
+		{ID: 2, Name: "inner", Address: inner.host, Port: inner.port, CredentialID: 2, ViaID: 1},
+		{ID: 3, Name: "broken", Address: inner.host, Port: inner.port, CredentialID: 9},
+	}
+
+	pool := newJumpPool(hosts, profiles, 5, "")
+	defer pool.close()
+
+	for _, name := range []string{"sw1", "sw2"} {
+		device := newTestServer(t, passwordConfig("device"))
+		d := newRemoteDevice(storage.Device{Hostname: name, IPAddress: device.host, Port: device.port}, storage.Auth{Login: "noc", Password: "device"}, DefaultDecoder())
+
+		via, err := pool.via(2)
+		if err != nil {
+			t.Fatalf("cannot connect jump hosts: %v", err)
+		}
+
+		auth, closeAgent, err := d.auth("")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		defer closeAgent()
+
+		client, err := d.sshClient(via, auth, 5)
+		if err != nil {
+			t.Fatalf("cannot connect %s: %v", name, err)
+		}
+
+		interfaces, err := d.getInterfaces(client)
+		if err != nil || len(interfaces) != 1 || interfaces[0] != "eth0" {
+			t.Errorf("expected [eth0] from %s, got %v (error: %v)", name, interfaces, err)
+		}
+		_ = client.Close()
+
+		if got := device.conns.Load(); got != 1 {
+			t.Errorf("expected 1 connection to %s, got %d", name, got)
+		}
+	}
+
+	if got := outer.conns.Load(); got != 1 {
+		t.Errorf("expected 1 connection to outer jump host, got %d", got)
+	}
+	if got := inner.conns.Load(); got != 1 {
+		t.Errorf("expected 1 connection to inner jump host, got %d", got)
+	}
+
+	if via, err := pool.via(0); via != nil || err != nil {
+		t.Errorf("expected direct connection without jump host, got %v (error: %v)", via, err)
+	}
+
+	_, err := pool.via(3)
+	if want := "jump host broken: unknown credential profile #9"; err == nil || err.Error() != want {
+		t.Errorf("expected error %q, got %v", want, err)
+	}
+}
@@ -0,0 +1,140 @@
+package monitor
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"pi-wegrzyn/ems/influx"
+	"pi-wegrzyn/ems/storage"
+)
+
+// staleRuns is the number of runs after which the stored state of an
+// interface no longer counts as a reading of its link.
+const staleRuns = 3
+
+// updateLinks reads span loss of links from the interface states stored
+// since the start of the oldest of the last staleRuns runs, writes it to
+// Influx and records events of degraded and recovered spans. Links with an
+// end in maintenance are left as they were.
+func (m Monitor) updateLinks(ctx context.Context, run monitoringRun, devices []storage.Device, since time.Time) {
+	links, err := m.db.Links(ctx)
+	if err != nil || len(links) == 0 {
+		if err != nil {
+			slog.ErrorContext(ctx, "cannot get links", slog.Any("error", err))
+		}
+
+		return
+	}
+
+	interfaces, err := m.db.Interfaces(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "cannot get interfaces", slog.Any("error", err))
+
+		return
+	}
+
+	type interfaceKey struct {
+		deviceID uint
+		name     string
+	}
+	states := make(map[interfaceKey]storage.Interface, len(interfaces))
+	for _, i := range interfaces {
+		if !i.Updated.Before(since) {
+			states[interfaceKey{i.DeviceID, i.Name}] = i
+		}
+	}
+
+	hostnames := make(map[uint]string, len(devices))
+	for _, d := range devices {
+		hostnames[d.ID] = d.Hostname
+	}
+
+	for _, link := range links {
+		if run.inMaintenance(link.ADeviceID) || run.inMaintenance(link.BDeviceID) {
+			slog.DebugContext(ctx, "skipping link in maintenance", slog.Any("linkID", link.ID))
+
+			continue
+		}
+
+		a, okA := states[interfaceKey{link.ADeviceID, link.AInterface}]
+		b, okB := states[interfaceKey{link.BDeviceID, link.BInterface}]
+		if !okA || !okB {
+			slog.DebugContext(ctx, "no recent reading of link", slog.Any("linkID", link.ID))
+
+			continue
+		}
+
+		var events []storage.Event
+		link, events = evaluateLink(link, a, b, time.Now())
+
+		for _, event := range events {
+			event.Hostname = hostnames[event.DeviceID]
+			if err := m.db.CreateEvent(ctx, event); err != nil {
+				slog.ErrorContext(ctx, "cannot create event", slog.Any("linkID", link.ID), slog.Any("error", err))
+			}
+		}
+
+		if err := m.db.UpdateLinkSpan(ctx, link); err != nil {
+			slog.ErrorContext(ctx, "cannot update link", slog.Any("linkID", link.ID), slog.Any("error", err))
+		}
+
+		m.influx.InsertSpan(influx.Span{
+			Link:     link.Name,
+			AHost:    hostnames[link.ADeviceID],
+			AIface:   link.AInterface,
+			BHost:    hostnames[link.BDeviceID],
+			BIface:   link.BInterface,
+			Loss:     *link.Loss,
+			Baseline: *link.Baseline,
+			Margin:   link.Margin,
+		})
+	}
+}
+
+// evaluateLink returns the link updated with a reading of A launching and B
+// receiving, and events of the span going over or back under its alarm.
+// The first reading becomes the baseline. A degraded span recovers only
+// within half of the alarm delta, so a span hovering at the alarm does not
+// flap.
+func evaluateLink(link storage.Link, a, b storage.Interface, now time.Time) (storage.Link, []storage.Event) {
+	loss := a.TxPower - b.RxPower
+	link.Loss = &loss
+	link.Margin = nil
+	if b.RxLowAlarm != 0 {
+		margin := b.RxPower - b.RxLowAlarm
+		link.Margin = &margin
+	}
+	link.Updated = now
+
+	if link.Baseline == nil {
+		link.Baseline = &loss
+		link.Degraded = false
+
+		return link, nil
+	}
+
+	baseline := *link.Baseline
+	event := storage.Event{DeviceID: link.BDeviceID, Interface: link.BInterface}
+	switch {
+	case !link.Degraded && loss > baseline+link.AlarmDelta:
+		link.Degraded = true
+		event.Kind = storage.EventSpanDegraded
+		event.Message = fmt.Sprintf("span loss of link %s rose to %.2f dB, %.2f dB over the baseline of %.2f dB",
+			link.Name, loss, loss-baseline, baseline)
+	case link.Degraded && loss < baseline+link.AlarmDelta/2:
+		link.Degraded = false
+		event.Kind = storage.EventSpanRecovered
+		event.Message = fmt.Sprintf("span loss of link %s fell to %.2f dB, %.2f dB over the baseline of %.2f dB",
+			link.Name, loss, loss-baseline, baseline)
+	default:
+		return link, nil
+	}
+
+	if link.Margin != nil {
+		event.Message += fmt.Sprintf(", %.2f dB over the Rx low alarm", *link.Margin)
+	}
+
+	return link, []storage.Event{event}
+}
@@ -0,0 +1,139 @@
+package monitor
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	gocmp "github.com/google/go-cmp/cmp"
+
+	"pi-wegrzyn/ems/storage"
+)
+
+func TestEeprom_PowerLowAlarms(t *testing.T) {
+	eeprom := Eeprom(testEEPROM())
+	if tx, rx := eeprom.TxPowerLowAlarm(), eeprom.RxPowerLowAlarm(); tx != 0 || rx != 0 {
+		t.Errorf("expected unset thresholds, got %v, %v", tx, rx)
+	}
+
+	// 100 * 0.1 µW is -20 dBm, 1000 * 0.1 µW is -10 dBm.
+	eeprom[Page02hRxPwrLowAlarm], eeprom[Page02hRxPwrLowAlarm+1] = 0x00, 0x64
+	eeprom[Page02hTxPwrLowAlarm], eeprom[Page02hTxPwrLowAlarm+1] = 0x03, 0xE8
+	if got := eeprom.RxPowerLowAlarm(); math.Abs(got+20) > 1e-9 {
+		t.Errorf("expected Rx threshold of -20 dBm, got %v", got)
+	}
+	if got := eeprom.TxPowerLowAlarm(); math.Abs(got+10) > 1e-9 {
+		t.Errorf("expected Tx threshold of -10 dBm, got %v", got)
+	}
+}
+
+func TestMeasuredPower(t *testing.T) {
+	for in, want := range map[float64]float64{-3: -3, 1.5: 1.5, math.Inf(-1): minPower, -55: minPower} {
+		if got := measuredPower(in); got != want {
+			t.Errorf("measuredPower(%v) = %v, want %v", in, got, want)
+		}
+	}
+}
+
+func TestEvaluateLink(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	ptr := func(f float64) *float64 { return &f }
+
+	a := storage.Interface{DeviceID: 1, Name: "eth1", TxPower: 1}
+	b := func(rx float64) storage.Interface {
+		return storage.Interface{DeviceID: 2, Name: "eth1", RxPower: rx, RxLowAlarm: -20}
+	}
+	link := storage.Link{ID: 1, Name: "a-b", ADeviceID: 1, AInterface: "eth1", BDeviceID: 2, BInterface: "eth1", AlarmDelta: 3}
+	healthy := link
+	healthy.Baseline = ptr(4.5)
+
+	degraded := healthy
+	degraded.Degraded = true
+
+	tests := []struct {
+		name       string
+		link       storage.Link
+		b          storage.Interface
+		want       storage.Link
+		wantEvents []storage.Event
+	}{
+		{
+			name: "first reading sets baseline",
+			link: link,
+			b:    b(-3.5),
+			want: storage.Link{
+				ID: 1, Name: "a-b", ADeviceID: 1, AInterface: "eth1", BDeviceID: 2, BInterface: "eth1", AlarmDelta: 3,
+				Baseline: ptr(4.5), Loss: ptr(4.5), Margin: ptr(16.5), Updated: now,
+			},
+		},
+		{
+			name: "within delta",
+			link: healthy,
+			b:    b(-6.5),
+			want: storage.Link{
+				ID: 1, Name: "a-b", ADeviceID: 1, AInterface: "eth1", BDeviceID: 2, BInterface: "eth1", AlarmDelta: 3,
+				Baseline: ptr(4.5), Loss: ptr(7.5), Margin: ptr(13.5), Updated: now,
+			},
+		},
+		{
+			name: "degraded",
+			link: healthy,
+			b:    b(-7),
+			want: storage.Link{
+				ID: 1, Name: "a-b", ADeviceID: 1, AInterface: "eth1", BDeviceID: 2, BInterface: "eth1", AlarmDelta: 3,
+				Baseline: ptr(4.5), Loss: ptr(8), Margin: ptr(13), Degraded: true, Updated: now,
+			},
+			wantEvents: []storage.Event{{
+				DeviceID: 2, Interface: "eth1", Kind: storage.EventSpanDegraded,
+				Message: "span loss of link a-b rose to 8.00 dB, 3.50 dB over the baseline of 4.50 dB, 13.00 dB over the Rx low alarm",
+			}},
+		},
+		{
+			name: "still degraded within hysteresis",
+			link: degraded,
+			b:    b(-5),
+			want: storage.Link{
+				ID: 1, Name: "a-b", ADeviceID: 1, AInterface: "eth1", BDeviceID: 2, BInterface: "eth1", AlarmDelta: 3,
+				Baseline: ptr(4.5), Loss: ptr(6), Margin: ptr(15), Degraded: true, Updated: now,
+			},
+		},
+		{
+			name: "recovered",
+			link: degraded,
+			b:    b(-4),
+			want: storage.Link{
+				ID: 1, Name: "a-b", ADeviceID: 1, AInterface: "eth1", BDeviceID: 2, BInterface: "eth1", AlarmDelta: 3,
+				Baseline: ptr(4.5), Loss: ptr(5), Margin: ptr(16), Updated: now,
+			},
+			wantEvents: []storage.Event{{
+				DeviceID: 2, Interface: "eth1", Kind: storage.EventSpanRecovered,
+				Message: "span loss of link a-b fell to 5.00 dB, 0.50 dB over the baseline of 4.50 dB, 16.00 dB over the Rx low alarm",
+			}},
+		},
+		{
+			name: "no light without threshold",
+			link: healthy,
+			b:    storage.Interface{DeviceID: 2, Name: "eth1", RxPower: minPower},
+			want: storage.Link{
+				ID: 1, Name: "a-b", ADeviceID: 1, AInterface: "eth1", BDeviceID: 2, BInterface: "eth1", AlarmDelta: 3,
+				Baseline: ptr(4.5), Loss: ptr(41), Degraded: true, Updated: now,
+			},
+			wantEvents: []storage.Event{{
+				DeviceID: 2, Interface: "eth1", Kind: storage.EventSpanDegraded,
+				Message: "span loss of link a-b rose to 41.00 dB, 36.50 dB over the baseline of 4.50 dB",
+			}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, events := evaluateLink(tt.link, a, tt.b, now)
+			if diff := gocmp.Diff(got, tt.want); diff != "" {
+				t.Errorf("link mismatch (-got +want):\n%s", diff)
+			}
+			if diff := gocmp.Diff(events, tt.wantEvents); diff != "" {
+				t.Errorf("events mismatch (-got +want):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,121 @@
+package monitor
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+
+	"pi-wegrzyn/ems/storage"
+)
+
+// eepromPages are upper pages of the EEPROM layout following the lower
+// page, in the order Eeprom offsets expect.
+var eepromPages = []byte{0x00, 0x01, 0x02, 0x04, 0x11, 0x12, 0x25}
+
+// localDevice reads modules of the host running EMS, so it does not have to
+// connect to itself over SSH. EEPROM goes through the decoder like show
+// command output.
+type localDevice struct {
+	remoteDevice
+	readModule func(iface string) ([]byte, error)
+}
+
+func newLocalDevice(dev storage.Device, decoder Decoder) localDevice {
+	d := localDevice{remoteDevice: newRemoteDevice(dev, storage.Auth{}, decoder), readModule: readModuleEEPROM}
+	if dev.Local.Path != "" {
+		d.readModule = d.readDump
+	}
+
+	return d
+}
+
+// readDump reads a raw EEPROM dump named after iface, like files of EEPROM
+// Generator.
+func (d localDevice) readDump(iface string) ([]byte, error) {
+	return os.ReadFile(filepath.Join(d.Local.Path, iface))
+}
+
+// getInterfaces returns configured interfaces, or all of them: dumps in the
+// path or interfaces of the host.
+func (d localDevice) getInterfaces() ([]string, error) {
+	if len(d.Local.Interfaces) != 0 {
+		return d.Local.Interfaces, nil
+	}
+
+	var names []string
+	if d.Local.Path != "" {
+		entries, err := os.ReadDir(d.Local.Path)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, entry := range entries {
+			if entry.Type().IsRegular() {
+				names = append(names, entry.Name())
+			}
+		}
+
+		return names, nil
+	}
+
+	interfaces, err := net.Interfaces()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, iface := range interfaces {
+		if iface.Flags&net.FlagLoopback == 0 {
+			names = append(names, iface.Name)
+		}
+	}
+
+	return names, nil
+}
+
+// monitorInterfaces reads every interface, those found without a module are
+// skipped unless they were configured.
+func (d localDevice) monitorInterfaces(interfaces []string) (measurements []interfaceMeasurement, err error) {
+	for _, inf := range interfaces {
+		eeprom, err2 := d.readModule(inf)
+		if err2 != nil {
+			if len(d.Local.Interfaces) == 0 && errNoModule(err2) {
+				continue
+			}
+			err = errors.Join(err, fmt.Errorf("%v (interface: %s)", err2, inf))
+			continue
+		}
+
+		dump := hexDump(eeprom)
+		if d.capture != nil {
+			d.capture(inf, dump)
+		}
+
+		ifData, err2 := d.processData(dump)
+		if err2 != nil {
+			err = errors.Join(err, fmt.Errorf("%v (interface: %s)", err2, inf))
+			continue
+		}
+
+		measurements = append(measurements, interfaceMeasurement{
+			Measurement: ifData,
+			Interface:   inf,
+		})
+	}
+
+	return measurements, err
+}
+
+// hexDump formats eeprom like the show-eeprom command, 16 bytes in hex per
+// line.
+func hexDump(eeprom []byte) []byte {
+	var dump []byte
+	for i := 0; i < len(eeprom); i += 16 {
+		dump = hex.AppendEncode(dump, eeprom[i:min(i+16, len(eeprom))])
+		dump = append(dump, '\n')
+	}
+
+	return dump
+}
@@ -0,0 +1,159 @@
+package monitor
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"pi-wegrzyn/ems/influx"
+	"pi-wegrzyn/ems/storage"
+
+	gocmp "github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+// testEEPROM returns an EEPROM layout of a ready module with data paths of
+// lanes 1-4 activated, 35.5 °C, 3.3 V, 1 mW Tx power, 0.5 mW Rx power and
+// 21.5 dB OSNR.
+func testEEPROM() []byte {
+	eeprom := make([]byte, EepromLength)
+	eeprom[PageLowModuleState] = 0x07
+	eeprom[Page11hDPState], eeprom[Page11hDPState+1] = 0x44, 0x44
+	eeprom[PageLowTemp], eeprom[PageLowTemp+1] = 0x03, 0x8C
+	eeprom[PageLowVcc], eeprom[PageLowVcc+1] = 0x80, 0xE8
+	eeprom[Page11hTxPwr], eeprom[Page11hTxPwr+1] = 0x27, 0x10
+	eeprom[Page11hRxPwr], eeprom[Page11hRxPwr+1] = 0x13, 0x88
+	eeprom[Page25hOsnr], eeprom[Page25hOsnr+1] = 0x00, 0xD7
+
+	return eeprom
+}
+
+func TestLocalDevice_monitorInterfaces(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "eth0"), testEEPROM(), 0o600); err != nil {
+		t.Fatalf("cannot write dump: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "eth1"), testEEPROM()[:PageLength], 0o600); err != nil {
+		t.Fatalf("cannot write dump: %v", err)
+	}
+
+	want := interfaceMeasurement{Interface: "eth0", Measurement: influx.Measurement{
+		Temperature:    35.46875,
+		Voltage:        3.3,
+		TxPower:        0,
+		RxPower:        -3.0103,
+		OSNR:           21.5,
+		ModuleState:    uint8(ModuleReady),
+		DataPathStates: []uint8{4, 4, 4, 4, 0, 0, 0, 0},
+	}}
+
+	tests := []struct {
+		name       string
+		interfaces []string
+		want       []interfaceMeasurement
+		wantErr    []string
+	}{
+		{
+			name:    "all dumps",
+			want:    []interfaceMeasurement{want},
+			wantErr: []string{"expected 1024 bytes of EEPROM, got 128 (interface: eth1)"},
+		},
+		{
+			name:       "configured interfaces",
+			interfaces: []string{"eth0", "eth2"},
+			want:       []interfaceMeasurement{want},
+			wantErr:    []string{"(interface: eth2)"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			d := newLocalDevice(storage.Device{Collector: storage.CollectorLocal, Local: storage.Local{Interfaces: tc.interfaces, Path: dir}}, DefaultDecoder())
+
+			interfaces, err := d.getInterfaces()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			got, err := d.monitorInterfaces(interfaces)
+			for _, wantErr := range tc.wantErr {
+				if err == nil || !strings.Contains(err.Error(), wantErr) {
+					t.Errorf("expected error with %q, got %v", wantErr, err)
+				}
+			}
+
+			if diff := gocmp.Diff(got, tc.want, cmpopts.EquateApprox(0, 1e-4)); diff != "" {
+				t.Errorf("measurements mismatch (-got +want):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestHexDump(t *testing.T) {
+	eeprom := testEEPROM()
+
+	decoded, err := DefaultDecoder()(hexDump(eeprom))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if diff := gocmp.Diff([]byte(decoded), eeprom); diff != "" {
+		t.Errorf("EEPROM mismatch (-got +want):\n%s", diff)
+	}
+}
+
+func TestLocalDevice_capture(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "eth0"), testEEPROM(), 0o600); err != nil {
+		t.Fatalf("cannot write dump: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "eth1"), testEEPROM()[:16], 0o600); err != nil {
+		t.Fatalf("cannot write dump: %v", err)
+	}
+
+	d := newLocalDevice(storage.Device{Collector: storage.CollectorLocal, Local: storage.Local{Path: dir}}, DefaultDecoder())
+
+	captured := make(map[string][]byte)
+	d.capture = func(iface string, raw []byte) { captured[iface] = raw }
+
+	if _, err := d.monitorInterfaces([]string{"eth0", "eth1"}); err == nil {
+		t.Error("expected error for the short dump")
+	}
+
+	// Dumps which cannot be decoded are the ones worth capturing.
+	want := map[string][]byte{"eth0": hexDump(testEEPROM()), "eth1": hexDump(testEEPROM()[:16])}
+	if diff := gocmp.Diff(captured, want); diff != "" {
+		t.Errorf("captures mismatch (-got +want):\n%s", diff)
+	}
+}
+
+func TestMeasure_laser(t *testing.T) {
+	eeprom := testEEPROM()
+
+	m, err := Measure(DefaultDecoder(), hexDump(eeprom))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if m.Laser != nil {
+		t.Errorf("expected no laser state of a fixed module, got %+v", m.Laser)
+	}
+
+	eeprom[Page01hTxTunable] = 0x40
+	eeprom[Page04hProgPwrMin], eeprom[Page04hProgPwrMin+1] = 0xF6, 0x3C // -25 dBm
+	eeprom[Page04hProgPwrMax], eeprom[Page04hProgPwrMax+1] = 0x00, 0x64 // 1 dBm
+	eeprom[Page12hGridSpacing] = 0x40                                   // 50 GHz
+	eeprom[Page12hChannel], eeprom[Page12hChannel+1] = 0xFF, 0xFE       // -2
+	eeprom[Page12hLaserFreq], eeprom[Page12hLaserFreq+1], eeprom[Page12hLaserFreq+2], eeprom[Page12hLaserFreq+3] = 0x0B, 0x80, 0xF2, 0x40
+	eeprom[Page12hTargetOutPwr], eeprom[Page12hTargetOutPwr+1] = 0xFC, 0x18 // -10 dBm
+
+	m, err = Measure(DefaultDecoder(), hexDump(eeprom))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := &influx.Laser{Frequency: 193.0, Wavelength: 1553.33, Channel: -2, GridSpacing: 50, TargetPower: -10, MinPower: -25, MaxPower: 1}
+	if diff := gocmp.Diff(m.Laser, want, cmpopts.EquateApprox(0, 0.005)); diff != "" {
+		t.Errorf("laser mismatch (-got +want):\n%s", diff)
+	}
+}
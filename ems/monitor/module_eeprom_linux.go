@@ -0,0 +1,232 @@
+//go:build linux
+
+package monitor
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+// Attributes of ETHTOOL_MSG_MODULE_EEPROM_GET, from linux/ethtool_netlink.h.
+const (
+	ethtoolAModuleEEPROMHeader     = 1
+	ethtoolAModuleEEPROMOffset     = 2
+	ethtoolAModuleEEPROMLength     = 3
+	ethtoolAModuleEEPROMPage       = 4
+	ethtoolAModuleEEPROMBank       = 5
+	ethtoolAModuleEEPROMI2CAddress = 6
+	ethtoolAModuleEEPROMData       = 7
+
+	moduleI2CAddress = 0x50
+)
+
+// readModuleEEPROM reads the EEPROM layout of the module plugged into iface
+// through the ethtool netlink API, page by page as `ethtool -m` does.
+func readModuleEEPROM(iface string) ([]byte, error) {
+	conn, err := dialGenericNetlink()
+	if err != nil {
+		return nil, err
+	}
+	defer conn.close()
+
+	family, err := conn.family(unix.ETHTOOL_GENL_NAME)
+	if err != nil {
+		return nil, fmt.Errorf("ethtool netlink family: %w", err)
+	}
+
+	eeprom := make([]byte, 0, VdmEepromLength)
+
+	lower, err := conn.modulePage(family, iface, 0, 0)
+	if err != nil {
+		return nil, err
+	}
+	eeprom = append(eeprom, lower...)
+
+	for _, page := range eepromPages {
+		upper, err := conn.modulePage(family, iface, uint32(PageLength), page)
+		if err != nil {
+			return nil, fmt.Errorf("page %02Xh: %w", page, err)
+		}
+		eeprom = append(eeprom, upper...)
+	}
+
+	if !Eeprom(eeprom).advertisesVdm() {
+		return eeprom, nil
+	}
+
+	// Modules with fewer VDM groups have no pages of the others, their
+	// descriptors are left empty.
+	for _, page := range vdmPages {
+		upper, err := conn.modulePage(family, iface, uint32(PageLength), page)
+		if err != nil {
+			upper = make([]byte, PageLength)
+		}
+		eeprom = append(eeprom, upper...)
+	}
+
+	return eeprom, nil
+}
+
+// errNoModule tells that the interface has no module to read, e.g. a
+// virtual or copper one.
+func errNoModule(err error) bool {
+	return errors.Is(err, unix.EOPNOTSUPP) || errors.Is(err, unix.ENODEV) || errors.Is(err, unix.EIO)
+}
+
+type genericNetlink struct {
+	fd  int
+	seq uint32
+}
+
+func dialGenericNetlink() (*genericNetlink, error) {
+	fd, err := unix.Socket(unix.AF_NETLINK, unix.SOCK_RAW|unix.SOCK_CLOEXEC, unix.NETLINK_GENERIC)
+	if err != nil {
+		return nil, os.NewSyscallError("socket", err)
+	}
+
+	if err := unix.Bind(fd, &unix.SockaddrNetlink{Family: unix.AF_NETLINK}); err != nil {
+		_ = unix.Close(fd)
+		return nil, os.NewSyscallError("bind", err)
+	}
+
+	return &genericNetlink{fd: fd}, nil
+}
+
+func (c *genericNetlink) close() {
+	_ = unix.Close(c.fd)
+}
+
+func (c *genericNetlink) family(name string) (uint16, error) {
+	attrs, err := c.request(unix.GENL_ID_CTRL, unix.CTRL_CMD_GETFAMILY, 1, netlinkAttr(unix.CTRL_ATTR_FAMILY_NAME, append([]byte(name), 0)))
+	if err != nil {
+		return 0, err
+	}
+
+	id, ok := parseNetlinkAttrs(attrs)[unix.CTRL_ATTR_FAMILY_ID]
+	if !ok || len(id) < 2 {
+		return 0, errors.New("no family ID in reply")
+	}
+
+	return binary.NativeEndian.Uint16(id), nil
+}
+
+// modulePage reads 128 bytes at offset, the lower page at 0 or the upper
+// page at 128.
+func (c *genericNetlink) modulePage(family uint16, iface string, offset uint32, page byte) ([]byte, error) {
+	var request []byte
+	request = append(request, netlinkAttr(ethtoolAModuleEEPROMHeader|unix.NLA_F_NESTED, netlinkAttr(unix.ETHTOOL_A_HEADER_DEV_NAME, append([]byte(iface), 0)))...)
+	request = append(request, netlinkAttr(ethtoolAModuleEEPROMOffset, binary.NativeEndian.AppendUint32(nil, offset))...)
+	request = append(request, netlinkAttr(ethtoolAModuleEEPROMLength, binary.NativeEndian.AppendUint32(nil, uint32(PageLength)))...)
+	request = append(request, netlinkAttr(ethtoolAModuleEEPROMPage, []byte{page})...)
+	request = append(request, netlinkAttr(ethtoolAModuleEEPROMBank, []byte{0})...)
+	request = append(request, netlinkAttr(ethtoolAModuleEEPROMI2CAddress, []byte{moduleI2CAddress})...)
+
+	attrs, err := c.request(family, unix.ETHTOOL_MSG_MODULE_EEPROM_GET, unix.ETHTOOL_GENL_VERSION, request)
+	if err != nil {
+		return nil, err
+	}
+
+	data := parseNetlinkAttrs(attrs)[ethtoolAModuleEEPROMData]
+	if len(data) != PageLength {
+		return nil, fmt.Errorf("expected %d bytes of EEPROM, got %d", PageLength, len(data))
+	}
+
+	return data, nil
+}
+
+// request sends a generic netlink message and returns attributes of the
+// reply.
+func (c *genericNetlink) request(msgType uint16, cmd, version uint8, attrs []byte) ([]byte, error) {
+	c.seq++
+
+	msg := make([]byte, unix.NLMSG_HDRLEN, unix.NLMSG_HDRLEN+4+len(attrs))
+	binary.NativeEndian.PutUint32(msg[0:4], uint32(unix.NLMSG_HDRLEN+4+len(attrs)))
+	binary.NativeEndian.PutUint16(msg[4:6], msgType)
+	binary.NativeEndian.PutUint16(msg[6:8], unix.NLM_F_REQUEST)
+	binary.NativeEndian.PutUint32(msg[8:12], c.seq)
+	msg = append(msg, cmd, version, 0, 0)
+	msg = append(msg, attrs...)
+
+	if err := unix.Sendto(c.fd, msg, 0, &unix.SockaddrNetlink{Family: unix.AF_NETLINK}); err != nil {
+		return nil, os.NewSyscallError("sendto", err)
+	}
+
+	buf := make([]byte, os.Getpagesize()*4)
+	for {
+		n, _, err := unix.Recvfrom(c.fd, buf, 0)
+		if err != nil {
+			return nil, os.NewSyscallError("recvfrom", err)
+		}
+
+		for data := buf[:n]; len(data) >= unix.NLMSG_HDRLEN; {
+			length := int(binary.NativeEndian.Uint32(data[0:4]))
+			if length < unix.NLMSG_HDRLEN || length > len(data) {
+				return nil, errors.New("malformed netlink message")
+			}
+
+			msgType := binary.NativeEndian.Uint16(data[4:6])
+			seq := binary.NativeEndian.Uint32(data[8:12])
+			payload := data[unix.NLMSG_HDRLEN:length]
+			data = data[min(netlinkAlign(length), len(data)):]
+
+			if seq != c.seq {
+				continue
+			}
+
+			if msgType == unix.NLMSG_ERROR {
+				if len(payload) < 4 {
+					return nil, errors.New("malformed netlink error")
+				}
+				if errno := int32(binary.NativeEndian.Uint32(payload[0:4])); errno != 0 {
+					return nil, unix.Errno(-errno)
+				}
+				return nil, nil
+			}
+
+			if len(payload) < 4 {
+				return nil, errors.New("malformed generic netlink message")
+			}
+
+			return payload[4:], nil
+		}
+	}
+}
+
+func netlinkAlign(n int) int {
+	return (n + unix.NLA_ALIGNTO - 1) &^ (unix.NLA_ALIGNTO - 1)
+}
+
+func netlinkAttr(attrType uint16, data []byte) []byte {
+	attr := make([]byte, 4, netlinkAlign(4+len(data)))
+	binary.NativeEndian.PutUint16(attr[0:2], uint16(4+len(data)))
+	binary.NativeEndian.PutUint16(attr[2:4], attrType)
+	attr = append(attr, data...)
+
+	return append(attr, make([]byte, cap(attr)-len(attr))...)
+}
+
+// parseNetlinkAttrs returns payloads of attributes by type, without the
+// nested and byte order flags.
+func parseNetlinkAttrs(data []byte) map[uint16][]byte {
+	attrs := make(map[uint16][]byte)
+	for len(data) >= 4 {
+		length := int(binary.NativeEndian.Uint16(data[0:2]))
+		if length < 4 || length > len(data) {
+			break
+		}
+
+		attrType := binary.NativeEndian.Uint16(data[2:4]) &^ (unix.NLA_F_NESTED | unix.NLA_F_NET_BYTEORDER)
+		attrs[attrType] = data[4:length]
+
+		if netlinkAlign(length) >= len(data) {
+			break
+		}
+		data = data[netlinkAlign(length):]
+	}
+
+	return attrs
+}
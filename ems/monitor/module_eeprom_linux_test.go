@@ -0,0 +1,37 @@
+//go:build linux
+
+package monitor
+
+import (
+	"testing"
+
+	gocmp "github.com/google/go-cmp/cmp"
+	"golang.org/x/sys/unix"
+)
+
+func TestNetlinkAttrs(t *testing.T) {
+	var data []byte
+	data = append(data, netlinkAttr(ethtoolAModuleEEPROMHeader|unix.NLA_F_NESTED, netlinkAttr(unix.ETHTOOL_A_HEADER_DEV_NAME, []byte("eth0\x00")))...)
+	data = append(data, netlinkAttr(ethtoolAModuleEEPROMPage, []byte{0x11})...)
+
+	if len(data)%unix.NLA_ALIGNTO != 0 {
+		t.Errorf("expected aligned attributes, got %d bytes", len(data))
+	}
+
+	attrs := parseNetlinkAttrs(data)
+	if diff := gocmp.Diff(attrs[ethtoolAModuleEEPROMPage], []byte{0x11}); diff != "" {
+		t.Errorf("page mismatch (-got +want):\n%s", diff)
+	}
+
+	header := parseNetlinkAttrs(attrs[ethtoolAModuleEEPROMHeader])
+	if got := string(header[unix.ETHTOOL_A_HEADER_DEV_NAME]); got != "eth0\x00" {
+		t.Errorf("expected eth0 in header, got %q", got)
+	}
+}
+
+func TestReadModuleEEPROM_noModule(t *testing.T) {
+	// The loopback interface never has a module.
+	if _, err := readModuleEEPROM("lo"); err == nil {
+		t.Error("expected error")
+	}
+}
@@ -0,0 +1,15 @@
+//go:build !linux
+
+package monitor
+
+import "errors"
+
+// readModuleEEPROM needs the ethtool netlink API of Linux, dumps from a
+// path work everywhere.
+func readModuleEEPROM(string) ([]byte, error) {
+	return nil, errors.New("reading module EEPROM is supported on Linux only")
+}
+
+func errNoModule(error) bool {
+	return false
+}
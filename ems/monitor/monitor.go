@@ -12,22 +12,58 @@ import (
 )
 
 type Config struct {
-	SleepTime      int `envconfig:"MONITOR_SLEEP_TIME_SECONDS" default:"30"`
-	SSHTimeout     int `envconfig:"MONITOR_SSH_TIMEOUT_SECONDS" default:"10"`
-	MaxConcurrency int `envconfig:"MONITOR_MAX_CONCURRENCY" default:"10"`
+	SleepTime   int `envconfig:"MONITOR_SLEEP_TIME_SECONDS" default:"30"`
+	SSHTimeout  int `envconfig:"MONITOR_SSH_TIMEOUT_SECONDS" default:"10"`
+	SNMPTimeout int `envconfig:"MONITOR_SNMP_TIMEOUT_SECONDS" default:"5"`
+	GNMITimeout int `envconfig:"MONITOR_GNMI_TIMEOUT_SECONDS" default:"10"`
+	// NETCONFMappings is a directory of platform mappings overriding and
+	// extending the built-in ones.
+	NETCONFMappings string `envconfig:"MONITOR_NETCONF_MAPPINGS_DIR"`
+	// RawCaptures is the number of raw EEPROM dumps kept per interface, the
+	// latest one feeds the EEPROM inspector. 0 turns capturing off.
+	RawCaptures int `envconfig:"MONITOR_RAW_CAPTURES" default:"1"`
+	// TrendDrift is the drift of powers and OSNR in dB per week reported as
+	// a slow degradation, TrendHorizon the number of days ahead alarm
+	// threshold crossings are forecast.
+	TrendDrift     float64 `envconfig:"MONITOR_TREND_DRIFT_DB_PER_WEEK" default:"0.5"`
+	TrendHorizon   float64 `envconfig:"MONITOR_TREND_FORECAST_DAYS" default:"30"`
+	MaxConcurrency int     `envconfig:"MONITOR_MAX_CONCURRENCY" default:"10"`
+	AgentSocket    string  `envconfig:"SSH_AUTH_SOCK"`
 }
 
 type Monitor struct {
 	config Config
 	db     *storage.DB
 	influx *influx.Client
+	// gnmi keeps gNMI subscriptions open between runs.
+	gnmi *gnmiPool
 }
 
 func New(cfg Config, db *storage.DB, influx *influx.Client) *Monitor {
-	return &Monitor{config: cfg, db: db, influx: influx}
+	return &Monitor{config: cfg, db: db, influx: influx, gnmi: newGNMIPool()}
+}
+
+// monitoringRun is read once per run and shared by all devices polled in it.
+type monitoringRun struct {
+	rules []storage.Rule
+	// maintenance holds open windows of devices in maintenance.
+	maintenance map[uint]storage.MaintenanceWindow
+}
+
+// inMaintenance tells whether the device is in an open maintenance window,
+// its events are then not recorded.
+func (r monitoringRun) inMaintenance(deviceID uint) bool {
+	_, ok := r.maintenance[deviceID]
+
+	return ok
 }
 
 func (m *Monitor) Run(ctx context.Context) error {
+	defer m.gnmi.close()
+
+	// starts are start times of the last staleRuns runs, however long they
+	// took.
+	var starts []time.Time
 	for {
 		slog.InfoContext(ctx, fmt.Sprintf("waiting %d seconds", m.config.SleepTime))
 		time.Sleep(time.Duration(m.config.SleepTime) * time.Second)
@@ -40,6 +76,11 @@ func (m *Monitor) Run(ctx context.Context) error {
 
 		slog.InfoContext(ctx, "starting monitoring")
 
+		starts = append(starts, time.Now())
+		if len(starts) > staleRuns {
+			starts = starts[1:]
+		}
+
 		devices, err := m.db.Devices(ctx)
 		if err != nil {
 			slog.ErrorContext(ctx, "error while getting devices", slog.Any("error", err))
@@ -47,6 +88,54 @@ func (m *Monitor) Run(ctx context.Context) error {
 			continue
 		}
 
+		// Profiles are read on every run, so a rotated profile is used by
+		// all its devices on the next poll.
+		credentials, err := m.db.Credentials(ctx)
+		if err != nil {
+			slog.ErrorContext(ctx, "error while getting credentials", slog.Any("error", err))
+
+			continue
+		}
+
+		profiles := make(map[uint]*storage.Credential, len(credentials))
+		for _, c := range credentials {
+			profiles[c.ID] = &c
+		}
+
+		jumpHosts, err := m.db.JumpHosts(ctx)
+		if err != nil {
+			slog.ErrorContext(ctx, "error while getting jump hosts", slog.Any("error", err))
+
+			continue
+		}
+
+		windows, err := m.db.MaintenanceWindows(ctx)
+		if err != nil {
+			slog.ErrorContext(ctx, "error while getting maintenance windows", slog.Any("error", err))
+
+			continue
+		}
+
+		rules, err := m.db.Rules(ctx)
+		if err != nil {
+			slog.ErrorContext(ctx, "error while getting rules", slog.Any("error", err))
+
+			continue
+		}
+
+		now := time.Now()
+		maintenance := make(map[uint]storage.MaintenanceWindow)
+		for _, d := range devices {
+			if w, ok := storage.Maintenance(windows, d, now); ok {
+				maintenance[d.ID] = w
+			}
+		}
+		run := monitoringRun{rules: rules, maintenance: maintenance}
+
+		// Devices behind the same jump host share its connection for the
+		// whole run.
+		jumps := newJumpPool(jumpHosts, profiles, m.config.SSHTimeout, m.config.AgentSocket)
+
 		streamDevices := make(chan storage.Device)
 
 		wg := sync.WaitGroup{}
@@ -54,9 +143,42 @@ func (m *Monitor) Run(ctx context.Context) error {
 		for range m.config.MaxConcurrency {
 			go func() {
 				for d := range streamDevices {
-					remoteDev := newRemoteDevice(d, DefaultDecoder())
+					window, inMaintenance := run.maintenance[d.ID]
+					if inMaintenance && window.SkipPolling {
+						slog.InfoContext(ctx, "skipping device in maintenance", slog.Any("deviceID", d.ID), slog.String("window", window.Name))
+						if err := m.updateStatus(ctx, &d, storage.StatusMaintenance); err != nil {
+							slog.ErrorContext(ctx, "error while updating device", slog.Any("deviceID", d.ID), slog.Any("status", storage.StatusMaintenance))
+						}
+
+						continue
+					}
+
+					var status int8
+					switch d.Collector {
+					case storage.CollectorSNMP:
+						status = m.monitorSNMPDevice(ctx, run, newSNMPDevice(d))
+					case storage.CollectorLocal:
+						status = m.monitorLocalDevice(ctx, run, newLocalDevice(d, DefaultDecoder()))
+					case storage.CollectorNETCONF:
+						status = m.monitorNETCONFDevice(ctx, run, newNETCONFDevice(d, d.Auth(profiles[d.CredentialID])), jumps)
+					case storage.CollectorGNMI:
+						status = m.monitorGNMIDevice(ctx, run, newGNMIDevice(d, d.Auth(profiles[d.CredentialID])))
+					default:
+						remoteDev := newRemoteDevice(d, d.Auth(profiles[d.CredentialID]), DefaultDecoder())
+
+						status = m.monitorDevice(ctx, run, remoteDev, jumps)
+					}
 
-					status := m.monitorDevice(ctx, remoteDev)
+					// Planned work is not an outage, failures within a
+					// window neither mark the device nor count against
+					// its availability.
+					if inMaintenance {
+						if !available(status) {
+							status = storage.StatusMaintenance
+						}
+					} else {
+						m.influx.InsertAvailability(d.Hostname, influx.Labels{Site: d.Site, Group: d.Group, Tags: d.Tags}, available(status))
+					}
 
 					if err := m.updateStatus(ctx, &d, status); err != nil {
 						slog.ErrorContext(ctx, "error while updating device", slog.Any("deviceID", d.ID), slog.Any("status", status))
@@ -73,22 +195,43 @@ func (m *Monitor) Run(ctx context.Context) error {
 		close(streamDevices)
 
 		wg.Wait()
+		jumps.close()
+
+		gnmiDevices := make(map[uint]bool)
+		for _, d := range devices {
+			if d.Collector == storage.CollectorGNMI && !maintenance[d.ID].SkipPolling {
+				gnmiDevices[d.ID] = true
+			}
+		}
+		m.gnmi.retain(gnmiDevices)
+
+		m.updateLinks(ctx, run, devices, starts[0])
 
 		slog.InfoContext(ctx, "finished monitoring")
 	}
 }
 
-func (m Monitor) monitorDevice(ctx context.Context, d remoteDevice) (status int8) {
+func (m Monitor) monitorDevice(ctx context.Context, run monitoringRun, d remoteDevice, jumps *jumpPool) (status int8) {
 	slog.InfoContext(ctx, "started device monitoring", slog.Any("deviceID", d.ID))
 
-	auth, err := d.auth()
+	d.capture = m.capture(ctx, d.ID)
+
+	auth, closeAgent, err := d.auth(m.config.AgentSocket)
 	if err != nil {
-		slog.ErrorContext(ctx, "cannot parse key", slog.Any("deviceID", d.ID), slog.Any("error", err))
+		slog.ErrorContext(ctx, "cannot prepare SSH authentication", slog.Any("deviceID", d.ID), slog.Any("error", err))
 
 		return storage.StatusErrorKeyfile
 	}
+	defer closeAgent()
+
+	via, err := jumps.via(d.JumpHostID)
+	if err != nil {
+		slog.ErrorContext(ctx, "cannot connect jump host", slog.Any("deviceID", d.ID), slog.Any("error", err))
+
+		return storage.StatusErrorSSH
+	}
 
-	client, err := d.sshClient(auth, m.config.SSHTimeout)
+	client, err := d.sshClient(via, auth, m.config.SSHTimeout)
 	if err != nil {
 		slog.ErrorContext(ctx, "SSH client error", slog.Any("deviceID", d.ID), slog.Any("error", err))
 
@@ -111,18 +254,140 @@ func (m Monitor) monitorDevice(ctx context.Context, d remoteDevice) (status int8
 
 	slog.DebugContext(ctx, "detected interfaces", slog.Any("deviceID", d.ID), slog.Int("interfaces", len(interfaces)))
 
+	return m.poll(ctx, run, d.Device, d.labels(), func() ([]interfaceMeasurement, error) {
+		return d.monitorInterfaces(client, interfaces)
+	}, storage.StatusErrorSSH)
+}
+
+// monitorSNMPDevice is monitorDevice of devices read over SNMP. Walks are
+// retried like SSH sessions.
+func (m Monitor) monitorSNMPDevice(ctx context.Context, run monitoringRun, d snmpDevice) (status int8) {
+	slog.InfoContext(ctx, "started SNMP device monitoring", slog.Any("deviceID", d.ID))
+
+	client, err := d.client(m.config.SNMPTimeout)
+	if err != nil {
+		slog.ErrorContext(ctx, "cannot prepare SNMP client", slog.Any("deviceID", d.ID), slog.Any("error", err))
+
+		return storage.StatusErrorCollector
+	}
+
+	if err := client.Connect(); err != nil {
+		slog.ErrorContext(ctx, "SNMP client error", slog.Any("deviceID", d.ID), slog.Any("error", err))
+
+		return storage.StatusErrorCollector
+	}
+	defer func() {
+		if err := client.Conn.Close(); err != nil {
+			slog.ErrorContext(ctx, "cannot close SNMP connection", slog.Any("error", err))
+		}
+	}()
+
+	return m.poll(ctx, run, d.Device, d.labels(), func() ([]interfaceMeasurement, error) {
+		return d.monitorInterfaces(client)
+	}, storage.StatusErrorCollector)
+}
+
+// monitorLocalDevice is monitorDevice of modules of the host running EMS.
+func (m Monitor) monitorLocalDevice(ctx context.Context, run monitoringRun, d localDevice) (status int8) {
+	slog.InfoContext(ctx, "started local device monitoring", slog.Any("deviceID", d.ID))
+
+	d.capture = m.capture(ctx, d.ID)
+
+	interfaces, err := d.getInterfaces()
+	if err != nil {
+		slog.ErrorContext(ctx, "error with getting interfaces", slog.Any("deviceID", d.ID), slog.Any("error", err))
+
+		return storage.StatusErrorCollector
+	}
+
+	return m.poll(ctx, run, d.Device, d.labels(), func() ([]interfaceMeasurement, error) {
+		return d.monitorInterfaces(interfaces)
+	}, storage.StatusErrorCollector)
+}
+
+// monitorNETCONFDevice is monitorDevice of devices read over NETCONF. The
+// mapping is read on every run, so edited mapping files apply right away.
+func (m Monitor) monitorNETCONFDevice(ctx context.Context, run monitoringRun, d netconfDevice, jumps *jumpPool) (status int8) {
+	slog.InfoContext(ctx, "started NETCONF device monitoring", slog.Any("deviceID", d.ID))
+
+	mapping, err := loadNETCONFMapping(m.config.NETCONFMappings, d.NETCONF.Platform)
+	if err != nil {
+		slog.ErrorContext(ctx, "cannot load NETCONF mapping", slog.Any("deviceID", d.ID), slog.Any("error", err))
+
+		return storage.StatusErrorCollector
+	}
+
+	auth, closeAgent, err := d.auth(m.config.AgentSocket)
+	if err != nil {
+		slog.ErrorContext(ctx, "cannot prepare SSH authentication", slog.Any("deviceID", d.ID), slog.Any("error", err))
+
+		return storage.StatusErrorKeyfile
+	}
+	defer closeAgent()
+
+	via, err := jumps.via(d.JumpHostID)
+	if err != nil {
+		slog.ErrorContext(ctx, "cannot connect jump host", slog.Any("deviceID", d.ID), slog.Any("error", err))
+
+		return storage.StatusErrorSSH
+	}
+
+	client, err := d.sshClient(via, auth, m.config.SSHTimeout)
+	if err != nil {
+		slog.ErrorContext(ctx, "SSH client error", slog.Any("deviceID", d.ID), slog.Any("error", err))
+
+		return storage.StatusErrorSSH
+	}
+	defer func() {
+		if err := client.Close(); err != nil {
+			slog.ErrorContext(ctx, "cannot close client connection", slog.Any("error", err))
+		}
+	}()
+
+	return m.poll(ctx, run, d.Device, d.labels(), func() ([]interfaceMeasurement, error) {
+		return d.monitorInterfaces(client, mapping)
+	}, storage.StatusErrorCollector)
+}
+
+// monitorGNMIDevice reports the status of the device subscription, which
+// writes measurements on its own as they are streamed, and evaluates the
+// latest measurements streamed since the previous run.
+func (m Monitor) monitorGNMIDevice(ctx context.Context, run monitoringRun, d gnmiDevice) (status int8) {
+	subscription := m.gnmi.subscription(ctx, d, func(measurement interfaceMeasurement) {
+		m.influx.InsertMeasurements(d.Hostname, d.labels(), measurement.Interface, measurement.Measurement)
+	})
+
+	status, err := subscription.status(time.Duration(m.config.GNMITimeout) * time.Second)
+	if err != nil {
+		slog.ErrorContext(ctx, "gNMI subscription error", slog.Any("deviceID", d.ID), slog.Any("error", err))
+	}
+
+	if data := subscription.latest(); len(data) != 0 {
+		m.evaluate(ctx, run, d.Device, data)
+	}
+
+	return status
+}
+
+// poll collects measurements of the device, retrying failed runs up to
+// FailedRunsLimit times, writes them to Influx and evaluates them. It returns
+// errStatus when all runs failed.
+func (m Monitor) poll(ctx context.Context, run monitoringRun, device storage.Device, labels influx.Labels, collect func() ([]interfaceMeasurement, error), errStatus int8) int8 {
 	failedRuns := 0
 	for failedRuns < FailedRunsLimit {
-		data, err := d.monitorInterfaces(client, interfaces)
+		data, err := collect()
 		if err != nil {
-			slog.WarnContext(ctx, "monitoring error", slog.Any("deviceID", d.ID), slog.Any("error", err))
+			slog.WarnContext(ctx, "monitoring error", slog.Any("deviceID", device.ID), slog.Any("error", err))
 			failedRuns += 1
 			continue
 		}
 
+		slog.DebugContext(ctx, "collected measurements", slog.Any("deviceID", device.ID), slog.Int("interfaces", len(data)))
+
 		for _, measurement := range data {
-			m.influx.InsertMeasurements(d.Hostname, measurement.Interface, measurement.Measurement)
+			m.influx.InsertMeasurements(device.Hostname, labels, measurement.Interface, measurement.Measurement)
 		}
+		m.evaluate(ctx, run, device, data)
 
 		break
 	}
@@ -131,13 +396,42 @@ func (m Monitor) monitorDevice(ctx context.Context, d remoteDevice) (status int8
 	case 0:
 		return storage.StatusOK
 	case FailedRunsLimit:
-		slog.WarnContext(ctx, "monitoring failed (error limit exceeded)", slog.Any("deviceID", d.ID))
-		return storage.StatusErrorSSH
+		slog.WarnContext(ctx, "monitoring failed (error limit exceeded)", slog.Any("deviceID", device.ID))
+		return errStatus
 	default:
 		return storage.StatusWarning
 	}
 }
 
+// evaluate follows states and trends of interfaces and checks alert rules
+// against readings written to Influx.
+func (m Monitor) evaluate(ctx context.Context, run monitoringRun, device storage.Device, data []interfaceMeasurement) {
+	m.updateInterfaces(ctx, run, device, data)
+	m.updateTrends(ctx, run, device, data)
+	m.evaluateRules(ctx, run, device, data)
+}
+
+// capture returns a function storing raw EEPROM of the device, nil when
+// captures are off.
+func (m Monitor) capture(ctx context.Context, deviceID uint) func(iface string, raw []byte) {
+	if m.config.RawCaptures <= 0 {
+		return nil
+	}
+
+	return func(iface string, raw []byte) {
+		capture := storage.Capture{DeviceID: deviceID, Interface: iface, Data: raw}
+		if err := m.db.CreateCapture(ctx, capture, m.config.RawCaptures); err != nil {
+			slog.ErrorContext(ctx, "cannot store raw EEPROM", slog.Any("deviceID", deviceID), slog.String("interface", iface), slog.Any("error", err))
+		}
+	}
+}
+
+// available tells whether the device answered the poll, possibly with errors
+// of some interfaces.
+func available(status int8) bool {
+	return status == storage.StatusOK || status == storage.StatusWarning
+}
+
 func (m *Monitor) updateStatus(ctx context.Context, device *storage.Device, status int8) (err error) {
 	device.LastStatus = status
 	if device.LastStatus == storage.StatusOK {
@@ -0,0 +1,449 @@
+package monitor
+
+import (
+	"bufio"
+	"bytes"
+	"embed"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"pi-wegrzyn/ems/influx"
+	"pi-wegrzyn/ems/storage"
+
+	"golang.org/x/crypto/ssh"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	netconfBase10 = "urn:ietf:params:netconf:base:1.0"
+	netconfBase11 = "urn:ietf:params:netconf:base:1.1"
+
+	// netconfEOM ends messages of base:1.0 and hello messages.
+	netconfEOM = "]]>]]>"
+
+	// netconfMaxMessage limits replies, optics state of a full chassis is
+	// far smaller.
+	netconfMaxMessage = 16 << 20
+)
+
+//go:embed netconf/*.yaml
+var netconfMappings embed.FS
+
+// netconfMapping tells where measurements are in the <data> of a <get>
+// reply. Paths are element names separated with slashes, namespaces are
+// ignored and the first matching element wins.
+type netconfMapping struct {
+	// Filter is the subtree filter used when the device has none.
+	Filter string `yaml:"filter"`
+	// Item is the path of elements holding one transceiver each, Name is the
+	// path of the interface name within an item.
+	Item string `yaml:"item"`
+	Name string `yaml:"name"`
+	// Match skips items without this element, e.g. components other than
+	// transceivers.
+	Match  string `yaml:"match"`
+	Fields struct {
+		Temperature string `yaml:"temperature"`
+		Voltage     string `yaml:"voltage"`
+		RxPower     string `yaml:"rx-power"`
+		TxPower     string `yaml:"tx-power"`
+		OSNR        string `yaml:"osnr"`
+	} `yaml:"fields"`
+	// Interfaces optionally renames items, when their names are not the
+	// interface names.
+	Interfaces *struct {
+		Item      string `yaml:"item"`
+		Name      string `yaml:"name"`
+		Component string `yaml:"component"`
+	} `yaml:"interfaces"`
+}
+
+// loadNETCONFMapping reads the mapping of platform from dir, falling back to
+// the built-in mappings.
+func loadNETCONFMapping(dir, platform string) (netconfMapping, error) {
+	if !storage.ValidNETCONFPlatform(platform) {
+		return netconfMapping{}, fmt.Errorf("wrong NETCONF platform %q", platform)
+	}
+
+	var data []byte
+	var err error
+	if dir != "" {
+		data, err = os.ReadFile(filepath.Join(dir, platform+".yaml"))
+	}
+	if dir == "" || errors.Is(err, os.ErrNotExist) {
+		data, err = netconfMappings.ReadFile("netconf/" + platform + ".yaml")
+		if errors.Is(err, os.ErrNotExist) {
+			return netconfMapping{}, fmt.Errorf("no mapping of NETCONF platform %q", platform)
+		}
+	}
+	if err != nil {
+		return netconfMapping{}, err
+	}
+
+	var mapping netconfMapping
+	if err := yaml.Unmarshal(data, &mapping); err != nil {
+		return netconfMapping{}, fmt.Errorf("mapping of NETCONF platform %q: %w", platform, err)
+	}
+
+	fields := mapping.Fields
+	if mapping.Item == "" || mapping.Name == "" || fields.Temperature+fields.Voltage+fields.RxPower+fields.TxPower+fields.OSNR == "" {
+		return netconfMapping{}, fmt.Errorf("mapping of NETCONF platform %q needs item, name and fields", platform)
+	}
+
+	return mapping, nil
+}
+
+// measurements reads transceivers from data, the <data> element of a reply.
+func (m netconfMapping) measurements(data *xmlNode) []interfaceMeasurement {
+	names := make(map[string]string)
+	if m.Interfaces != nil {
+		for _, iface := range data.findAll(m.Interfaces.Item) {
+			if component := iface.text(m.Interfaces.Component); component != "" {
+				names[component] = iface.text(m.Interfaces.Name)
+			}
+		}
+	}
+
+	var measurements []interfaceMeasurement
+	for _, item := range data.findAll(m.Item) {
+		name := item.text(m.Name)
+		if name == "" || (m.Match != "" && item.find(m.Match) == nil) {
+			continue
+		}
+		if iface, ok := names[name]; ok && iface != "" {
+			name = iface
+		}
+
+		var found bool
+		var measurement influx.Measurement
+		for _, field := range []struct {
+			path  string
+			value *float64
+		}{
+			{m.Fields.Temperature, &measurement.Temperature},
+			{m.Fields.Voltage, &measurement.Voltage},
+			{m.Fields.RxPower, &measurement.RxPower},
+			{m.Fields.TxPower, &measurement.TxPower},
+			{m.Fields.OSNR, &measurement.OSNR},
+		} {
+			if field.path == "" {
+				continue
+			}
+
+			v, err := strconv.ParseFloat(item.text(field.path), 64)
+			if err != nil {
+				continue
+			}
+			*field.value, found = v, true
+		}
+
+		if found {
+			measurements = append(measurements, interfaceMeasurement{Interface: name, Measurement: measurement})
+		}
+	}
+
+	return measurements
+}
+
+// xmlNode is an element of a reply with local names only.
+type xmlNode struct {
+	name     string
+	content  string
+	children []*xmlNode
+}
+
+func parseXML(r io.Reader) (*xmlNode, error) {
+	root := &xmlNode{}
+	stack := []*xmlNode{root}
+
+	decoder := xml.NewDecoder(r)
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			node := &xmlNode{name: t.Name.Local}
+			parent := stack[len(stack)-1]
+			parent.children = append(parent.children, node)
+			stack = append(stack, node)
+		case xml.EndElement:
+			stack = stack[:len(stack)-1]
+		case xml.CharData:
+			stack[len(stack)-1].content += string(t)
+		}
+	}
+
+	return root, nil
+}
+
+// findAll returns elements at path, every step may match many elements.
+func (n *xmlNode) findAll(path string) []*xmlNode {
+	nodes := []*xmlNode{n}
+	for _, name := range strings.Split(strings.Trim(path, "/"), "/") {
+		var next []*xmlNode
+		for _, node := range nodes {
+			for _, child := range node.children {
+				if child.name == name {
+					next = append(next, child)
+				}
+			}
+		}
+		nodes = next
+	}
+
+	return nodes
+}
+
+func (n *xmlNode) find(path string) *xmlNode {
+	if nodes := n.findAll(path); len(nodes) != 0 {
+		return nodes[0]
+	}
+
+	return nil
+}
+
+func (n *xmlNode) text(path string) string {
+	if node := n.find(path); node != nil {
+		return strings.TrimSpace(node.content)
+	}
+
+	return ""
+}
+
+// netconfSession exchanges messages of NETCONF over an SSH subsystem,
+// framed with end-of-message markers in base:1.0 and chunks in base:1.1.
+type netconfSession struct {
+	w       io.Writer
+	r       *bufio.Reader
+	chunked bool
+	id      int
+}
+
+func newNETCONFSession(w io.Writer, r io.Reader) *netconfSession {
+	return &netconfSession{w: w, r: bufio.NewReader(r)}
+}
+
+// hello exchanges capabilities, chunked framing is used when both sides
+// support base:1.1.
+func (s *netconfSession) hello() error {
+	hello := `<?xml version="1.0" encoding="UTF-8"?>` +
+		`<hello xmlns="urn:ietf:params:xml:ns:netconf:base:1.0"><capabilities>` +
+		`<capability>` + netconfBase10 + `</capability>` +
+		`<capability>` + netconfBase11 + `</capability>` +
+		`</capabilities></hello>`
+	if _, err := io.WriteString(s.w, hello+netconfEOM); err != nil {
+		return err
+	}
+
+	reply, err := s.readEOM()
+	if err != nil {
+		return fmt.Errorf("cannot read hello: %w", err)
+	}
+
+	root, err := parseXML(bytes.NewReader(reply))
+	if err != nil {
+		return fmt.Errorf("cannot parse hello: %w", err)
+	}
+
+	for _, capability := range root.findAll("hello/capabilities/capability") {
+		if strings.TrimSpace(capability.content) == netconfBase11 {
+			s.chunked = true
+		}
+	}
+
+	return nil
+}
+
+// rpc sends operation and returns the reply, <rpc-error> becomes an error.
+func (s *netconfSession) rpc(operation string) (*xmlNode, error) {
+	s.id++
+	request := fmt.Sprintf(`<?xml version="1.0" encoding="UTF-8"?><rpc message-id="%d" xmlns="urn:ietf:params:xml:ns:netconf:base:1.0">%s</rpc>`, s.id, operation)
+	if err := s.write([]byte(request)); err != nil {
+		return nil, err
+	}
+
+	message, err := s.read()
+	if err != nil {
+		return nil, err
+	}
+
+	root, err := parseXML(bytes.NewReader(message))
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse reply: %w", err)
+	}
+
+	reply := root.find("rpc-reply")
+	if reply == nil {
+		return nil, errors.New("no rpc-reply in NETCONF message")
+	}
+
+	// Warnings do not fail the operation.
+	for _, rpcError := range reply.findAll("rpc-error") {
+		if rpcError.text("error-severity") != "warning" {
+			return nil, fmt.Errorf("NETCONF rpc-error: %s", rpcError.text("error-message"))
+		}
+	}
+
+	return reply, nil
+}
+
+// get runs <get> with a subtree filter and returns <data> of the reply.
+func (s *netconfSession) get(filter string) (*xmlNode, error) {
+	reply, err := s.rpc(`<get><filter type="subtree">` + filter + `</filter></get>`)
+	if err != nil {
+		return nil, err
+	}
+
+	data := reply.find("data")
+	if data == nil {
+		return &xmlNode{name: "data"}, nil
+	}
+
+	return data, nil
+}
+
+func (s *netconfSession) close() error {
+	_, err := s.rpc(`<close-session/>`)
+
+	return err
+}
+
+func (s *netconfSession) write(message []byte) error {
+	if !s.chunked {
+		_, err := s.w.Write(append(message, netconfEOM...))
+		return err
+	}
+
+	_, err := fmt.Fprintf(s.w, "\n#%d\n%s\n##\n", len(message), message)
+
+	return err
+}
+
+func (s *netconfSession) read() ([]byte, error) {
+	if !s.chunked {
+		return s.readEOM()
+	}
+
+	var message []byte
+	for {
+		header, err := s.r.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		if header == "\n" {
+			// Line feed opening the chunk header.
+			continue
+		}
+		if header == "##\n" {
+			return message, nil
+		}
+
+		size, err := strconv.ParseUint(strings.TrimSuffix(strings.TrimPrefix(header, "#"), "\n"), 10, 32)
+		if !strings.HasPrefix(header, "#") || err != nil || size == 0 {
+			return nil, fmt.Errorf("wrong NETCONF chunk header %q", header)
+		}
+		if len(message)+int(size) > netconfMaxMessage {
+			return nil, errors.New("NETCONF message too long")
+		}
+
+		chunk := make([]byte, size)
+		if _, err := io.ReadFull(s.r, chunk); err != nil {
+			return nil, err
+		}
+		message = append(message, chunk...)
+	}
+}
+
+func (s *netconfSession) readEOM() ([]byte, error) {
+	var message []byte
+	for {
+		line, err := s.r.ReadBytes('>')
+		message = append(message, line...)
+		if bytes.HasSuffix(message, []byte(netconfEOM)) {
+			return bytes.TrimSuffix(message, []byte(netconfEOM)), nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(message) > netconfMaxMessage {
+			return nil, errors.New("NETCONF message too long")
+		}
+	}
+}
+
+// netconfDevice reads optics state with <get> over SSH, for platforms with
+// show command output changing between releases. The SSH connection is made
+// like for remoteDevice, to the NETCONF port.
+type netconfDevice struct {
+	remoteDevice
+}
+
+func newNETCONFDevice(dev storage.Device, credentials storage.Auth) netconfDevice {
+	dev.Port = dev.NETCONF.Port
+
+	return netconfDevice{remoteDevice: newRemoteDevice(dev, credentials, nil)}
+}
+
+// filter returns the subtree filter of the device, or of its platform.
+func (d netconfDevice) filter(mapping netconfMapping) string {
+	if d.NETCONF.Filter != "" {
+		return d.NETCONF.Filter
+	}
+
+	return mapping.Filter
+}
+
+func (d netconfDevice) monitorInterfaces(client *ssh.Client, mapping netconfMapping) ([]interfaceMeasurement, error) {
+	session, err := client.NewSession()
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := session.Close(); err != nil && !errors.Is(err, io.EOF) {
+			slog.Error("cannot close session", slog.Any("error", err))
+		}
+	}()
+
+	stdin, err := session.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+
+	stdout, err := session.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := session.RequestSubsystem("netconf"); err != nil {
+		return nil, err
+	}
+
+	netconf := newNETCONFSession(stdin, stdout)
+	if err := netconf.hello(); err != nil {
+		return nil, err
+	}
+
+	data, err := netconf.get(d.filter(mapping))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := netconf.close(); err != nil {
+		slog.Warn("cannot close NETCONF session", slog.Any("deviceID", d.ID), slog.Any("error", err))
+	}
+
+	return mapping.measurements(data), nil
+}
@@ -0,0 +1,91 @@
+package monitor
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// testNETCONFData is the <data> of <get> replies of the NETCONF stand-in,
+// shaped like SR OS state.
+const testNETCONFData = `<state xmlns="urn:nokia.com:sros:ns:yang:sr:state">
+  <port>
+    <port-id>1/1/c1/1</port-id>
+    <transceiver>
+      <digital-diagnostic-monitoring>
+        <temperature><current>35.4</current></temperature>
+        <supply-voltage><current>3.3</current></supply-voltage>
+        <tx-output-power><current>-1.5</current></tx-output-power>
+        <rx-optical-power><current>-3.12</current></rx-optical-power>
+      </digital-diagnostic-monitoring>
+    </transceiver>
+  </port>
+  <port>
+    <port-id>1/1/c2/1</port-id>
+    <transceiver/>
+  </port>
+</state>`
+
+// serveTestNETCONF answers a NETCONF session with base:1.1 framing. Filters
+// naming unknown elements get an rpc-error, close-session ends the session.
+func serveTestNETCONF(rw io.ReadWriter) {
+	hello := `<hello xmlns="urn:ietf:params:xml:ns:netconf:base:1.0"><capabilities>` +
+		`<capability>` + netconfBase10 + `</capability>` +
+		`<capability>` + netconfBase11 + `</capability>` +
+		`</capabilities><session-id>1</session-id></hello>`
+	if _, err := io.WriteString(rw, hello+netconfEOM); err != nil {
+		return
+	}
+
+	session := newNETCONFSession(rw, rw)
+	if _, err := session.readEOM(); err != nil {
+		return
+	}
+	session.chunked = true
+
+	for {
+		message, err := session.read()
+		if err != nil {
+			return
+		}
+
+		root, err := parseXML(bytes.NewReader(message))
+		if err != nil {
+			return
+		}
+		rpc := root.find("rpc")
+		if rpc == nil {
+			return
+		}
+
+		var reply string
+		switch {
+		case rpc.find("close-session") != nil:
+			reply = `<ok/>`
+		case rpc.find("get/filter/unknown") != nil:
+			reply = `<rpc-error><error-type>application</error-type><error-tag>unknown-element</error-tag>` +
+				`<error-severity>error</error-severity><error-message>unknown element</error-message></rpc-error>`
+		case rpc.find("get") != nil:
+			reply = `<data>` + testNETCONFData + `</data>`
+		default:
+			reply = `<rpc-error><error-type>protocol</error-type><error-tag>operation-not-supported</error-tag>` +
+				`<error-severity>error</error-severity><error-message>not supported</error-message></rpc-error>`
+		}
+
+		id := strings.SplitN(string(message), `message-id="`, 2)
+		messageID := "0"
+		if len(id) == 2 {
+			messageID, _, _ = strings.Cut(id[1], `"`)
+		}
+
+		response := fmt.Sprintf(`<rpc-reply message-id="%s" xmlns="urn:ietf:params:xml:ns:netconf:base:1.0">%s</rpc-reply>`, messageID, reply)
+		if err := session.write([]byte(response)); err != nil {
+			return
+		}
+
+		if rpc.find("close-session") != nil {
+			return
+		}
+	}
+}
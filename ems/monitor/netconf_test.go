@@ -0,0 +1,215 @@
+package monitor
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"pi-wegrzyn/ems/influx"
+	"pi-wegrzyn/ems/storage"
+
+	gocmp "github.com/google/go-cmp/cmp"
+	"golang.org/x/crypto/ssh"
+)
+
+func TestNETCONFDevice_monitorInterfaces(t *testing.T) {
+	server := newTestServer(t, &ssh.ServerConfig{
+		PasswordCallback: func(conn ssh.ConnMetadata, p []byte) (*ssh.Permissions, error) {
+			if string(p) == "secret" {
+				return nil, nil
+			}
+			return nil, errors.New("wrong password")
+		},
+	})
+
+	mapping, err := loadNETCONFMapping("", storage.NETCONFPlatformSROS)
+	if err != nil {
+		t.Fatalf("cannot load mapping: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		filter  string
+		want    []interfaceMeasurement
+		wantErr string
+	}{
+		{
+			name: "platform filter",
+			want: []interfaceMeasurement{
+				{Interface: "1/1/c1/1", Measurement: influx.Measurement{Temperature: 35.4, Voltage: 3.3, TxPower: -1.5, RxPower: -3.12}},
+			},
+		},
+		{
+			name:    "rejected filter",
+			filter:  "<unknown/>",
+			wantErr: "NETCONF rpc-error: unknown element",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			d := newNETCONFDevice(storage.Device{
+				IPAddress: server.host,
+				Collector: storage.CollectorNETCONF,
+				NETCONF:   storage.NETCONF{Port: server.port, Platform: storage.NETCONFPlatformSROS, Filter: tc.filter},
+			}, storage.Auth{Login: "noc", Password: "secret"})
+
+			auth, closeAgent, err := d.auth("")
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			defer closeAgent()
+
+			client, err := d.sshClient(nil, auth, 5)
+			if err != nil {
+				t.Fatalf("cannot connect: %v", err)
+			}
+			defer func() { _ = client.Close() }()
+
+			got, err := d.monitorInterfaces(client, mapping)
+			if tc.wantErr != "" {
+				if err == nil || err.Error() != tc.wantErr {
+					t.Fatalf("expected error %q, got %v", tc.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if diff := gocmp.Diff(got, tc.want); diff != "" {
+				t.Errorf("measurements mismatch (-got +want):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestNETCONFSession_base10(t *testing.T) {
+	// A base:1.0 server frames every message with the end-of-message marker.
+	input := `<hello><capabilities><capability>` + netconfBase10 + `</capability></capabilities></hello>` + netconfEOM +
+		`<rpc-reply message-id="1"><data><a>1</a></data></rpc-reply>` + netconfEOM
+	output := new(bytes.Buffer)
+
+	session := newNETCONFSession(output, strings.NewReader(input))
+	if err := session.hello(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if session.chunked {
+		t.Fatal("expected end-of-message framing")
+	}
+
+	data, err := session.get("<a/>")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := data.text("a"); got != "1" {
+		t.Errorf("expected a=1, got %q", got)
+	}
+
+	if !strings.HasSuffix(output.String(), `<get><filter type="subtree"><a/></filter></get></rpc>`+netconfEOM) {
+		t.Errorf("unexpected request framing: %q", output.String())
+	}
+}
+
+func TestNETCONFSession_chunked(t *testing.T) {
+	// Replies may be split into many chunks.
+	input := "\n#10\n<rpc-reply\n#33\n message-id=\"1\"><ok/></rpc-reply>\n##\n" +
+		"\n#3\n<rp\n##\n"
+
+	session := newNETCONFSession(new(bytes.Buffer), strings.NewReader(input))
+	session.chunked = true
+
+	if _, err := session.rpc("<lock/>"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := session.rpc("<lock/>"); err == nil {
+		t.Fatal("expected error of a truncated reply")
+	}
+
+	session = newNETCONFSession(new(bytes.Buffer), strings.NewReader("\n#x\n"))
+	session.chunked = true
+	if _, err := session.read(); err == nil || !strings.Contains(err.Error(), "wrong NETCONF chunk header") {
+		t.Errorf("expected chunk header error, got %v", err)
+	}
+}
+
+func TestLoadNETCONFMapping(t *testing.T) {
+	for _, platform := range storage.NETCONFPlatforms {
+		if _, err := loadNETCONFMapping("", platform); err != nil {
+			t.Errorf("built-in mapping of %s: %v", platform, err)
+		}
+	}
+
+	dir := t.TempDir()
+	custom := "item: ports/port\nname: name\nfields:\n  rx-power: optics/rx\n"
+	if err := os.WriteFile(filepath.Join(dir, "custom.yaml"), []byte(custom), 0o600); err != nil {
+		t.Fatalf("cannot write mapping: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "broken.yaml"), []byte("item: ports/port\n"), 0o600); err != nil {
+		t.Fatalf("cannot write mapping: %v", err)
+	}
+
+	mapping, err := loadNETCONFMapping(dir, "custom")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if mapping.Fields.RxPower != "optics/rx" {
+		t.Errorf("expected mapping from the directory, got %+v", mapping)
+	}
+
+	// Built-in mappings are used when the directory has none.
+	if _, err := loadNETCONFMapping(dir, storage.NETCONFPlatformJunos); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	for platform, wantErr := range map[string]string{
+		"broken": `mapping of NETCONF platform "broken" needs item, name and fields`,
+		"eos":    `no mapping of NETCONF platform "eos"`,
+		"../etc": `wrong NETCONF platform "../etc"`,
+	} {
+		if _, err := loadNETCONFMapping(dir, platform); err == nil || err.Error() != wantErr {
+			t.Errorf("expected error %q, got %v", wantErr, err)
+		}
+	}
+}
+
+func TestNETCONFMapping_measurements(t *testing.T) {
+	mapping, err := loadNETCONFMapping("", storage.NETCONFPlatformJunos)
+	if err != nil {
+		t.Fatalf("cannot load mapping: %v", err)
+	}
+
+	root, err := parseXML(strings.NewReader(`<data>
+<components xmlns="http://openconfig.net/yang/platform">
+  <component><name>FPC0</name><state><temperature><instant>41</instant></temperature></state></component>
+  <component>
+    <name>FPC0:PIC0:PORT0:Xcvr0</name>
+    <state><temperature><instant>33.5</instant></temperature></state>
+    <transceiver xmlns="http://openconfig.net/yang/platform/transceiver">
+      <state><supply-voltage><instant>3.29</instant></supply-voltage></state>
+      <physical-channels>
+        <channel><index>0</index><state><input-power><instant>-2.5</instant></input-power><output-power><instant>0.5</instant></output-power></state></channel>
+        <channel><index>1</index><state><input-power><instant>-9</instant></input-power></state></channel>
+      </physical-channels>
+    </transceiver>
+  </component>
+</components>
+<interfaces xmlns="http://openconfig.net/yang/interfaces">
+  <interface><name>et-0/0/0</name><state><transceiver>FPC0:PIC0:PORT0:Xcvr0</transceiver></state></interface>
+</interfaces>
+</data>`))
+	if err != nil {
+		t.Fatalf("cannot parse: %v", err)
+	}
+
+	want := []interfaceMeasurement{
+		{Interface: "et-0/0/0", Measurement: influx.Measurement{Temperature: 33.5, Voltage: 3.29, RxPower: -2.5, TxPower: 0.5}},
+	}
+	if diff := gocmp.Diff(mapping.measurements(root.find("data")), want); diff != "" {
+		t.Errorf("measurements mismatch (-got +want):\n%s", diff)
+	}
+}
@@ -5,8 +5,9 @@ import (
 	"fmt"
 	"io"
 	"log/slog"
+	"net"
+	"strconv"
 	"strings"
-	"time"
 
 	"pi-wegrzyn/ems/influx"
 	"pi-wegrzyn/ems/storage"
@@ -28,38 +29,32 @@ type interfaceMeasurement struct {
 
 type remoteDevice struct {
 	storage.Device
-	decodeFunc Decoder
+	credentials storage.Auth
+	decodeFunc  Decoder
+	// capture, when set, gets decoder input of every interface.
+	capture func(iface string, raw []byte)
 }
 
-func newRemoteDevice(dev storage.Device, decoder func([]byte) (Eeprom, error)) remoteDevice {
+func newRemoteDevice(dev storage.Device, credentials storage.Auth, decoder func([]byte) (Eeprom, error)) remoteDevice {
 	return remoteDevice{
-		Device:     dev,
-		decodeFunc: decoder,
+		Device:      dev,
+		credentials: credentials,
+		decodeFunc:  decoder,
 	}
 }
 
-func (d remoteDevice) auth() ([]ssh.AuthMethod, error) {
-	if len(d.Keyfile) == 0 {
-		return []ssh.AuthMethod{ssh.Password(d.Password)}, nil
-	}
-
-	signer, err := ssh.ParsePrivateKey(d.Keyfile)
-	if err != nil {
-		return nil, err
-	}
-
-	return []ssh.AuthMethod{ssh.PublicKeys(signer)}, nil
+func (d remoteDevice) labels() influx.Labels {
+	return influx.Labels{Site: d.Site, Group: d.Group, Tags: d.Tags}
 }
 
-func (d remoteDevice) sshClient(auth []ssh.AuthMethod, timeout int) (*ssh.Client, error) {
-	sshCfg := &ssh.ClientConfig{
-		Auth:            auth,
-		User:            d.Login,
-		HostKeyCallback: ssh.InsecureIgnoreHostKey(),
-		Timeout:         time.Duration(timeout) * time.Second,
-	}
+func (d remoteDevice) auth(agentSocket string) ([]ssh.AuthMethod, func(), error) {
+	return authMethods(d.credentials, agentSocket)
+}
 
-	return ssh.Dial("tcp", d.IPAddress+":22", sshCfg)
+// sshClient connects to the device, through via when it is reached over
+// jump hosts.
+func (d remoteDevice) sshClient(via *ssh.Client, auth []ssh.AuthMethod, timeout int) (*ssh.Client, error) {
+	return dialSSH(via, net.JoinHostPort(d.IPAddress, strconv.Itoa(int(d.Port))), clientConfig(d.credentials.Login, auth, timeout))
 }
 
 func (d remoteDevice) getInterfaces(client *ssh.Client) ([]string, error) {
@@ -102,6 +97,10 @@ func (d remoteDevice) monitorInterfaces(client *ssh.Client, interfaces []string)
 			continue
 		}
 
+		if d.capture != nil {
+			d.capture(inf, got)
+		}
+
 		ifData, err2 := d.processData(got)
 		if err2 != nil {
 			err = errors.Join(err, fmt.Errorf("%v (interface: %s)", err2, inf))
@@ -118,16 +117,52 @@ func (d remoteDevice) monitorInterfaces(client *ssh.Client, interfaces []string)
 }
 
 func (d remoteDevice) processData(input []byte) (influx.Measurement, error) {
-	decoded, err := d.decodeFunc(input)
+	return Measure(d.decodeFunc, input)
+}
+
+// Measure decodes input and reads the measurements written to Influx.
+func Measure(decode Decoder, input []byte) (influx.Measurement, error) {
+	decoded, err := decode(input)
 	if err != nil {
 		return influx.Measurement{}, err
 	}
+	if len(decoded) < EepromLength {
+		return influx.Measurement{}, fmt.Errorf("expected %d bytes of EEPROM, got %d", EepromLength, len(decoded))
+	}
 
-	return influx.Measurement{
+	measurement := influx.Measurement{
 		Temperature: decoded.Temperature(),
 		Voltage:     decoded.Voltage(),
 		TxPower:     decoded.TxPower(),
 		RxPower:     decoded.RxPower(),
 		OSNR:        decoded.Osnr(),
-	}, nil
+		ModuleState: uint8(decoded.ModuleState()),
+
+		TxPowerLowAlarm: decoded.TxPowerLowAlarm(),
+		RxPowerLowAlarm: decoded.RxPowerLowAlarm(),
+	}
+	for _, state := range decoded.DataPathStates() {
+		measurement.DataPathStates = append(measurement.DataPathStates, uint8(state))
+	}
+
+	if decoded.Tunable() && decoded.LaserFrequency() != 0 {
+		measurement.Laser = &influx.Laser{
+			Frequency:   decoded.LaserFrequency(),
+			Wavelength:  decoded.Wavelength(),
+			Channel:     decoded.Channel(),
+			GridSpacing: decoded.GridSpacing(),
+			TargetPower: decoded.TargetOutputPower(),
+			MinPower:    decoded.ProgOutputPowerMin(),
+			MaxPower:    decoded.ProgOutputPowerMax(),
+		}
+	}
+
+	if observables := decoded.Vdm(); len(observables) != 0 {
+		measurement.VDM = make(map[string]float64, len(observables))
+		for _, o := range observables {
+			measurement.VDM[o.Field()] = o.Value
+		}
+	}
+
+	return measurement, nil
 }
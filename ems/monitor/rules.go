@@ -0,0 +1,163 @@
+package monitor
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"pi-wegrzyn/ems/storage"
+)
+
+// evaluateRules checks readings of interfaces against enabled rules matching
+// them and records events of alerts raised and cleared. Alerts of silenced
+// rules and devices in maintenance keep counting breaches but are raised or
+// cleared only once the suppression ends.
+func (m Monitor) evaluateRules(ctx context.Context, run monitoringRun, device storage.Device, data []interfaceMeasurement) {
+	if len(run.rules) == 0 {
+		return
+	}
+
+	now := time.Now()
+	inMaintenance := run.inMaintenance(device.ID)
+
+	stored, err := m.db.DeviceAlerts(ctx, device.ID)
+	if err != nil {
+		slog.ErrorContext(ctx, "cannot get alerts", slog.Any("deviceID", device.ID), slog.Any("error", err))
+
+		return
+	}
+
+	type alertKey struct {
+		ruleID uint
+		iface  string
+	}
+	previous := make(map[alertKey]storage.Alert, len(stored))
+	for _, a := range stored {
+		previous[alertKey{a.RuleID, a.Interface}] = a
+	}
+
+	for _, measurement := range data {
+		for _, rule := range run.rules {
+			if !rule.Enabled || !rule.Matches(device, measurement.Interface) {
+				continue
+			}
+
+			value, ok := measurement.Field(rule.Metric)
+			if !ok {
+				continue
+			}
+
+			alert, ok := previous[alertKey{rule.ID, measurement.Interface}]
+			if !ok {
+				alert = storage.Alert{RuleID: rule.ID, DeviceID: device.ID, Interface: measurement.Interface}
+			}
+
+			alert, kind := evaluateRule(rule, alert, value, now, inMaintenance)
+			if kind != "" {
+				event := storage.Event{
+					DeviceID:  device.ID,
+					Hostname:  device.Hostname,
+					Interface: measurement.Interface,
+					Kind:      kind,
+					Message:   alertMessage(rule, alert, kind),
+				}
+				if err := m.db.CreateEvent(ctx, event); err != nil {
+					slog.ErrorContext(ctx, "cannot create event", slog.Any("deviceID", device.ID), slog.Any("ruleID", rule.ID), slog.Any("error", err))
+				}
+			}
+
+			if err := m.db.UpdateAlert(ctx, alert); err != nil {
+				slog.ErrorContext(ctx, "cannot update alert", slog.Any("deviceID", device.ID), slog.Any("ruleID", rule.ID), slog.Any("error", err))
+			}
+		}
+	}
+}
+
+// evaluateRule returns the alert with value read at now and the kind of
+// event of the alert being raised or cleared, empty when it stays as it
+// was. Rises and falls compare the change of value within the window of the
+// rule. While the rule is silenced or the device in maintenance the alert
+// stays as it was, so a breach lasting past the suppression is raised then.
+func evaluateRule(rule storage.Rule, alert storage.Alert, value float64, now time.Time, inMaintenance bool) (storage.Alert, string) {
+	compared := value
+	previous := alert.Samples
+	alert.Samples = nil
+	if rule.Comparison == storage.ComparisonRises || rule.Comparison == storage.ComparisonFalls {
+		var samples []storage.Sample
+		for _, s := range previous {
+			if now.Sub(s.Time) <= rule.Window {
+				samples = append(samples, s)
+			}
+		}
+		samples = append(samples, storage.Sample{Time: now, Value: value})
+		alert.Samples = samples
+
+		lowest, highest := value, value
+		for _, s := range samples {
+			lowest, highest = min(lowest, s.Value), max(highest, s.Value)
+		}
+
+		compared = value - lowest
+		if rule.Comparison == storage.ComparisonFalls {
+			compared = highest - value
+		}
+	}
+	alert.Value = compared
+	alert.Updated = now
+
+	breaking := compared > rule.Threshold
+	cleared := compared <= rule.Threshold-rule.Hysteresis
+	if rule.Comparison == storage.ComparisonBelow {
+		breaking = compared < rule.Threshold
+		cleared = compared >= rule.Threshold+rule.Hysteresis
+	}
+
+	switch {
+	case breaking:
+		if alert.Breaches == 0 {
+			alert.Since = now
+		}
+		alert.Breaches++
+	case !alert.Firing:
+		alert.Breaches, alert.Since = 0, time.Time{}
+	}
+
+	if rule.Silenced(now) || inMaintenance {
+		return alert, ""
+	}
+
+	lasted := now.Sub(alert.Since) >= time.Duration(rule.ForSeconds)*time.Second
+	switch {
+	case !alert.Firing && breaking && alert.Breaches >= max(rule.ForPolls, 1) && lasted:
+		alert.Firing = true
+
+		return alert, storage.EventAlert
+	case alert.Firing && cleared:
+		alert.Firing = false
+		alert.Breaches, alert.Since = 0, time.Time{}
+
+		return alert, storage.EventAlertCleared
+	default:
+		return alert, ""
+	}
+}
+
+// alertMessage describes the alert of the rule being raised or cleared.
+func alertMessage(rule storage.Rule, alert storage.Alert, kind string) string {
+	var reading string
+	switch rule.Comparison {
+	case storage.ComparisonRises:
+		reading = fmt.Sprintf("%s rose by %.2f within %s", rule.Metric, alert.Value, rule.Window)
+	case storage.ComparisonFalls:
+		reading = fmt.Sprintf("%s fell by %.2f within %s", rule.Metric, alert.Value, rule.Window)
+	default:
+		reading = fmt.Sprintf("%s is %.2f", rule.Metric, alert.Value)
+	}
+
+	if kind == storage.EventAlertCleared {
+		return fmt.Sprintf("rule %s cleared, %s", rule.Name, reading)
+	}
+
+	return fmt.Sprintf("rule %s raised, %s, %s %g for %d poll(s)", rule.Name, reading, rule.Comparison, rule.Threshold, alert.Breaches)
+}
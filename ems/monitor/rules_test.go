@@ -0,0 +1,166 @@
+package monitor
+
+import (
+	"testing"
+	"time"
+
+	"pi-wegrzyn/ems/storage"
+)
+
+// feedRule evaluates the rule against values read every interval and returns
+// the final alert and kinds of events raised.
+func feedRule(rule storage.Rule, values []float64, interval time.Duration) (storage.Alert, []string) {
+	start := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	alert := storage.Alert{RuleID: rule.ID, DeviceID: 1, Interface: "eth0"}
+
+	var kinds []string
+	for i, v := range values {
+		var kind string
+		alert, kind = evaluateRule(rule, alert, v, start.Add(time.Duration(i)*interval), false)
+		if kind != "" {
+			kinds = append(kinds, kind)
+		}
+	}
+
+	return alert, kinds
+}
+
+func TestEvaluateRule(t *testing.T) {
+	below := storage.Rule{ID: 1, Name: "rx-low", Metric: "rx_pwr", Comparison: storage.ComparisonBelow, Threshold: -18, Hysteresis: 1, ForPolls: 3}
+	rises := storage.Rule{ID: 2, Name: "temp-jump", Metric: "temp", Comparison: storage.ComparisonRises, Threshold: 5, Window: 10 * time.Minute, ForPolls: 1}
+	lasting := storage.Rule{ID: 3, Name: "hot", Metric: "temp", Comparison: storage.ComparisonAbove, Threshold: 70, ForPolls: 1, ForSeconds: 300}
+	// Silenced for the first 3 polls of feedRule.
+	silenced := below
+	silenced.SilencedUntil = time.Date(2026, 10, 19, 12, 2, 30, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		rule       storage.Rule
+		values     []float64
+		interval   time.Duration
+		wantKinds  []string
+		wantFiring bool
+	}{
+		{
+			name:     "below for fewer polls",
+			rule:     below,
+			values:   []float64{-17, -19, -19, -17, -19, -19},
+			interval: time.Minute,
+		},
+		{
+			name:       "below for polls",
+			rule:       below,
+			values:     []float64{-17, -19, -19, -19},
+			interval:   time.Minute,
+			wantKinds:  []string{storage.EventAlert},
+			wantFiring: true,
+		},
+		{
+			name:       "held within hysteresis",
+			rule:       below,
+			values:     []float64{-19, -19, -19, -17.5, -18.5, -17.2},
+			interval:   time.Minute,
+			wantKinds:  []string{storage.EventAlert},
+			wantFiring: true,
+		},
+		{
+			name:      "cleared past hysteresis",
+			rule:      below,
+			values:    []float64{-19, -19, -19, -17.5, -16.9},
+			interval:  time.Minute,
+			wantKinds: []string{storage.EventAlert, storage.EventAlertCleared},
+		},
+		{
+			name:       "rises within window",
+			rule:       rises,
+			values:     []float64{40, 41, 43, 46},
+			interval:   3 * time.Minute,
+			wantKinds:  []string{storage.EventAlert},
+			wantFiring: true,
+		},
+		{
+			name:     "rises slower than window",
+			rule:     rises,
+			values:   []float64{40, 41.5, 43, 44.5, 46, 47.5},
+			interval: 6 * time.Minute,
+		},
+		{
+			name:     "above for less than duration",
+			rule:     lasting,
+			values:   []float64{75, 75, 75, 60},
+			interval: 2 * time.Minute,
+		},
+		{
+			name:       "above for duration",
+			rule:       lasting,
+			values:     []float64{75, 75, 75, 75},
+			interval:   2 * time.Minute,
+			wantKinds:  []string{storage.EventAlert},
+			wantFiring: true,
+		},
+		{
+			name:     "silenced breach",
+			rule:     silenced,
+			values:   []float64{-19, -19, -19},
+			interval: time.Minute,
+		},
+		{
+			name:       "breach persists past silence",
+			rule:       silenced,
+			values:     []float64{-19, -19, -19, -19},
+			interval:   time.Minute,
+			wantKinds:  []string{storage.EventAlert},
+			wantFiring: true,
+		},
+		{
+			name:     "breach cleared within silence",
+			rule:     silenced,
+			values:   []float64{-19, -19, -16, -16},
+			interval: time.Minute,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			alert, kinds := feedRule(tt.rule, tt.values, tt.interval)
+			if len(kinds) != len(tt.wantKinds) {
+				t.Fatalf("expected events %v, got %v", tt.wantKinds, kinds)
+			}
+			for i := range kinds {
+				if kinds[i] != tt.wantKinds[i] {
+					t.Errorf("expected events %v, got %v", tt.wantKinds, kinds)
+				}
+			}
+			if alert.Firing != tt.wantFiring {
+				t.Errorf("expected firing %v, got %v", tt.wantFiring, alert.Firing)
+			}
+		})
+	}
+}
+
+func TestEvaluateRule_samplesWithinWindow(t *testing.T) {
+	rule := storage.Rule{Metric: "temp", Comparison: storage.ComparisonFalls, Threshold: 5, Window: 10 * time.Minute}
+	alert, _ := feedRule(rule, []float64{50, 49, 48, 47, 46, 45}, 3*time.Minute)
+
+	if len(alert.Samples) != 4 {
+		t.Errorf("expected 4 samples within window, got %d", len(alert.Samples))
+	}
+	if alert.Value != 3 {
+		t.Errorf("expected fall of 3, got %v", alert.Value)
+	}
+}
+
+func TestAlertMessage(t *testing.T) {
+	rule := storage.Rule{Name: "temp-jump", Metric: "temp", Comparison: storage.ComparisonRises, Threshold: 5, Window: 10 * time.Minute}
+	alert := storage.Alert{Value: 6, Breaches: 1}
+
+	want := "rule temp-jump raised, temp rose by 6.00 within 10m0s, rises 5 for 1 poll(s)"
+	if got := alertMessage(rule, alert, storage.EventAlert); got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+
+	want = "rule temp-jump cleared, temp rose by 6.00 within 10m0s"
+	if got := alertMessage(rule, alert, storage.EventAlertCleared); got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
@@ -0,0 +1,118 @@
+package monitor
+
+import (
+	"net"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/gosnmp/gosnmp"
+)
+
+type testAgent struct {
+	host string
+	port uint16
+}
+
+// newTestAgent starts an SNMPv2c agent on localhost which answers get-next
+// and get-bulk requests from pdus. Requests with another community are
+// dropped, like real agents do.
+func newTestAgent(t *testing.T, community string, pdus []gosnmp.SnmpPDU) *testAgent {
+	t.Helper()
+
+	pdus = slices.Clone(pdus)
+	slices.SortFunc(pdus, func(a, b gosnmp.SnmpPDU) int { return compareOIDs(a.Name, b.Name) })
+
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("cannot listen: %v", err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+
+	go func() {
+		decoder := &gosnmp.GoSNMP{Version: gosnmp.Version2c}
+		buf := make([]byte, 65535)
+		for {
+			n, addr, err := conn.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+
+			request, err := decoder.SnmpDecodePacket(buf[:n])
+			if err != nil || request.Community != community {
+				continue
+			}
+
+			response := &gosnmp.SnmpPacket{
+				Version:   gosnmp.Version2c,
+				Community: community,
+				PDUType:   gosnmp.GetResponse,
+				RequestID: request.RequestID,
+				Variables: answer(pdus, request),
+			}
+			out, err := response.MarshalMsg()
+			if err != nil {
+				t.Errorf("cannot marshal response: %v", err)
+				continue
+			}
+			_, _ = conn.WriteTo(out, addr)
+		}
+	}()
+
+	host, port, err := net.SplitHostPort(conn.LocalAddr().String())
+	if err != nil {
+		t.Fatalf("cannot split address: %v", err)
+	}
+
+	p, err := strconv.ParseUint(port, 10, 16)
+	if err != nil {
+		t.Fatalf("cannot parse port: %v", err)
+	}
+
+	return &testAgent{host: host, port: uint16(p)}
+}
+
+func answer(pdus []gosnmp.SnmpPDU, request *gosnmp.SnmpPacket) []gosnmp.SnmpPDU {
+	var variables []gosnmp.SnmpPDU
+	for _, v := range request.Variables {
+		switch request.PDUType {
+		case gosnmp.GetRequest:
+			i := slices.IndexFunc(pdus, func(p gosnmp.SnmpPDU) bool { return p.Name == v.Name })
+			if i < 0 {
+				variables = append(variables, gosnmp.SnmpPDU{Name: v.Name, Type: gosnmp.NoSuchObject})
+				continue
+			}
+			variables = append(variables, pdus[i])
+		case gosnmp.GetNextRequest:
+			variables = append(variables, next(pdus, v.Name, 1)...)
+		case gosnmp.GetBulkRequest:
+			variables = append(variables, next(pdus, v.Name, int(request.MaxRepetitions))...)
+		}
+	}
+
+	return variables
+}
+
+// next returns up to count variables following oid.
+func next(pdus []gosnmp.SnmpPDU, oid string, count int) []gosnmp.SnmpPDU {
+	i := slices.IndexFunc(pdus, func(p gosnmp.SnmpPDU) bool { return compareOIDs(p.Name, oid) > 0 })
+	if i < 0 {
+		return []gosnmp.SnmpPDU{{Name: oid, Type: gosnmp.EndOfMibView}}
+	}
+
+	return pdus[i:min(i+count, len(pdus))]
+}
+
+func compareOIDs(a, b string) int {
+	as, bs := strings.Split(strings.Trim(a, "."), "."), strings.Split(strings.Trim(b, "."), ".")
+	for i := range min(len(as), len(bs)) {
+		x, _ := strconv.Atoi(as[i])
+		y, _ := strconv.Atoi(bs[i])
+		if x != y {
+			return x - y
+		}
+	}
+
+	return len(as) - len(bs)
+}
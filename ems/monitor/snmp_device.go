@@ -0,0 +1,428 @@
+package monitor
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"pi-wegrzyn/ems/influx"
+	"pi-wegrzyn/ems/storage"
+
+	"github.com/gosnmp/gosnmp"
+)
+
+// OIDs of ENTITY-MIB, ENTITY-SENSOR-MIB and IF-MIB columns walked by the
+// SNMP collector.
+const (
+	OIDEntPhysicalDescr          string = ".1.3.6.1.2.1.47.1.1.1.1.2"
+	OIDEntPhysicalContainedIn    string = ".1.3.6.1.2.1.47.1.1.1.1.4"
+	OIDEntPhysicalClass          string = ".1.3.6.1.2.1.47.1.1.1.1.5"
+	OIDEntPhysicalName           string = ".1.3.6.1.2.1.47.1.1.1.1.7"
+	OIDEntAliasMappingIdentifier string = ".1.3.6.1.2.1.47.1.3.2.1.2"
+	OIDEntPhySensorType          string = ".1.3.6.1.2.1.99.1.1.1.1"
+	OIDEntPhySensorScale         string = ".1.3.6.1.2.1.99.1.1.1.2"
+	OIDEntPhySensorPrecision     string = ".1.3.6.1.2.1.99.1.1.1.3"
+	OIDEntPhySensorValue         string = ".1.3.6.1.2.1.99.1.1.1.4"
+	OIDEntPhySensorOperStatus    string = ".1.3.6.1.2.1.99.1.1.1.5"
+	OIDIfName                    string = ".1.3.6.1.2.1.31.1.1.1.1"
+)
+
+// Values of PhysicalClass, EntitySensorDataType, EntitySensorDataScale and
+// EntitySensorStatus used by the collector.
+const (
+	entityClassPort = 10
+
+	sensorTypeVoltsDC = 4
+	sensorTypeWatts   = 6
+	sensorTypeCelsius = 8
+	sensorTypeDBm     = 14
+
+	sensorScaleUnits = 9
+
+	sensorStatusOK = 1
+)
+
+// maxContainmentDepth stops walking up entPhysicalContainedIn, agents
+// sometimes report loops.
+const maxContainmentDepth = 16
+
+var snmpAuthProtocols = map[string]gosnmp.SnmpV3AuthProtocol{
+	"":       gosnmp.NoAuth,
+	"MD5":    gosnmp.MD5,
+	"SHA":    gosnmp.SHA,
+	"SHA224": gosnmp.SHA224,
+	"SHA256": gosnmp.SHA256,
+	"SHA384": gosnmp.SHA384,
+	"SHA512": gosnmp.SHA512,
+}
+
+var snmpPrivProtocols = map[string]gosnmp.SnmpV3PrivProtocol{
+	"":        gosnmp.NoPriv,
+	"DES":     gosnmp.DES,
+	"AES":     gosnmp.AES,
+	"AES192":  gosnmp.AES192,
+	"AES256":  gosnmp.AES256,
+	"AES192C": gosnmp.AES192C,
+	"AES256C": gosnmp.AES256C,
+}
+
+// snmpDevice reads DOM values from ENTITY-SENSOR-MIB, as an alternative to
+// show commands of remoteDevice on devices without SSH access.
+type snmpDevice struct {
+	storage.Device
+}
+
+func newSNMPDevice(dev storage.Device) snmpDevice {
+	return snmpDevice{Device: dev}
+}
+
+func (d snmpDevice) labels() influx.Labels {
+	return influx.Labels{Site: d.Site, Group: d.Group, Tags: d.Tags}
+}
+
+// client returns an SNMP client of the device, not connected yet.
+func (d snmpDevice) client(timeout int) (*gosnmp.GoSNMP, error) {
+	client := &gosnmp.GoSNMP{
+		Target:             d.IPAddress,
+		Port:               d.SNMP.Port,
+		Transport:          "udp",
+		Timeout:            time.Duration(timeout) * time.Second,
+		Retries:            1,
+		MaxOids:            gosnmp.MaxOids,
+		MaxRepetitions:     25,
+		ExponentialTimeout: true,
+	}
+	if client.Port == 0 {
+		client.Port = storage.DefaultSNMPPort
+	}
+
+	switch d.SNMP.Version {
+	case storage.SNMPVersion2c, "":
+		client.Version = gosnmp.Version2c
+		client.Community = d.SNMP.Community
+	case storage.SNMPVersion3:
+		auth, ok := snmpAuthProtocols[d.SNMP.AuthProtocol]
+		if !ok {
+			return nil, fmt.Errorf("unknown SNMP auth protocol %q", d.SNMP.AuthProtocol)
+		}
+		priv, ok := snmpPrivProtocols[d.SNMP.PrivProtocol]
+		if !ok {
+			return nil, fmt.Errorf("unknown SNMP privacy protocol %q", d.SNMP.PrivProtocol)
+		}
+
+		flags := gosnmp.NoAuthNoPriv
+		switch {
+		case priv != gosnmp.NoPriv:
+			flags = gosnmp.AuthPriv
+		case auth != gosnmp.NoAuth:
+			flags = gosnmp.AuthNoPriv
+		}
+
+		client.Version = gosnmp.Version3
+		client.SecurityModel = gosnmp.UserSecurityModel
+		client.MsgFlags = flags
+		client.SecurityParameters = &gosnmp.UsmSecurityParameters{
+			UserName:                 d.SNMP.User,
+			AuthenticationProtocol:   auth,
+			AuthenticationPassphrase: d.SNMP.AuthPassword,
+			PrivacyProtocol:          priv,
+			PrivacyPassphrase:        d.SNMP.PrivPassword,
+		}
+	default:
+		return nil, fmt.Errorf("unknown SNMP version %q", d.SNMP.Version)
+	}
+
+	return client, nil
+}
+
+// sensor is a row of entPhySensorTable.
+type sensor struct {
+	index     int
+	kind      int
+	scale     int
+	precision int
+	value     int64
+	status    int
+}
+
+// reading converts the raw value to units, e.g. 1234 with scale milli and
+// precision 1 is 0.1234.
+func (s sensor) reading() float64 {
+	return float64(s.value) * math.Pow10(3*(s.scale-sensorScaleUnits)) / math.Pow10(s.precision)
+}
+
+// entity is a row of entPhysicalTable.
+type entity struct {
+	containedIn int
+	class       int
+	name        string
+	descr       string
+}
+
+// monitorInterfaces walks sensor and entity tables and returns one
+// measurement per interface with transceiver sensors. Sensors belong to the
+// interface of the nearest port entity they are contained in. Of several lanes
+// the first one is used, like with EEPROM.
+func (d snmpDevice) monitorInterfaces(client *gosnmp.GoSNMP) ([]interfaceMeasurement, error) {
+	sensors, err := walkSensors(client)
+	if err != nil {
+		return nil, err
+	}
+
+	entities, err := walkEntities(client)
+	if err != nil {
+		return nil, err
+	}
+
+	ifNames, err := walkIfNames(client, entities)
+	if err != nil {
+		return nil, err
+	}
+
+	type measured struct {
+		influx.Measurement
+		set map[string]bool
+	}
+	byInterface := make(map[string]*measured)
+	var order []string
+
+	for _, s := range sensors {
+		if s.status != sensorStatusOK {
+			continue
+		}
+
+		inf := interfaceOf(s.index, entities, ifNames)
+		if inf == "" {
+			continue
+		}
+
+		field, value, ok := sensorField(s, entities[s.index])
+		if !ok {
+			continue
+		}
+
+		m, ok := byInterface[inf]
+		if !ok {
+			m = &measured{set: make(map[string]bool)}
+			byInterface[inf] = m
+			order = append(order, inf)
+		}
+		if m.set[field] {
+			continue
+		}
+		m.set[field] = true
+
+		switch field {
+		case "temp":
+			m.Temperature = value
+		case "vcc":
+			m.Voltage = value
+		case "tx_pwr":
+			m.TxPower = value
+		case "rx_pwr":
+			m.RxPower = value
+		}
+	}
+
+	measurements := make([]interfaceMeasurement, 0, len(order))
+	for _, inf := range order {
+		measurements = append(measurements, interfaceMeasurement{Measurement: byInterface[inf].Measurement, Interface: inf})
+	}
+
+	return measurements, nil
+}
+
+// sensorField tells which Influx field the sensor reports. Power sensors are
+// told apart by their name, vendors name them e.g. "Te1/1 Receive Power".
+func sensorField(s sensor, e entity) (string, float64, bool) {
+	switch s.kind {
+	case sensorTypeCelsius:
+		return "temp", s.reading(), true
+	case sensorTypeVoltsDC:
+		return "vcc", s.reading(), true
+	case sensorTypeDBm, sensorTypeWatts:
+		value := s.reading()
+		if s.kind == sensorTypeWatts {
+			if value <= 0 {
+				return "", 0, false
+			}
+			value = 10 * math.Log10(value*1000)
+		}
+
+		words := strings.FieldsFunc(strings.ToLower(e.name+" "+e.descr), func(r rune) bool {
+			return (r < 'a' || r > 'z') && (r < '0' || r > '9')
+		})
+		switch {
+		case slices.Contains(words, "rx"), slices.Contains(words, "receive"), slices.Contains(words, "received"):
+			return "rx_pwr", value, true
+		case slices.Contains(words, "tx"), slices.Contains(words, "transmit"), slices.Contains(words, "transmitted"):
+			return "tx_pwr", value, true
+		}
+	}
+
+	return "", 0, false
+}
+
+// interfaceOf walks up from the sensor to the first entity mapped to an
+// interface name, or to a port entity when the agent has no alias mapping.
+func interfaceOf(index int, entities map[int]entity, ifNames map[int]string) string {
+	for range maxContainmentDepth {
+		if name, ok := ifNames[index]; ok {
+			return name
+		}
+
+		e, ok := entities[index]
+		if !ok {
+			return ""
+		}
+		if e.class == entityClassPort && e.name != "" {
+			return e.name
+		}
+
+		index = e.containedIn
+	}
+
+	return ""
+}
+
+func walkSensors(client *gosnmp.GoSNMP) ([]sensor, error) {
+	byIndex := make(map[int]*sensor)
+	columns := map[string]func(*sensor, gosnmp.SnmpPDU){
+		OIDEntPhySensorType:       func(s *sensor, pdu gosnmp.SnmpPDU) { s.kind = int(gosnmp.ToBigInt(pdu.Value).Int64()) },
+		OIDEntPhySensorScale:      func(s *sensor, pdu gosnmp.SnmpPDU) { s.scale = int(gosnmp.ToBigInt(pdu.Value).Int64()) },
+		OIDEntPhySensorPrecision:  func(s *sensor, pdu gosnmp.SnmpPDU) { s.precision = int(gosnmp.ToBigInt(pdu.Value).Int64()) },
+		OIDEntPhySensorValue:      func(s *sensor, pdu gosnmp.SnmpPDU) { s.value = gosnmp.ToBigInt(pdu.Value).Int64() },
+		OIDEntPhySensorOperStatus: func(s *sensor, pdu gosnmp.SnmpPDU) { s.status = int(gosnmp.ToBigInt(pdu.Value).Int64()) },
+	}
+
+	for oid, set := range columns {
+		pdus, err := client.BulkWalkAll(oid)
+		if err != nil {
+			return nil, fmt.Errorf("cannot walk %s: %w", oid, err)
+		}
+
+		for _, pdu := range pdus {
+			index, ok := oidIndex(oid, pdu.Name)
+			if !ok {
+				continue
+			}
+			if byIndex[index] == nil {
+				byIndex[index] = &sensor{index: index, scale: sensorScaleUnits}
+			}
+			set(byIndex[index], pdu)
+		}
+	}
+
+	sensors := make([]sensor, 0, len(byIndex))
+	for _, s := range byIndex {
+		sensors = append(sensors, *s)
+	}
+	slices.SortFunc(sensors, func(a, b sensor) int { return a.index - b.index })
+
+	return sensors, nil
+}
+
+func walkEntities(client *gosnmp.GoSNMP) (map[int]entity, error) {
+	entities := make(map[int]entity)
+	columns := map[string]func(*entity, gosnmp.SnmpPDU){
+		OIDEntPhysicalDescr:       func(e *entity, pdu gosnmp.SnmpPDU) { e.descr = pduString(pdu) },
+		OIDEntPhysicalContainedIn: func(e *entity, pdu gosnmp.SnmpPDU) { e.containedIn = int(gosnmp.ToBigInt(pdu.Value).Int64()) },
+		OIDEntPhysicalClass:       func(e *entity, pdu gosnmp.SnmpPDU) { e.class = int(gosnmp.ToBigInt(pdu.Value).Int64()) },
+		OIDEntPhysicalName:        func(e *entity, pdu gosnmp.SnmpPDU) { e.name = pduString(pdu) },
+	}
+
+	for oid, set := range columns {
+		pdus, err := client.BulkWalkAll(oid)
+		if err != nil {
+			return nil, fmt.Errorf("cannot walk %s: %w", oid, err)
+		}
+
+		for _, pdu := range pdus {
+			index, ok := oidIndex(oid, pdu.Name)
+			if !ok {
+				continue
+			}
+			e := entities[index]
+			set(&e, pdu)
+			entities[index] = e
+		}
+	}
+
+	return entities, nil
+}
+
+// walkIfNames maps entity indexes to ifName through entAliasMappingTable.
+// Agents without the table are fine, port entity names are used then.
+func walkIfNames(client *gosnmp.GoSNMP, entities map[int]entity) (map[int]string, error) {
+	aliases, err := client.BulkWalkAll(OIDEntAliasMappingIdentifier)
+	if err != nil {
+		return nil, fmt.Errorf("cannot walk %s: %w", OIDEntAliasMappingIdentifier, err)
+	}
+	if len(aliases) == 0 {
+		return nil, nil
+	}
+
+	names, err := client.BulkWalkAll(OIDIfName)
+	if err != nil {
+		return nil, fmt.Errorf("cannot walk %s: %w", OIDIfName, err)
+	}
+
+	byIfIndex := make(map[int]string, len(names))
+	for _, pdu := range names {
+		if ifIndex, ok := oidIndex(OIDIfName, pdu.Name); ok {
+			byIfIndex[ifIndex] = pduString(pdu)
+		}
+	}
+
+	ifNames := make(map[int]string)
+	for _, pdu := range aliases {
+		// The index is entPhysicalIndex.entAliasLogicalIndexOrZero, the
+		// value is an ifIndex instance, e.g. ifIndex.5.
+		suffix := strings.TrimPrefix(pdu.Name, OIDEntAliasMappingIdentifier+".")
+		index, err := strconv.Atoi(strings.Split(suffix, ".")[0])
+		if err != nil {
+			continue
+		}
+		if _, ok := entities[index]; !ok {
+			continue
+		}
+
+		target, ok := pdu.Value.(string)
+		if !ok {
+			continue
+		}
+		ifIndex, err := strconv.Atoi(target[strings.LastIndex(target, ".")+1:])
+		if err != nil {
+			continue
+		}
+
+		if name, ok := byIfIndex[ifIndex]; ok && name != "" {
+			ifNames[index] = name
+		}
+	}
+
+	return ifNames, nil
+}
+
+// oidIndex returns the single-number index of a column instance.
+func oidIndex(column string, name string) (int, bool) {
+	suffix, ok := strings.CutPrefix(name, column+".")
+	if !ok {
+		return 0, false
+	}
+
+	index, err := strconv.Atoi(suffix)
+
+	return index, err == nil
+}
+
+func pduString(pdu gosnmp.SnmpPDU) string {
+	if b, ok := pdu.Value.([]byte); ok {
+		return string(b)
+	}
+
+	return fmt.Sprint(pdu.Value)
+}
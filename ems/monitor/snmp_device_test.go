@@ -0,0 +1,160 @@
+package monitor
+
+import (
+	"testing"
+
+	"pi-wegrzyn/ems/influx"
+	"pi-wegrzyn/ems/storage"
+
+	gocmp "github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/gosnmp/gosnmp"
+)
+
+func TestSNMPDevice_monitorInterfaces(t *testing.T) {
+	integer := func(column string, index string, value int) gosnmp.SnmpPDU {
+		return gosnmp.SnmpPDU{Name: column + "." + index, Type: gosnmp.Integer, Value: value}
+	}
+	str := func(column string, index string, value string) gosnmp.SnmpPDU {
+		return gosnmp.SnmpPDU{Name: column + "." + index, Type: gosnmp.OctetString, Value: []byte(value)}
+	}
+	entity := func(index string, class int, containedIn int, name string) []gosnmp.SnmpPDU {
+		return []gosnmp.SnmpPDU{
+			integer(OIDEntPhysicalClass, index, class),
+			integer(OIDEntPhysicalContainedIn, index, containedIn),
+			str(OIDEntPhysicalName, index, name),
+			str(OIDEntPhysicalDescr, index, name),
+		}
+	}
+	sensor := func(index string, kind, scale, precision, value, status int) []gosnmp.SnmpPDU {
+		return []gosnmp.SnmpPDU{
+			integer(OIDEntPhySensorType, index, kind),
+			integer(OIDEntPhySensorScale, index, scale),
+			integer(OIDEntPhySensorPrecision, index, precision),
+			integer(OIDEntPhySensorValue, index, value),
+			integer(OIDEntPhySensorOperStatus, index, status),
+		}
+	}
+
+	var pdus []gosnmp.SnmpPDU
+	for _, rows := range [][]gosnmp.SnmpPDU{
+		entity("1", 3, 0, "chassis"),
+		// Port mapped to ifName through entAliasMappingTable.
+		entity("10", 10, 1, "Eth1/1 port"),
+		entity("11", 9, 10, "Eth1/1 transceiver"),
+		entity("12", 8, 11, "Eth1/1 Temperature Sensor"),
+		sensor("12", sensorTypeCelsius, 9, 1, 354, 1),
+		entity("13", 8, 11, "Eth1/1 Supply Voltage Sensor"),
+		sensor("13", sensorTypeVoltsDC, 8, 0, 3300, 1),
+		entity("14", 8, 11, "Eth1/1 Lane 1 Receive Power Sensor"),
+		sensor("14", sensorTypeDBm, 9, 2, -312, 1),
+		entity("15", 8, 11, "Eth1/1 Lane 2 Receive Power Sensor"),
+		sensor("15", sensorTypeDBm, 9, 2, -500, 1),
+		entity("16", 8, 11, "Eth1/1 Lane 1 Tx Power Sensor"),
+		sensor("16", sensorTypeWatts, 7, 0, 1000, 1),
+		// Port without alias mapping, its name is used.
+		entity("20", 10, 1, "Ethernet1/2"),
+		entity("21", 8, 20, "Ethernet1/2 Temperature"),
+		sensor("21", sensorTypeCelsius, 9, 0, 40, 1),
+		entity("22", 8, 20, "Ethernet1/2 Rx Power"),
+		sensor("22", sensorTypeDBm, 9, 0, -40, 2),
+		// Sensors outside of ports are skipped.
+		entity("30", 8, 1, "Inlet Temperature"),
+		sensor("30", sensorTypeCelsius, 9, 0, 25, 1),
+		{
+			{Name: OIDEntAliasMappingIdentifier + ".10.0", Type: gosnmp.ObjectIdentifier, Value: ".1.3.6.1.2.1.2.2.1.1.5"},
+			str(OIDIfName, "5", "Ethernet1/1"),
+		},
+	} {
+		pdus = append(pdus, rows...)
+	}
+
+	agent := newTestAgent(t, "public", pdus)
+
+	d := newSNMPDevice(storage.Device{
+		IPAddress: agent.host,
+		Collector: storage.CollectorSNMP,
+		SNMP:      storage.SNMP{Version: storage.SNMPVersion2c, Port: agent.port, Community: "public"},
+	})
+
+	client, err := d.client(5)
+	if err != nil {
+		t.Fatalf("cannot create client: %v", err)
+	}
+	if err := client.Connect(); err != nil {
+		t.Fatalf("cannot connect: %v", err)
+	}
+	defer func() { _ = client.Conn.Close() }()
+
+	got, err := d.monitorInterfaces(client)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []interfaceMeasurement{
+		{Interface: "Ethernet1/1", Measurement: influx.Measurement{Temperature: 35.4, Voltage: 3.3, RxPower: -3.12, TxPower: 0}},
+		{Interface: "Ethernet1/2", Measurement: influx.Measurement{Temperature: 40}},
+	}
+	if diff := gocmp.Diff(got, want, cmpopts.EquateApprox(0, 1e-9)); diff != "" {
+		t.Errorf("measurements mismatch (-got +want):\n%s", diff)
+	}
+}
+
+func TestSNMPDevice_client(t *testing.T) {
+	tests := []struct {
+		name      string
+		snmp      storage.SNMP
+		wantFlags gosnmp.SnmpV3MsgFlags
+		wantErr   string
+	}{
+		{
+			name:      "v3 without auth",
+			snmp:      storage.SNMP{Version: storage.SNMPVersion3, User: "noc"},
+			wantFlags: gosnmp.NoAuthNoPriv,
+		},
+		{
+			name:      "v3 with auth",
+			snmp:      storage.SNMP{Version: storage.SNMPVersion3, User: "noc", AuthProtocol: "SHA256", AuthPassword: "password"},
+			wantFlags: gosnmp.AuthNoPriv,
+		},
+		{
+			name:      "v3 with auth and privacy",
+			snmp:      storage.SNMP{Version: storage.SNMPVersion3, User: "noc", AuthProtocol: "SHA", AuthPassword: "password", PrivProtocol: "AES", PrivPassword: "password"},
+			wantFlags: gosnmp.AuthPriv,
+		},
+		{
+			name:    "unknown auth protocol",
+			snmp:    storage.SNMP{Version: storage.SNMPVersion3, User: "noc", AuthProtocol: "SHA1024"},
+			wantErr: `unknown SNMP auth protocol "SHA1024"`,
+		},
+		{
+			name:    "unknown version",
+			snmp:    storage.SNMP{Version: "1"},
+			wantErr: `unknown SNMP version "1"`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client, err := newSNMPDevice(storage.Device{IPAddress: "192.0.2.1", SNMP: tc.snmp}).client(5)
+			if tc.wantErr != "" {
+				if err == nil || err.Error() != tc.wantErr {
+					t.Fatalf("expected error %q, got %v", tc.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if client.Version != gosnmp.Version3 || client.MsgFlags != tc.wantFlags || client.Port != storage.DefaultSNMPPort {
+				t.Errorf("expected v3 on port %d with flags %v, got %v on port %d with flags %v", storage.DefaultSNMPPort, tc.wantFlags, client.Version, client.Port, client.MsgFlags)
+			}
+
+			params, ok := client.SecurityParameters.(*gosnmp.UsmSecurityParameters)
+			if !ok || params.UserName != tc.snmp.User {
+				t.Errorf("expected USM parameters of %s, got %v", tc.snmp.User, client.SecurityParameters)
+			}
+		})
+	}
+}
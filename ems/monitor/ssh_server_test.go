@@ -0,0 +1,198 @@
+package monitor
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/binary"
+	"io"
+	"net"
+	"strconv"
+	"sync/atomic"
+	"testing"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// testCommands are answered by the test SSH server.
+var testCommands = map[string]string{
+	CmdShowFiberInterfaces: "eth0\n",
+}
+
+// testSubsystems are served by the test SSH server on subsystem requests.
+var testSubsystems = map[string]func(io.ReadWriter){
+	"netconf": serveTestNETCONF,
+}
+
+type testServer struct {
+	host string
+	port uint16
+	// conns counts accepted SSH connections.
+	conns atomic.Int32
+}
+
+// newTestServer starts an SSH server on localhost which runs testCommands
+// and testSubsystems, and forwards direct-tcpip channels, so it can act as
+// a jump host.
+func newTestServer(t *testing.T, config *ssh.ServerConfig) *testServer {
+	t.Helper()
+
+	config.AddHostKey(newTestSigner(t))
+	server := &testServer{}
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("cannot listen: %v", err)
+	}
+	t.Cleanup(func() { _ = ln.Close() })
+
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+
+			go server.serve(conn, config)
+		}
+	}()
+
+	host, port, err := net.SplitHostPort(ln.Addr().String())
+	if err != nil {
+		t.Fatalf("cannot split address: %v", err)
+	}
+
+	p, err := strconv.ParseUint(port, 10, 16)
+	if err != nil {
+		t.Fatalf("cannot parse port: %v", err)
+	}
+
+	server.host, server.port = host, uint16(p)
+
+	return server
+}
+
+func (s *testServer) serve(conn net.Conn, config *ssh.ServerConfig) {
+	_, chans, reqs, err := ssh.NewServerConn(conn, config)
+	if err != nil {
+		_ = conn.Close()
+		return
+	}
+	s.conns.Add(1)
+	go ssh.DiscardRequests(reqs)
+
+	for newChannel := range chans {
+		switch newChannel.ChannelType() {
+		case "session":
+		case "direct-tcpip":
+			go forwardTestChannel(newChannel)
+			continue
+		default:
+			_ = newChannel.Reject(ssh.UnknownChannelType, "unsupported channel type")
+			continue
+		}
+
+		channel, requests, err := newChannel.Accept()
+		if err != nil {
+			continue
+		}
+
+		go func() {
+			defer func() { _ = channel.Close() }()
+
+			for req := range requests {
+				if req.Type == "subsystem" {
+					var payload struct{ Name string }
+					if err := ssh.Unmarshal(req.Payload, &payload); err != nil {
+						_ = req.Reply(false, nil)
+						continue
+					}
+
+					serve, ok := testSubsystems[payload.Name]
+					_ = req.Reply(ok, nil)
+					if !ok {
+						continue
+					}
+
+					go ssh.DiscardRequests(requests)
+					serve(channel)
+
+					return
+				}
+
+				if req.Type != "exec" {
+					_ = req.Reply(false, nil)
+					continue
+				}
+
+				var payload struct{ Command string }
+				if err := ssh.Unmarshal(req.Payload, &payload); err != nil {
+					_ = req.Reply(false, nil)
+					continue
+				}
+				_ = req.Reply(true, nil)
+
+				status := uint32(0)
+				output, ok := testCommands[payload.Command]
+				if !ok {
+					status = 127
+				}
+				_, _ = channel.Write([]byte(output))
+
+				exit := make([]byte, 4)
+				binary.BigEndian.PutUint32(exit, status)
+				_, _ = channel.SendRequest("exit-status", false, exit)
+
+				return
+			}
+		}()
+	}
+}
+
+func forwardTestChannel(newChannel ssh.NewChannel) {
+	var target struct {
+		Host       string
+		Port       uint32
+		OriginHost string
+		OriginPort uint32
+	}
+	if err := ssh.Unmarshal(newChannel.ExtraData(), &target); err != nil {
+		_ = newChannel.Reject(ssh.ConnectionFailed, err.Error())
+		return
+	}
+
+	conn, err := net.Dial("tcp", net.JoinHostPort(target.Host, strconv.Itoa(int(target.Port))))
+	if err != nil {
+		_ = newChannel.Reject(ssh.ConnectionFailed, err.Error())
+		return
+	}
+
+	channel, requests, err := newChannel.Accept()
+	if err != nil {
+		_ = conn.Close()
+		return
+	}
+	go ssh.DiscardRequests(requests)
+
+	go func() {
+		_, _ = io.Copy(conn, channel)
+		_ = conn.Close()
+	}()
+	_, _ = io.Copy(channel, conn)
+	_ = channel.Close()
+}
+
+func newTestSigner(t *testing.T) ssh.Signer {
+	t.Helper()
+
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("cannot generate key: %v", err)
+	}
+
+	signer, err := ssh.NewSignerFromKey(key)
+	if err != nil {
+		t.Fatalf("cannot create signer: %v", err)
+	}
+
+	return signer
+}
@@ -0,0 +1,62 @@
+package monitor
+
+import "fmt"
+
+// ModuleState is the CMIS module state machine state of the lower page.
+type ModuleState byte
+
+const (
+	ModuleLowPwr ModuleState = iota + 1
+	ModulePwrUp
+	ModuleReady
+	ModulePwrDn
+	ModuleFault
+)
+
+var moduleStates = map[ModuleState]string{
+	ModuleLowPwr: "ModuleLowPwr",
+	ModulePwrUp:  "ModulePwrUp",
+	ModuleReady:  "ModuleReady",
+	ModulePwrDn:  "ModulePwrDn",
+	ModuleFault:  "ModuleFault",
+}
+
+func (s ModuleState) String() string {
+	if name, ok := moduleStates[s]; ok {
+		return name
+	}
+
+	return fmt.Sprintf("reserved (%d)", s)
+}
+
+// DataPathState is the CMIS data path state machine state of a host lane,
+// from page 11h.
+type DataPathState byte
+
+const (
+	DPDeactivated DataPathState = iota + 1
+	DPInit
+	DPDeinit
+	DPActivated
+	DPTxTurnOn
+	DPTxTurnOff
+	DPInitialized
+)
+
+var dataPathStates = map[DataPathState]string{
+	DPDeactivated: "DPDeactivated",
+	DPInit:        "DPInit",
+	DPDeinit:      "DPDeinit",
+	DPActivated:   "DPActivated",
+	DPTxTurnOn:    "DPTxTurnOn",
+	DPTxTurnOff:   "DPTxTurnOff",
+	DPInitialized: "DPInitialized",
+}
+
+func (s DataPathState) String() string {
+	if name, ok := dataPathStates[s]; ok {
+		return name
+	}
+
+	return fmt.Sprintf("reserved (%d)", s)
+}
@@ -0,0 +1,239 @@
+package monitor
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+	"time"
+
+	"pi-wegrzyn/ems/influx"
+	"pi-wegrzyn/ems/storage"
+)
+
+const (
+	// baselineTau is the time constant of rolling baselines, a new level
+	// becomes the baseline after a few of them.
+	baselineTau = 6 * time.Hour
+	// fitTau is the time constant of drift fits, older readings fade out.
+	fitTau = 7 * 24 * time.Hour
+	// minFitSpread is the standard deviation of reading times a fit needs
+	// before its slope counts, in days.
+	minFitSpread = 0.5
+
+	// Steps are changes over stepSigma standard deviations of the
+	// baseline, once it has minSamples readings.
+	stepSigma  = 4
+	minSamples = 3
+)
+
+// trendMetric is a reading followed by rolling baselines. Slow drifts are
+// tracked for readings in dB only.
+type trendMetric struct {
+	name  string
+	unit  string
+	value func(influx.Measurement) float64
+	// lowAlarm is the threshold forecasts aim at, 0 when not known.
+	lowAlarm func(influx.Measurement) float64
+	// minStep is the smallest change counted as a step, however quiet the
+	// reading is.
+	minStep float64
+	drift   bool
+}
+
+var trendMetrics = []trendMetric{
+	{
+		name: "tx_pwr", unit: "dBm", minStep: 3, drift: true,
+		value:    func(m influx.Measurement) float64 { return measuredPower(m.TxPower) },
+		lowAlarm: func(m influx.Measurement) float64 { return m.TxPowerLowAlarm },
+	},
+	{
+		name: "rx_pwr", unit: "dBm", minStep: 3, drift: true,
+		value:    func(m influx.Measurement) float64 { return measuredPower(m.RxPower) },
+		lowAlarm: func(m influx.Measurement) float64 { return m.RxPowerLowAlarm },
+	},
+	{
+		name: "osnr", unit: "dB", minStep: 3, drift: true,
+		value: func(m influx.Measurement) float64 { return m.OSNR },
+	},
+	{
+		name: "temp", unit: "°C", minStep: 10,
+		value: func(m influx.Measurement) float64 { return m.Temperature },
+	},
+	{
+		name: "vcc", unit: "V", minStep: 0.2,
+		value: func(m influx.Measurement) float64 { return m.Voltage },
+	},
+}
+
+// updateTrends feeds readings of interfaces to their rolling baselines and
+// drift fits and records events of steps, drifts and forecast threshold
+// crossings. Devices in maintenance record no events: steps of planned work
+// become the baseline silently, drifts and forecasts lasting past the window
+// are raised then.
+func (m Monitor) updateTrends(ctx context.Context, run monitoringRun, device storage.Device, data []interfaceMeasurement) {
+	stored, err := m.db.DeviceTrends(ctx, device.ID)
+	if err != nil {
+		slog.ErrorContext(ctx, "cannot get trends", slog.Any("deviceID", device.ID), slog.Any("error", err))
+
+		return
+	}
+
+	type trendKey struct {
+		iface  string
+		metric string
+	}
+	previous := make(map[trendKey]storage.Trend, len(stored))
+	for _, t := range stored {
+		previous[trendKey{t.Interface, t.Metric}] = t
+	}
+
+	now := time.Now()
+	for _, measurement := range data {
+		for _, metric := range trendMetrics {
+			value := metric.value(measurement.Measurement)
+
+			trend, ok := previous[trendKey{measurement.Interface, metric.name}]
+			if !ok {
+				// Readings a module does not report, e.g. OSNR of
+				// non-coherent optics, are zero from the start.
+				if value == 0 {
+					continue
+				}
+				trend = storage.Trend{DeviceID: device.ID, Interface: measurement.Interface, Metric: metric.name}
+			}
+
+			var lowAlarm float64
+			if metric.lowAlarm != nil {
+				lowAlarm = metric.lowAlarm(measurement.Measurement)
+			}
+
+			before := trend
+			var events []storage.Event
+			trend, events = updateTrend(trend, metric, value, lowAlarm, now, m.config)
+			if run.inMaintenance(device.ID) {
+				events = nil
+				trend.Drifting = before.Drifting
+				if before.ForecastDays == nil {
+					trend.ForecastDays = nil
+				}
+			}
+
+			for _, event := range events {
+				event.Hostname = device.Hostname
+				if err := m.db.CreateEvent(ctx, event); err != nil {
+					slog.ErrorContext(ctx, "cannot create event", slog.Any("deviceID", device.ID), slog.String("interface", measurement.Interface), slog.Any("error", err))
+				}
+			}
+
+			if err := m.db.UpdateTrend(ctx, trend); err != nil {
+				slog.ErrorContext(ctx, "cannot update trend", slog.Any("deviceID", device.ID), slog.String("interface", measurement.Interface), slog.Any("error", err))
+			}
+		}
+	}
+}
+
+// updateTrend returns the trend with value read at now and events of the
+// reading stepping away from the baseline, of the fit starting to drift
+// faster than cfg.TrendDrift and of the fit reaching lowAlarm within
+// cfg.TrendHorizon. Events are raised once, until the condition clears.
+func updateTrend(trend storage.Trend, metric trendMetric, value, lowAlarm float64, now time.Time, cfg Config) (storage.Trend, []storage.Event) {
+	elapsed := max(now.Sub(trend.Updated), 0)
+	if trend.Samples == 0 {
+		trend.Mean, trend.Variance = value, 0
+		trend.Origin, trend.Fit = now, storage.TrendFit{}
+		elapsed = 0
+	}
+
+	var events []storage.Event
+	newEvent := func(kind, message string) {
+		events = append(events, storage.Event{DeviceID: trend.DeviceID, Interface: trend.Interface, Kind: kind, Message: message})
+	}
+
+	deviation := value - trend.Mean
+	limit := max(stepSigma*math.Sqrt(trend.Variance), metric.minStep)
+	stepped := trend.Samples >= minSamples && math.Abs(deviation) > limit
+	if stepped && !trend.Step {
+		newEvent(storage.EventStepChange, fmt.Sprintf("%s stepped from %.2f to %.2f %s", metric.name, trend.Mean, value, metric.unit))
+
+		// A step is not a drift, the fit starts over at the new level.
+		trend.Origin, trend.Fit = now, storage.TrendFit{}
+		elapsed = 0
+	}
+	trend.Step = stepped
+
+	alpha := 1 - math.Exp(-elapsed.Seconds()/baselineTau.Seconds())
+	trend.Mean += alpha * deviation
+	trend.Variance = (1 - alpha) * (trend.Variance + alpha*deviation*deviation)
+
+	t := now.Sub(trend.Origin).Hours() / 24
+	trend.Fit = fitAdd(trend.Fit, t, value, math.Exp(-elapsed.Seconds()/fitTau.Seconds()))
+	trend.Samples++
+	trend.Updated = now
+
+	trend.Slope = 0
+	if slope, ok := fitSlope(trend.Fit); ok && metric.drift {
+		trend.Slope = slope
+	}
+
+	perWeek := trend.Slope * 7
+	switch {
+	case !trend.Drifting && cfg.TrendDrift > 0 && math.Abs(perWeek) >= cfg.TrendDrift:
+		trend.Drifting = true
+		direction := "rising"
+		if perWeek < 0 {
+			direction = "declining"
+		}
+		newEvent(storage.EventDrift, fmt.Sprintf("%s %s by %.2f dB/week", metric.name, direction, math.Abs(perWeek)))
+	case trend.Drifting && math.Abs(perWeek) < cfg.TrendDrift/2:
+		trend.Drifting = false
+	}
+
+	forecast := trend.ForecastDays
+	trend.ForecastDays = nil
+	if lowAlarm != 0 && trend.Slope < 0 {
+		// Readings already under the threshold are alarms of the module.
+		fitted := fitAt(trend.Fit, trend.Slope, t)
+		if days := (lowAlarm - fitted) / trend.Slope; fitted > lowAlarm && days <= cfg.TrendHorizon {
+			trend.ForecastDays = &days
+			if forecast == nil {
+				newEvent(storage.EventThresholdForecast, fmt.Sprintf("%s reaches the low alarm threshold of %.2f %s in %.1f days at %.2f dB/week",
+					metric.name, lowAlarm, metric.unit, days, perWeek))
+			}
+		}
+	}
+
+	return trend, events
+}
+
+// fitAdd fades sums of the fit by decay and adds value read at t.
+func fitAdd(f storage.TrendFit, t, value, decay float64) storage.TrendFit {
+	return storage.TrendFit{
+		W:  f.W*decay + 1,
+		T:  f.T*decay + t,
+		X:  f.X*decay + value,
+		TT: f.TT*decay + t*t,
+		TX: f.TX*decay + t*value,
+	}
+}
+
+// fitSlope returns the slope of the weighted least squares line per day,
+// not ok until readings spread over minFitSpread.
+func fitSlope(f storage.TrendFit) (float64, bool) {
+	if f.W == 0 {
+		return 0, false
+	}
+
+	meanT := f.T / f.W
+	varT := f.TT/f.W - meanT*meanT
+	if varT < minFitSpread*minFitSpread {
+		return 0, false
+	}
+
+	return (f.TX/f.W - meanT*f.X/f.W) / varT, true
+}
+
+// fitAt returns the value of the fitted line at t.
+func fitAt(f storage.TrendFit, slope, t float64) float64 {
+	return f.X/f.W + slope*(t-f.T/f.W)
+}
@@ -0,0 +1,117 @@
+package monitor
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	gocmp "github.com/google/go-cmp/cmp"
+
+	"pi-wegrzyn/ems/storage"
+)
+
+var testTrendConfig = Config{TrendDrift: 0.5, TrendHorizon: 30}
+
+// feedTrend reads values every interval and returns the last trend and
+// kinds of all events.
+func feedTrend(metric trendMetric, values func(i int) float64, n int, interval time.Duration, lowAlarm float64) (storage.Trend, []string) {
+	now := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+	trend := storage.Trend{DeviceID: 1, Interface: "eth0", Metric: metric.name}
+
+	var kinds []string
+	for i := range n {
+		var events []storage.Event
+		trend, events = updateTrend(trend, metric, values(i), lowAlarm, now.Add(time.Duration(i)*interval), testTrendConfig)
+		for _, e := range events {
+			kinds = append(kinds, e.Kind)
+		}
+	}
+
+	return trend, kinds
+}
+
+func TestUpdateTrend_step(t *testing.T) {
+	rxPower := trendMetrics[1]
+
+	// The Rx drop of testdata/generator.yaml, read every 30 seconds.
+	trend, kinds := feedTrend(rxPower, func(i int) float64 {
+		if i < 4 {
+			return -11 + 0.01*float64(i%2)
+		}
+		return -38.5
+	}, 10, 30*time.Second, 0)
+
+	if diff := gocmp.Diff(kinds, []string{storage.EventStepChange}); diff != "" {
+		t.Errorf("events mismatch (-got +want):\n%s", diff)
+	}
+	if !trend.Step || trend.Slope != 0 || trend.Drifting {
+		t.Errorf("expected step without drift, got %+v", trend)
+	}
+
+	// The new level becomes the baseline.
+	trend, kinds = feedTrend(rxPower, func(i int) float64 {
+		if i < 4 {
+			return -11
+		}
+		return -38.5
+	}, 200, 10*time.Minute, 0)
+	if len(kinds) != 1 || trend.Step || math.Abs(trend.Mean+38.5) > 0.5 {
+		t.Errorf("expected step absorbed by baseline, got %+v (events %v)", trend, kinds)
+	}
+}
+
+func TestUpdateTrend_event(t *testing.T) {
+	rxPower := trendMetrics[1]
+	now := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+
+	trend := storage.Trend{DeviceID: 1, Interface: "eth0", Metric: "rx_pwr", Samples: 10, Mean: -11, Variance: 0.0001, Origin: now, Updated: now}
+	_, events := updateTrend(trend, rxPower, -38.5, 0, now.Add(30*time.Second), testTrendConfig)
+
+	want := []storage.Event{{DeviceID: 1, Interface: "eth0", Kind: storage.EventStepChange, Message: "rx_pwr stepped from -11.00 to -38.50 dBm"}}
+	if diff := gocmp.Diff(events, want); diff != "" {
+		t.Errorf("events mismatch (-got +want):\n%s", diff)
+	}
+}
+
+func TestUpdateTrend_drift(t *testing.T) {
+	txPower := trendMetrics[0]
+
+	// 1 dB per week down from -3 dBm read hourly for two weeks, the low
+	// alarm of -8 dBm is reached after 5 weeks.
+	trend, kinds := feedTrend(txPower, func(i int) float64 { return -3 - float64(i)/24/7 }, 14*24+1, time.Hour, -8)
+
+	if diff := gocmp.Diff(kinds, []string{storage.EventDrift, storage.EventThresholdForecast}); diff != "" {
+		t.Errorf("events mismatch (-got +want):\n%s", diff)
+	}
+	if math.Abs(trend.Slope*7+1) > 1e-6 || !trend.Drifting {
+		t.Errorf("expected drift of -1 dB/week, got %v", trend.Slope*7)
+	}
+	if trend.ForecastDays == nil || math.Abs(*trend.ForecastDays-21) > 1e-6 {
+		t.Errorf("expected threshold reached in 21 days, got %v", trend.ForecastDays)
+	}
+}
+
+func TestUpdateTrend_noise(t *testing.T) {
+	for _, metric := range trendMetrics {
+		trend, kinds := feedTrend(metric, func(i int) float64 { return 20 + 0.05*float64(i%3-1) }, 14*24, time.Hour, 10)
+
+		if len(kinds) != 0 || trend.Step || trend.Drifting || trend.ForecastDays != nil {
+			t.Errorf("%s: expected no anomalies, got %+v (events %v)", metric.name, trend, kinds)
+		}
+	}
+}
+
+func TestFitSlope(t *testing.T) {
+	var fit storage.TrendFit
+	if _, ok := fitSlope(fit); ok {
+		t.Errorf("expected no slope of an empty fit")
+	}
+
+	for day := range 3 {
+		fit = fitAdd(fit, float64(day), 2*float64(day)+1, 1)
+	}
+	slope, ok := fitSlope(fit)
+	if !ok || math.Abs(slope-2) > 1e-9 || math.Abs(fitAt(fit, slope, 5)-11) > 1e-9 {
+		t.Errorf("expected slope 2 through (0, 1), got %v (ok %v)", slope, ok)
+	}
+}
@@ -0,0 +1,215 @@
+package monitor
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// vdmPages are upper pages of VDM descriptors 20h-23h and samples 24h, 26h
+// and 27h, appended to the EEPROM layout of modules advertising VDM. Samples
+// of page 25h stay in the base layout.
+var vdmPages = []byte{0x20, 0x21, 0x22, 0x23, 0x24, 0x26, 0x27}
+
+const (
+	// VdmEepromLength is EepromLength with VDM pages.
+	VdmEepromLength int = EepromLength + 7*PageLength
+
+	Page01hVdmSupported int = 2*PageLength + 0x0E
+
+	vdmTypeOSNR = 139
+
+	// vdmGroups of 64 observables each, descriptors of group g on page
+	// 20h+g and samples on page 24h+g.
+	vdmGroups      = 4
+	vdmGroupLength = PageLength / 2
+)
+
+// vdmSampleOffsets are offsets of sample pages 24h-27h in the layout.
+var vdmSampleOffsets = [vdmGroups]int{12 * PageLength, 7 * PageLength, 13 * PageLength, 14 * PageLength}
+
+// VdmObservable is a decoded VDM sample. Lane is 1-based, Value is in Unit.
+type VdmObservable struct {
+	Type  int
+	Name  string
+	Unit  string
+	Lane  int
+	Value float64
+}
+
+// Field names the observable as an Influx field, lanes other than the
+// first one get a suffix.
+func (o VdmObservable) Field() string {
+	if o.Lane <= 1 {
+		return "vdm_" + o.Name
+	}
+
+	return "vdm_" + o.Name + "_l" + strconv.Itoa(o.Lane)
+}
+
+// vdmFormat decodes a 16-bit sample.
+type vdmFormat func(raw uint16) float64
+
+func vdmU16(scale float64) vdmFormat {
+	return func(raw uint16) float64 { return float64(raw) * scale }
+}
+
+func vdmS16(scale float64) vdmFormat {
+	return func(raw uint16) float64 { return float64(int16(raw)) * scale }
+}
+
+// vdmF16 is the CMIS floating point format, a 5-bit exponent biased by 24
+// and an 11-bit mantissa: m * 10^(e-24).
+func vdmF16(raw uint16) float64 {
+	exponent := int(raw>>11) - 24
+	mantissa := float64(raw & 0x07FF)
+
+	return mantissa * math.Pow10(exponent)
+}
+
+type vdmType struct {
+	name   string
+	unit   string
+	format vdmFormat
+}
+
+// vdmTypes are observable types of CMIS 5.2 (1-24) and OIF C-CMIS (128-148)
+// by type ID. Observables of other types are skipped, their format is
+// unknown.
+var vdmTypes = map[int]vdmType{
+	1:  {"laser_age", "%", vdmU16(1)},
+	2:  {"tec_current", "%", vdmS16(100.0 / 32767)},
+	3:  {"laser_freq_error", "MHz", vdmS16(10)},
+	4:  {"laser_temp", "°C", vdmS16(1.0 / 256)},
+	5:  {"esnr_media", "dB", vdmU16(1.0 / 256)},
+	6:  {"esnr_host", "dB", vdmU16(1.0 / 256)},
+	7:  {"ltp_media", "dB", vdmU16(1.0 / 256)},
+	8:  {"ltp_host", "dB", vdmU16(1.0 / 256)},
+	9:  {"pre_fec_ber_min_media", "", vdmF16},
+	10: {"pre_fec_ber_min_host", "", vdmF16},
+	11: {"pre_fec_ber_max_media", "", vdmF16},
+	12: {"pre_fec_ber_max_host", "", vdmF16},
+	13: {"pre_fec_ber_avg_media", "", vdmF16},
+	14: {"pre_fec_ber_avg_host", "", vdmF16},
+	15: {"pre_fec_ber_media", "", vdmF16},
+	16: {"pre_fec_ber_host", "", vdmF16},
+	17: {"frame_error_min_media", "", vdmF16},
+	18: {"frame_error_min_host", "", vdmF16},
+	19: {"frame_error_max_media", "", vdmF16},
+	20: {"frame_error_max_host", "", vdmF16},
+	21: {"frame_error_avg_media", "", vdmF16},
+	22: {"frame_error_avg_host", "", vdmF16},
+	23: {"frame_error_media", "", vdmF16},
+	24: {"frame_error_host", "", vdmF16},
+
+	128: {"mod_bias_xi", "%", vdmU16(100.0 / 65535)},
+	129: {"mod_bias_xq", "%", vdmU16(100.0 / 65535)},
+	130: {"mod_bias_yi", "%", vdmU16(100.0 / 65535)},
+	131: {"mod_bias_yq", "%", vdmU16(100.0 / 65535)},
+	132: {"mod_bias_x_phase", "%", vdmU16(100.0 / 65535)},
+	133: {"mod_bias_y_phase", "%", vdmU16(100.0 / 65535)},
+	134: {"cd_short", "ps/nm", vdmS16(1)},
+	135: {"cd_long", "ps/nm", vdmS16(20)},
+	136: {"dgd", "ps", vdmU16(0.01)},
+	137: {"sopmd", "ps²", vdmU16(0.01)},
+	138: {"pdl", "dB", vdmU16(0.1)},
+	139: {"osnr", "dB", vdmU16(0.1)},
+	140: {"esnr", "dB", vdmU16(0.1)},
+	141: {"cfo", "MHz", vdmS16(1)},
+	142: {"evm", "%", vdmU16(100.0 / 65535)},
+	143: {"tx_pwr", "dBm", vdmS16(0.01)},
+	144: {"rx_total_pwr", "dBm", vdmS16(0.01)},
+	145: {"rx_signal_pwr", "dBm", vdmS16(0.01)},
+	146: {"sop_roc", "krad/s", vdmU16(1)},
+	147: {"mer", "dB", vdmU16(0.1)},
+	148: {"clock_recovery_loop", "%", vdmS16(100.0 / 32767)},
+}
+
+// VdmSupported tells that the module advertises VDM pages and the layout
+// has them.
+func (e Eeprom) VdmSupported() bool {
+	return len(e) >= VdmEepromLength && e.advertisesVdm()
+}
+
+func (e Eeprom) advertisesVdm() bool {
+	return e[Page01hVdmSupported]&0x40 != 0
+}
+
+// Vdm decodes every observable advertised by VDM descriptors, in the order
+// of descriptors.
+func (e Eeprom) Vdm() []VdmObservable {
+	if !e.VdmSupported() {
+		return nil
+	}
+
+	var observables []VdmObservable
+	for group := range vdmGroups {
+		descriptors := e[EepromLength+group*PageLength:]
+		samples := e[vdmSampleOffsets[group]:]
+
+		for i := range vdmGroupLength {
+			lane, typeID := int(descriptors[2*i]&0x0F), int(descriptors[2*i+1])
+			t, ok := vdmTypes[typeID]
+			if !ok {
+				continue
+			}
+
+			raw := uint16(samples[2*i])<<8 | uint16(samples[2*i+1])
+			observables = append(observables, VdmObservable{Type: typeID, Name: t.name, Unit: t.unit, Lane: lane + 1, Value: t.format(raw)})
+		}
+	}
+
+	return observables
+}
+
+// vdmDescriptor formats a descriptor for the inspector.
+func vdmDescriptor(b []byte) string {
+	typeID := int(b[1])
+	if typeID == 0 {
+		return "unused"
+	}
+
+	name := "type " + strconv.Itoa(typeID)
+	if t, ok := vdmTypes[typeID]; ok {
+		name = t.name
+	}
+
+	return fmt.Sprintf("%s, lane %d, threshold set %d", name, b[0]&0x0F+1, b[0]>>4)
+}
+
+// vdmSpecs lists descriptors and samples of VDM pages 20h-27h, as advertised
+// by the module.
+func vdmSpecs(e Eeprom) []pageSpec {
+	descriptorPages := make([]pageSpec, 0, vdmGroups)
+	samplePages := make([]pageSpec, 0, vdmGroups)
+
+	for group := range vdmGroups {
+		offset := EepromLength + group*PageLength
+		descriptors := e[offset:]
+
+		descriptorPage := pageSpec{name: fmt.Sprintf("Page %02Xh (VDM descriptors)", 0x20+group), offset: offset}
+		samplePage := pageSpec{name: fmt.Sprintf("Page %02Xh (VDM samples)", 0x24+group), offset: vdmSampleOffsets[group]}
+
+		for i := range vdmGroupLength {
+			if descriptors[2*i] == 0 && descriptors[2*i+1] == 0 {
+				continue
+			}
+
+			address := PageLength + 2*i
+			descriptorPage.fields = append(descriptorPage.fields, fieldSpec{address, 2, fmt.Sprintf("Observable %d", group*vdmGroupLength+i+1), vdmDescriptor})
+
+			if t, ok := vdmTypes[int(descriptors[2*i+1])]; ok {
+				name := fmt.Sprintf("%s, lane %d", t.name, descriptors[2*i]&0x0F+1)
+				samplePage.fields = append(samplePage.fields, fieldSpec{address, 2, name, func(b []byte) string {
+					return strings.TrimSpace(fmt.Sprintf("%.4g %s", t.format(u16(b)), t.unit))
+				}})
+			}
+		}
+
+		descriptorPages = append(descriptorPages, descriptorPage)
+		samplePages = append(samplePages, samplePage)
+	}
+
+	return append(descriptorPages, samplePages...)
+}
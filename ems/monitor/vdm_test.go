@@ -0,0 +1,117 @@
+package monitor
+
+import (
+	"testing"
+
+	gocmp "github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+// testVdmEEPROM returns testEEPROM with VDM pages advertising pre-FEC BER
+// 1.5e-3 of lane 1, laser temperature 45.5 °C of lane 2, OSNR 24.5 dB in
+// the slot of the base layout and an observable of unknown type.
+func testVdmEEPROM() []byte {
+	eeprom := append(testEEPROM(), make([]byte, VdmEepromLength-EepromLength)...)
+	eeprom[Page01hVdmSupported] = 0x40
+
+	page20h, page21h, page24h := EepromLength, EepromLength+PageLength, vdmSampleOffsets[0]
+
+	eeprom[page20h], eeprom[page20h+1] = 0x10, 15
+	eeprom[page24h], eeprom[page24h+1] = 0xA0, 0x0F
+
+	eeprom[page20h+2], eeprom[page20h+3] = 0x01, 4
+	eeprom[page24h+2], eeprom[page24h+3] = 0x2D, 0x80
+
+	eeprom[page20h+4], eeprom[page20h+5] = 0x00, 200
+	eeprom[page24h+4], eeprom[page24h+5] = 0xFF, 0xFF
+
+	eeprom[page21h+22], eeprom[page21h+23] = 0x00, 139
+	eeprom[Page25hOsnr], eeprom[Page25hOsnr+1] = 0x00, 0xF5
+
+	return eeprom
+}
+
+func TestVdmF16(t *testing.T) {
+	tests := []struct {
+		raw  uint16
+		want float64
+	}{
+		{raw: 0x0000, want: 0},
+		{raw: 24<<11 | 7, want: 7},
+		{raw: 20<<11 | 15, want: 1.5e-3},
+		{raw: 31<<11 | 0x07FF, want: 2047e7},
+	}
+
+	for _, tt := range tests {
+		if diff := gocmp.Diff(vdmF16(tt.raw), tt.want, cmpopts.EquateApprox(1e-9, 0)); diff != "" {
+			t.Errorf("vdmF16(%04X) mismatch (-got +want):\n%s", tt.raw, diff)
+		}
+	}
+}
+
+func TestEeprom_Vdm(t *testing.T) {
+	eeprom := Eeprom(testVdmEEPROM())
+
+	want := []VdmObservable{
+		{Type: 15, Name: "pre_fec_ber_media", Lane: 1, Value: 1.5e-3},
+		{Type: 4, Name: "laser_temp", Unit: "°C", Lane: 2, Value: 45.5},
+		{Type: 139, Name: "osnr", Unit: "dB", Lane: 1, Value: 24.5},
+	}
+	if diff := gocmp.Diff(eeprom.Vdm(), want, cmpopts.EquateApprox(1e-9, 0)); diff != "" {
+		t.Errorf("observables mismatch (-got +want):\n%s", diff)
+	}
+
+	if got := eeprom.Osnr(); got != 24.5 {
+		t.Errorf("expected OSNR of the descriptor, got %v", got)
+	}
+
+	if got := Eeprom(testEEPROM()).Vdm(); got != nil {
+		t.Errorf("expected no observables without VDM pages, got %+v", got)
+	}
+}
+
+func TestMeasure_vdm(t *testing.T) {
+	m, err := Measure(DefaultDecoder(), hexDump(testVdmEEPROM()))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := map[string]float64{"vdm_pre_fec_ber_media": 1.5e-3, "vdm_laser_temp_l2": 45.5, "vdm_osnr": 24.5}
+	if diff := gocmp.Diff(m.VDM, want, cmpopts.EquateApprox(1e-9, 0)); diff != "" {
+		t.Errorf("VDM fields mismatch (-got +want):\n%s", diff)
+	}
+	if m.OSNR != 24.5 {
+		t.Errorf("expected OSNR 24.5, got %v", m.OSNR)
+	}
+}
+
+func TestInspect_vdm(t *testing.T) {
+	pages := Inspect(testVdmEEPROM())
+
+	var names []string
+	for _, p := range pages {
+		names = append(names, p.Name)
+	}
+	if got := names[len(names)-8:]; got[0] != "Page 20h (VDM descriptors)" || got[5] != "Page 25h (VDM samples)" || got[7] != "Page 27h (VDM samples)" {
+		t.Fatalf("unexpected VDM pages: %v", got)
+	}
+
+	var descriptors, samples []string
+	for _, f := range pages[len(pages)-8].Fields {
+		if !f.Unknown {
+			descriptors = append(descriptors, f.Value)
+		}
+	}
+	for _, f := range pages[len(pages)-4].Fields {
+		if !f.Unknown {
+			samples = append(samples, f.Name+": "+f.Value)
+		}
+	}
+
+	if diff := gocmp.Diff(descriptors, []string{"pre_fec_ber_media, lane 1, threshold set 1", "laser_temp, lane 2, threshold set 0", "type 200, lane 1, threshold set 0"}); diff != "" {
+		t.Errorf("descriptors mismatch (-got +want):\n%s", diff)
+	}
+	if diff := gocmp.Diff(samples, []string{"pre_fec_ber_media, lane 1: 0.0015", "laser_temp, lane 2: 45.5 °C"}); diff != "" {
+		t.Errorf("samples mismatch (-got +want):\n%s", diff)
+	}
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+
+	"pi-wegrzyn/ems/monitor"
+)
+
+// replay runs the decoder on captured dumps, given as files or as archives
+// downloaded from the device page, and prints what monitoring would write.
+// It returns the exit code.
+func replay(stdout, stderr io.Writer, args []string) int {
+	if len(args) == 0 {
+		fmt.Fprintln(stderr, "usage: ems replay DUMP_OR_ARCHIVE...")
+		return 2
+	}
+
+	w := tabwriter.NewWriter(stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "DUMP\tTEMPERATURE\tVOLTAGE\tTX POWER\tRX POWER\tOSNR")
+
+	code := 0
+	for _, arg := range args {
+		err := readDumps(arg, func(name string, data []byte) {
+			m, err := monitor.Measure(monitor.DefaultDecoder(), data)
+			if err != nil {
+				fmt.Fprintf(w, "%s\terror: %v\n", name, err)
+				code = 1
+				return
+			}
+
+			fmt.Fprintf(w, "%s\t%.2f\t%.2f\t%.2f\t%.2f\t%.2f\n", name, m.Temperature, m.Voltage, m.TxPower, m.RxPower, m.OSNR)
+		})
+		if err != nil {
+			fmt.Fprintf(stderr, "cannot read %s: %v\n", arg, err)
+			code = 1
+		}
+	}
+
+	if err := w.Flush(); err != nil {
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+
+	return code
+}
+
+// readDumps calls f for the dump in path, or for every dump of a zip archive.
+func readDumps(path string, f func(name string, data []byte)) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	if !bytes.HasPrefix(data, []byte("PK\x03\x04")) {
+		f(path, data)
+		return nil
+	}
+
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return err
+	}
+
+	for _, file := range archive.File {
+		if file.FileInfo().IsDir() {
+			continue
+		}
+
+		r, err := file.Open()
+		if err != nil {
+			return err
+		}
+		dump, err := io.ReadAll(r)
+		_ = r.Close()
+		if err != nil {
+			return err
+		}
+
+		f(path+":"+file.Name, dump)
+	}
+
+	return nil
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"archive/zip"
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var testDump = "00000000000000000000000000000380\n" + strings.Repeat(strings.Repeat("0", 32)+"\n", 63)
+
+func TestReplay(t *testing.T) {
+	dir := t.TempDir()
+
+	dump := filepath.Join(dir, "eth0.eeprom")
+	if err := os.WriteFile(dump, []byte(testDump), 0o600); err != nil {
+		t.Fatalf("cannot write dump: %v", err)
+	}
+
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+	for name, data := range map[string]string{"eth1/20261019-120000-1.eeprom": testDump, "eth2/20261019-120000-2.eeprom": "0000\n00"} {
+		f, err := archive.Create(name)
+		if err != nil {
+			t.Fatalf("cannot create archive entry: %v", err)
+		}
+		if _, err := f.Write([]byte(data)); err != nil {
+			t.Fatalf("cannot write archive entry: %v", err)
+		}
+	}
+	if err := archive.Close(); err != nil {
+		t.Fatalf("cannot close archive: %v", err)
+	}
+
+	captures := filepath.Join(dir, "captures.zip")
+	if err := os.WriteFile(captures, buf.Bytes(), 0o600); err != nil {
+		t.Fatalf("cannot write archive: %v", err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	if code := replay(&stdout, &stderr, []string{dump, captures, filepath.Join(dir, "missing")}); code != 1 {
+		t.Errorf("expected exit code 1, got %d", code)
+	}
+
+	rows := make(map[string]string)
+	for _, line := range strings.Split(stdout.String(), "\n") {
+		if name, row, ok := strings.Cut(line, " "); ok {
+			rows[name] = strings.Join(strings.Fields(row), " ")
+		}
+	}
+
+	for name, want := range map[string]string{
+		dump: "35.00 0.00 -Inf -Inf 0.00",
+		captures + ":eth1/20261019-120000-1.eeprom": "35.00 0.00 -Inf -Inf 0.00",
+		captures + ":eth2/20261019-120000-2.eeprom": "error: encoding/hex: invalid byte: U+000A",
+	} {
+		if rows[name] != want {
+			t.Errorf("expected %q for %s, got %q", want, name, rows[name])
+		}
+	}
+
+	if !strings.Contains(stderr.String(), "cannot read "+filepath.Join(dir, "missing")) {
+		t.Errorf("expected error of the missing file, got %q", stderr.String())
+	}
+}
+
+func TestReplay_usage(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	if code := replay(&stdout, &stderr, nil); code != 2 {
+		t.Errorf("expected exit code 2, got %d", code)
+	}
+}
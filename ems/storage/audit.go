@@ -0,0 +1,169 @@
+package storage
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	AuditActionCreate = "create"
+	AuditActionUpdate = "update"
+	AuditActionDelete = "delete"
+
+	AuditTargetDevice = "device"
+
+	maskedValue = "******"
+)
+
+type AuditEntry struct {
+	ID         uint
+	Actor      string
+	Action     string
+	TargetType string
+	TargetID   uint
+	TargetName string
+	Changes    []Change
+	Created    time.Time
+}
+
+type AuditFilter struct {
+	Actor      string
+	Action     string
+	TargetName string
+	Limit      int
+}
+
+type Change struct {
+	Field  string `json:"field"`
+	Before string `json:"before"`
+	After  string `json:"after"`
+}
+
+func (c Change) String() string {
+	return fmt.Sprintf("%s: %q -> %q", c.Field, c.Before, c.After)
+}
+
+// secretFields are never written to the audit log in clear text. A
+// certificate is public, but too long to be useful in a diff.
+var secretFields = []string{"password", "keyfile", "passphrase", "certificate", "snmp-community", "snmp-auth-password", "snmp-priv-password"}
+
+func (d Device) AuditFields() map[string]string {
+	fields := map[string]string{
+		"hostname":    d.Hostname,
+		"ip":          d.IPAddress,
+		"port":        strconv.Itoa(int(d.Port)),
+		"login":       d.Login,
+		"password":    d.Password,
+		"keyfile":     string(d.Keyfile),
+		"passphrase":  d.Passphrase,
+		"certificate": string(d.Certificate),
+		"profile":     idRef(d.CredentialID),
+		"jump-host":   idRef(d.JumpHostID),
+		"site":        d.Site,
+		"group":       d.Group,
+		"tags":        strings.Join(d.Tags, ","),
+		"collector":   d.Collector,
+	}
+
+	// Settings of other collectors are not used, so they are left out.
+	if d.Collector == CollectorSNMP {
+		fields["snmp-version"] = d.SNMP.Version
+		fields["snmp-port"] = strconv.Itoa(int(d.SNMP.Port))
+		fields["snmp-community"] = d.SNMP.Community
+		fields["snmp-user"] = d.SNMP.User
+		fields["snmp-auth-protocol"] = d.SNMP.AuthProtocol
+		fields["snmp-auth-password"] = d.SNMP.AuthPassword
+		fields["snmp-priv-protocol"] = d.SNMP.PrivProtocol
+		fields["snmp-priv-password"] = d.SNMP.PrivPassword
+	}
+	if d.Collector == CollectorGNMI {
+		fields["gnmi-port"] = strconv.Itoa(int(d.GNMI.Port))
+		fields["gnmi-mode"] = d.GNMI.Mode
+		fields["gnmi-sample-interval"] = strconv.Itoa(int(d.GNMI.SampleInterval))
+		fields["gnmi-tls"] = strconv.FormatBool(d.GNMI.TLS)
+		fields["gnmi-skip-verify"] = strconv.FormatBool(d.GNMI.SkipVerify)
+	}
+	if d.Collector == CollectorNETCONF {
+		fields["netconf-port"] = strconv.Itoa(int(d.NETCONF.Port))
+		fields["netconf-platform"] = d.NETCONF.Platform
+		fields["netconf-filter"] = d.NETCONF.Filter
+	}
+	if d.Collector == CollectorLocal {
+		fields["local-interfaces"] = strings.Join(d.Local.Interfaces, ",")
+		fields["local-path"] = d.Local.Path
+	}
+
+	return fields
+}
+
+// idRef refers to a credential profile or a jump host in the audit log.
+func idRef(id uint) string {
+	if id == 0 {
+		return ""
+	}
+
+	return "#" + strconv.Itoa(int(id))
+}
+
+// Diff lists fields that differ between before and after. Creation and
+// deletion are diffed against nil. Secret fields are compared in clear text
+// but only masked values end up in the result.
+func Diff(before, after map[string]string) []Change {
+	fields := make([]string, 0, len(before)+len(after))
+	for k := range before {
+		fields = append(fields, k)
+	}
+	for k := range after {
+		if _, exists := before[k]; !exists {
+			fields = append(fields, k)
+		}
+	}
+	slices.Sort(fields)
+
+	changes := []Change{}
+	for _, f := range fields {
+		if before[f] == after[f] {
+			continue
+		}
+
+		if slices.Contains(secretFields, f) {
+			changes = append(changes, Change{Field: f, Before: mask(before[f]), After: mask(after[f])})
+			continue
+		}
+
+		changes = append(changes, Change{Field: f, Before: before[f], After: after[f]})
+	}
+
+	return changes
+}
+
+func mask(secret string) string {
+	if secret == "" {
+		return ""
+	}
+
+	return maskedValue
+}
+
+func encodeChanges(changes []Change) (string, error) {
+	b, err := json.Marshal(changes)
+	return string(b), err
+}
+
+func decodeChanges(s string) ([]Change, error) {
+	var changes []Change
+	err := json.Unmarshal([]byte(s), &changes)
+	return changes, err
+}
+
+// containsPattern returns a LIKE pattern matching s anywhere, with wildcards
+// and the escape character of s matched literally.
+func containsPattern(s string) string {
+	return "%" + likeEscaper.Replace(s) + "%"
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
@@ -0,0 +1,92 @@
+package storage
+
+import (
+	"testing"
+
+	gocmp "github.com/google/go-cmp/cmp"
+)
+
+func TestDiff(t *testing.T) {
+	tests := []struct {
+		name   string
+		before map[string]string
+		after  map[string]string
+		want   []Change
+	}{
+		{
+			name:   "creation lists all set fields",
+			before: nil,
+			after:  Device{Hostname: "r1", IPAddress: "10.0.0.1", Port: 22, Login: "noc", Password: "secret", Tags: []string{"core", "waw"}}.AuditFields(),
+			want: []Change{
+				{Field: "hostname", Before: "", After: "r1"},
+				{Field: "ip", Before: "", After: "10.0.0.1"},
+				{Field: "login", Before: "", After: "noc"},
+				{Field: "password", Before: "", After: maskedValue},
+				{Field: "port", Before: "", After: "22"},
+				{Field: "tags", Before: "", After: "core,waw"},
+			},
+		},
+		{
+			name:   "update masks changed secrets",
+			before: Device{Hostname: "r1", Password: "old", Keyfile: []byte("key")}.AuditFields(),
+			after:  Device{Hostname: "r2", Password: "new", Keyfile: []byte("key")}.AuditFields(),
+			want: []Change{
+				{Field: "hostname", Before: "r1", After: "r2"},
+				{Field: "password", Before: maskedValue, After: maskedValue},
+			},
+		},
+		{
+			name:   "switch to SNMP masks community",
+			before: Device{Hostname: "r1", Collector: CollectorSSH}.AuditFields(),
+			after:  Device{Hostname: "r1", Collector: CollectorSNMP, SNMP: SNMP{Version: SNMPVersion2c, Port: DefaultSNMPPort, Community: "public"}}.AuditFields(),
+			want: []Change{
+				{Field: "collector", Before: "ssh", After: "snmp"},
+				{Field: "snmp-community", Before: "", After: maskedValue},
+				{Field: "snmp-port", Before: "", After: "161"},
+				{Field: "snmp-version", Before: "", After: "2c"},
+			},
+		},
+		{
+			name:   "no changes",
+			before: Device{Hostname: "r1"}.AuditFields(),
+			after:  Device{Hostname: "r1"}.AuditFields(),
+			want:   []Change{},
+		},
+		{
+			name:   "deletion",
+			before: Device{Hostname: "r1", Port: 22, Keyfile: []byte("key")}.AuditFields(),
+			after:  nil,
+			want: []Change{
+				{Field: "hostname", Before: "r1", After: ""},
+				{Field: "keyfile", Before: maskedValue, After: ""},
+				{Field: "port", Before: "22", After: ""},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := Diff(tc.before, tc.after)
+
+			if diff := gocmp.Diff(got, tc.want); diff != "" {
+				t.Errorf("changes mismatch (-got +want):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestContainsPattern(t *testing.T) {
+	tests := map[string]string{
+		"":        "%%",
+		"core1":   "%core1%",
+		"core_1":  `%core\_1%`,
+		"50%":     `%50\%%`,
+		`dc\core`: `%dc\\core%`,
+	}
+
+	for s, want := range tests {
+		if got := containsPattern(s); got != want {
+			t.Errorf("containsPattern(%q): expected %q, got %q", s, want, got)
+		}
+	}
+}
@@ -0,0 +1,30 @@
+package storage
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestCompress(t *testing.T) {
+	data := bytes.Repeat([]byte("0123456789abcdef0123456789abcdef\n"), 64)
+
+	compressed, err := compress(data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(compressed) >= len(data) {
+		t.Errorf("expected less than %d bytes, got %d", len(data), len(compressed))
+	}
+
+	got, err := decompress(compressed)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !bytes.Equal(got, data) {
+		t.Errorf("expected %q, got %q", data, got)
+	}
+
+	if _, err := decompress(data); err == nil {
+		t.Error("expected error for uncompressed data")
+	}
+}
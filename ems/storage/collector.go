@@ -0,0 +1,134 @@
+package storage
+
+import (
+	"regexp"
+	"slices"
+)
+
+// Collectors read transceiver data from devices. SSH runs show commands,
+// SNMP walks ENTITY-SENSOR-MIB, gNMI subscribes to OpenConfig paths,
+// NETCONF gets optics state over SSH and local reads modules of the host
+// running EMS.
+const (
+	CollectorSSH     = "ssh"
+	CollectorSNMP    = "snmp"
+	CollectorGNMI    = "gnmi"
+	CollectorNETCONF = "netconf"
+	CollectorLocal   = "local"
+)
+
+var Collectors = []string{CollectorSSH, CollectorSNMP, CollectorGNMI, CollectorNETCONF, CollectorLocal}
+
+func ValidCollector(collector string) bool {
+	return slices.Contains(Collectors, collector)
+}
+
+const (
+	SNMPVersion2c = "2c"
+	SNMPVersion3  = "3"
+
+	DefaultSNMPPort uint16 = 161
+)
+
+var (
+	SNMPVersions = []string{SNMPVersion2c, SNMPVersion3}
+	// SNMPAuthProtocols and SNMPPrivProtocols are USM protocols of SNMPv3,
+	// empty means noAuth or noPriv.
+	SNMPAuthProtocols = []string{"", "MD5", "SHA", "SHA224", "SHA256", "SHA384", "SHA512"}
+	SNMPPrivProtocols = []string{"", "DES", "AES", "AES192", "AES256", "AES192C", "AES256C"}
+)
+
+// SNMP holds settings of the SNMP collector. Community is used by v2c, user
+// and protocols by v3.
+type SNMP struct {
+	Version      string
+	Port         uint16
+	Community    string
+	User         string
+	AuthProtocol string
+	AuthPassword string
+	PrivProtocol string
+	PrivPassword string
+}
+
+func ValidSNMPVersion(version string) bool {
+	return slices.Contains(SNMPVersions, version)
+}
+
+func ValidSNMPAuthProtocol(protocol string) bool {
+	return slices.Contains(SNMPAuthProtocols, protocol)
+}
+
+func ValidSNMPPrivProtocol(protocol string) bool {
+	return slices.Contains(SNMPPrivProtocols, protocol)
+}
+
+const (
+	GNMIModeSample   = "sample"
+	GNMIModeOnChange = "on-change"
+
+	DefaultGNMIPort           uint16 = 9339
+	DefaultGNMISampleInterval uint   = 10
+)
+
+var GNMIModes = []string{GNMIModeSample, GNMIModeOnChange}
+
+// GNMI holds settings of the gNMI collector. The device login and password
+// are sent as gRPC metadata.
+type GNMI struct {
+	Port uint16
+	Mode string
+	// SampleInterval is in seconds, used in sample mode.
+	SampleInterval uint
+	TLS            bool
+	// SkipVerify accepts any target certificate, e.g. a self-signed one.
+	SkipVerify bool
+}
+
+func ValidGNMIMode(mode string) bool {
+	return slices.Contains(GNMIModes, mode)
+}
+
+const (
+	NETCONFPlatformJunos = "junos"
+	NETCONFPlatformSROS  = "sros"
+
+	DefaultNETCONFPort uint16 = 830
+)
+
+// NETCONFPlatforms have built-in mappings, others are read from the mapping
+// directory of the monitor.
+var NETCONFPlatforms = []string{NETCONFPlatformJunos, NETCONFPlatformSROS}
+
+var netconfPlatformPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9\-_]{0,31}$`)
+
+// NETCONF holds settings of the NETCONF collector. The SSH login, secrets and
+// jump host of the device are used.
+type NETCONF struct {
+	Port uint16
+	// Platform names the mapping of replies to measurements.
+	Platform string
+	// Filter is the subtree filter of <get>, empty uses the platform one.
+	Filter string
+}
+
+func ValidNETCONFPlatform(platform string) bool {
+	return netconfPlatformPattern.MatchString(platform)
+}
+
+// InterfaceNamePattern matches Linux interface names.
+const InterfaceNamePattern string = `^[a-zA-Z0-9][a-zA-Z0-9_\.:@\-]{0,14}$`
+
+var interfaceNamePattern = regexp.MustCompile(InterfaceNamePattern)
+
+// Local holds settings of the local collector, which reads modules of the
+// host through ethtool, or EEPROM dumps from Path named after interfaces.
+type Local struct {
+	// Interfaces to read, all interfaces with modules when empty.
+	Interfaces []string
+	Path       string
+}
+
+func ValidInterfaceName(name string) bool {
+	return interfaceNamePattern.MatchString(name)
+}
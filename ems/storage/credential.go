@@ -0,0 +1,98 @@
+package storage
+
+import (
+	"slices"
+)
+
+const (
+	CredentialPassword = "password"
+	CredentialKey      = "key"
+	CredentialAgent    = "agent"
+
+	AuditTargetCredential = "credential"
+)
+
+var CredentialKinds = []string{CredentialPassword, CredentialKey, CredentialAgent}
+
+// Credential is a named login profile shared by many devices.
+type Credential struct {
+	ID         uint
+	Name       string
+	Kind       string
+	Login      string
+	Password   string
+	Keyfile    []byte
+	Passphrase string
+	// Certificate is an optional OpenSSH user certificate of Keyfile.
+	Certificate []byte
+}
+
+func (c Credential) AuditFields() map[string]string {
+	return map[string]string{
+		"name":        c.Name,
+		"kind":        c.Kind,
+		"login":       c.Login,
+		"password":    c.Password,
+		"keyfile":     string(c.Keyfile),
+		"passphrase":  c.Passphrase,
+		"certificate": string(c.Certificate),
+	}
+}
+
+func ValidCredentialKind(kind string) bool {
+	return slices.Contains(CredentialKinds, kind)
+}
+
+// Auth is what is needed to log into a device once its credential profile
+// and per-device overrides are combined. All set methods are offered to the
+// device, see monitor for the order.
+type Auth struct {
+	Login       string
+	Password    string
+	Keyfile     []byte
+	Passphrase  string
+	Certificate []byte
+	Agent       bool
+}
+
+// Auth resolves credentials of the profile alone, as used by jump hosts.
+func (c Credential) Auth() Auth {
+	auth := Auth{Login: c.Login}
+	switch c.Kind {
+	case CredentialPassword:
+		auth.Password = c.Password
+	case CredentialKey:
+		auth.Keyfile = c.Keyfile
+		auth.Passphrase = c.Passphrase
+		auth.Certificate = c.Certificate
+	case CredentialAgent:
+		auth.Agent = true
+	}
+
+	return auth
+}
+
+// Auth resolves device credentials. The profile is the base, a login set on
+// the device replaces the profile login and a password or key set on the
+// device replaces the profile secret. Pass nil for devices without a profile.
+func (d Device) Auth(profile *Credential) Auth {
+	if profile == nil {
+		return Auth{Login: d.Login, Password: d.Password, Keyfile: d.Keyfile, Passphrase: d.Passphrase, Certificate: d.Certificate}
+	}
+
+	auth := profile.Auth()
+
+	if d.Login != "" {
+		auth.Login = d.Login
+	}
+
+	if d.Password != "" || len(d.Keyfile) != 0 {
+		auth.Password = d.Password
+		auth.Keyfile = d.Keyfile
+		auth.Passphrase = d.Passphrase
+		auth.Certificate = d.Certificate
+		auth.Agent = false
+	}
+
+	return auth
+}
@@ -0,0 +1,66 @@
+package storage
+
+import (
+	"testing"
+
+	gocmp "github.com/google/go-cmp/cmp"
+)
+
+func TestDevice_Auth(t *testing.T) {
+	keyProfile := &Credential{ID: 1, Kind: CredentialKey, Login: "noc", Password: "unused", Keyfile: []byte("key"), Passphrase: "pass"}
+	agentProfile := &Credential{ID: 2, Kind: CredentialAgent, Login: "noc"}
+	passwordProfile := &Credential{ID: 3, Kind: CredentialPassword, Login: "noc", Password: "secret"}
+
+	tests := []struct {
+		name    string
+		device  Device
+		profile *Credential
+		want    Auth
+	}{
+		{
+			name:   "device credentials without profile",
+			device: Device{Login: "admin", Password: "pw"},
+			want:   Auth{Login: "admin", Password: "pw"},
+		},
+		{
+			name:    "key profile",
+			device:  Device{CredentialID: 1},
+			profile: keyProfile,
+			want:    Auth{Login: "noc", Keyfile: []byte("key"), Passphrase: "pass"},
+		},
+		{
+			name:    "agent profile with login override",
+			device:  Device{CredentialID: 2, Login: "admin"},
+			profile: agentProfile,
+			want:    Auth{Login: "admin", Agent: true},
+		},
+		{
+			name:    "password override replaces profile secret",
+			device:  Device{CredentialID: 1, Password: "local"},
+			profile: keyProfile,
+			want:    Auth{Login: "noc", Password: "local"},
+		},
+		{
+			name:    "device key with certificate replaces profile secret",
+			device:  Device{CredentialID: 2, Keyfile: []byte("device key"), Passphrase: "phrase", Certificate: []byte("cert")},
+			profile: agentProfile,
+			want:    Auth{Login: "noc", Keyfile: []byte("device key"), Passphrase: "phrase", Certificate: []byte("cert")},
+		},
+		{
+			name:    "password profile",
+			device:  Device{CredentialID: 3, Keyfile: []byte{}},
+			profile: passwordProfile,
+			want:    Auth{Login: "noc", Password: "secret"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := tc.device.Auth(tc.profile)
+
+			if diff := gocmp.Diff(got, tc.want); diff != "" {
+				t.Errorf("auth mismatch (-got +want):\n%s", diff)
+			}
+		})
+	}
+}
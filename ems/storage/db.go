@@ -6,24 +6,34 @@ import (
 	"context"
 	"database/sql"
 	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"log/slog"
+	"strings"
 	"time"
 
 	sqlc "pi-wegrzyn/ems/storage/sqlc/generated"
 )
 
 type DB struct {
-	q *sqlc.Queries
+	conn *sql.DB
+	q    *sqlc.Queries
 }
 
 func New(dbConn *sql.DB) *DB {
-	return &DB{q: sqlc.New(dbConn)}
+	return &DB{conn: dbConn, q: sqlc.New(dbConn)}
 }
 
-func (d *DB) CreateDevice(ctx context.Context, device Device) error {
+func (d *DB) CreateDevice(ctx context.Context, device Device) (uint, error) {
+	return createDevice(ctx, d.q, device)
+}
+
+func createDevice(ctx context.Context, q *sqlc.Queries, device Device) (uint, error) {
 	createParams := sqlc.CreateDeviceParams{
 		Hostname: device.Hostname,
 		Ip:       device.IPAddress,
+		Port:     device.Port,
 		Login:    device.Login,
 		Passwd: sql.NullString{
 			Valid:  true,
@@ -33,10 +43,41 @@ func (d *DB) CreateDevice(ctx context.Context, device Device) error {
 			Valid:  true,
 			String: base64.StdEncoding.EncodeToString(device.Keyfile),
 		},
-		Connected: time.Now(),
+		Passphrase:   encodeSecret([]byte(device.Passphrase)),
+		Certificate:  encodeSecret(device.Certificate),
+		CredentialID: nullID(device.CredentialID),
+		JumpHostID:   nullID(device.JumpHostID),
+		Site:         device.Site,
+		DeviceGroup:  device.Group,
+		Tags:         strings.Join(device.Tags, ","),
+		Connected:    time.Now(),
+
+		Collector:        collectorOrDefault(device.Collector),
+		SnmpVersion:      snmpVersionOrDefault(device.SNMP.Version),
+		SnmpPort:         snmpPortOrDefault(device.SNMP.Port),
+		SnmpCommunity:    encodeSecret([]byte(device.SNMP.Community)),
+		SnmpUser:         device.SNMP.User,
+		SnmpAuthProtocol: device.SNMP.AuthProtocol,
+		SnmpAuthPassword: encodeSecret([]byte(device.SNMP.AuthPassword)),
+		SnmpPrivProtocol: device.SNMP.PrivProtocol,
+		SnmpPrivPassword: encodeSecret([]byte(device.SNMP.PrivPassword)),
+
+		GnmiPort:           gnmiPortOrDefault(device.GNMI.Port),
+		GnmiMode:           gnmiModeOrDefault(device.GNMI.Mode),
+		GnmiSampleInterval: uint32(gnmiSampleIntervalOrDefault(device.GNMI.SampleInterval)),
+		GnmiTls:            device.GNMI.TLS,
+		GnmiSkipVerify:     device.GNMI.SkipVerify,
+
+		NetconfPort:     netconfPortOrDefault(device.NETCONF.Port),
+		NetconfPlatform: netconfPlatformOrDefault(device.NETCONF.Platform),
+		NetconfFilter:   device.NETCONF.Filter,
+		LocalInterfaces: strings.Join(device.Local.Interfaces, ","),
+		LocalPath:       device.Local.Path,
 	}
 
-	return d.q.CreateDevice(ctx, createParams)
+	id, err := q.CreateDevice(ctx, createParams)
+
+	return uint(id), err
 }
 
 func (d *DB) Device(ctx context.Context, id uint) (Device, error) {
@@ -45,26 +86,7 @@ func (d *DB) Device(ctx context.Context, id uint) (Device, error) {
 		return Device{}, err
 	}
 
-	password, err := base64.StdEncoding.DecodeString(dbDevice.Passwd.String)
-	if err != nil {
-		slog.ErrorContext(ctx, "cannot decode password", slog.Any("deviceID", dbDevice.ID), slog.Any("error", err))
-	}
-
-	keyfile, err := base64.StdEncoding.DecodeString(dbDevice.Keyfile.String)
-	if err != nil {
-		slog.ErrorContext(ctx, "cannot decode keyfile", slog.Any("deviceID", dbDevice.ID), slog.Any("error", err))
-	}
-
-	return Device{
-		ID:         uint(dbDevice.ID),
-		Hostname:   dbDevice.Hostname,
-		IPAddress:  dbDevice.Ip,
-		Login:      dbDevice.Login,
-		Password:   string(password),
-		Keyfile:    keyfile,
-		Connected:  dbDevice.Connected,
-		LastStatus: int8(dbDevice.LastStatus),
-	}, nil
+	return deviceFromDB(ctx, dbDevice), nil
 }
 
 func (d *DB) Devices(ctx context.Context) ([]Device, error) {
@@ -75,36 +97,79 @@ func (d *DB) Devices(ctx context.Context) ([]Device, error) {
 
 	devices := make([]Device, 0, len(dbDevices))
 	for _, dev := range dbDevices {
-		password, err := base64.StdEncoding.DecodeString(dev.Passwd.String)
-		if err != nil {
-			slog.ErrorContext(ctx, "cannot decode password", slog.Any("deviceID", dev.ID), slog.Any("error", err))
-		}
+		devices = append(devices, deviceFromDB(ctx, dev))
+	}
 
-		keyfile, err := base64.StdEncoding.DecodeString(dev.Keyfile.String)
+	return devices, nil
+}
+
+func deviceFromDB(ctx context.Context, dev sqlc.Device) Device {
+	decode := func(field string, value sql.NullString) []byte {
+		decoded, err := base64.StdEncoding.DecodeString(value.String)
 		if err != nil {
-			slog.ErrorContext(ctx, "cannot decode keyfile", slog.Any("deviceID", dev.ID), slog.Any("error", err))
+			slog.ErrorContext(ctx, "cannot decode "+field, slog.Any("deviceID", dev.ID), slog.Any("error", err))
 		}
 
-		devices = append(devices, Device{
-			ID:         uint(dev.ID),
-			Hostname:   dev.Hostname,
-			Login:      dev.Login,
-			IPAddress:  dev.Ip,
-			Password:   string(password),
-			Keyfile:    keyfile,
-			Connected:  dev.Connected,
-			LastStatus: int8(dev.LastStatus),
-		})
+		return decoded
 	}
 
-	return devices, nil
+	return Device{
+		ID:           uint(dev.ID),
+		Hostname:     dev.Hostname,
+		IPAddress:    dev.Ip,
+		Port:         dev.Port,
+		Login:        dev.Login,
+		Password:     string(decode("password", dev.Passwd)),
+		Keyfile:      decode("keyfile", dev.Keyfile),
+		Passphrase:   string(decode("passphrase", dev.Passphrase)),
+		Certificate:  decode("certificate", dev.Certificate),
+		CredentialID: uint(dev.CredentialID.Int32),
+		JumpHostID:   uint(dev.JumpHostID.Int32),
+		Collector:    dev.Collector,
+		SNMP: SNMP{
+			Version:      dev.SnmpVersion,
+			Port:         dev.SnmpPort,
+			Community:    string(decode("SNMP community", dev.SnmpCommunity)),
+			User:         dev.SnmpUser,
+			AuthProtocol: dev.SnmpAuthProtocol,
+			AuthPassword: string(decode("SNMP auth password", dev.SnmpAuthPassword)),
+			PrivProtocol: dev.SnmpPrivProtocol,
+			PrivPassword: string(decode("SNMP priv password", dev.SnmpPrivPassword)),
+		},
+		GNMI: GNMI{
+			Port:           dev.GnmiPort,
+			Mode:           dev.GnmiMode,
+			SampleInterval: uint(dev.GnmiSampleInterval),
+			TLS:            dev.GnmiTls,
+			SkipVerify:     dev.GnmiSkipVerify,
+		},
+		NETCONF: NETCONF{
+			Port:     dev.NetconfPort,
+			Platform: dev.NetconfPlatform,
+			Filter:   dev.NetconfFilter,
+		},
+		Local: Local{
+			Interfaces: ParseTags(dev.LocalInterfaces),
+			Path:       dev.LocalPath,
+		},
+		Site:       dev.Site,
+		Group:      dev.DeviceGroup,
+		Tags:       ParseTags(dev.Tags),
+		Connected:  dev.Connected,
+		LastStatus: int8(dev.LastStatus),
+	}
 }
 
 func (d *DB) UpdateDevice(ctx context.Context, device Device) error {
+	return updateDevice(ctx, d.q, device)
+}
+
+func updateDevice(ctx context.Context, q *sqlc.Queries, device Device) error {
 	updateParams := sqlc.UpdateDeviceParams{
 		ID:       uint32(device.ID),
 		Hostname: device.Hostname,
 		Ip:       device.IPAddress,
+		Port:     device.Port,
 		Login:    device.Login,
 		Passwd: sql.NullString{
 			Valid:  true,
@@ -114,11 +179,71 @@ func (d *DB) UpdateDevice(ctx context.Context, device Device) error {
 			Valid:  true,
 			String: base64.StdEncoding.EncodeToString(device.Keyfile),
 		},
-		Connected:  device.Connected,
-		LastStatus: int32(device.LastStatus),
+		Passphrase:   encodeSecret([]byte(device.Passphrase)),
+		Certificate:  encodeSecret(device.Certificate),
+		CredentialID: nullID(device.CredentialID),
+		JumpHostID:   nullID(device.JumpHostID),
+		Site:         device.Site,
+		DeviceGroup:  device.Group,
+		Tags:         strings.Join(device.Tags, ","),
+		Connected:    device.Connected,
+		LastStatus:   int32(device.LastStatus),
+
+		Collector:        collectorOrDefault(device.Collector),
+		SnmpVersion:      snmpVersionOrDefault(device.SNMP.Version),
+		SnmpPort:         snmpPortOrDefault(device.SNMP.Port),
+		SnmpCommunity:    encodeSecret([]byte(device.SNMP.Community)),
+		SnmpUser:         device.SNMP.User,
+		SnmpAuthProtocol: device.SNMP.AuthProtocol,
+		SnmpAuthPassword: encodeSecret([]byte(device.SNMP.AuthPassword)),
+		SnmpPrivProtocol: device.SNMP.PrivProtocol,
+		SnmpPrivPassword: encodeSecret([]byte(device.SNMP.PrivPassword)),
+
+		GnmiPort:           gnmiPortOrDefault(device.GNMI.Port),
+		GnmiMode:           gnmiModeOrDefault(device.GNMI.Mode),
+		GnmiSampleInterval: uint32(gnmiSampleIntervalOrDefault(device.GNMI.SampleInterval)),
+		GnmiTls:            device.GNMI.TLS,
+		GnmiSkipVerify:     device.GNMI.SkipVerify,
+
+		NetconfPort:     netconfPortOrDefault(device.NETCONF.Port),
+		NetconfPlatform: netconfPlatformOrDefault(device.NETCONF.Platform),
+		NetconfFilter:   device.NETCONF.Filter,
+		LocalInterfaces: strings.Join(device.Local.Interfaces, ","),
+		LocalPath:       device.Local.Path,
+	}
+
+	return q.UpdateDevice(ctx, updateParams)
+}
+
+// ImportDevices creates and updates devices in a single transaction. Created
+// devices are returned with their new IDs.
+func (d *DB) ImportDevices(ctx context.Context, creates []Device, updates []Device) (created []Device, err error) {
+	tx, err := d.conn.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			err = errors.Join(err, tx.Rollback())
+		}
+	}()
+
+	q := d.q.WithTx(tx)
+
+	for _, device := range creates {
+		if device.ID, err = createDevice(ctx, q, device); err != nil {
+			return nil, fmt.Errorf("cannot create %s: %w", device.Hostname, err)
+		}
+		created = append(created, device)
+	}
+
+	for _, device := range updates {
+		if err = updateDevice(ctx, q, device); err != nil {
+			return nil, fmt.Errorf("cannot update %s: %w", device.Hostname, err)
+		}
 	}
 
-	return d.q.UpdateDevice(ctx, updateParams)
+	return created, tx.Commit()
 }
 
 func (d *DB) UpdateDeviceStatus(ctx context.Context, device Device) error {
@@ -134,3 +259,855 @@ func (d *DB) UpdateDeviceStatus(ctx context.Context, device Device) error {
 func (d *DB) DeleteDevice(ctx context.Context, id uint) error {
 	return d.q.DeleteDevice(ctx, uint32(id))
 }
+
+func (d *DB) CreateAuditEntry(ctx context.Context, entry AuditEntry) error {
+	changes, err := encodeChanges(entry.Changes)
+	if err != nil {
+		return err
+	}
+
+	createParams := sqlc.CreateAuditEntryParams{
+		Actor:      entry.Actor,
+		Action:     entry.Action,
+		TargetType: entry.TargetType,
+		TargetID:   uint32(entry.TargetID),
+		TargetName: entry.TargetName,
+		Changes:    changes,
+		Created:    time.Now(),
+	}
+
+	return d.q.CreateAuditEntry(ctx, createParams)
+}
+
+func (d *DB) AuditEntries(ctx context.Context, filter AuditFilter) ([]AuditEntry, error) {
+	dbEntries, err := d.q.AuditEntries(ctx, sqlc.AuditEntriesParams{
+		Actor:         filter.Actor,
+		Action:        filter.Action,
+		TargetPattern: containsPattern(filter.TargetName),
+		Limit:         int32(filter.Limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]AuditEntry, 0, len(dbEntries))
+	for _, e := range dbEntries {
+		changes, err := decodeChanges(e.Changes)
+		if err != nil {
+			slog.ErrorContext(ctx, "cannot decode audit changes", slog.Any("auditID", e.ID), slog.Any("error", err))
+		}
+
+		entries = append(entries, AuditEntry{
+			ID:         uint(e.ID),
+			Actor:      e.Actor,
+			Action:     e.Action,
+			TargetType: e.TargetType,
+			TargetID:   uint(e.TargetID),
+			TargetName: e.TargetName,
+			Changes:    changes,
+			Created:    e.Created,
+		})
+	}
+
+	return entries, nil
+}
+
+func (d *DB) CreateCredential(ctx context.Context, credential Credential) (uint, error) {
+	id, err := d.q.CreateCredential(ctx, sqlc.CreateCredentialParams{
+		Name:        credential.Name,
+		Kind:        credential.Kind,
+		Login:       credential.Login,
+		Passwd:      encodeSecret([]byte(credential.Password)),
+		Keyfile:     encodeSecret(credential.Keyfile),
+		Passphrase:  encodeSecret([]byte(credential.Passphrase)),
+		Certificate: encodeSecret(credential.Certificate),
+	})
+
+	return uint(id), err
+}
+
+func (d *DB) Credential(ctx context.Context, id uint) (Credential, error) {
+	dbCredential, err := d.q.Credential(ctx, uint32(id))
+	if err != nil {
+		return Credential{}, err
+	}
+
+	return credentialFromDB(ctx, dbCredential), nil
+}
+
+func (d *DB) Credentials(ctx context.Context) ([]Credential, error) {
+	dbCredentials, err := d.q.Credentials(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	credentials := make([]Credential, 0, len(dbCredentials))
+	for _, c := range dbCredentials {
+		credentials = append(credentials, credentialFromDB(ctx, c))
+	}
+
+	return credentials, nil
+}
+
+func (d *DB) UpdateCredential(ctx context.Context, credential Credential) error {
+	return d.q.UpdateCredential(ctx, sqlc.UpdateCredentialParams{
+		ID:          uint32(credential.ID),
+		Name:        credential.Name,
+		Kind:        credential.Kind,
+		Login:       credential.Login,
+		Passwd:      encodeSecret([]byte(credential.Password)),
+		Keyfile:     encodeSecret(credential.Keyfile),
+		Passphrase:  encodeSecret([]byte(credential.Passphrase)),
+		Certificate: encodeSecret(credential.Certificate),
+	})
+}
+
+func (d *DB) DeleteCredential(ctx context.Context, id uint) error {
+	return d.q.DeleteCredential(ctx, uint32(id))
+}
+
+// CredentialUsage returns the number of devices and jump hosts using the
+// profile.
+func (d *DB) CredentialUsage(ctx context.Context, id uint) (int, error) {
+	devices, err := d.q.CredentialUsage(ctx, nullID(id))
+	if err != nil {
+		return 0, err
+	}
+
+	jumpHosts, err := d.q.CredentialJumpHostUsage(ctx, uint32(id))
+
+	return int(devices + jumpHosts), err
+}
+
+func credentialFromDB(ctx context.Context, c sqlc.Credential) Credential {
+	return Credential{
+		ID:          uint(c.ID),
+		Name:        c.Name,
+		Kind:        c.Kind,
+		Login:       c.Login,
+		Password:    string(decodeSecret(ctx, c.ID, "password", c.Passwd)),
+		Keyfile:     decodeSecret(ctx, c.ID, "keyfile", c.Keyfile),
+		Certificate: decodeSecret(ctx, c.ID, "certificate", c.Certificate),
+		Passphrase:  string(decodeSecret(ctx, c.ID, "passphrase", c.Passphrase)),
+	}
+}
+
+func encodeSecret(secret []byte) sql.NullString {
+	return sql.NullString{
+		Valid:  true,
+		String: base64.StdEncoding.EncodeToString(secret),
+	}
+}
+
+func decodeSecret(ctx context.Context, credentialID uint32, field string, secret sql.NullString) []byte {
+	decoded, err := base64.StdEncoding.DecodeString(secret.String)
+	if err != nil {
+		slog.ErrorContext(ctx, "cannot decode "+field, slog.Any("credentialID", credentialID), slog.Any("error", err))
+	}
+
+	return decoded
+}
+
+// Devices created before collectors were selectable, e.g. by an import,
+// keep the defaults of the table.
+func collectorOrDefault(collector string) string {
+	if collector == "" {
+		return CollectorSSH
+	}
+
+	return collector
+}
+
+func snmpVersionOrDefault(version string) string {
+	if version == "" {
+		return SNMPVersion2c
+	}
+
+	return version
+}
+
+func snmpPortOrDefault(port uint16) uint16 {
+	if port == 0 {
+		return DefaultSNMPPort
+	}
+
+	return port
+}
+
+func gnmiPortOrDefault(port uint16) uint16 {
+	if port == 0 {
+		return DefaultGNMIPort
+	}
+
+	return port
+}
+
+func gnmiModeOrDefault(mode string) string {
+	if mode == "" {
+		return GNMIModeSample
+	}
+
+	return mode
+}
+
+func gnmiSampleIntervalOrDefault(interval uint) uint {
+	if interval == 0 {
+		return DefaultGNMISampleInterval
+	}
+
+	return interval
+}
+
+func netconfPortOrDefault(port uint16) uint16 {
+	if port == 0 {
+		return DefaultNETCONFPort
+	}
+
+	return port
+}
+
+func netconfPlatformOrDefault(platform string) string {
+	if platform == "" {
+		return NETCONFPlatformJunos
+	}
+
+	return platform
+}
+
+func nullID(id uint) sql.NullInt32 {
+	return sql.NullInt32{Int32: int32(id), Valid: id != 0}
+}
+
+func (d *DB) CreateJumpHost(ctx context.Context, host JumpHost) (uint, error) {
+	id, err := d.q.CreateJumpHost(ctx, sqlc.CreateJumpHostParams{
+		Name:         host.Name,
+		Address:      host.Address,
+		Port:         host.Port,
+		CredentialID: uint32(host.CredentialID),
+		ViaID:        nullID(host.ViaID),
+	})
+
+	return uint(id), err
+}
+
+func (d *DB) JumpHost(ctx context.Context, id uint) (JumpHost, error) {
+	dbHost, err := d.q.JumpHost(ctx, uint32(id))
+	if err != nil {
+		return JumpHost{}, err
+	}
+
+	return jumpHostFromDB(dbHost), nil
+}
+
+func (d *DB) JumpHosts(ctx context.Context) ([]JumpHost, error) {
+	dbHosts, err := d.q.JumpHosts(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	hosts := make([]JumpHost, 0, len(dbHosts))
+	for _, h := range dbHosts {
+		hosts = append(hosts, jumpHostFromDB(h))
+	}
+
+	return hosts, nil
+}
+
+func (d *DB) UpdateJumpHost(ctx context.Context, host JumpHost) error {
+	return d.q.UpdateJumpHost(ctx, sqlc.UpdateJumpHostParams{
+		ID:           uint32(host.ID),
+		Name:         host.Name,
+		Address:      host.Address,
+		Port:         host.Port,
+		CredentialID: uint32(host.CredentialID),
+		ViaID:        nullID(host.ViaID),
+	})
+}
+
+func (d *DB) DeleteJumpHost(ctx context.Context, id uint) error {
+	return d.q.DeleteJumpHost(ctx, uint32(id))
+}
+
+// JumpHostUsage returns the number of devices and jump hosts reached through
+// the jump host.
+func (d *DB) JumpHostUsage(ctx context.Context, id uint) (int, error) {
+	devices, err := d.q.JumpHostUsage(ctx, nullID(id))
+	if err != nil {
+		return 0, err
+	}
+
+	jumpHosts, err := d.q.JumpHostViaUsage(ctx, nullID(id))
+
+	return int(devices + jumpHosts), err
+}
+
+func jumpHostFromDB(h sqlc.JumpHost) JumpHost {
+	return JumpHost{
+		ID:           uint(h.ID),
+		Name:         h.Name,
+		Address:      h.Address,
+		Port:         h.Port,
+		CredentialID: uint(h.CredentialID),
+		ViaID:        uint(h.ViaID.Int32),
+	}
+}
+
+func (d *DB) CreateLink(ctx context.Context, link Link) (uint, error) {
+	id, err := d.q.CreateLink(ctx, sqlc.CreateLinkParams{
+		Name:       link.Name,
+		ADeviceID:  uint32(link.ADeviceID),
+		AInterface: link.AInterface,
+		BDeviceID:  uint32(link.BDeviceID),
+		BInterface: link.BInterface,
+		AlarmDelta: link.AlarmDelta,
+	})
+
+	return uint(id), err
+}
+
+func (d *DB) Link(ctx context.Context, id uint) (Link, error) {
+	dbLink, err := d.q.Link(ctx, uint32(id))
+	if err != nil {
+		return Link{}, err
+	}
+
+	return linkFromDB(dbLink), nil
+}
+
+func (d *DB) Links(ctx context.Context) ([]Link, error) {
+	dbLinks, err := d.q.Links(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	links := make([]Link, 0, len(dbLinks))
+	for _, l := range dbLinks {
+		links = append(links, linkFromDB(l))
+	}
+
+	return links, nil
+}
+
+func (d *DB) UpdateLink(ctx context.Context, link Link) error {
+	return d.q.UpdateLink(ctx, sqlc.UpdateLinkParams{
+		ID:         uint32(link.ID),
+		Name:       link.Name,
+		ADeviceID:  uint32(link.ADeviceID),
+		AInterface: link.AInterface,
+		BDeviceID:  uint32(link.BDeviceID),
+		BInterface: link.BInterface,
+		AlarmDelta: link.AlarmDelta,
+	})
+}
+
+// UpdateLinkSpan stores the baseline and the last reading of the link.
+func (d *DB) UpdateLinkSpan(ctx context.Context, link Link) error {
+	return d.q.UpdateLinkSpan(ctx, sqlc.UpdateLinkSpanParams{
+		ID:       uint32(link.ID),
+		Baseline: nullFloat(link.Baseline),
+		Loss:     nullFloat(link.Loss),
+		Margin:   nullFloat(link.Margin),
+		Degraded: link.Degraded,
+		Updated:  sql.NullTime{Time: link.Updated, Valid: !link.Updated.IsZero()},
+	})
+}
+
+// ResetLinkBaseline clears the baseline, the next reading becomes the new
+// one, e.g. after a repair.
+func (d *DB) ResetLinkBaseline(ctx context.Context, id uint) error {
+	return d.q.ResetLinkBaseline(ctx, uint32(id))
+}
+
+func (d *DB) DeleteLink(ctx context.Context, id uint) error {
+	return d.q.DeleteLink(ctx, uint32(id))
+}
+
+func linkFromDB(l sqlc.Link) Link {
+	return Link{
+		ID:         uint(l.ID),
+		Name:       l.Name,
+		ADeviceID:  uint(l.ADeviceID),
+		AInterface: l.AInterface,
+		BDeviceID:  uint(l.BDeviceID),
+		BInterface: l.BInterface,
+		AlarmDelta: l.AlarmDelta,
+		Baseline:   floatFromNull(l.Baseline),
+		Loss:       floatFromNull(l.Loss),
+		Margin:     floatFromNull(l.Margin),
+		Degraded:   l.Degraded,
+		Updated:    l.Updated.Time,
+	}
+}
+
+func nullFloat(f *float64) sql.NullFloat64 {
+	if f == nil {
+		return sql.NullFloat64{}
+	}
+
+	return sql.NullFloat64{Float64: *f, Valid: true}
+}
+
+func floatFromNull(f sql.NullFloat64) *float64 {
+	if !f.Valid {
+		return nil
+	}
+
+	return &f.Float64
+}
+
+// CreateCapture stores a compressed dump and removes dumps of the interface
+// beyond the last keep.
+func (d *DB) CreateCapture(ctx context.Context, capture Capture, keep int) (err error) {
+	data, err := compress(capture.Data)
+	if err != nil {
+		return err
+	}
+
+	tx, err := d.conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			err = errors.Join(err, tx.Rollback())
+		}
+	}()
+
+	q := d.q.WithTx(tx)
+
+	if err = q.CreateCapture(ctx, sqlc.CreateCaptureParams{
+		DeviceID:  uint32(capture.DeviceID),
+		Interface: capture.Interface,
+		Data:      data,
+		Created:   time.Now(),
+	}); err != nil {
+		return err
+	}
+
+	if err = q.PruneCaptures(ctx, sqlc.PruneCapturesParams{
+		DeviceID:  uint32(capture.DeviceID),
+		Interface: capture.Interface,
+		Limit:     int32(keep),
+	}); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// Captures returns dumps of the device by interface, newest first.
+func (d *DB) Captures(ctx context.Context, deviceID uint) ([]Capture, error) {
+	dbCaptures, err := d.q.Captures(ctx, uint32(deviceID))
+	if err != nil {
+		return nil, err
+	}
+
+	captures := make([]Capture, 0, len(dbCaptures))
+	for _, c := range dbCaptures {
+		data, err := decompress(c.Data)
+		if err != nil {
+			return nil, fmt.Errorf("capture %d: %w", c.ID, err)
+		}
+
+		captures = append(captures, Capture{
+			ID:        uint(c.ID),
+			DeviceID:  uint(c.DeviceID),
+			Interface: c.Interface,
+			Data:      data,
+			Created:   c.Created,
+		})
+	}
+
+	return captures, nil
+}
+
+func (d *DB) UpdateInterface(ctx context.Context, iface Interface) error {
+	return d.q.UpsertInterface(ctx, sqlc.UpsertInterfaceParams{
+		DeviceID:       uint32(iface.DeviceID),
+		Name:           iface.Name,
+		Frequency:      iface.Frequency,
+		Channel:        int16(iface.Channel),
+		GridSpacing:    iface.GridSpacing,
+		TargetPower:    iface.TargetPower,
+		ModuleState:    iface.ModuleState,
+		DatapathStates: append([]byte{}, iface.DataPathStates...), // not NULL when unknown
+		TxPower:        iface.TxPower,
+		RxPower:        iface.RxPower,
+		RxLowAlarm:     iface.RxLowAlarm,
+		Updated:        time.Now(),
+	})
+}
+
+// Interfaces returns states of interfaces of all devices.
+func (d *DB) Interfaces(ctx context.Context) ([]Interface, error) {
+	dbInterfaces, err := d.q.Interfaces(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return interfacesFromDB(dbInterfaces), nil
+}
+
+func (d *DB) DeviceInterfaces(ctx context.Context, deviceID uint) ([]Interface, error) {
+	dbInterfaces, err := d.q.DeviceInterfaces(ctx, uint32(deviceID))
+	if err != nil {
+		return nil, err
+	}
+
+	return interfacesFromDB(dbInterfaces), nil
+}
+
+func interfacesFromDB(dbInterfaces []sqlc.Interface) []Interface {
+	interfaces := make([]Interface, 0, len(dbInterfaces))
+	for _, i := range dbInterfaces {
+		interfaces = append(interfaces, Interface{
+			DeviceID:       uint(i.DeviceID),
+			Name:           i.Name,
+			Frequency:      i.Frequency,
+			Channel:        int(i.Channel),
+			GridSpacing:    i.GridSpacing,
+			TargetPower:    i.TargetPower,
+			ModuleState:    i.ModuleState,
+			DataPathStates: i.DatapathStates,
+			TxPower:        i.TxPower,
+			RxPower:        i.RxPower,
+			RxLowAlarm:     i.RxLowAlarm,
+			Updated:        i.Updated,
+		})
+	}
+
+	return interfaces
+}
+
+func (d *DB) CreateEvent(ctx context.Context, event Event) error {
+	return d.q.CreateEvent(ctx, sqlc.CreateEventParams{
+		DeviceID:  uint32(event.DeviceID),
+		Hostname:  event.Hostname,
+		Interface: event.Interface,
+		Kind:      event.Kind,
+		Message:   event.Message,
+		Created:   time.Now(),
+	})
+}
+
+// Events returns events matching the filter, newest first.
+func (d *DB) Events(ctx context.Context, filter EventFilter) ([]Event, error) {
+	dbEvents, err := d.q.Events(ctx, sqlc.EventsParams{
+		DeviceID: uint32(filter.DeviceID),
+		Kind:     filter.Kind,
+		Limit:    int32(filter.Limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]Event, 0, len(dbEvents))
+	for _, e := range dbEvents {
+		events = append(events, Event{
+			ID:        uint(e.ID),
+			DeviceID:  uint(e.DeviceID),
+			Hostname:  e.Hostname,
+			Interface: e.Interface,
+			Kind:      e.Kind,
+			Message:   e.Message,
+			Created:   e.Created,
+		})
+	}
+
+	return events, nil
+}
+
+func (d *DB) UpdateTrend(ctx context.Context, trend Trend) error {
+	return d.q.UpsertTrend(ctx, sqlc.UpsertTrendParams{
+		DeviceID:     uint32(trend.DeviceID),
+		Interface:    trend.Interface,
+		Metric:       trend.Metric,
+		Samples:      uint32(trend.Samples),
+		Mean:         trend.Mean,
+		Variance:     trend.Variance,
+		Origin:       trend.Origin,
+		FitWeight:    trend.Fit.W,
+		FitT:         trend.Fit.T,
+		FitX:         trend.Fit.X,
+		FitTt:        trend.Fit.TT,
+		FitTx:        trend.Fit.TX,
+		Slope:        trend.Slope,
+		Step:         trend.Step,
+		Drifting:     trend.Drifting,
+		ForecastDays: nullFloat(trend.ForecastDays),
+		Updated:      trend.Updated,
+	})
+}
+
+func (d *DB) DeviceTrends(ctx context.Context, deviceID uint) ([]Trend, error) {
+	dbTrends, err := d.q.DeviceTrends(ctx, uint32(deviceID))
+	if err != nil {
+		return nil, err
+	}
+
+	return trendsFromDB(dbTrends), nil
+}
+
+// FlaggedTrends returns trends of all devices with a step, drift or
+// forecast to show.
+func (d *DB) FlaggedTrends(ctx context.Context) ([]Trend, error) {
+	dbTrends, err := d.q.FlaggedTrends(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return trendsFromDB(dbTrends), nil
+}
+
+func trendsFromDB(dbTrends []sqlc.Trend) []Trend {
+	trends := make([]Trend, 0, len(dbTrends))
+	for _, t := range dbTrends {
+		trends = append(trends, Trend{
+			DeviceID:     uint(t.DeviceID),
+			Interface:    t.Interface,
+			Metric:       t.Metric,
+			Samples:      int(t.Samples),
+			Mean:         t.Mean,
+			Variance:     t.Variance,
+			Origin:       t.Origin,
+			Fit:          TrendFit{W: t.FitWeight, T: t.FitT, X: t.FitX, TT: t.FitTt, TX: t.FitTx},
+			Slope:        t.Slope,
+			Step:         t.Step,
+			Drifting:     t.Drifting,
+			ForecastDays: floatFromNull(t.ForecastDays),
+			Updated:      t.Updated,
+		})
+	}
+
+	return trends
+}
+
+func (d *DB) CreateRule(ctx context.Context, rule Rule) (uint, error) {
+	id, err := d.q.CreateRule(ctx, sqlc.CreateRuleParams{
+		Name:          rule.Name,
+		Enabled:       rule.Enabled,
+		Metric:        rule.Metric,
+		Comparison:    rule.Comparison,
+		Threshold:     rule.Threshold,
+		Hysteresis:    rule.Hysteresis,
+		WindowSeconds: uint32(rule.Window.Seconds()),
+		ForPolls:      uint32(rule.ForPolls),
+		ForSeconds:    uint32(rule.ForSeconds),
+		Site:          rule.Site,
+		DeviceGroup:   rule.Group,
+		Tag:           rule.Tag,
+		DeviceID:      nullID(rule.DeviceID),
+		Interface:     rule.Interface,
+	})
+
+	return uint(id), err
+}
+
+func (d *DB) Rule(ctx context.Context, id uint) (Rule, error) {
+	dbRule, err := d.q.Rule(ctx, uint32(id))
+	if err != nil {
+		return Rule{}, err
+	}
+
+	return ruleFromDB(dbRule), nil
+}
+
+func (d *DB) Rules(ctx context.Context) ([]Rule, error) {
+	dbRules, err := d.q.Rules(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rules := make([]Rule, 0, len(dbRules))
+	for _, r := range dbRules {
+		rules = append(rules, ruleFromDB(r))
+	}
+
+	return rules, nil
+}
+
+func (d *DB) UpdateRule(ctx context.Context, rule Rule) error {
+	return d.q.UpdateRule(ctx, sqlc.UpdateRuleParams{
+		ID:            uint32(rule.ID),
+		Name:          rule.Name,
+		Enabled:       rule.Enabled,
+		Metric:        rule.Metric,
+		Comparison:    rule.Comparison,
+		Threshold:     rule.Threshold,
+		Hysteresis:    rule.Hysteresis,
+		WindowSeconds: uint32(rule.Window.Seconds()),
+		ForPolls:      uint32(rule.ForPolls),
+		ForSeconds:    uint32(rule.ForSeconds),
+		Site:          rule.Site,
+		DeviceGroup:   rule.Group,
+		Tag:           rule.Tag,
+		DeviceID:      nullID(rule.DeviceID),
+		Interface:     rule.Interface,
+	})
+}
+
+// SilenceRule keeps alerts of the rule quiet until the given time, zero time
+// lifts the silence.
+func (d *DB) SilenceRule(ctx context.Context, id uint, until time.Time) error {
+	return d.q.SilenceRule(ctx, sqlc.SilenceRuleParams{
+		ID:            uint32(id),
+		SilencedUntil: sql.NullTime{Time: until, Valid: !until.IsZero()},
+	})
+}
+
+func (d *DB) DeleteRule(ctx context.Context, id uint) error {
+	return d.q.DeleteRule(ctx, uint32(id))
+}
+
+func ruleFromDB(r sqlc.Rule) Rule {
+	return Rule{
+		ID:            uint(r.ID),
+		Name:          r.Name,
+		Enabled:       r.Enabled,
+		Metric:        r.Metric,
+		Comparison:    r.Comparison,
+		Threshold:     r.Threshold,
+		Hysteresis:    r.Hysteresis,
+		Window:        time.Duration(r.WindowSeconds) * time.Second,
+		ForPolls:      int(r.ForPolls),
+		ForSeconds:    int(r.ForSeconds),
+		Site:          r.Site,
+		Group:         r.DeviceGroup,
+		Tag:           r.Tag,
+		DeviceID:      uint(r.DeviceID.Int32),
+		Interface:     r.Interface,
+		SilencedUntil: r.SilencedUntil.Time,
+	}
+}
+
+func (d *DB) UpdateAlert(ctx context.Context, alert Alert) error {
+	samples, err := json.Marshal(alert.Samples)
+	if err != nil {
+		return err
+	}
+
+	return d.q.UpsertAlert(ctx, sqlc.UpsertAlertParams{
+		RuleID:    uint32(alert.RuleID),
+		DeviceID:  uint32(alert.DeviceID),
+		Interface: alert.Interface,
+		Breaches:  uint32(alert.Breaches),
+		Since:     sql.NullTime{Time: alert.Since, Valid: !alert.Since.IsZero()},
+		Firing:    alert.Firing,
+		Value:     alert.Value,
+		Samples:   string(samples),
+		Updated:   alert.Updated,
+	})
+}
+
+func (d *DB) DeviceAlerts(ctx context.Context, deviceID uint) ([]Alert, error) {
+	dbAlerts, err := d.q.DeviceAlerts(ctx, uint32(deviceID))
+	if err != nil {
+		return nil, err
+	}
+
+	return alertsFromDB(dbAlerts)
+}
+
+// FiringAlerts returns alerts of all devices currently firing.
+func (d *DB) FiringAlerts(ctx context.Context) ([]Alert, error) {
+	dbAlerts, err := d.q.FiringAlerts(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return alertsFromDB(dbAlerts)
+}
+
+func alertsFromDB(dbAlerts []sqlc.Alert) ([]Alert, error) {
+	alerts := make([]Alert, 0, len(dbAlerts))
+	for _, a := range dbAlerts {
+		var samples []Sample
+		if err := json.Unmarshal([]byte(a.Samples), &samples); err != nil {
+			return nil, fmt.Errorf("samples of rule %d: %w", a.RuleID, err)
+		}
+
+		alerts = append(alerts, Alert{
+			RuleID:    uint(a.RuleID),
+			DeviceID:  uint(a.DeviceID),
+			Interface: a.Interface,
+			Breaches:  int(a.Breaches),
+			Since:     a.Since.Time,
+			Firing:    a.Firing,
+			Value:     a.Value,
+			Samples:   samples,
+			Updated:   a.Updated,
+		})
+	}
+
+	return alerts, nil
+}
+
+func (d *DB) CreateMaintenanceWindow(ctx context.Context, window MaintenanceWindow) (uint, error) {
+	id, err := d.q.CreateMaintenanceWindow(ctx, sqlc.CreateMaintenanceWindowParams{
+		Name:            window.Name,
+		Site:            window.Site,
+		DeviceGroup:     window.Group,
+		DeviceID:        nullID(window.DeviceID),
+		Starts:          window.Starts,
+		DurationMinutes: uint32(window.Duration / time.Minute),
+		Schedule:        window.Schedule,
+		SkipPolling:     window.SkipPolling,
+	})
+
+	return uint(id), err
+}
+
+func (d *DB) MaintenanceWindow(ctx context.Context, id uint) (MaintenanceWindow, error) {
+	dbWindow, err := d.q.MaintenanceWindow(ctx, uint32(id))
+	if err != nil {
+		return MaintenanceWindow{}, err
+	}
+
+	return maintenanceWindowFromDB(dbWindow), nil
+}
+
+func (d *DB) MaintenanceWindows(ctx context.Context) ([]MaintenanceWindow, error) {
+	dbWindows, err := d.q.MaintenanceWindows(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	windows := make([]MaintenanceWindow, 0, len(dbWindows))
+	for _, w := range dbWindows {
+		windows = append(windows, maintenanceWindowFromDB(w))
+	}
+
+	return windows, nil
+}
+
+func (d *DB) UpdateMaintenanceWindow(ctx context.Context, window MaintenanceWindow) error {
+	return d.q.UpdateMaintenanceWindow(ctx, sqlc.UpdateMaintenanceWindowParams{
+		ID:              uint32(window.ID),
+		Name:            window.Name,
+		Site:            window.Site,
+		DeviceGroup:     window.Group,
+		DeviceID:        nullID(window.DeviceID),
+		Starts:          window.Starts,
+		DurationMinutes: uint32(window.Duration / time.Minute),
+		Schedule:        window.Schedule,
+		SkipPolling:     window.SkipPolling,
+	})
+}
+
+func (d *DB) DeleteMaintenanceWindow(ctx context.Context, id uint) error {
+	return d.q.DeleteMaintenanceWindow(ctx, uint32(id))
+}
+
+func maintenanceWindowFromDB(w sqlc.MaintenanceWindow) MaintenanceWindow {
+	return MaintenanceWindow{
+		ID:          uint(w.ID),
+		Name:        w.Name,
+		Site:        w.Site,
+		Group:       w.DeviceGroup,
+		DeviceID:    uint(w.DeviceID.Int32),
+		Starts:      w.Starts,
+		Duration:    time.Duration(w.DurationMinutes) * time.Minute,
+		Schedule:    w.Schedule,
+		SkipPolling: w.SkipPolling,
+	}
+}
@@ -17,6 +17,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"strings"
 	"testing"
 	"time"
 
@@ -196,7 +197,7 @@ VALUES (1,'hostname1','10.0.0.1','user1','2024-05-22 00:00:00');`),
 			}
 
 			db := New(conn)
-			err = db.CreateDevice(tc.args.ctx, tc.args.device)
+			_, err = db.CreateDevice(tc.args.ctx, tc.args.device)
 
 			errComp := gocmp.Comparer(func(x, y error) bool {
 				return x.Error() == y.Error()
@@ -246,8 +247,10 @@ func TestDB_Device(t *testing.T) {
 					ID:         2,
 					Hostname:   "hostname2",
 					IPAddress:  "10.0.0.2",
+					Port:       22,
 					Login:      "user2",
 					Keyfile:    []byte{},
+					Tags:       []string{},
 					Connected:  time.Date(2024, 5, 22, 0, 0, 0, 0, time.UTC),
 					LastStatus: -1,
 				},
@@ -348,16 +351,20 @@ func TestDB_Devices(t *testing.T) {
 						ID:         1,
 						Hostname:   "hostname1",
 						IPAddress:  "10.0.0.1",
+						Port:       22,
 						Login:      "user1",
 						Keyfile:    []byte{},
+						Tags:       []string{},
 						Connected:  time.Date(2024, 5, 22, 0, 0, 0, 0, time.UTC),
 						LastStatus: -1,
 					}, {
 						ID:         2,
 						Hostname:   "hostname2",
 						IPAddress:  "10.0.0.2",
+						Port:       22,
 						Login:      "user2",
 						Keyfile:    []byte{},
+						Tags:       []string{},
 						Connected:  time.Date(2024, 5, 22, 0, 0, 0, 0, time.UTC),
 						LastStatus: -1,
 					},
@@ -456,8 +463,10 @@ func TestDB_UpdateDevice(t *testing.T) {
 					ID:         1,
 					Hostname:   "hostname1new",
 					IPAddress:  "1.0.0.10",
+					Port:       22,
 					Login:      "user1new",
 					Keyfile:    []byte{},
+					Tags:       []string{},
 					Connected:  time.Date(2024, 5, 23, 0, 0, 0, 0, time.UTC),
 					LastStatus: -1,
 				},
@@ -468,8 +477,10 @@ func TestDB_UpdateDevice(t *testing.T) {
 						ID:         1,
 						Hostname:   "hostname1new",
 						IPAddress:  "1.0.0.10",
+						Port:       22,
 						Login:      "user1new",
 						Keyfile:    []byte{},
+						Tags:       []string{},
 						Connected:  time.Date(2024, 5, 23, 0, 0, 0, 0, time.UTC),
 						LastStatus: -1,
 					},
@@ -477,8 +488,10 @@ func TestDB_UpdateDevice(t *testing.T) {
 						ID:         2,
 						Hostname:   "hostname2",
 						IPAddress:  "10.0.0.2",
+						Port:       22,
 						Login:      "user2",
 						Keyfile:    []byte{},
+						Tags:       []string{},
 						Connected:  time.Date(2024, 5, 22, 0, 0, 0, 0, time.UTC),
 						LastStatus: -1,
 					},
@@ -574,8 +587,10 @@ func TestDB_UpdateDeviceStatus(t *testing.T) {
 						ID:         1,
 						Hostname:   "hostname1",
 						IPAddress:  "10.0.0.1",
+						Port:       22,
 						Login:      "user1",
 						Keyfile:    []byte{},
+						Tags:       []string{},
 						Connected:  time.Date(2024, 5, 23, 0, 0, 0, 0, time.UTC).Add(time.Hour),
 						LastStatus: 0,
 					},
@@ -583,8 +598,10 @@ func TestDB_UpdateDeviceStatus(t *testing.T) {
 						ID:         2,
 						Hostname:   "hostname2",
 						IPAddress:  "10.0.0.2",
+						Port:       22,
 						Login:      "user2",
 						Keyfile:    []byte{},
+						Tags:       []string{},
 						Connected:  time.Date(2024, 5, 22, 0, 0, 0, 0, time.UTC),
 						LastStatus: -1,
 					},
@@ -716,3 +733,808 @@ VALUES (1,'hostname1','10.0.0.1','user1','2024-05-22 00:00:00'),
 		})
 	}
 }
+
+func TestDB_ImportDevices(t *testing.T) {
+	type args struct {
+		ctx     context.Context
+		creates []Device
+		updates []Device
+	}
+	type want struct {
+		count *int
+		err   error
+	}
+	type database struct {
+		prepare func(*testing.T, *sql.DB)
+		cleanup func(*testing.T, *sql.DB)
+	}
+	tests := []struct {
+		name     string
+		args     args
+		want     want
+		database database
+	}{
+		{
+			name: "creates and updates devices",
+			args: args{
+				ctx: context.Background(),
+				creates: []Device{
+					{Hostname: "hostname2", IPAddress: "10.0.0.2", Port: 22, Login: "user2"},
+					{Hostname: "hostname3", IPAddress: "10.0.0.3", Port: 2222, Login: "user3", Tags: []string{"core"}},
+				},
+				updates: []Device{
+					{ID: 1, Hostname: "hostname1", IPAddress: "10.0.0.10", Port: 22, Login: "user1"},
+				},
+			},
+			want: want{
+				count: ptr(3),
+			},
+			database: database{
+				prepare: exec(`INSERT INTO devices(id, hostname, ip, login, connected)
+VALUES (1,'hostname1','10.0.0.1','user1','2024-05-22 00:00:00');`),
+				cleanup: cleanup("devices"),
+			},
+		},
+		{
+			name: "rolls back on error",
+			args: args{
+				ctx: context.Background(),
+				creates: []Device{
+					{Hostname: "hostname2", IPAddress: "10.0.0.2", Port: 22, Login: "user2"},
+					{Hostname: strings.Repeat("h", 101), IPAddress: "10.0.0.3", Port: 22, Login: "user3"},
+				},
+			},
+			want: want{
+				count: ptr(1),
+				err:   fmt.Errorf("cannot create %s: Error 1406 (22001): Data too long for column 'hostname' at row 1", strings.Repeat("h", 101)),
+			},
+			database: database{
+				prepare: exec(`INSERT INTO devices(id, hostname, ip, login, connected)
+VALUES (1,'hostname1','10.0.0.1','user1','2024-05-22 00:00:00');`),
+				cleanup: cleanup("devices"),
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			conn, err := connect()
+			if err != nil {
+				t.Fatalf("unable to connect to database: %v", err)
+			}
+
+			if tc.database.prepare != nil {
+				tc.database.prepare(t, conn)
+			}
+			if tc.database.cleanup != nil {
+				t.Cleanup(func() { tc.database.cleanup(t, conn) })
+			}
+
+			db := New(conn)
+			_, err = db.ImportDevices(tc.args.ctx, tc.args.creates, tc.args.updates)
+
+			errComp := gocmp.Comparer(func(x, y error) bool {
+				return x.Error() == y.Error()
+			})
+
+			if diff := gocmp.Diff(err, tc.want.err, errComp); diff != "" {
+				t.Errorf("error mismatch (-got +want):\n%s", diff)
+			}
+
+			if tc.want.count != nil {
+				got := count("devices")(t, conn)
+				if diff := gocmp.Diff(got, *tc.want.count); diff != "" {
+					t.Errorf("devices count mismatch (-got +want):\n%s", diff)
+				}
+			}
+		})
+	}
+}
+
+func TestDB_AuditEntries(t *testing.T) {
+	type args struct {
+		ctx    context.Context
+		filter AuditFilter
+	}
+	type want struct {
+		entries []AuditEntry
+		err     error
+	}
+	type database struct {
+		prepare func(*testing.T, *sql.DB)
+		cleanup func(*testing.T, *sql.DB)
+	}
+
+	const rows = `INSERT INTO audit_log(id, actor, action, target_type, target_id, target_name, changes, created)
+VALUES (1,'admin','create','device',1,'hostname1','[{"field":"hostname","before":"","after":"hostname1"}]','2024-05-22 00:00:00'),
+       (2,'jdoe','update','device',1,'hostname1','[{"field":"password","before":"******","after":"******"}]','2024-05-23 00:00:00'),
+       (3,'admin','delete','device',2,'other','[]','2024-05-24 00:00:00');`
+
+	tests := []struct {
+		name     string
+		args     args
+		want     want
+		database database
+	}{
+		{
+			name: "returns newest entries first",
+			args: args{
+				ctx:    context.Background(),
+				filter: AuditFilter{Limit: 2},
+			},
+			want: want{
+				entries: []AuditEntry{
+					{
+						ID:         3,
+						Actor:      "admin",
+						Action:     AuditActionDelete,
+						TargetType: AuditTargetDevice,
+						TargetID:   2,
+						TargetName: "other",
+						Changes:    []Change{},
+						Created:    time.Date(2024, 5, 24, 0, 0, 0, 0, time.UTC),
+					},
+					{
+						ID:         2,
+						Actor:      "jdoe",
+						Action:     AuditActionUpdate,
+						TargetType: AuditTargetDevice,
+						TargetID:   1,
+						TargetName: "hostname1",
+						Changes:    []Change{{Field: "password", Before: maskedValue, After: maskedValue}},
+						Created:    time.Date(2024, 5, 23, 0, 0, 0, 0, time.UTC),
+					},
+				},
+			},
+			database: database{
+				prepare: exec(rows),
+				cleanup: cleanup("audit_log"),
+			},
+		},
+		{
+			name: "filters by actor and target",
+			args: args{
+				ctx:    context.Background(),
+				filter: AuditFilter{Actor: "admin", TargetName: "host", Limit: 10},
+			},
+			want: want{
+				entries: []AuditEntry{
+					{
+						ID:         1,
+						Actor:      "admin",
+						Action:     AuditActionCreate,
+						TargetType: AuditTargetDevice,
+						TargetID:   1,
+						TargetName: "hostname1",
+						Changes:    []Change{{Field: "hostname", Before: "", After: "hostname1"}},
+						Created:    time.Date(2024, 5, 22, 0, 0, 0, 0, time.UTC),
+					},
+				},
+			},
+			database: database{
+				prepare: exec(rows),
+				cleanup: cleanup("audit_log"),
+			},
+		},
+		{
+			name: "matches wildcards of target literally",
+			args: args{
+				ctx:    context.Background(),
+				filter: AuditFilter{TargetName: "core_1", Limit: 10},
+			},
+			want: want{
+				entries: []AuditEntry{
+					{
+						ID:         4,
+						Actor:      "admin",
+						Action:     AuditActionCreate,
+						TargetType: AuditTargetDevice,
+						TargetID:   3,
+						TargetName: "core_1",
+						Changes:    []Change{},
+						Created:    time.Date(2024, 5, 25, 0, 0, 0, 0, time.UTC),
+					},
+				},
+			},
+			database: database{
+				prepare: exec(`INSERT INTO audit_log(id, actor, action, target_type, target_id, target_name, changes, created)
+VALUES (4,'admin','create','device',3,'core_1','[]','2024-05-25 00:00:00'),
+       (5,'admin','create','device',4,'coreX1','[]','2024-05-26 00:00:00');`),
+				cleanup: cleanup("audit_log"),
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			conn, err := connect()
+			if err != nil {
+				t.Fatalf("unable to connect to database: %v", err)
+			}
+
+			if tc.database.prepare != nil {
+				tc.database.prepare(t, conn)
+			}
+			if tc.database.cleanup != nil {
+				t.Cleanup(func() { tc.database.cleanup(t, conn) })
+			}
+
+			db := New(conn)
+
+			entries, err := db.AuditEntries(tc.args.ctx, tc.args.filter)
+
+			errComp := gocmp.Comparer(func(x, y error) bool {
+				return x.Error() == y.Error()
+			})
+
+			if diff := gocmp.Diff(err, tc.want.err, errComp); diff != "" {
+				t.Errorf("error mismatch (-got +want):\n%s", diff)
+			}
+
+			if diff := gocmp.Diff(entries, tc.want.entries); diff != "" {
+				t.Errorf("audit entries mismatch (-got +want):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDB_Credentials(t *testing.T) {
+	conn, err := connect()
+	if err != nil {
+		t.Fatalf("unable to connect to database: %v", err)
+	}
+	t.Cleanup(func() { cleanup("devices", "credentials")(t, conn) })
+
+	ctx := context.Background()
+	db := New(conn)
+
+	credential := Credential{Name: "noc", Kind: CredentialKey, Login: "noc", Keyfile: []byte("key"), Passphrase: "phrase", Certificate: []byte("cert")}
+	if credential.ID, err = db.CreateCredential(ctx, credential); err != nil {
+		t.Fatalf("unable to create credential: %v", err)
+	}
+
+	got, err := db.Credential(ctx, credential.ID)
+	if err != nil {
+		t.Fatalf("unable to get credential: %v", err)
+	}
+	if diff := gocmp.Diff(got, credential); diff != "" {
+		t.Errorf("credential mismatch (-got +want):\n%s", diff)
+	}
+
+	if _, err := db.CreateCredential(ctx, Credential{Name: "noc", Kind: CredentialAgent, Login: "other"}); err == nil {
+		t.Errorf("expected error for duplicate name, got nil")
+	}
+
+	if _, err := db.CreateDevice(ctx, Device{Hostname: "hostname", IPAddress: "10.0.0.1", Port: DefaultPort, CredentialID: credential.ID, Tags: []string{}}); err != nil {
+		t.Fatalf("unable to create device: %v", err)
+	}
+
+	usage, err := db.CredentialUsage(ctx, credential.ID)
+	if err != nil || usage != 1 {
+		t.Errorf("expected usage 1, got %d (error: %v)", usage, err)
+	}
+
+	if err := db.DeleteCredential(ctx, credential.ID); err == nil {
+		t.Errorf("expected error when deleting used credential, got nil")
+	}
+
+	credential.Kind, credential.Keyfile, credential.Passphrase, credential.Certificate, credential.Password = CredentialPassword, []byte{}, "", []byte{}, "rotated"
+	if err := db.UpdateCredential(ctx, credential); err != nil {
+		t.Fatalf("unable to update credential: %v", err)
+	}
+
+	credentials, err := db.Credentials(ctx)
+	if err != nil {
+		t.Fatalf("unable to list credentials: %v", err)
+	}
+	if diff := gocmp.Diff(credentials, []Credential{credential}); diff != "" {
+		t.Errorf("credentials mismatch (-got +want):\n%s", diff)
+	}
+}
+
+func TestDB_JumpHosts(t *testing.T) {
+	conn, err := connect()
+	if err != nil {
+		t.Fatalf("unable to connect to database: %v", err)
+	}
+	t.Cleanup(func() {
+		cleanup("devices")(t, conn)
+		exec("UPDATE jump_hosts SET via_id = NULL")(t, conn)
+		cleanup("jump_hosts", "credentials")(t, conn)
+	})
+
+	ctx := context.Background()
+	db := New(conn)
+
+	credentialID, err := db.CreateCredential(ctx, Credential{Name: "jump", Kind: CredentialAgent, Login: "jump"})
+	if err != nil {
+		t.Fatalf("unable to create credential: %v", err)
+	}
+
+	outer := JumpHost{Name: "outer", Address: "bastion.example.net", Port: DefaultPort, CredentialID: credentialID}
+	if outer.ID, err = db.CreateJumpHost(ctx, outer); err != nil {
+		t.Fatalf("unable to create jump host: %v", err)
+	}
+	inner := JumpHost{Name: "inner", Address: "10.0.0.1", Port: 2222, CredentialID: credentialID, ViaID: outer.ID}
+	if inner.ID, err = db.CreateJumpHost(ctx, inner); err != nil {
+		t.Fatalf("unable to create jump host: %v", err)
+	}
+
+	got, err := db.JumpHost(ctx, inner.ID)
+	if err != nil {
+		t.Fatalf("unable to get jump host: %v", err)
+	}
+	if diff := gocmp.Diff(got, inner); diff != "" {
+		t.Errorf("jump host mismatch (-got +want):\n%s", diff)
+	}
+
+	if _, err := db.CreateDevice(ctx, Device{Hostname: "hostname", IPAddress: "10.0.1.1", Port: DefaultPort, Login: "noc", JumpHostID: inner.ID, Tags: []string{}}); err != nil {
+		t.Fatalf("unable to create device: %v", err)
+	}
+
+	for id, want := range map[uint]int{outer.ID: 1, inner.ID: 1} {
+		usage, err := db.JumpHostUsage(ctx, id)
+		if err != nil || usage != want {
+			t.Errorf("expected usage %d of jump host #%d, got %d (error: %v)", want, id, usage, err)
+		}
+	}
+
+	usage, err := db.CredentialUsage(ctx, credentialID)
+	if err != nil || usage != 2 {
+		t.Errorf("expected credential usage 2, got %d (error: %v)", usage, err)
+	}
+
+	if err := db.DeleteJumpHost(ctx, outer.ID); err == nil {
+		t.Errorf("expected error when deleting used jump host, got nil")
+	}
+
+	inner.ViaID, inner.Port = 0, DefaultPort
+	if err := db.UpdateJumpHost(ctx, inner); err != nil {
+		t.Fatalf("unable to update jump host: %v", err)
+	}
+
+	hosts, err := db.JumpHosts(ctx)
+	if err != nil {
+		t.Fatalf("unable to list jump hosts: %v", err)
+	}
+	if diff := gocmp.Diff(hosts, []JumpHost{inner, outer}); diff != "" {
+		t.Errorf("jump hosts mismatch (-got +want):\n%s", diff)
+	}
+
+	if err := db.DeleteJumpHost(ctx, outer.ID); err != nil {
+		t.Errorf("unable to delete jump host: %v", err)
+	}
+}
+
+func TestDB_DeviceCollector(t *testing.T) {
+	conn, err := connect()
+	if err != nil {
+		t.Fatalf("unable to connect to database: %v", err)
+	}
+	t.Cleanup(func() { cleanup("devices")(t, conn) })
+
+	ctx := context.Background()
+	db := New(conn)
+
+	snmp := SNMP{Version: SNMPVersion3, Port: 1161, User: "noc", AuthProtocol: "SHA256", AuthPassword: "auth-secret", PrivProtocol: "AES", PrivPassword: "priv-secret"}
+	id, err := db.CreateDevice(ctx, Device{Hostname: "hostname", IPAddress: "10.0.0.1", Port: DefaultPort, Collector: CollectorSNMP, SNMP: snmp, Tags: []string{}})
+	if err != nil {
+		t.Fatalf("unable to create device: %v", err)
+	}
+
+	device, err := db.Device(ctx, id)
+	if err != nil {
+		t.Fatalf("unable to get device: %v", err)
+	}
+	if device.Collector != CollectorSNMP {
+		t.Errorf("expected collector %s, got %s", CollectorSNMP, device.Collector)
+	}
+	if diff := gocmp.Diff(device.SNMP, snmp); diff != "" {
+		t.Errorf("SNMP settings mismatch (-got +want):\n%s", diff)
+	}
+
+	// Devices without a collector, e.g. imported ones, use SSH.
+	id, err = db.CreateDevice(ctx, Device{Hostname: "imported", IPAddress: "10.0.0.2", Port: DefaultPort, Login: "noc", Tags: []string{}})
+	if err != nil {
+		t.Fatalf("unable to create device: %v", err)
+	}
+
+	device, err = db.Device(ctx, id)
+	if err != nil {
+		t.Fatalf("unable to get device: %v", err)
+	}
+	if device.Collector != CollectorSSH || device.SNMP.Version != SNMPVersion2c || device.SNMP.Port != DefaultSNMPPort {
+		t.Errorf("expected SSH collector with default SNMP settings, got %s, %+v", device.Collector, device.SNMP)
+	}
+	if device.GNMI.Port != DefaultGNMIPort || device.GNMI.Mode != GNMIModeSample || device.GNMI.SampleInterval != DefaultGNMISampleInterval {
+		t.Errorf("expected default gNMI settings, got %+v", device.GNMI)
+	}
+
+	gnmi := GNMI{Port: 57400, Mode: GNMIModeOnChange, SampleInterval: 30, TLS: true, SkipVerify: true}
+	id, err = db.CreateDevice(ctx, Device{Hostname: "streaming", IPAddress: "10.0.0.3", Port: DefaultPort, Collector: CollectorGNMI, GNMI: gnmi, Tags: []string{}})
+	if err != nil {
+		t.Fatalf("unable to create device: %v", err)
+	}
+
+	device, err = db.Device(ctx, id)
+	if err != nil {
+		t.Fatalf("unable to get device: %v", err)
+	}
+	if diff := gocmp.Diff(device.GNMI, gnmi); diff != "" {
+		t.Errorf("gNMI settings mismatch (-got +want):\n%s", diff)
+	}
+	if device.NETCONF.Port != DefaultNETCONFPort || device.NETCONF.Platform != NETCONFPlatformJunos {
+		t.Errorf("expected default NETCONF settings, got %+v", device.NETCONF)
+	}
+
+	netconf := NETCONF{Port: 22, Platform: NETCONFPlatformSROS, Filter: "<state><port/></state>"}
+	id, err = db.CreateDevice(ctx, Device{Hostname: "netconf", IPAddress: "10.0.0.4", Port: DefaultPort, Login: "noc", Collector: CollectorNETCONF, NETCONF: netconf, Tags: []string{}})
+	if err != nil {
+		t.Fatalf("unable to create device: %v", err)
+	}
+
+	device, err = db.Device(ctx, id)
+	if err != nil {
+		t.Fatalf("unable to get device: %v", err)
+	}
+	if diff := gocmp.Diff(device.NETCONF, netconf); diff != "" {
+		t.Errorf("NETCONF settings mismatch (-got +want):\n%s", diff)
+	}
+	if len(device.Local.Interfaces) != 0 || device.Local.Path != "" {
+		t.Errorf("expected no local settings, got %+v", device.Local)
+	}
+
+	local := Local{Interfaces: []string{"eth0", "eth1"}, Path: "/var/lib/ems/dumps"}
+	id, err = db.CreateDevice(ctx, Device{Hostname: "local", IPAddress: "127.0.0.1", Port: DefaultPort, Collector: CollectorLocal, Local: local, Tags: []string{}})
+	if err != nil {
+		t.Fatalf("unable to create device: %v", err)
+	}
+
+	device, err = db.Device(ctx, id)
+	if err != nil {
+		t.Fatalf("unable to get device: %v", err)
+	}
+	if diff := gocmp.Diff(device.Local, local); diff != "" {
+		t.Errorf("local settings mismatch (-got +want):\n%s", diff)
+	}
+}
+
+func TestDB_Captures(t *testing.T) {
+	conn, err := connect()
+	if err != nil {
+		t.Fatalf("unable to connect to database: %v", err)
+	}
+	t.Cleanup(func() { cleanup("eeprom_captures", "devices")(t, conn) })
+
+	ctx := context.Background()
+	db := New(conn)
+
+	id, err := db.CreateDevice(ctx, Device{Hostname: "hostname", IPAddress: "10.0.0.1", Port: DefaultPort, Tags: []string{}})
+	if err != nil {
+		t.Fatalf("unable to create device: %v", err)
+	}
+
+	for _, c := range []Capture{
+		{DeviceID: id, Interface: "eth0", Data: []byte("first\n")},
+		{DeviceID: id, Interface: "eth1", Data: []byte("other\n")},
+		{DeviceID: id, Interface: "eth0", Data: []byte("second\n")},
+		{DeviceID: id, Interface: "eth0", Data: []byte("third\n")},
+	} {
+		if err := db.CreateCapture(ctx, c, 2); err != nil {
+			t.Fatalf("unable to create capture: %v", err)
+		}
+	}
+
+	captures, err := db.Captures(ctx, id)
+	if err != nil {
+		t.Fatalf("unable to get captures: %v", err)
+	}
+
+	var got []string
+	for _, c := range captures {
+		got = append(got, c.Interface+": "+string(c.Data))
+	}
+	if diff := gocmp.Diff(got, []string{"eth0: third\n", "eth0: second\n", "eth1: other\n"}); diff != "" {
+		t.Errorf("captures mismatch (-got +want):\n%s", diff)
+	}
+
+	if err := db.DeleteDevice(ctx, id); err != nil {
+		t.Fatalf("unable to delete device: %v", err)
+	}
+	if got := count("eeprom_captures")(t, conn); got != 0 {
+		t.Errorf("expected captures deleted with the device, got %d", got)
+	}
+}
+
+func TestDB_InterfacesEvents(t *testing.T) {
+	conn, err := connect()
+	if err != nil {
+		t.Fatalf("unable to connect to database: %v", err)
+	}
+	t.Cleanup(func() { cleanup("events", "interfaces", "devices")(t, conn) })
+
+	ctx := context.Background()
+	db := New(conn)
+
+	id, err := db.CreateDevice(ctx, Device{Hostname: "hostname", IPAddress: "10.0.0.1", Port: DefaultPort, Tags: []string{}})
+	if err != nil {
+		t.Fatalf("unable to create device: %v", err)
+	}
+
+	for _, i := range []Interface{
+		{DeviceID: id, Name: "eth0", Frequency: 193.1, Channel: 0, GridSpacing: 100, TargetPower: -1},
+		{DeviceID: id, Name: "eth0", Frequency: 193.2, Channel: 1, GridSpacing: 100, TargetPower: -2, ModuleState: 3, DataPathStates: []uint8{4, 4, 1, 1}},
+		{DeviceID: id, Name: "eth1"},
+	} {
+		if err := db.UpdateInterface(ctx, i); err != nil {
+			t.Fatalf("unable to update interface: %v", err)
+		}
+	}
+
+	interfaces, err := db.DeviceInterfaces(ctx, id)
+	if err != nil {
+		t.Fatalf("unable to get interfaces: %v", err)
+	}
+	if len(interfaces) != 2 || interfaces[0].Frequency != 193.2 || interfaces[0].Channel != 1 || interfaces[0].TargetPower != -2 {
+		t.Errorf("unexpected interfaces: %+v", interfaces)
+	}
+	if diff := gocmp.Diff(interfaces[0].DataPathStates, []uint8{4, 4, 1, 1}); interfaces[0].ModuleState != 3 || diff != "" {
+		t.Errorf("unexpected states: %d, %v", interfaces[0].ModuleState, interfaces[0].DataPathStates)
+	}
+
+	for _, e := range []Event{
+		{DeviceID: id, Hostname: "hostname", Interface: "eth0", Kind: EventLaserFrequency, Message: "first"},
+		{DeviceID: id, Hostname: "hostname", Interface: "eth0", Kind: "other", Message: "second"},
+	} {
+		if err := db.CreateEvent(ctx, e); err != nil {
+			t.Fatalf("unable to create event: %v", err)
+		}
+	}
+
+	events, err := db.Events(ctx, EventFilter{DeviceID: id, Kind: EventLaserFrequency, Limit: 10})
+	if err != nil {
+		t.Fatalf("unable to get events: %v", err)
+	}
+	if len(events) != 1 || events[0].Message != "first" {
+		t.Errorf("unexpected events: %+v", events)
+	}
+
+	if err := db.DeleteDevice(ctx, id); err != nil {
+		t.Fatalf("unable to delete device: %v", err)
+	}
+	if got := count("events")(t, conn) + count("interfaces")(t, conn); got != 0 {
+		t.Errorf("expected interfaces and events deleted with the device, got %d", got)
+	}
+}
+
+func TestDB_Links(t *testing.T) {
+	conn, err := connect()
+	if err != nil {
+		t.Fatalf("unable to connect to database: %v", err)
+	}
+	t.Cleanup(func() { cleanup("links", "devices")(t, conn) })
+
+	ctx := context.Background()
+	db := New(conn)
+
+	var ids []uint
+	for _, hostname := range []string{"ep-site-a", "ep-site-b"} {
+		id, err := db.CreateDevice(ctx, Device{Hostname: hostname, IPAddress: "10.0.0.1", Port: DefaultPort, Tags: []string{}})
+		if err != nil {
+			t.Fatalf("unable to create device: %v", err)
+		}
+		ids = append(ids, id)
+	}
+
+	link := Link{Name: "a-b", ADeviceID: ids[0], AInterface: "eth1", BDeviceID: ids[1], BInterface: "eth1", AlarmDelta: DefaultLinkAlarmDelta}
+	if link.ID, err = db.CreateLink(ctx, link); err != nil {
+		t.Fatalf("unable to create link: %v", err)
+	}
+
+	baseline, loss, margin := 4.5, 8.0, 6.25
+	link.Baseline, link.Loss, link.Margin, link.Degraded = &baseline, &loss, &margin, true
+	link.Updated = time.Now().Truncate(time.Second).UTC()
+	if err := db.UpdateLinkSpan(ctx, link); err != nil {
+		t.Fatalf("unable to update link span: %v", err)
+	}
+
+	got, err := db.Link(ctx, link.ID)
+	if err != nil {
+		t.Fatalf("unable to get link: %v", err)
+	}
+	if diff := gocmp.Diff(got, link); diff != "" {
+		t.Errorf("link mismatch (-got +want):\n%s", diff)
+	}
+
+	if err := db.ResetLinkBaseline(ctx, link.ID); err != nil {
+		t.Fatalf("unable to reset baseline: %v", err)
+	}
+	link.Baseline, link.Degraded = nil, false
+
+	link.Name, link.AlarmDelta = "site-a to site-b", 2
+	if err := db.UpdateLink(ctx, link); err != nil {
+		t.Fatalf("unable to update link: %v", err)
+	}
+
+	links, err := db.Links(ctx)
+	if err != nil {
+		t.Fatalf("unable to list links: %v", err)
+	}
+	if diff := gocmp.Diff(links, []Link{link}); diff != "" {
+		t.Errorf("links mismatch (-got +want):\n%s", diff)
+	}
+
+	if err := db.DeleteDevice(ctx, ids[1]); err != nil {
+		t.Fatalf("unable to delete device: %v", err)
+	}
+	if got := count("links")(t, conn); got != 0 {
+		t.Errorf("expected link deleted with the device, got %d", got)
+	}
+}
+
+func TestDB_Trends(t *testing.T) {
+	conn, err := connect()
+	if err != nil {
+		t.Fatalf("unable to connect to database: %v", err)
+	}
+	t.Cleanup(func() { cleanup("trends", "devices")(t, conn) })
+
+	ctx := context.Background()
+	db := New(conn)
+
+	id, err := db.CreateDevice(ctx, Device{Hostname: "hostname", IPAddress: "10.0.0.1", Port: DefaultPort, Tags: []string{}})
+	if err != nil {
+		t.Fatalf("unable to create device: %v", err)
+	}
+
+	now := time.Now().Truncate(time.Second).UTC()
+	days := 12.5
+	steady := Trend{DeviceID: id, Interface: "eth0", Metric: "tx_pwr", Samples: 3, Mean: -2, Variance: 0.01, Origin: now, Fit: TrendFit{W: 3, T: 1, X: -6, TT: 1, TX: -2}, Updated: now}
+	drifting := Trend{DeviceID: id, Interface: "eth0", Metric: "rx_pwr", Samples: 3, Mean: -10, Origin: now, Slope: -0.2, Drifting: true, ForecastDays: &days, Updated: now}
+	for _, trend := range []Trend{steady, drifting, drifting} {
+		if err := db.UpdateTrend(ctx, trend); err != nil {
+			t.Fatalf("unable to update trend: %v", err)
+		}
+	}
+
+	trends, err := db.DeviceTrends(ctx, id)
+	if err != nil {
+		t.Fatalf("unable to get trends: %v", err)
+	}
+	if len(trends) != 2 {
+		t.Errorf("expected 2 trends, got %+v", trends)
+	}
+
+	flagged, err := db.FlaggedTrends(ctx)
+	if err != nil {
+		t.Fatalf("unable to get flagged trends: %v", err)
+	}
+	if diff := gocmp.Diff(flagged, []Trend{drifting}); diff != "" {
+		t.Errorf("flagged trends mismatch (-got +want):\n%s", diff)
+	}
+
+	if err := db.DeleteDevice(ctx, id); err != nil {
+		t.Fatalf("unable to delete device: %v", err)
+	}
+	if got := count("trends")(t, conn); got != 0 {
+		t.Errorf("expected trends deleted with the device, got %d", got)
+	}
+}
+
+func TestDB_RulesAlerts(t *testing.T) {
+	conn, err := connect()
+	if err != nil {
+		t.Fatalf("unable to connect to database: %v", err)
+	}
+	t.Cleanup(func() { cleanup("alerts", "rules", "devices")(t, conn) })
+
+	ctx := context.Background()
+	db := New(conn)
+
+	deviceID, err := db.CreateDevice(ctx, Device{Hostname: "hostname", IPAddress: "10.0.0.1", Port: DefaultPort, Site: "WAW", Tags: []string{}})
+	if err != nil {
+		t.Fatalf("unable to create device: %v", err)
+	}
+
+	rule := Rule{Name: "low rx", Enabled: true, Metric: "rx_pwr", Comparison: ComparisonBelow, Threshold: -18, Hysteresis: 1, ForPolls: 3, Site: "WAW"}
+	if rule.ID, err = db.CreateRule(ctx, rule); err != nil {
+		t.Fatalf("unable to create rule: %v", err)
+	}
+
+	rule.Comparison, rule.Window, rule.DeviceID, rule.Interface = ComparisonFalls, 10*time.Minute, deviceID, "eth0"
+	if err := db.UpdateRule(ctx, rule); err != nil {
+		t.Fatalf("unable to update rule: %v", err)
+	}
+
+	rule.SilencedUntil = time.Now().Add(time.Hour).Truncate(time.Second).UTC()
+	if err := db.SilenceRule(ctx, rule.ID, rule.SilencedUntil); err != nil {
+		t.Fatalf("unable to silence rule: %v", err)
+	}
+
+	got, err := db.Rule(ctx, rule.ID)
+	if err != nil {
+		t.Fatalf("unable to get rule: %v", err)
+	}
+	if diff := gocmp.Diff(got, rule); diff != "" {
+		t.Errorf("rule mismatch (-got +want):\n%s", diff)
+	}
+
+	now := time.Now().Truncate(time.Second).UTC()
+	alert := Alert{RuleID: rule.ID, DeviceID: deviceID, Interface: "eth0", Breaches: 3, Since: now, Firing: true, Value: -19, Samples: []Sample{{Time: now, Value: -19}}, Updated: now}
+	if err := db.UpdateAlert(ctx, alert); err != nil {
+		t.Fatalf("unable to update alert: %v", err)
+	}
+
+	firing, err := db.FiringAlerts(ctx)
+	if err != nil {
+		t.Fatalf("unable to get firing alerts: %v", err)
+	}
+	if diff := gocmp.Diff(firing, []Alert{alert}); diff != "" {
+		t.Errorf("alerts mismatch (-got +want):\n%s", diff)
+	}
+
+	if err := db.DeleteRule(ctx, rule.ID); err != nil {
+		t.Fatalf("unable to delete rule: %v", err)
+	}
+	if got := count("alerts")(t, conn); got != 0 {
+		t.Errorf("expected alerts deleted with the rule, got %d", got)
+	}
+}
+
+func TestDB_MaintenanceWindows(t *testing.T) {
+	conn, err := connect()
+	if err != nil {
+		t.Fatalf("unable to connect to database: %v", err)
+	}
+	t.Cleanup(func() { cleanup("maintenance_windows", "devices")(t, conn) })
+
+	ctx := context.Background()
+	db := New(conn)
+
+	id, err := db.CreateDevice(ctx, Device{Hostname: "hostname", IPAddress: "10.0.0.1", Port: DefaultPort, Tags: []string{}})
+	if err != nil {
+		t.Fatalf("unable to create device: %v", err)
+	}
+
+	starts := time.Now().Truncate(time.Second).UTC()
+	site := MaintenanceWindow{Name: "waw-nightly", Site: "WAW", Starts: starts, Duration: time.Hour, Schedule: "0 2 * * *"}
+	if site.ID, err = db.CreateMaintenanceWindow(ctx, site); err != nil {
+		t.Fatalf("unable to create maintenance window: %v", err)
+	}
+
+	device := MaintenanceWindow{Name: "fiber repair", DeviceID: id, Starts: starts.Add(time.Hour), Duration: 90 * time.Minute, SkipPolling: true}
+	if device.ID, err = db.CreateMaintenanceWindow(ctx, device); err != nil {
+		t.Fatalf("unable to create maintenance window: %v", err)
+	}
+
+	got, err := db.MaintenanceWindow(ctx, device.ID)
+	if err != nil {
+		t.Fatalf("unable to get maintenance window: %v", err)
+	}
+	if diff := gocmp.Diff(got, device); diff != "" {
+		t.Errorf("maintenance window mismatch (-got +want):\n%s", diff)
+	}
+
+	site.Group, site.Schedule = "core", "0 3 * * 6"
+	if err := db.UpdateMaintenanceWindow(ctx, site); err != nil {
+		t.Fatalf("unable to update maintenance window: %v", err)
+	}
+
+	windows, err := db.MaintenanceWindows(ctx)
+	if err != nil {
+		t.Fatalf("unable to list maintenance windows: %v", err)
+	}
+	if diff := gocmp.Diff(windows, []MaintenanceWindow{site, device}); diff != "" {
+		t.Errorf("maintenance windows mismatch (-got +want):\n%s", diff)
+	}
+
+	if err := db.DeleteMaintenanceWindow(ctx, site.ID); err != nil {
+		t.Fatalf("unable to delete maintenance window: %v", err)
+	}
+	if err := db.DeleteDevice(ctx, id); err != nil {
+		t.Fatalf("unable to delete device: %v", err)
+	}
+	if got := count("maintenance_windows")(t, conn); got != 0 {
+		t.Errorf("expected window deleted with the device, got %d", got)
+	}
+}
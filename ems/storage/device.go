@@ -3,24 +3,53 @@ package storage
 import (
 	"fmt"
 	"net"
+	"slices"
+	"strings"
 	"time"
 )
 
+const DefaultPort uint16 = 22
+
 const (
 	StatusUndefined = iota - 1
 	StatusOK
 	StatusErrorSSH
 	StatusErrorKeyfile
 	StatusWarning
+	// StatusErrorCollector is a failure of a collector other than SSH.
+	StatusErrorCollector
+	// StatusMaintenance is a device in a maintenance window, either not
+	// polled or failing to be.
+	StatusMaintenance
 )
 
 type Device struct {
-	ID         uint
-	Hostname   string
-	IPAddress  string
-	Login      string
-	Password   string
-	Keyfile    []byte
+	ID        uint
+	Hostname  string
+	IPAddress string
+	Port      uint16
+	Login     string
+	Password  string
+	Keyfile   []byte
+	// Passphrase decrypts Keyfile, Certificate is an optional OpenSSH user
+	// certificate of it.
+	Passphrase  string
+	Certificate []byte
+	// CredentialID points to a shared credential profile, 0 means none.
+	CredentialID uint
+	// JumpHostID is the jump host the device is reached through, 0 means
+	// the device is dialed directly.
+	JumpHostID uint
+	// Collector is the method of reading transceiver data, SNMP, GNMI,
+	// NETCONF and Local settings are only used by their collectors.
+	Collector  string
+	SNMP       SNMP
+	GNMI       GNMI
+	NETCONF    NETCONF
+	Local      Local
+	Site       string
+	Group      string
+	Tags       []string
 	Connected  time.Time
 	LastStatus int8
 }
@@ -34,6 +63,21 @@ func (d *Device) IPVersion() int {
 	return 6
 }
 
+// StatusName is a stable key of LastStatus, used in filters.
+func (d *Device) StatusName() string {
+	return StatusNames[d.LastStatus]
+}
+
+var StatusNames = map[int8]string{
+	StatusUndefined:      "undefined",
+	StatusOK:             "ok",
+	StatusErrorSSH:       "ssh-error",
+	StatusErrorKeyfile:   "keyfile-error",
+	StatusWarning:        "warning",
+	StatusErrorCollector: "collector-error",
+	StatusMaintenance:    "maintenance",
+}
+
 func (d *Device) StatusConnected() string {
 	connected := d.Connected.Format(time.RFC3339)
 
@@ -48,7 +92,24 @@ func (d *Device) StatusConnected() string {
 		return fmt.Sprintf("KEYFILE ERROR (last connection: %s)", connected)
 	case StatusWarning:
 		return fmt.Sprintf("SOME ERRORS OCCURRED (last connection: %s)", connected)
+	case StatusErrorCollector:
+		return fmt.Sprintf("%s COLLECTOR ERROR (last connection: %s)", strings.ToUpper(d.Collector), connected)
+	case StatusMaintenance:
+		return fmt.Sprintf("IN MAINTENANCE (last connection: %s)", connected)
 	default:
 		return "STATUS UNKNOWN"
 	}
 }
+
+// ParseTags splits comma-separated tags, dropping blanks and duplicates.
+func ParseTags(s string) []string {
+	tags := []string{}
+	for _, t := range strings.Split(s, ",") {
+		t = strings.TrimSpace(t)
+		if t != "" && !slices.Contains(tags, t) {
+			tags = append(tags, t)
+		}
+	}
+
+	return tags
+}
@@ -0,0 +1,60 @@
+package storage
+
+import "time"
+
+const (
+	EventLaserFrequency      = "laser-frequency"
+	EventModuleFault         = "module-fault"
+	EventDataPathDeactivated = "datapath-deactivated"
+	EventSpanDegraded        = "span-degraded"
+	EventSpanRecovered       = "span-recovered"
+)
+
+// Interface is the last decoded state of a device interface, kept to spot
+// changes between polls.
+type Interface struct {
+	DeviceID uint
+	Name     string
+	// Frequency is the current laser frequency in THz, 0 when the module
+	// is not tunable.
+	Frequency   float64
+	Channel     int
+	GridSpacing float64
+	TargetPower float64
+	// ModuleState and DataPathStates of host lanes are CMIS state codes,
+	// 0 when not known.
+	ModuleState    uint8
+	DataPathStates []uint8
+	// TxPower and RxPower are the last powers in dBm, RxLowAlarm is the Rx
+	// power low alarm threshold, 0 when not known.
+	TxPower    float64
+	RxPower    float64
+	RxLowAlarm float64
+	Updated    time.Time
+}
+
+// Wavelength returns the laser wavelength in nm.
+func (i Interface) Wavelength() float64 {
+	if i.Frequency == 0 {
+		return 0
+	}
+
+	return 299792.458 / i.Frequency
+}
+
+// Event is a state change of an interface seen by the monitor.
+type Event struct {
+	ID        uint
+	DeviceID  uint
+	Hostname  string
+	Interface string
+	Kind      string
+	Message   string
+	Created   time.Time
+}
+
+type EventFilter struct {
+	DeviceID uint
+	Kind     string
+	Limit    int
+}
@@ -0,0 +1,60 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+const AuditTargetJumpHost = "jump-host"
+
+var ErrJumpLoop = errors.New("jump hosts form a loop")
+
+// JumpHost is a bastion devices are reached through. It logs in with its own
+// credential profile and may itself be reached through another jump host,
+// like ProxyJump in OpenSSH.
+type JumpHost struct {
+	ID           uint
+	Name         string
+	Address      string
+	Port         uint16
+	CredentialID uint
+	// ViaID is the jump host this one is reached through, 0 means direct.
+	ViaID uint
+}
+
+func (j JumpHost) AuditFields() map[string]string {
+	return map[string]string{
+		"name":    j.Name,
+		"address": j.Address,
+		"port":    strconv.Itoa(int(j.Port)),
+		"profile": idRef(j.CredentialID),
+		"via":     idRef(j.ViaID),
+	}
+}
+
+// JumpChain returns jump hosts to pass through, outermost first, to reach a
+// host behind jump host id. Zero id means a direct connection.
+func JumpChain(hosts []JumpHost, id uint) ([]JumpHost, error) {
+	byID := make(map[uint]JumpHost, len(hosts))
+	for _, h := range hosts {
+		byID[h.ID] = h
+	}
+
+	var chain []JumpHost
+	for id != 0 {
+		host, ok := byID[id]
+		if !ok {
+			return nil, fmt.Errorf("unknown jump host #%d", id)
+		}
+
+		if len(chain) == len(hosts) {
+			return nil, ErrJumpLoop
+		}
+
+		chain = append([]JumpHost{host}, chain...)
+		id = host.ViaID
+	}
+
+	return chain, nil
+}
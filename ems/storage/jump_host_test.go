@@ -0,0 +1,71 @@
+package storage
+
+import (
+	"errors"
+	"testing"
+
+	gocmp "github.com/google/go-cmp/cmp"
+)
+
+func TestJumpChain(t *testing.T) {
+	hosts := []JumpHost{
+		{ID: 1, Name: "edge"},
+		{ID: 2, Name: "mgmt", ViaID: 1},
+		{ID: 3, Name: "lab", ViaID: 2},
+		{ID: 4, Name: "loop-a", ViaID: 5},
+		{ID: 5, Name: "loop-b", ViaID: 4},
+		{ID: 6, Name: "orphan", ViaID: 9},
+	}
+
+	tests := []struct {
+		name    string
+		id      uint
+		want    []JumpHost
+		wantErr error
+	}{
+		{
+			name: "direct connection",
+			id:   0,
+		},
+		{
+			name: "single jump host",
+			id:   1,
+			want: []JumpHost{hosts[0]},
+		},
+		{
+			name: "chain starts with outermost host",
+			id:   3,
+			want: []JumpHost{hosts[0], hosts[1], hosts[2]},
+		},
+		{
+			name:    "loop",
+			id:      4,
+			wantErr: ErrJumpLoop,
+		},
+		{
+			name:    "unknown jump host",
+			id:      6,
+			wantErr: errors.New("unknown jump host #9"),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := JumpChain(hosts, tc.id)
+
+			if tc.wantErr != nil {
+				if err == nil || err.Error() != tc.wantErr.Error() {
+					t.Fatalf("expected error %v, got %v", tc.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if diff := gocmp.Diff(got, tc.want); diff != "" {
+				t.Errorf("chain mismatch (-got +want):\n%s", diff)
+			}
+		})
+	}
+}
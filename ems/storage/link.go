@@ -0,0 +1,47 @@
+package storage
+
+import (
+	"strconv"
+	"time"
+)
+
+const (
+	AuditTargetLink = "link"
+
+	// DefaultLinkAlarmDelta is the span loss increase in dB raising an
+	// alarm when a link does not set its own.
+	DefaultLinkAlarmDelta = 3.0
+)
+
+// Link is a fiber span from the Tx of interface A to the Rx of interface B.
+// Its span loss is the power launched by A less the power received by B.
+type Link struct {
+	ID         uint
+	Name       string
+	ADeviceID  uint
+	AInterface string
+	BDeviceID  uint
+	BInterface string
+	// AlarmDelta is the span loss increase over Baseline in dB marking the
+	// link degraded.
+	AlarmDelta float64
+
+	// Baseline is the span loss of the healthy link in dB, nil until the
+	// first reading or after a reset.
+	Baseline *float64
+	// Loss and Margin over the Rx low alarm threshold of B are the last
+	// readings in dB, nil when not known.
+	Loss     *float64
+	Margin   *float64
+	Degraded bool
+	Updated  time.Time
+}
+
+func (l Link) AuditFields() map[string]string {
+	return map[string]string{
+		"name":        l.Name,
+		"a":           idRef(l.ADeviceID) + " " + l.AInterface,
+		"b":           idRef(l.BDeviceID) + " " + l.BInterface,
+		"alarm-delta": strconv.FormatFloat(l.AlarmDelta, 'f', -1, 64),
+	}
+}
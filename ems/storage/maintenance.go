@@ -0,0 +1,239 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const AuditTargetMaintenance = "maintenance"
+
+// MaintenanceWindow is planned work on devices. Devices within an active
+// window raise no alerts and count to no availability, the window may also
+// keep them from being polled at all.
+type MaintenanceWindow struct {
+	ID   uint
+	Name string
+	// Site, Group and DeviceID scope the window, at least one is set and all
+	// set ones have to match.
+	Site     string
+	Group    string
+	DeviceID uint
+	// Starts is the start of a one-off window, or the time a recurring one
+	// takes effect.
+	Starts   time.Time
+	Duration time.Duration
+	// Schedule is a cron expression of starts of a recurring window, in
+	// local time of the server. Empty for a one-off window.
+	Schedule    string
+	SkipPolling bool
+}
+
+// Matches tells whether the window applies to the device.
+func (w MaintenanceWindow) Matches(device Device) bool {
+	if w.Site == "" && w.Group == "" && w.DeviceID == 0 {
+		return false
+	}
+
+	return (w.Site == "" || w.Site == device.Site) &&
+		(w.Group == "" || w.Group == device.Group) &&
+		(w.DeviceID == 0 || w.DeviceID == device.ID)
+}
+
+// Active tells whether the window is open at now. Windows with a wrong
+// schedule are never open.
+func (w MaintenanceWindow) Active(now time.Time) bool {
+	start, ok := w.NextStart(now.Add(-w.Duration))
+
+	return ok && !start.After(now)
+}
+
+// NextStart returns the first start of the window after the given time, false
+// when there is none.
+func (w MaintenanceWindow) NextStart(after time.Time) (time.Time, bool) {
+	if w.Schedule == "" {
+		return w.Starts, w.Starts.After(after)
+	}
+
+	schedule, err := ParseSchedule(w.Schedule)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	// A recurring window takes effect at Starts, not within it.
+	if after.Before(w.Starts) {
+		after = w.Starts.Add(-time.Minute)
+	}
+
+	return schedule.Next(after.Local())
+}
+
+func (w MaintenanceWindow) AuditFields() map[string]string {
+	return map[string]string{
+		"name":         w.Name,
+		"site":         w.Site,
+		"group":        w.Group,
+		"device":       idRef(w.DeviceID),
+		"starts":       w.Starts.Local().Format(time.DateTime),
+		"duration":     w.Duration.String(),
+		"schedule":     w.Schedule,
+		"skip-polling": strconv.FormatBool(w.SkipPolling),
+	}
+}
+
+// Maintenance returns the active window of the device, preferring the ones
+// skipping polling, false when the device is not under maintenance.
+func Maintenance(windows []MaintenanceWindow, device Device, now time.Time) (MaintenanceWindow, bool) {
+	var found MaintenanceWindow
+	var ok bool
+	for _, w := range windows {
+		if !w.Matches(device) || !w.Active(now) {
+			continue
+		}
+		if !ok || w.SkipPolling && !found.SkipPolling {
+			found, ok = w, true
+		}
+	}
+
+	return found, ok
+}
+
+// Schedule is a parsed cron expression of five fields: minute, hour, day of
+// month, month and day of week. Fields are *, numbers, ranges and lists of
+// them, each optionally with a /step. Sunday is 0 or 7. As in cron, a day
+// matches either of both day fields when both are restricted.
+type Schedule struct {
+	minute, hour, dom, month, dow uint64
+	domAny, dowAny                bool
+}
+
+var scheduleFields = []struct {
+	name     string
+	min, max int
+}{
+	{"minute", 0, 59},
+	{"hour", 0, 23},
+	{"day of month", 1, 31},
+	{"month", 1, 12},
+	{"day of week", 0, 7},
+}
+
+func ParseSchedule(expr string) (Schedule, error) {
+	parts := strings.Fields(expr)
+	if len(parts) != len(scheduleFields) {
+		return Schedule{}, fmt.Errorf("expected %d fields, got %d", len(scheduleFields), len(parts))
+	}
+
+	var bits [5]uint64
+	for i, part := range parts {
+		f := scheduleFields[i]
+		for _, item := range strings.Split(part, ",") {
+			b, err := scheduleBits(item, f.min, f.max)
+			if err != nil {
+				return Schedule{}, fmt.Errorf("wrong %s %q: %w", f.name, item, err)
+			}
+			bits[i] |= b
+		}
+	}
+
+	// Sunday is both 0 and 7.
+	if bits[4]&(1<<7) != 0 {
+		bits[4] |= 1
+	}
+
+	return Schedule{
+		minute: bits[0],
+		hour:   bits[1],
+		dom:    bits[2],
+		month:  bits[3],
+		dow:    bits[4],
+		domAny: parts[2] == "*",
+		dowAny: parts[4] == "*",
+	}, nil
+}
+
+func scheduleBits(item string, min, max int) (uint64, error) {
+	rng, stepText, stepped := strings.Cut(item, "/")
+	step := 1
+	if stepped {
+		var err error
+		if step, err = strconv.Atoi(stepText); err != nil || step < 1 {
+			return 0, errors.New("wrong step")
+		}
+	}
+
+	first, last := min, max
+	if rng != "*" {
+		firstText, lastText, ranged := strings.Cut(rng, "-")
+		var err error
+		if first, err = strconv.Atoi(firstText); err != nil {
+			return 0, errors.New("not a number")
+		}
+		last = first
+		if ranged {
+			if last, err = strconv.Atoi(lastText); err != nil {
+				return 0, errors.New("not a number")
+			}
+		} else if stepped {
+			last = max
+		}
+	}
+
+	if first < min || last > max || first > last {
+		return 0, fmt.Errorf("out of range %d-%d", min, max)
+	}
+
+	var bits uint64
+	for v := first; v <= last; v += step {
+		bits |= 1 << v
+	}
+
+	return bits, nil
+}
+
+// Matches tells whether t falls on a minute of the schedule.
+func (s Schedule) Matches(t time.Time) bool {
+	return s.minute&(1<<t.Minute()) != 0 && s.hour&(1<<t.Hour()) != 0 && s.matchesDay(t)
+}
+
+func (s Schedule) matchesDay(t time.Time) bool {
+	if s.month&(1<<int(t.Month())) == 0 {
+		return false
+	}
+
+	dom := s.dom&(1<<t.Day()) != 0
+	dow := s.dow&(1<<int(t.Weekday())) != 0
+	switch {
+	case s.domAny:
+		return dow
+	case s.dowAny:
+		return dom
+	default:
+		return dom || dow
+	}
+}
+
+// Next returns the first minute of the schedule after the given time, in its
+// location, false when there is none within five years, e.g. of February
+// 30th.
+func (s Schedule) Next(after time.Time) (time.Time, bool) {
+	loc := after.Location()
+	t := time.Date(after.Year(), after.Month(), after.Day(), after.Hour(), after.Minute(), 0, 0, loc).Add(time.Minute)
+
+	for limit := t.AddDate(5, 0, 0); t.Before(limit); {
+		switch {
+		case !s.matchesDay(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+		case s.hour&(1<<t.Hour()) == 0:
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+		case s.minute&(1<<t.Minute()) == 0:
+			t = t.Add(time.Minute)
+		default:
+			return t, true
+		}
+	}
+
+	return time.Time{}, false
+}
@@ -0,0 +1,134 @@
+package storage
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseSchedule(t *testing.T) {
+	for _, expr := range []string{"* * * * *", "0 2 * * 0", "*/15 1-5 1,15 * 1-5", "30 22 * 1-12/3 7"} {
+		if _, err := ParseSchedule(expr); err != nil {
+			t.Errorf("ParseSchedule(%q): unexpected error %v", expr, err)
+		}
+	}
+
+	for _, expr := range []string{"", "* * * *", "60 * * * *", "* 24 * * *", "* * 0 * *", "*/0 * * * *", "5-1 * * * *", "a * * * *"} {
+		if _, err := ParseSchedule(expr); err == nil {
+			t.Errorf("ParseSchedule(%q): expected error", expr)
+		}
+	}
+}
+
+func TestSchedule_Next(t *testing.T) {
+	// Monday.
+	after := time.Date(2026, 10, 19, 12, 7, 30, 0, time.UTC)
+
+	tests := []struct {
+		expr string
+		want time.Time
+	}{
+		{expr: "* * * * *", want: time.Date(2026, 10, 19, 12, 8, 0, 0, time.UTC)},
+		{expr: "*/15 * * * *", want: time.Date(2026, 10, 19, 12, 15, 0, 0, time.UTC)},
+		{expr: "0 2 * * *", want: time.Date(2026, 10, 20, 2, 0, 0, 0, time.UTC)},
+		{expr: "0 2 * * 7", want: time.Date(2026, 10, 25, 2, 0, 0, 0, time.UTC)},
+		{expr: "30 1 1 * *", want: time.Date(2026, 11, 1, 1, 30, 0, 0, time.UTC)},
+		// Either day field matches when both are restricted.
+		{expr: "0 0 1 * 3", want: time.Date(2026, 10, 21, 0, 0, 0, 0, time.UTC)},
+		{expr: "0 0 29 2 *", want: time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			schedule, err := ParseSchedule(tt.expr)
+			if err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+
+			got, ok := schedule.Next(after)
+			if !ok || !got.Equal(tt.want) {
+				t.Errorf("expected %v, got %v (%v)", tt.want, got, ok)
+			}
+		})
+	}
+
+	schedule, _ := ParseSchedule("0 0 30 2 *")
+	if got, ok := schedule.Next(after); ok {
+		t.Errorf("expected no start of February 30th, got %v", got)
+	}
+}
+
+func TestMaintenanceWindow_Active(t *testing.T) {
+	now := time.Date(2026, 10, 19, 2, 30, 0, 0, time.Local)
+
+	tests := []struct {
+		name   string
+		window MaintenanceWindow
+		want   bool
+	}{
+		{
+			name:   "one-off open",
+			window: MaintenanceWindow{Starts: now.Add(-time.Hour), Duration: 2 * time.Hour},
+			want:   true,
+		},
+		{
+			name:   "one-off over",
+			window: MaintenanceWindow{Starts: now.Add(-time.Hour), Duration: time.Hour},
+		},
+		{
+			name:   "one-off ahead",
+			window: MaintenanceWindow{Starts: now.Add(time.Minute), Duration: time.Hour},
+		},
+		{
+			name:   "recurring open",
+			window: MaintenanceWindow{Starts: now.AddDate(0, 0, -7), Duration: time.Hour, Schedule: "0 2 * * *"},
+			want:   true,
+		},
+		{
+			name:   "recurring closed",
+			window: MaintenanceWindow{Starts: now.AddDate(0, 0, -7), Duration: 15 * time.Minute, Schedule: "0 2 * * *"},
+		},
+		{
+			name:   "recurring not in effect yet",
+			window: MaintenanceWindow{Starts: now.Add(-15 * time.Minute), Duration: time.Hour, Schedule: "0 2 * * *"},
+		},
+		{
+			name:   "wrong schedule",
+			window: MaintenanceWindow{Starts: now.AddDate(0, 0, -7), Duration: time.Hour, Schedule: "0 2 * *"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.window.Active(now); got != tt.want {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestMaintenance(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.Local)
+	device := Device{ID: 1, Site: "WAW", Group: "core"}
+	open := func(w MaintenanceWindow) MaintenanceWindow {
+		w.Starts, w.Duration = now.Add(-time.Minute), time.Hour
+		return w
+	}
+
+	windows := []MaintenanceWindow{
+		open(MaintenanceWindow{ID: 1}),
+		open(MaintenanceWindow{ID: 2, Site: "KRK"}),
+		open(MaintenanceWindow{ID: 3, Site: "WAW", Group: "core"}),
+		open(MaintenanceWindow{ID: 4, DeviceID: 1, SkipPolling: true}),
+		{ID: 5, DeviceID: 1, Starts: now.Add(time.Hour), Duration: time.Hour, SkipPolling: true},
+	}
+
+	if got, ok := Maintenance(windows[:3], device, now); !ok || got.ID != 3 {
+		t.Errorf("expected window 3, got %v (%v)", got.ID, ok)
+	}
+	if got, ok := Maintenance(windows, device, now); !ok || got.ID != 4 {
+		t.Errorf("expected window 4 skipping polling, got %v (%v)", got.ID, ok)
+	}
+	if got, ok := Maintenance(windows[:2], device, now); ok {
+		t.Errorf("expected no window, got %v", got.ID)
+	}
+}
@@ -0,0 +1,99 @@
+package storage
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+)
+
+const (
+	SortHostname = "hostname"
+	SortStatus   = "status"
+	SortSite     = "site"
+
+	DefaultPageSize = 50
+)
+
+// DeviceQuery filters, sorts and paginates the device list shown on the
+// dashboard. Empty fields match everything.
+type DeviceQuery struct {
+	Search   string
+	Site     string
+	Group    string
+	Tag      string
+	Status   string
+	Sort     string
+	Desc     bool
+	Page     int
+	PageSize int
+}
+
+// Apply returns the requested page and the number of all matching devices.
+// Page is clamped to the available range.
+func (q *DeviceQuery) Apply(devices []Device) (page []Device, total int) {
+	matching := make([]Device, 0, len(devices))
+	for _, d := range devices {
+		if q.matches(d) {
+			matching = append(matching, d)
+		}
+	}
+
+	slices.SortStableFunc(matching, func(a, b Device) int {
+		var c int
+		switch q.Sort {
+		case SortStatus:
+			c = cmp.Compare(a.LastStatus, b.LastStatus)
+		case SortSite:
+			c = cmp.Compare(strings.ToLower(a.Site), strings.ToLower(b.Site))
+		}
+		if c == 0 {
+			c = cmp.Compare(strings.ToLower(a.Hostname), strings.ToLower(b.Hostname))
+		}
+		if q.Desc {
+			return -c
+		}
+		return c
+	})
+
+	if q.PageSize <= 0 {
+		q.PageSize = DefaultPageSize
+	}
+	q.Page = max(1, min(q.Page, q.Pages(len(matching))))
+
+	start := min((q.Page-1)*q.PageSize, len(matching))
+	end := min(start+q.PageSize, len(matching))
+
+	return matching[start:end], len(matching)
+}
+
+func (q *DeviceQuery) Pages(total int) int {
+	return max(1, (total+q.PageSize-1)/q.PageSize)
+}
+
+func (q *DeviceQuery) matches(d Device) bool {
+	if q.Site != "" && d.Site != q.Site {
+		return false
+	}
+	if q.Group != "" && d.Group != q.Group {
+		return false
+	}
+	if q.Tag != "" && !slices.Contains(d.Tags, q.Tag) {
+		return false
+	}
+	if q.Status != "" && d.StatusName() != q.Status {
+		return false
+	}
+
+	if q.Search == "" {
+		return true
+	}
+
+	search := strings.ToLower(q.Search)
+	for _, field := range append([]string{d.Hostname, d.IPAddress, d.Login, d.Site, d.Group}, d.Tags...) {
+		if strings.Contains(strings.ToLower(field), search) {
+			return true
+		}
+	}
+
+	return false
+}
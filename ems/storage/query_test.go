@@ -0,0 +1,109 @@
+package storage
+
+import (
+	"testing"
+
+	gocmp "github.com/google/go-cmp/cmp"
+)
+
+func TestDeviceQuery_Apply(t *testing.T) {
+	devices := []Device{
+		{ID: 1, Hostname: "waw-core-1", IPAddress: "10.0.0.1", Site: "WAW", Group: "core", Tags: []string{"juniper"}, LastStatus: StatusOK},
+		{ID: 2, Hostname: "krk-edge-1", IPAddress: "10.0.1.1", Site: "KRK", Group: "edge", Tags: []string{"cisco"}, LastStatus: StatusErrorSSH},
+		{ID: 3, Hostname: "WAW-edge-2", IPAddress: "10.0.0.2", Site: "WAW", Group: "edge", Tags: []string{"cisco", "lab"}, LastStatus: StatusUndefined},
+		{ID: 4, Hostname: "gdn-core-1", IPAddress: "10.0.2.1", Site: "GDN", Group: "core", LastStatus: StatusOK},
+	}
+
+	tests := []struct {
+		name      string
+		query     DeviceQuery
+		wantIDs   []uint
+		wantTotal int
+		wantPage  int
+	}{
+		{
+			name:      "sorts by hostname by default",
+			query:     DeviceQuery{},
+			wantIDs:   []uint{4, 2, 1, 3},
+			wantTotal: 4,
+			wantPage:  1,
+		},
+		{
+			name:      "filters by site and sorts descending",
+			query:     DeviceQuery{Site: "WAW", Desc: true},
+			wantIDs:   []uint{3, 1},
+			wantTotal: 2,
+			wantPage:  1,
+		},
+		{
+			name:      "filters by group, tag and status",
+			query:     DeviceQuery{Group: "edge", Tag: "cisco", Status: "ssh-error"},
+			wantIDs:   []uint{2},
+			wantTotal: 1,
+			wantPage:  1,
+		},
+		{
+			name:      "searches case insensitively in tags and addresses",
+			query:     DeviceQuery{Search: "LAB"},
+			wantIDs:   []uint{3},
+			wantTotal: 1,
+			wantPage:  1,
+		},
+		{
+			name:      "sorts by status then hostname",
+			query:     DeviceQuery{Sort: SortStatus},
+			wantIDs:   []uint{3, 4, 1, 2},
+			wantTotal: 4,
+			wantPage:  1,
+		},
+		{
+			name:      "sorts by site",
+			query:     DeviceQuery{Sort: SortSite},
+			wantIDs:   []uint{4, 2, 1, 3},
+			wantTotal: 4,
+			wantPage:  1,
+		},
+		{
+			name:      "paginates",
+			query:     DeviceQuery{PageSize: 3, Page: 2},
+			wantIDs:   []uint{3},
+			wantTotal: 4,
+			wantPage:  2,
+		},
+		{
+			name:      "clamps page out of range",
+			query:     DeviceQuery{PageSize: 3, Page: 7},
+			wantIDs:   []uint{3},
+			wantTotal: 4,
+			wantPage:  2,
+		},
+		{
+			name:      "no matches",
+			query:     DeviceQuery{Site: "POZ", Page: 3},
+			wantIDs:   []uint{},
+			wantTotal: 0,
+			wantPage:  1,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			page, total := tc.query.Apply(devices)
+
+			ids := []uint{}
+			for _, d := range page {
+				ids = append(ids, d.ID)
+			}
+
+			if diff := gocmp.Diff(ids, tc.wantIDs); diff != "" {
+				t.Errorf("devices mismatch (-got +want):\n%s", diff)
+			}
+			if total != tc.wantTotal {
+				t.Errorf("expected total %d, got %d", tc.wantTotal, total)
+			}
+			if tc.query.Page != tc.wantPage {
+				t.Errorf("expected page %d, got %d", tc.wantPage, tc.query.Page)
+			}
+		})
+	}
+}
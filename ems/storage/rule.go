@@ -0,0 +1,111 @@
+package storage
+
+import (
+	"slices"
+	"strconv"
+	"time"
+)
+
+const (
+	AuditTargetRule = "rule"
+
+	EventAlert        = "alert"
+	EventAlertCleared = "alert-cleared"
+)
+
+// Comparisons of rules. Below and above compare readings with the
+// threshold, rises and falls compare the change of a reading within the
+// window.
+const (
+	ComparisonBelow = "below"
+	ComparisonAbove = "above"
+	ComparisonRises = "rises"
+	ComparisonFalls = "falls"
+)
+
+var Comparisons = []string{ComparisonBelow, ComparisonAbove, ComparisonRises, ComparisonFalls}
+
+func ValidComparison(comparison string) bool {
+	return slices.Contains(Comparisons, comparison)
+}
+
+// Rule raises an alert when a reading of matching interfaces breaks the
+// threshold for ForPolls consecutive polls and at least ForSeconds. The
+// alert clears once the reading is Hysteresis back past the threshold.
+type Rule struct {
+	ID      uint
+	Name    string
+	Enabled bool
+	// Metric is an Influx field of interface measurements, e.g. rx_pwr.
+	Metric     string
+	Comparison string
+	Threshold  float64
+	Hysteresis float64
+	// Window is the time rises and falls are measured over.
+	Window     time.Duration
+	ForPolls   int
+	ForSeconds int
+	// Site, Group, Tag, DeviceID and Interface narrow the rule down, empty
+	// ones match all.
+	Site      string
+	Group     string
+	Tag       string
+	DeviceID  uint
+	Interface string
+	// SilencedUntil keeps alerts of the rule quiet, their state is still
+	// followed.
+	SilencedUntil time.Time
+}
+
+// Matches tells whether the rule applies to the interface of the device.
+func (r Rule) Matches(device Device, iface string) bool {
+	return (r.Site == "" || r.Site == device.Site) &&
+		(r.Group == "" || r.Group == device.Group) &&
+		(r.Tag == "" || slices.Contains(device.Tags, r.Tag)) &&
+		(r.DeviceID == 0 || r.DeviceID == device.ID) &&
+		(r.Interface == "" || r.Interface == iface)
+}
+
+func (r Rule) Silenced(now time.Time) bool {
+	return now.Before(r.SilencedUntil)
+}
+
+func (r Rule) AuditFields() map[string]string {
+	return map[string]string{
+		"name":       r.Name,
+		"enabled":    strconv.FormatBool(r.Enabled),
+		"metric":     r.Metric,
+		"comparison": r.Comparison,
+		"threshold":  strconv.FormatFloat(r.Threshold, 'f', -1, 64),
+		"hysteresis": strconv.FormatFloat(r.Hysteresis, 'f', -1, 64),
+		"window":     r.Window.String(),
+		"for-polls":  strconv.Itoa(r.ForPolls),
+		"for":        (time.Duration(r.ForSeconds) * time.Second).String(),
+		"site":       r.Site,
+		"group":      r.Group,
+		"tag":        r.Tag,
+		"device":     idRef(r.DeviceID),
+		"interface":  r.Interface,
+	}
+}
+
+// Alert is the state of a rule on an interface.
+type Alert struct {
+	RuleID    uint
+	DeviceID  uint
+	Interface string
+	// Breaches counts consecutive readings breaking the threshold since
+	// Since.
+	Breaches int
+	Since    time.Time
+	Firing   bool
+	Value    float64
+	// Samples are readings within the window of rises and falls.
+	Samples []Sample
+	Updated time.Time
+}
+
+type Sample struct {
+	Time  time.Time `json:"t"`
+	Value float64   `json:"v"`
+}
@@ -0,0 +1,47 @@
+package storage
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRule_Matches(t *testing.T) {
+	device := Device{ID: 1, Site: "WAW", Group: "core", Tags: []string{"lab", "dwdm"}}
+
+	tests := []struct {
+		name  string
+		rule  Rule
+		iface string
+		want  bool
+	}{
+		{name: "any", rule: Rule{}, iface: "eth0", want: true},
+		{name: "site", rule: Rule{Site: "WAW"}, iface: "eth0", want: true},
+		{name: "other site", rule: Rule{Site: "KRK"}, iface: "eth0"},
+		{name: "group and tag", rule: Rule{Group: "core", Tag: "dwdm"}, iface: "eth0", want: true},
+		{name: "missing tag", rule: Rule{Tag: "prod"}, iface: "eth0"},
+		{name: "device and interface", rule: Rule{DeviceID: 1, Interface: "eth1"}, iface: "eth1", want: true},
+		{name: "other interface", rule: Rule{DeviceID: 1, Interface: "eth1"}, iface: "eth0"},
+		{name: "other device", rule: Rule{DeviceID: 2}, iface: "eth0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.rule.Matches(device, tt.iface); got != tt.want {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestRule_Silenced(t *testing.T) {
+	now := time.Now()
+	if (Rule{}).Silenced(now) {
+		t.Errorf("expected rule without silence not silenced")
+	}
+	if !(Rule{SilencedUntil: now.Add(time.Hour)}).Silenced(now) {
+		t.Errorf("expected rule silenced for an hour silenced")
+	}
+	if (Rule{SilencedUntil: now.Add(-time.Hour)}).Silenced(now) {
+		t.Errorf("expected expired silence lifted")
+	}
+}
@@ -9,6 +9,48 @@ import (
 	"time"
 )
 
+// State of rules per interface
+type Alert struct {
+	RuleID    uint32
+	DeviceID  uint32
+	Interface string
+	Breaches  uint32
+	// First reading of the current breach
+	Since  sql.NullTime
+	Firing bool
+	// Last value compared with the threshold
+	Value float64
+	// JSON readings within the window of rises and falls
+	Samples string
+	Updated time.Time
+}
+
+// Who changed what in EMS configuration
+type AuditLog struct {
+	ID         uint32
+	Actor      string
+	Action     string
+	TargetType string
+	TargetID   uint32
+	TargetName string
+	Changes    string
+	Created    time.Time
+}
+
+// Credential profiles shared by devices
+type Credential struct {
+	ID   uint32
+	Name string
+	// password, key or agent
+	Kind       string
+	Login      string
+	Passwd     sql.NullString
+	Keyfile    sql.NullString
+	Passphrase sql.NullString
+	// OpenSSH user certificate of keyfile
+	Certificate sql.NullString
+}
+
 // Network devices set up for monitoring
 type Device struct {
 	ID         uint32
@@ -19,4 +61,187 @@ type Device struct {
 	Keyfile    sql.NullString
 	Connected  time.Time
 	LastStatus int32
+	Port       uint16
+	// Comma-separated device tags
+	Tags         string
+	Site         string
+	DeviceGroup  string
+	CredentialID sql.NullInt32
+	// Passphrase of encrypted keyfile
+	Passphrase sql.NullString
+	// OpenSSH user certificate of keyfile
+	Certificate sql.NullString
+	JumpHostID  sql.NullInt32
+	// Method of collecting transceiver data
+	Collector   string
+	SnmpVersion string
+	SnmpPort    uint16
+	// SNMPv2c community
+	SnmpCommunity sql.NullString
+	// SNMPv3 user name
+	SnmpUser         string
+	SnmpAuthProtocol string
+	SnmpAuthPassword sql.NullString
+	SnmpPrivProtocol string
+	SnmpPrivPassword sql.NullString
+	GnmiPort         uint16
+	// gNMI subscription mode, sample or on-change
+	GnmiMode string
+	// gNMI sample interval in seconds
+	GnmiSampleInterval uint32
+	GnmiTls            bool
+	// Accept any gNMI target certificate
+	GnmiSkipVerify bool
+	NetconfPort    uint16
+	// Mapping of NETCONF replies to measurements
+	NetconfPlatform string
+	// Subtree filter of <get>, empty uses the platform one
+	NetconfFilter string
+	// Comma-separated host interfaces, empty reads all of them
+	LocalInterfaces string
+	// Directory of EEPROM dumps read instead of the modules
+	LocalPath string
+}
+
+// Last raw EEPROM dumps of device interfaces
+type EepromCapture struct {
+	ID        uint32
+	DeviceID  uint32
+	Interface string
+	// Gzip-compressed decoder input
+	Data    []byte
+	Created time.Time
+}
+
+// State changes of device interfaces seen by the monitor
+type Event struct {
+	ID        uint32
+	DeviceID  uint32
+	Hostname  string
+	Interface string
+	Kind      string
+	Message   string
+	Created   time.Time
+}
+
+// Last decoded state of device interfaces
+type Interface struct {
+	DeviceID uint32
+	Name     string
+	// Current laser frequency in THz, 0 when not tunable
+	Frequency float64
+	Channel   int16
+	// GHz
+	GridSpacing float64
+	// dBm
+	TargetPower float64
+	Updated     time.Time
+	// CMIS module state, 0 when not known
+	ModuleState uint8
+	// CMIS data path states of host lanes, a byte each
+	DatapathStates []byte
+	// dBm
+	TxPower float64
+	// dBm
+	RxPower float64
+	// Rx power low alarm threshold of page 02h in dBm, 0 when not known
+	RxLowAlarm float64
+}
+
+// SSH jump hosts (bastions) in front of devices
+type JumpHost struct {
+	ID           uint32
+	Name         string
+	Address      string
+	Port         uint16
+	CredentialID uint32
+	// Jump host this one is reached through
+	ViaID sql.NullInt32
+}
+
+// Fiber spans from the Tx of interface A to the Rx of interface B
+type Link struct {
+	ID         uint32
+	Name       string
+	ADeviceID  uint32
+	AInterface string
+	BDeviceID  uint32
+	BInterface string
+	// Span loss increase over the baseline raising an alarm, in dB
+	AlarmDelta float64
+	// Span loss of the healthy link in dB, set by the first reading
+	Baseline sql.NullFloat64
+	// Last span loss in dB
+	Loss sql.NullFloat64
+	// Last margin over the Rx low alarm threshold of B in dB
+	Margin   sql.NullFloat64
+	Degraded bool
+	Updated  sql.NullTime
+}
+
+// Planned work on devices, groups or sites
+type MaintenanceWindow struct {
+	ID          uint32
+	Name        string
+	Site        string
+	DeviceGroup string
+	DeviceID    sql.NullInt32
+	// Start of a one-off window, or the time a recurring one takes effect
+	Starts          time.Time
+	DurationMinutes uint32
+	// Cron expression of recurring starts, empty for a one-off window
+	Schedule    string
+	SkipPolling bool
+}
+
+// User-defined alert rules on measurements
+type Rule struct {
+	ID      uint32
+	Name    string
+	Enabled bool
+	// Influx field, e.g. rx_pwr or vdm_osnr
+	Metric string
+	// below, above, rises or falls
+	Comparison string
+	Threshold  float64
+	// Margin past the threshold clearing the alert
+	Hysteresis float64
+	// Time a rise or fall is measured over
+	WindowSeconds uint32
+	// Consecutive breaching readings raising the alert
+	ForPolls uint32
+	// Time the breach has to last raising the alert
+	ForSeconds    uint32
+	Site          string
+	DeviceGroup   string
+	Tag           string
+	DeviceID      sql.NullInt32
+	Interface     string
+	SilencedUntil sql.NullTime
+}
+
+// Rolling baselines and drift fits of interface readings
+type Trend struct {
+	DeviceID  uint32
+	Interface string
+	Metric    string
+	Samples   uint32
+	// Rolling baseline
+	Mean     float64
+	Variance float64
+	// Time 0 of the drift fit
+	Origin time.Time
+	// Exponentially weighted sums of the drift fit, time in days since origin
+	FitWeight float64
+	FitT      float64
+	FitX      float64
+	FitTt     float64
+	FitTx     float64
+	// Drift per day, 0 until the fit spans enough time
+	Slope    float64
+	Step     bool
+	Drifting bool
+	// Days until the fit reaches the alarm threshold, set within the horizon only
+	ForecastDays sql.NullFloat64
+	Updated      time.Time
 }
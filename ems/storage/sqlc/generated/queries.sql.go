@@ -11,85 +11,1290 @@ import (
 	"time"
 )
 
-const createDevice = `-- name: CreateDevice :exec
-INSERT INTO devices (hostname, ip, login, passwd, keyfile, connected)
+const auditEntries = `-- name: AuditEntries :many
+SELECT id, actor, action, target_type, target_id, target_name, changes, created FROM audit_log
+WHERE (? = '' OR audit_log.actor = ?)
+  AND (? = '' OR audit_log.action = ?)
+  AND audit_log.target_name LIKE ?
+ORDER BY audit_log.created DESC, audit_log.id DESC
+LIMIT ?
+`
+
+type AuditEntriesParams struct {
+	Actor         string
+	Action        string
+	TargetPattern string
+	Limit         int32
+}
+
+func (q *Queries) AuditEntries(ctx context.Context, arg AuditEntriesParams) ([]AuditLog, error) {
+	rows, err := q.db.QueryContext(ctx, auditEntries,
+		arg.Actor,
+		arg.Actor,
+		arg.Action,
+		arg.Action,
+		arg.TargetPattern,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []AuditLog
+	for rows.Next() {
+		var i AuditLog
+		if err := rows.Scan(
+			&i.ID,
+			&i.Actor,
+			&i.Action,
+			&i.TargetType,
+			&i.TargetID,
+			&i.TargetName,
+			&i.Changes,
+			&i.Created,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const captures = `-- name: Captures :many
+SELECT id, device_id, interface, data, created FROM eeprom_captures
+WHERE eeprom_captures.device_id = ?
+ORDER BY eeprom_captures.interface, eeprom_captures.id DESC
+`
+
+func (q *Queries) Captures(ctx context.Context, deviceID uint32) ([]EepromCapture, error) {
+	rows, err := q.db.QueryContext(ctx, captures, deviceID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []EepromCapture
+	for rows.Next() {
+		var i EepromCapture
+		if err := rows.Scan(
+			&i.ID,
+			&i.DeviceID,
+			&i.Interface,
+			&i.Data,
+			&i.Created,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const createAuditEntry = `-- name: CreateAuditEntry :exec
+INSERT INTO audit_log (actor, action, target_type, target_id, target_name, changes, created)
+VALUES (?, ?, ?, ?, ?, ?, ?)
+`
+
+type CreateAuditEntryParams struct {
+	Actor      string
+	Action     string
+	TargetType string
+	TargetID   uint32
+	TargetName string
+	Changes    string
+	Created    time.Time
+}
+
+func (q *Queries) CreateAuditEntry(ctx context.Context, arg CreateAuditEntryParams) error {
+	_, err := q.db.ExecContext(ctx, createAuditEntry,
+		arg.Actor,
+		arg.Action,
+		arg.TargetType,
+		arg.TargetID,
+		arg.TargetName,
+		arg.Changes,
+		arg.Created,
+	)
+	return err
+}
+
+const createCapture = `-- name: CreateCapture :exec
+INSERT INTO eeprom_captures (device_id, interface, data, created)
+VALUES (?, ?, ?, ?)
+`
+
+type CreateCaptureParams struct {
+	DeviceID  uint32
+	Interface string
+	Data      []byte
+	Created   time.Time
+}
+
+func (q *Queries) CreateCapture(ctx context.Context, arg CreateCaptureParams) error {
+	_, err := q.db.ExecContext(ctx, createCapture,
+		arg.DeviceID,
+		arg.Interface,
+		arg.Data,
+		arg.Created,
+	)
+	return err
+}
+
+const createCredential = `-- name: CreateCredential :execlastid
+INSERT INTO credentials (name, kind, login, passwd, keyfile, passphrase, certificate)
+VALUES (?, ?, ?, ?, ?, ?, ?)
+`
+
+type CreateCredentialParams struct {
+	Name        string
+	Kind        string
+	Login       string
+	Passwd      sql.NullString
+	Keyfile     sql.NullString
+	Passphrase  sql.NullString
+	Certificate sql.NullString
+}
+
+func (q *Queries) CreateCredential(ctx context.Context, arg CreateCredentialParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, createCredential,
+		arg.Name,
+		arg.Kind,
+		arg.Login,
+		arg.Passwd,
+		arg.Keyfile,
+		arg.Passphrase,
+		arg.Certificate,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.LastInsertId()
+}
+
+const createDevice = `-- name: CreateDevice :execlastid
+INSERT INTO devices (hostname, ip, port, login, passwd, keyfile, passphrase, certificate, credential_id, jump_host_id, site, device_group, tags, connected,
+                     collector, snmp_version, snmp_port, snmp_community, snmp_user, snmp_auth_protocol, snmp_auth_password, snmp_priv_protocol, snmp_priv_password,
+                     gnmi_port, gnmi_mode, gnmi_sample_interval, gnmi_tls, gnmi_skip_verify,
+                     netconf_port, netconf_platform, netconf_filter, local_interfaces, local_path)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?,
+        ?, ?, ?, ?, ?, ?, ?, ?, ?,
+        ?, ?, ?, ?, ?,
+        ?, ?, ?, ?, ?)
+`
+
+type CreateDeviceParams struct {
+	Hostname           string
+	Ip                 string
+	Port               uint16
+	Login              string
+	Passwd             sql.NullString
+	Keyfile            sql.NullString
+	Passphrase         sql.NullString
+	Certificate        sql.NullString
+	CredentialID       sql.NullInt32
+	JumpHostID         sql.NullInt32
+	Site               string
+	DeviceGroup        string
+	Tags               string
+	Connected          time.Time
+	Collector          string
+	SnmpVersion        string
+	SnmpPort           uint16
+	SnmpCommunity      sql.NullString
+	SnmpUser           string
+	SnmpAuthProtocol   string
+	SnmpAuthPassword   sql.NullString
+	SnmpPrivProtocol   string
+	SnmpPrivPassword   sql.NullString
+	GnmiPort           uint16
+	GnmiMode           string
+	GnmiSampleInterval uint32
+	GnmiTls            bool
+	GnmiSkipVerify     bool
+	NetconfPort        uint16
+	NetconfPlatform    string
+	NetconfFilter      string
+	LocalInterfaces    string
+	LocalPath          string
+}
+
+func (q *Queries) CreateDevice(ctx context.Context, arg CreateDeviceParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, createDevice,
+		arg.Hostname,
+		arg.Ip,
+		arg.Port,
+		arg.Login,
+		arg.Passwd,
+		arg.Keyfile,
+		arg.Passphrase,
+		arg.Certificate,
+		arg.CredentialID,
+		arg.JumpHostID,
+		arg.Site,
+		arg.DeviceGroup,
+		arg.Tags,
+		arg.Connected,
+		arg.Collector,
+		arg.SnmpVersion,
+		arg.SnmpPort,
+		arg.SnmpCommunity,
+		arg.SnmpUser,
+		arg.SnmpAuthProtocol,
+		arg.SnmpAuthPassword,
+		arg.SnmpPrivProtocol,
+		arg.SnmpPrivPassword,
+		arg.GnmiPort,
+		arg.GnmiMode,
+		arg.GnmiSampleInterval,
+		arg.GnmiTls,
+		arg.GnmiSkipVerify,
+		arg.NetconfPort,
+		arg.NetconfPlatform,
+		arg.NetconfFilter,
+		arg.LocalInterfaces,
+		arg.LocalPath,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.LastInsertId()
+}
+
+const createEvent = `-- name: CreateEvent :exec
+INSERT INTO events (device_id, hostname, interface, kind, message, created)
 VALUES (?, ?, ?, ?, ?, ?)
 `
 
-type CreateDeviceParams struct {
-	Hostname  string
-	Ip        string
-	Login     string
-	Passwd    sql.NullString
-	Keyfile   sql.NullString
-	Connected time.Time
+type CreateEventParams struct {
+	DeviceID  uint32
+	Hostname  string
+	Interface string
+	Kind      string
+	Message   string
+	Created   time.Time
+}
+
+func (q *Queries) CreateEvent(ctx context.Context, arg CreateEventParams) error {
+	_, err := q.db.ExecContext(ctx, createEvent,
+		arg.DeviceID,
+		arg.Hostname,
+		arg.Interface,
+		arg.Kind,
+		arg.Message,
+		arg.Created,
+	)
+	return err
+}
+
+const createJumpHost = `-- name: CreateJumpHost :execlastid
+INSERT INTO jump_hosts (name, address, port, credential_id, via_id)
+VALUES (?, ?, ?, ?, ?)
+`
+
+type CreateJumpHostParams struct {
+	Name         string
+	Address      string
+	Port         uint16
+	CredentialID uint32
+	ViaID        sql.NullInt32
+}
+
+func (q *Queries) CreateJumpHost(ctx context.Context, arg CreateJumpHostParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, createJumpHost,
+		arg.Name,
+		arg.Address,
+		arg.Port,
+		arg.CredentialID,
+		arg.ViaID,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.LastInsertId()
+}
+
+const createLink = `-- name: CreateLink :execlastid
+INSERT INTO links (name, a_device_id, a_interface, b_device_id, b_interface, alarm_delta)
+VALUES (?, ?, ?, ?, ?, ?)
+`
+
+type CreateLinkParams struct {
+	Name       string
+	ADeviceID  uint32
+	AInterface string
+	BDeviceID  uint32
+	BInterface string
+	AlarmDelta float64
+}
+
+func (q *Queries) CreateLink(ctx context.Context, arg CreateLinkParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, createLink,
+		arg.Name,
+		arg.ADeviceID,
+		arg.AInterface,
+		arg.BDeviceID,
+		arg.BInterface,
+		arg.AlarmDelta,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.LastInsertId()
+}
+
+const createMaintenanceWindow = `-- name: CreateMaintenanceWindow :execlastid
+INSERT INTO maintenance_windows (name, site, device_group, device_id, starts, duration_minutes, schedule, skip_polling)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+`
+
+type CreateMaintenanceWindowParams struct {
+	Name            string
+	Site            string
+	DeviceGroup     string
+	DeviceID        sql.NullInt32
+	Starts          time.Time
+	DurationMinutes uint32
+	Schedule        string
+	SkipPolling     bool
+}
+
+func (q *Queries) CreateMaintenanceWindow(ctx context.Context, arg CreateMaintenanceWindowParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, createMaintenanceWindow,
+		arg.Name,
+		arg.Site,
+		arg.DeviceGroup,
+		arg.DeviceID,
+		arg.Starts,
+		arg.DurationMinutes,
+		arg.Schedule,
+		arg.SkipPolling,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.LastInsertId()
+}
+
+const createRule = `-- name: CreateRule :execlastid
+INSERT INTO rules (name, enabled, metric, comparison, threshold, hysteresis, window_seconds, for_polls, for_seconds, site, device_group, tag, device_id, interface)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+`
+
+type CreateRuleParams struct {
+	Name          string
+	Enabled       bool
+	Metric        string
+	Comparison    string
+	Threshold     float64
+	Hysteresis    float64
+	WindowSeconds uint32
+	ForPolls      uint32
+	ForSeconds    uint32
+	Site          string
+	DeviceGroup   string
+	Tag           string
+	DeviceID      sql.NullInt32
+	Interface     string
+}
+
+func (q *Queries) CreateRule(ctx context.Context, arg CreateRuleParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, createRule,
+		arg.Name,
+		arg.Enabled,
+		arg.Metric,
+		arg.Comparison,
+		arg.Threshold,
+		arg.Hysteresis,
+		arg.WindowSeconds,
+		arg.ForPolls,
+		arg.ForSeconds,
+		arg.Site,
+		arg.DeviceGroup,
+		arg.Tag,
+		arg.DeviceID,
+		arg.Interface,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.LastInsertId()
+}
+
+const credential = `-- name: Credential :one
+SELECT id, name, kind, login, passwd, keyfile, passphrase, certificate FROM credentials
+WHERE credentials.id = ?
+`
+
+func (q *Queries) Credential(ctx context.Context, id uint32) (Credential, error) {
+	row := q.db.QueryRowContext(ctx, credential, id)
+	var i Credential
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Kind,
+		&i.Login,
+		&i.Passwd,
+		&i.Keyfile,
+		&i.Passphrase,
+		&i.Certificate,
+	)
+	return i, err
+}
+
+const credentialJumpHostUsage = `-- name: CredentialJumpHostUsage :one
+SELECT COUNT(*) FROM jump_hosts
+WHERE jump_hosts.credential_id = ?
+`
+
+func (q *Queries) CredentialJumpHostUsage(ctx context.Context, credentialID uint32) (int64, error) {
+	row := q.db.QueryRowContext(ctx, credentialJumpHostUsage, credentialID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const credentialUsage = `-- name: CredentialUsage :one
+SELECT COUNT(*) FROM devices
+WHERE devices.credential_id = ?
+`
+
+func (q *Queries) CredentialUsage(ctx context.Context, credentialID sql.NullInt32) (int64, error) {
+	row := q.db.QueryRowContext(ctx, credentialUsage, credentialID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const credentials = `-- name: Credentials :many
+SELECT id, name, kind, login, passwd, keyfile, passphrase, certificate FROM credentials
+ORDER BY credentials.name
+`
+
+func (q *Queries) Credentials(ctx context.Context) ([]Credential, error) {
+	rows, err := q.db.QueryContext(ctx, credentials)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Credential
+	for rows.Next() {
+		var i Credential
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Kind,
+			&i.Login,
+			&i.Passwd,
+			&i.Keyfile,
+			&i.Passphrase,
+			&i.Certificate,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const deleteCredential = `-- name: DeleteCredential :exec
+DELETE FROM credentials
+WHERE credentials.id = ?
+`
+
+func (q *Queries) DeleteCredential(ctx context.Context, id uint32) error {
+	_, err := q.db.ExecContext(ctx, deleteCredential, id)
+	return err
+}
+
+const deleteDevice = `-- name: DeleteDevice :exec
+DELETE FROM devices
+WHERE devices.id = ?
+`
+
+func (q *Queries) DeleteDevice(ctx context.Context, id uint32) error {
+	_, err := q.db.ExecContext(ctx, deleteDevice, id)
+	return err
+}
+
+const deleteJumpHost = `-- name: DeleteJumpHost :exec
+DELETE FROM jump_hosts
+WHERE jump_hosts.id = ?
+`
+
+func (q *Queries) DeleteJumpHost(ctx context.Context, id uint32) error {
+	_, err := q.db.ExecContext(ctx, deleteJumpHost, id)
+	return err
+}
+
+const deleteLink = `-- name: DeleteLink :exec
+DELETE FROM links
+WHERE links.id = ?
+`
+
+func (q *Queries) DeleteLink(ctx context.Context, id uint32) error {
+	_, err := q.db.ExecContext(ctx, deleteLink, id)
+	return err
+}
+
+const deleteMaintenanceWindow = `-- name: DeleteMaintenanceWindow :exec
+DELETE FROM maintenance_windows
+WHERE maintenance_windows.id = ?
+`
+
+func (q *Queries) DeleteMaintenanceWindow(ctx context.Context, id uint32) error {
+	_, err := q.db.ExecContext(ctx, deleteMaintenanceWindow, id)
+	return err
+}
+
+const deleteRule = `-- name: DeleteRule :exec
+DELETE FROM rules
+WHERE rules.id = ?
+`
+
+func (q *Queries) DeleteRule(ctx context.Context, id uint32) error {
+	_, err := q.db.ExecContext(ctx, deleteRule, id)
+	return err
+}
+
+const device = `-- name: Device :one
+SELECT id, hostname, ip, login, passwd, keyfile, connected, last_status, port, tags, site, device_group, credential_id, passphrase, certificate, jump_host_id, collector, snmp_version, snmp_port, snmp_community, snmp_user, snmp_auth_protocol, snmp_auth_password, snmp_priv_protocol, snmp_priv_password, gnmi_port, gnmi_mode, gnmi_sample_interval, gnmi_tls, gnmi_skip_verify, netconf_port, netconf_platform, netconf_filter, local_interfaces, local_path FROM devices
+WHERE devices.id = ?
+`
+
+func (q *Queries) Device(ctx context.Context, id uint32) (Device, error) {
+	row := q.db.QueryRowContext(ctx, device, id)
+	var i Device
+	err := row.Scan(
+		&i.ID,
+		&i.Hostname,
+		&i.Ip,
+		&i.Login,
+		&i.Passwd,
+		&i.Keyfile,
+		&i.Connected,
+		&i.LastStatus,
+		&i.Port,
+		&i.Tags,
+		&i.Site,
+		&i.DeviceGroup,
+		&i.CredentialID,
+		&i.Passphrase,
+		&i.Certificate,
+		&i.JumpHostID,
+		&i.Collector,
+		&i.SnmpVersion,
+		&i.SnmpPort,
+		&i.SnmpCommunity,
+		&i.SnmpUser,
+		&i.SnmpAuthProtocol,
+		&i.SnmpAuthPassword,
+		&i.SnmpPrivProtocol,
+		&i.SnmpPrivPassword,
+		&i.GnmiPort,
+		&i.GnmiMode,
+		&i.GnmiSampleInterval,
+		&i.GnmiTls,
+		&i.GnmiSkipVerify,
+		&i.NetconfPort,
+		&i.NetconfPlatform,
+		&i.NetconfFilter,
+		&i.LocalInterfaces,
+		&i.LocalPath,
+	)
+	return i, err
+}
+
+const deviceAlerts = `-- name: DeviceAlerts :many
+SELECT rule_id, device_id, interface, breaches, since, firing, value, samples, updated FROM alerts
+WHERE alerts.device_id = ?
+`
+
+func (q *Queries) DeviceAlerts(ctx context.Context, deviceID uint32) ([]Alert, error) {
+	rows, err := q.db.QueryContext(ctx, deviceAlerts, deviceID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Alert
+	for rows.Next() {
+		var i Alert
+		if err := rows.Scan(
+			&i.RuleID,
+			&i.DeviceID,
+			&i.Interface,
+			&i.Breaches,
+			&i.Since,
+			&i.Firing,
+			&i.Value,
+			&i.Samples,
+			&i.Updated,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const deviceInterfaces = `-- name: DeviceInterfaces :many
+SELECT device_id, name, frequency, channel, grid_spacing, target_power, updated, module_state, datapath_states, tx_power, rx_power, rx_low_alarm FROM interfaces
+WHERE interfaces.device_id = ?
+ORDER BY interfaces.name
+`
+
+func (q *Queries) DeviceInterfaces(ctx context.Context, deviceID uint32) ([]Interface, error) {
+	rows, err := q.db.QueryContext(ctx, deviceInterfaces, deviceID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Interface
+	for rows.Next() {
+		var i Interface
+		if err := rows.Scan(
+			&i.DeviceID,
+			&i.Name,
+			&i.Frequency,
+			&i.Channel,
+			&i.GridSpacing,
+			&i.TargetPower,
+			&i.Updated,
+			&i.ModuleState,
+			&i.DatapathStates,
+			&i.TxPower,
+			&i.RxPower,
+			&i.RxLowAlarm,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const deviceTrends = `-- name: DeviceTrends :many
+SELECT device_id, interface, metric, samples, mean, variance, origin, fit_weight, fit_t, fit_x, fit_tt, fit_tx, slope, step, drifting, forecast_days, updated FROM trends
+WHERE trends.device_id = ?
+`
+
+func (q *Queries) DeviceTrends(ctx context.Context, deviceID uint32) ([]Trend, error) {
+	rows, err := q.db.QueryContext(ctx, deviceTrends, deviceID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Trend
+	for rows.Next() {
+		var i Trend
+		if err := rows.Scan(
+			&i.DeviceID,
+			&i.Interface,
+			&i.Metric,
+			&i.Samples,
+			&i.Mean,
+			&i.Variance,
+			&i.Origin,
+			&i.FitWeight,
+			&i.FitT,
+			&i.FitX,
+			&i.FitTt,
+			&i.FitTx,
+			&i.Slope,
+			&i.Step,
+			&i.Drifting,
+			&i.ForecastDays,
+			&i.Updated,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const devices = `-- name: Devices :many
+SELECT id, hostname, ip, login, passwd, keyfile, connected, last_status, port, tags, site, device_group, credential_id, passphrase, certificate, jump_host_id, collector, snmp_version, snmp_port, snmp_community, snmp_user, snmp_auth_protocol, snmp_auth_password, snmp_priv_protocol, snmp_priv_password, gnmi_port, gnmi_mode, gnmi_sample_interval, gnmi_tls, gnmi_skip_verify, netconf_port, netconf_platform, netconf_filter, local_interfaces, local_path FROM devices
+`
+
+func (q *Queries) Devices(ctx context.Context) ([]Device, error) {
+	rows, err := q.db.QueryContext(ctx, devices)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Device
+	for rows.Next() {
+		var i Device
+		if err := rows.Scan(
+			&i.ID,
+			&i.Hostname,
+			&i.Ip,
+			&i.Login,
+			&i.Passwd,
+			&i.Keyfile,
+			&i.Connected,
+			&i.LastStatus,
+			&i.Port,
+			&i.Tags,
+			&i.Site,
+			&i.DeviceGroup,
+			&i.CredentialID,
+			&i.Passphrase,
+			&i.Certificate,
+			&i.JumpHostID,
+			&i.Collector,
+			&i.SnmpVersion,
+			&i.SnmpPort,
+			&i.SnmpCommunity,
+			&i.SnmpUser,
+			&i.SnmpAuthProtocol,
+			&i.SnmpAuthPassword,
+			&i.SnmpPrivProtocol,
+			&i.SnmpPrivPassword,
+			&i.GnmiPort,
+			&i.GnmiMode,
+			&i.GnmiSampleInterval,
+			&i.GnmiTls,
+			&i.GnmiSkipVerify,
+			&i.NetconfPort,
+			&i.NetconfPlatform,
+			&i.NetconfFilter,
+			&i.LocalInterfaces,
+			&i.LocalPath,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const events = `-- name: Events :many
+SELECT id, device_id, hostname, interface, kind, message, created FROM events
+WHERE (? = 0 OR events.device_id = ?)
+  AND (? = '' OR events.kind = ?)
+ORDER BY events.created DESC, events.id DESC
+LIMIT ?
+`
+
+type EventsParams struct {
+	DeviceID uint32
+	Kind     string
+	Limit    int32
+}
+
+func (q *Queries) Events(ctx context.Context, arg EventsParams) ([]Event, error) {
+	rows, err := q.db.QueryContext(ctx, events,
+		arg.DeviceID,
+		arg.DeviceID,
+		arg.Kind,
+		arg.Kind,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Event
+	for rows.Next() {
+		var i Event
+		if err := rows.Scan(
+			&i.ID,
+			&i.DeviceID,
+			&i.Hostname,
+			&i.Interface,
+			&i.Kind,
+			&i.Message,
+			&i.Created,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const firingAlerts = `-- name: FiringAlerts :many
+SELECT rule_id, device_id, interface, breaches, since, firing, value, samples, updated FROM alerts
+WHERE alerts.firing
+ORDER BY alerts.rule_id, alerts.device_id, alerts.interface
+`
+
+func (q *Queries) FiringAlerts(ctx context.Context) ([]Alert, error) {
+	rows, err := q.db.QueryContext(ctx, firingAlerts)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Alert
+	for rows.Next() {
+		var i Alert
+		if err := rows.Scan(
+			&i.RuleID,
+			&i.DeviceID,
+			&i.Interface,
+			&i.Breaches,
+			&i.Since,
+			&i.Firing,
+			&i.Value,
+			&i.Samples,
+			&i.Updated,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const flaggedTrends = `-- name: FlaggedTrends :many
+SELECT device_id, interface, metric, samples, mean, variance, origin, fit_weight, fit_t, fit_x, fit_tt, fit_tx, slope, step, drifting, forecast_days, updated FROM trends
+WHERE trends.step OR trends.drifting OR trends.forecast_days IS NOT NULL
+ORDER BY trends.device_id, trends.interface, trends.metric
+`
+
+func (q *Queries) FlaggedTrends(ctx context.Context) ([]Trend, error) {
+	rows, err := q.db.QueryContext(ctx, flaggedTrends)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Trend
+	for rows.Next() {
+		var i Trend
+		if err := rows.Scan(
+			&i.DeviceID,
+			&i.Interface,
+			&i.Metric,
+			&i.Samples,
+			&i.Mean,
+			&i.Variance,
+			&i.Origin,
+			&i.FitWeight,
+			&i.FitT,
+			&i.FitX,
+			&i.FitTt,
+			&i.FitTx,
+			&i.Slope,
+			&i.Step,
+			&i.Drifting,
+			&i.ForecastDays,
+			&i.Updated,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const interfaces = `-- name: Interfaces :many
+SELECT device_id, name, frequency, channel, grid_spacing, target_power, updated, module_state, datapath_states, tx_power, rx_power, rx_low_alarm FROM interfaces
+ORDER BY interfaces.device_id, interfaces.name
+`
+
+func (q *Queries) Interfaces(ctx context.Context) ([]Interface, error) {
+	rows, err := q.db.QueryContext(ctx, interfaces)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Interface
+	for rows.Next() {
+		var i Interface
+		if err := rows.Scan(
+			&i.DeviceID,
+			&i.Name,
+			&i.Frequency,
+			&i.Channel,
+			&i.GridSpacing,
+			&i.TargetPower,
+			&i.Updated,
+			&i.ModuleState,
+			&i.DatapathStates,
+			&i.TxPower,
+			&i.RxPower,
+			&i.RxLowAlarm,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const jumpHost = `-- name: JumpHost :one
+SELECT id, name, address, port, credential_id, via_id FROM jump_hosts
+WHERE jump_hosts.id = ?
+`
+
+func (q *Queries) JumpHost(ctx context.Context, id uint32) (JumpHost, error) {
+	row := q.db.QueryRowContext(ctx, jumpHost, id)
+	var i JumpHost
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Address,
+		&i.Port,
+		&i.CredentialID,
+		&i.ViaID,
+	)
+	return i, err
 }
 
-func (q *Queries) CreateDevice(ctx context.Context, arg CreateDeviceParams) error {
-	_, err := q.db.ExecContext(ctx, createDevice,
-		arg.Hostname,
-		arg.Ip,
-		arg.Login,
-		arg.Passwd,
-		arg.Keyfile,
-		arg.Connected,
+const jumpHostUsage = `-- name: JumpHostUsage :one
+SELECT COUNT(*) FROM devices
+WHERE devices.jump_host_id = ?
+`
+
+func (q *Queries) JumpHostUsage(ctx context.Context, jumpHostID sql.NullInt32) (int64, error) {
+	row := q.db.QueryRowContext(ctx, jumpHostUsage, jumpHostID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const jumpHostViaUsage = `-- name: JumpHostViaUsage :one
+SELECT COUNT(*) FROM jump_hosts
+WHERE jump_hosts.via_id = ?
+`
+
+func (q *Queries) JumpHostViaUsage(ctx context.Context, viaID sql.NullInt32) (int64, error) {
+	row := q.db.QueryRowContext(ctx, jumpHostViaUsage, viaID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const jumpHosts = `-- name: JumpHosts :many
+SELECT id, name, address, port, credential_id, via_id FROM jump_hosts
+ORDER BY jump_hosts.name
+`
+
+func (q *Queries) JumpHosts(ctx context.Context) ([]JumpHost, error) {
+	rows, err := q.db.QueryContext(ctx, jumpHosts)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []JumpHost
+	for rows.Next() {
+		var i JumpHost
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Address,
+			&i.Port,
+			&i.CredentialID,
+			&i.ViaID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const link = `-- name: Link :one
+SELECT id, name, a_device_id, a_interface, b_device_id, b_interface, alarm_delta, baseline, loss, margin, degraded, updated FROM links
+WHERE links.id = ?
+`
+
+func (q *Queries) Link(ctx context.Context, id uint32) (Link, error) {
+	row := q.db.QueryRowContext(ctx, link, id)
+	var i Link
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.ADeviceID,
+		&i.AInterface,
+		&i.BDeviceID,
+		&i.BInterface,
+		&i.AlarmDelta,
+		&i.Baseline,
+		&i.Loss,
+		&i.Margin,
+		&i.Degraded,
+		&i.Updated,
+	)
+	return i, err
+}
+
+const links = `-- name: Links :many
+SELECT id, name, a_device_id, a_interface, b_device_id, b_interface, alarm_delta, baseline, loss, margin, degraded, updated FROM links
+ORDER BY links.name
+`
+
+func (q *Queries) Links(ctx context.Context) ([]Link, error) {
+	rows, err := q.db.QueryContext(ctx, links)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Link
+	for rows.Next() {
+		var i Link
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.ADeviceID,
+			&i.AInterface,
+			&i.BDeviceID,
+			&i.BInterface,
+			&i.AlarmDelta,
+			&i.Baseline,
+			&i.Loss,
+			&i.Margin,
+			&i.Degraded,
+			&i.Updated,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const maintenanceWindow = `-- name: MaintenanceWindow :one
+SELECT id, name, site, device_group, device_id, starts, duration_minutes, schedule, skip_polling FROM maintenance_windows
+WHERE maintenance_windows.id = ?
+`
+
+func (q *Queries) MaintenanceWindow(ctx context.Context, id uint32) (MaintenanceWindow, error) {
+	row := q.db.QueryRowContext(ctx, maintenanceWindow, id)
+	var i MaintenanceWindow
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Site,
+		&i.DeviceGroup,
+		&i.DeviceID,
+		&i.Starts,
+		&i.DurationMinutes,
+		&i.Schedule,
+		&i.SkipPolling,
+	)
+	return i, err
+}
+
+const maintenanceWindows = `-- name: MaintenanceWindows :many
+SELECT id, name, site, device_group, device_id, starts, duration_minutes, schedule, skip_polling FROM maintenance_windows
+ORDER BY maintenance_windows.starts, maintenance_windows.name
+`
+
+func (q *Queries) MaintenanceWindows(ctx context.Context) ([]MaintenanceWindow, error) {
+	rows, err := q.db.QueryContext(ctx, maintenanceWindows)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []MaintenanceWindow
+	for rows.Next() {
+		var i MaintenanceWindow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Site,
+			&i.DeviceGroup,
+			&i.DeviceID,
+			&i.Starts,
+			&i.DurationMinutes,
+			&i.Schedule,
+			&i.SkipPolling,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const pruneCaptures = `-- name: PruneCaptures :exec
+DELETE FROM eeprom_captures
+WHERE eeprom_captures.device_id = ?
+  AND eeprom_captures.interface = ?
+  AND eeprom_captures.id NOT IN (
+    SELECT recent.id FROM (
+      SELECT c.id FROM eeprom_captures AS c
+      WHERE c.device_id = ? AND c.interface = ?
+      ORDER BY c.id DESC
+      LIMIT ?
+    ) AS recent
+  )
+`
+
+type PruneCapturesParams struct {
+	DeviceID  uint32
+	Interface string
+	Limit     int32
+}
+
+func (q *Queries) PruneCaptures(ctx context.Context, arg PruneCapturesParams) error {
+	_, err := q.db.ExecContext(ctx, pruneCaptures,
+		arg.DeviceID,
+		arg.Interface,
+		arg.DeviceID,
+		arg.Interface,
+		arg.Limit,
 	)
 	return err
 }
 
-const deleteDevice = `-- name: DeleteDevice :exec
-DELETE FROM devices
-WHERE devices.id = ?
+const resetLinkBaseline = `-- name: ResetLinkBaseline :exec
+UPDATE links
+SET baseline = NULL,
+    degraded = FALSE
+WHERE links.id = ?
 `
 
-func (q *Queries) DeleteDevice(ctx context.Context, id uint32) error {
-	_, err := q.db.ExecContext(ctx, deleteDevice, id)
+func (q *Queries) ResetLinkBaseline(ctx context.Context, id uint32) error {
+	_, err := q.db.ExecContext(ctx, resetLinkBaseline, id)
 	return err
 }
 
-const device = `-- name: Device :one
-SELECT id, hostname, ip, login, passwd, keyfile, connected, last_status FROM devices
-WHERE devices.id = ?
+const rule = `-- name: Rule :one
+SELECT id, name, enabled, metric, comparison, threshold, hysteresis, window_seconds, for_polls, for_seconds, site, device_group, tag, device_id, interface, silenced_until FROM rules
+WHERE rules.id = ?
 `
 
-func (q *Queries) Device(ctx context.Context, id uint32) (Device, error) {
-	row := q.db.QueryRowContext(ctx, device, id)
-	var i Device
+func (q *Queries) Rule(ctx context.Context, id uint32) (Rule, error) {
+	row := q.db.QueryRowContext(ctx, rule, id)
+	var i Rule
 	err := row.Scan(
 		&i.ID,
-		&i.Hostname,
-		&i.Ip,
-		&i.Login,
-		&i.Passwd,
-		&i.Keyfile,
-		&i.Connected,
-		&i.LastStatus,
+		&i.Name,
+		&i.Enabled,
+		&i.Metric,
+		&i.Comparison,
+		&i.Threshold,
+		&i.Hysteresis,
+		&i.WindowSeconds,
+		&i.ForPolls,
+		&i.ForSeconds,
+		&i.Site,
+		&i.DeviceGroup,
+		&i.Tag,
+		&i.DeviceID,
+		&i.Interface,
+		&i.SilencedUntil,
 	)
 	return i, err
 }
 
-const devices = `-- name: Devices :many
-SELECT id, hostname, ip, login, passwd, keyfile, connected, last_status FROM devices
+const rules = `-- name: Rules :many
+SELECT id, name, enabled, metric, comparison, threshold, hysteresis, window_seconds, for_polls, for_seconds, site, device_group, tag, device_id, interface, silenced_until FROM rules
+ORDER BY rules.name
 `
 
-func (q *Queries) Devices(ctx context.Context) ([]Device, error) {
-	rows, err := q.db.QueryContext(ctx, devices)
+func (q *Queries) Rules(ctx context.Context) ([]Rule, error) {
+	rows, err := q.db.QueryContext(ctx, rules)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []Device
+	var items []Rule
 	for rows.Next() {
-		var i Device
+		var i Rule
 		if err := rows.Scan(
 			&i.ID,
-			&i.Hostname,
-			&i.Ip,
-			&i.Login,
-			&i.Passwd,
-			&i.Keyfile,
-			&i.Connected,
-			&i.LastStatus,
+			&i.Name,
+			&i.Enabled,
+			&i.Metric,
+			&i.Comparison,
+			&i.Threshold,
+			&i.Hysteresis,
+			&i.WindowSeconds,
+			&i.ForPolls,
+			&i.ForSeconds,
+			&i.Site,
+			&i.DeviceGroup,
+			&i.Tag,
+			&i.DeviceID,
+			&i.Interface,
+			&i.SilencedUntil,
 		); err != nil {
 			return nil, err
 		}
@@ -104,38 +1309,172 @@ func (q *Queries) Devices(ctx context.Context) ([]Device, error) {
 	return items, nil
 }
 
-const updateDevice = `-- name: UpdateDevice :exec
-UPDATE devices
-SET hostname    = ?,
-    ip          = ?,
+const silenceRule = `-- name: SilenceRule :exec
+UPDATE rules
+SET silenced_until = ?
+WHERE rules.id = ?
+`
+
+type SilenceRuleParams struct {
+	SilencedUntil sql.NullTime
+	ID            uint32
+}
+
+func (q *Queries) SilenceRule(ctx context.Context, arg SilenceRuleParams) error {
+	_, err := q.db.ExecContext(ctx, silenceRule, arg.SilencedUntil, arg.ID)
+	return err
+}
+
+const updateCredential = `-- name: UpdateCredential :exec
+UPDATE credentials
+SET name        = ?,
+    kind        = ?,
     login       = ?,
     passwd      = ?,
     keyfile     = ?,
-    last_status = ?,
-    connected   = ?
+    passphrase  = ?,
+    certificate = ?
+WHERE credentials.id = ?
+`
+
+type UpdateCredentialParams struct {
+	Name        string
+	Kind        string
+	Login       string
+	Passwd      sql.NullString
+	Keyfile     sql.NullString
+	Passphrase  sql.NullString
+	Certificate sql.NullString
+	ID          uint32
+}
+
+func (q *Queries) UpdateCredential(ctx context.Context, arg UpdateCredentialParams) error {
+	_, err := q.db.ExecContext(ctx, updateCredential,
+		arg.Name,
+		arg.Kind,
+		arg.Login,
+		arg.Passwd,
+		arg.Keyfile,
+		arg.Passphrase,
+		arg.Certificate,
+		arg.ID,
+	)
+	return err
+}
+
+const updateDevice = `-- name: UpdateDevice :exec
+UPDATE devices
+SET hostname      = ?,
+    ip            = ?,
+    port          = ?,
+    login         = ?,
+    passwd        = ?,
+    keyfile       = ?,
+    passphrase    = ?,
+    certificate   = ?,
+    credential_id = ?,
+    jump_host_id  = ?,
+    site          = ?,
+    device_group  = ?,
+    tags          = ?,
+    last_status   = ?,
+    connected     = ?,
+    collector          = ?,
+    snmp_version       = ?,
+    snmp_port          = ?,
+    snmp_community     = ?,
+    snmp_user          = ?,
+    snmp_auth_protocol = ?,
+    snmp_auth_password = ?,
+    snmp_priv_protocol = ?,
+    snmp_priv_password = ?,
+    gnmi_port            = ?,
+    gnmi_mode            = ?,
+    gnmi_sample_interval = ?,
+    gnmi_tls             = ?,
+    gnmi_skip_verify     = ?,
+    netconf_port     = ?,
+    netconf_platform = ?,
+    netconf_filter   = ?,
+    local_interfaces = ?,
+    local_path       = ?
 WHERE devices.id = ?
 `
 
 type UpdateDeviceParams struct {
-	Hostname   string
-	Ip         string
-	Login      string
-	Passwd     sql.NullString
-	Keyfile    sql.NullString
-	LastStatus int32
-	Connected  time.Time
-	ID         uint32
+	Hostname           string
+	Ip                 string
+	Port               uint16
+	Login              string
+	Passwd             sql.NullString
+	Keyfile            sql.NullString
+	Passphrase         sql.NullString
+	Certificate        sql.NullString
+	CredentialID       sql.NullInt32
+	JumpHostID         sql.NullInt32
+	Site               string
+	DeviceGroup        string
+	Tags               string
+	LastStatus         int32
+	Connected          time.Time
+	Collector          string
+	SnmpVersion        string
+	SnmpPort           uint16
+	SnmpCommunity      sql.NullString
+	SnmpUser           string
+	SnmpAuthProtocol   string
+	SnmpAuthPassword   sql.NullString
+	SnmpPrivProtocol   string
+	SnmpPrivPassword   sql.NullString
+	GnmiPort           uint16
+	GnmiMode           string
+	GnmiSampleInterval uint32
+	GnmiTls            bool
+	GnmiSkipVerify     bool
+	NetconfPort        uint16
+	NetconfPlatform    string
+	NetconfFilter      string
+	LocalInterfaces    string
+	LocalPath          string
+	ID                 uint32
 }
 
 func (q *Queries) UpdateDevice(ctx context.Context, arg UpdateDeviceParams) error {
 	_, err := q.db.ExecContext(ctx, updateDevice,
 		arg.Hostname,
 		arg.Ip,
+		arg.Port,
 		arg.Login,
 		arg.Passwd,
 		arg.Keyfile,
+		arg.Passphrase,
+		arg.Certificate,
+		arg.CredentialID,
+		arg.JumpHostID,
+		arg.Site,
+		arg.DeviceGroup,
+		arg.Tags,
 		arg.LastStatus,
 		arg.Connected,
+		arg.Collector,
+		arg.SnmpVersion,
+		arg.SnmpPort,
+		arg.SnmpCommunity,
+		arg.SnmpUser,
+		arg.SnmpAuthProtocol,
+		arg.SnmpAuthPassword,
+		arg.SnmpPrivProtocol,
+		arg.SnmpPrivPassword,
+		arg.GnmiPort,
+		arg.GnmiMode,
+		arg.GnmiSampleInterval,
+		arg.GnmiTls,
+		arg.GnmiSkipVerify,
+		arg.NetconfPort,
+		arg.NetconfPlatform,
+		arg.NetconfFilter,
+		arg.LocalInterfaces,
+		arg.LocalPath,
 		arg.ID,
 	)
 	return err
@@ -158,3 +1497,345 @@ func (q *Queries) UpdateDeviceStatus(ctx context.Context, arg UpdateDeviceStatus
 	_, err := q.db.ExecContext(ctx, updateDeviceStatus, arg.LastStatus, arg.Connected, arg.ID)
 	return err
 }
+
+const updateJumpHost = `-- name: UpdateJumpHost :exec
+UPDATE jump_hosts
+SET name          = ?,
+    address       = ?,
+    port          = ?,
+    credential_id = ?,
+    via_id        = ?
+WHERE jump_hosts.id = ?
+`
+
+type UpdateJumpHostParams struct {
+	Name         string
+	Address      string
+	Port         uint16
+	CredentialID uint32
+	ViaID        sql.NullInt32
+	ID           uint32
+}
+
+func (q *Queries) UpdateJumpHost(ctx context.Context, arg UpdateJumpHostParams) error {
+	_, err := q.db.ExecContext(ctx, updateJumpHost,
+		arg.Name,
+		arg.Address,
+		arg.Port,
+		arg.CredentialID,
+		arg.ViaID,
+		arg.ID,
+	)
+	return err
+}
+
+const updateLink = `-- name: UpdateLink :exec
+UPDATE links
+SET name        = ?,
+    a_device_id = ?,
+    a_interface = ?,
+    b_device_id = ?,
+    b_interface = ?,
+    alarm_delta = ?
+WHERE links.id = ?
+`
+
+type UpdateLinkParams struct {
+	Name       string
+	ADeviceID  uint32
+	AInterface string
+	BDeviceID  uint32
+	BInterface string
+	AlarmDelta float64
+	ID         uint32
+}
+
+func (q *Queries) UpdateLink(ctx context.Context, arg UpdateLinkParams) error {
+	_, err := q.db.ExecContext(ctx, updateLink,
+		arg.Name,
+		arg.ADeviceID,
+		arg.AInterface,
+		arg.BDeviceID,
+		arg.BInterface,
+		arg.AlarmDelta,
+		arg.ID,
+	)
+	return err
+}
+
+const updateLinkSpan = `-- name: UpdateLinkSpan :exec
+UPDATE links
+SET baseline = ?,
+    loss     = ?,
+    margin   = ?,
+    degraded = ?,
+    updated  = ?
+WHERE links.id = ?
+`
+
+type UpdateLinkSpanParams struct {
+	Baseline sql.NullFloat64
+	Loss     sql.NullFloat64
+	Margin   sql.NullFloat64
+	Degraded bool
+	Updated  sql.NullTime
+	ID       uint32
+}
+
+func (q *Queries) UpdateLinkSpan(ctx context.Context, arg UpdateLinkSpanParams) error {
+	_, err := q.db.ExecContext(ctx, updateLinkSpan,
+		arg.Baseline,
+		arg.Loss,
+		arg.Margin,
+		arg.Degraded,
+		arg.Updated,
+		arg.ID,
+	)
+	return err
+}
+
+const updateMaintenanceWindow = `-- name: UpdateMaintenanceWindow :exec
+UPDATE maintenance_windows
+SET name             = ?,
+    site             = ?,
+    device_group     = ?,
+    device_id        = ?,
+    starts           = ?,
+    duration_minutes = ?,
+    schedule         = ?,
+    skip_polling     = ?
+WHERE maintenance_windows.id = ?
+`
+
+type UpdateMaintenanceWindowParams struct {
+	Name            string
+	Site            string
+	DeviceGroup     string
+	DeviceID        sql.NullInt32
+	Starts          time.Time
+	DurationMinutes uint32
+	Schedule        string
+	SkipPolling     bool
+	ID              uint32
+}
+
+func (q *Queries) UpdateMaintenanceWindow(ctx context.Context, arg UpdateMaintenanceWindowParams) error {
+	_, err := q.db.ExecContext(ctx, updateMaintenanceWindow,
+		arg.Name,
+		arg.Site,
+		arg.DeviceGroup,
+		arg.DeviceID,
+		arg.Starts,
+		arg.DurationMinutes,
+		arg.Schedule,
+		arg.SkipPolling,
+		arg.ID,
+	)
+	return err
+}
+
+const updateRule = `-- name: UpdateRule :exec
+UPDATE rules
+SET name           = ?,
+    enabled        = ?,
+    metric         = ?,
+    comparison     = ?,
+    threshold      = ?,
+    hysteresis     = ?,
+    window_seconds = ?,
+    for_polls      = ?,
+    for_seconds    = ?,
+    site           = ?,
+    device_group   = ?,
+    tag            = ?,
+    device_id      = ?,
+    interface      = ?
+WHERE rules.id = ?
+`
+
+type UpdateRuleParams struct {
+	Name          string
+	Enabled       bool
+	Metric        string
+	Comparison    string
+	Threshold     float64
+	Hysteresis    float64
+	WindowSeconds uint32
+	ForPolls      uint32
+	ForSeconds    uint32
+	Site          string
+	DeviceGroup   string
+	Tag           string
+	DeviceID      sql.NullInt32
+	Interface     string
+	ID            uint32
+}
+
+func (q *Queries) UpdateRule(ctx context.Context, arg UpdateRuleParams) error {
+	_, err := q.db.ExecContext(ctx, updateRule,
+		arg.Name,
+		arg.Enabled,
+		arg.Metric,
+		arg.Comparison,
+		arg.Threshold,
+		arg.Hysteresis,
+		arg.WindowSeconds,
+		arg.ForPolls,
+		arg.ForSeconds,
+		arg.Site,
+		arg.DeviceGroup,
+		arg.Tag,
+		arg.DeviceID,
+		arg.Interface,
+		arg.ID,
+	)
+	return err
+}
+
+const upsertAlert = `-- name: UpsertAlert :exec
+INSERT INTO alerts (rule_id, device_id, interface, breaches, since, firing, value, samples, updated)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+ON DUPLICATE KEY UPDATE breaches = VALUES(breaches),
+                        since    = VALUES(since),
+                        firing   = VALUES(firing),
+                        value    = VALUES(value),
+                        samples  = VALUES(samples),
+                        updated  = VALUES(updated)
+`
+
+type UpsertAlertParams struct {
+	RuleID    uint32
+	DeviceID  uint32
+	Interface string
+	Breaches  uint32
+	Since     sql.NullTime
+	Firing    bool
+	Value     float64
+	Samples   string
+	Updated   time.Time
+}
+
+func (q *Queries) UpsertAlert(ctx context.Context, arg UpsertAlertParams) error {
+	_, err := q.db.ExecContext(ctx, upsertAlert,
+		arg.RuleID,
+		arg.DeviceID,
+		arg.Interface,
+		arg.Breaches,
+		arg.Since,
+		arg.Firing,
+		arg.Value,
+		arg.Samples,
+		arg.Updated,
+	)
+	return err
+}
+
+const upsertInterface = `-- name: UpsertInterface :exec
+INSERT INTO interfaces (device_id, name, frequency, channel, grid_spacing, target_power, module_state, datapath_states, tx_power, rx_power, rx_low_alarm, updated)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+ON DUPLICATE KEY UPDATE frequency       = VALUES(frequency),
+                        channel         = VALUES(channel),
+                        grid_spacing    = VALUES(grid_spacing),
+                        target_power    = VALUES(target_power),
+                        module_state    = VALUES(module_state),
+                        datapath_states = VALUES(datapath_states),
+                        tx_power        = VALUES(tx_power),
+                        rx_power        = VALUES(rx_power),
+                        rx_low_alarm    = VALUES(rx_low_alarm),
+                        updated         = VALUES(updated)
+`
+
+type UpsertInterfaceParams struct {
+	DeviceID       uint32
+	Name           string
+	Frequency      float64
+	Channel        int16
+	GridSpacing    float64
+	TargetPower    float64
+	ModuleState    uint8
+	DatapathStates []byte
+	TxPower        float64
+	RxPower        float64
+	RxLowAlarm     float64
+	Updated        time.Time
+}
+
+func (q *Queries) UpsertInterface(ctx context.Context, arg UpsertInterfaceParams) error {
+	_, err := q.db.ExecContext(ctx, upsertInterface,
+		arg.DeviceID,
+		arg.Name,
+		arg.Frequency,
+		arg.Channel,
+		arg.GridSpacing,
+		arg.TargetPower,
+		arg.ModuleState,
+		arg.DatapathStates,
+		arg.TxPower,
+		arg.RxPower,
+		arg.RxLowAlarm,
+		arg.Updated,
+	)
+	return err
+}
+
+const upsertTrend = `-- name: UpsertTrend :exec
+INSERT INTO trends (device_id, interface, metric, samples, mean, variance, origin, fit_weight, fit_t, fit_x, fit_tt, fit_tx, slope, step, drifting, forecast_days, updated)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+ON DUPLICATE KEY UPDATE samples       = VALUES(samples),
+                        mean          = VALUES(mean),
+                        variance      = VALUES(variance),
+                        origin        = VALUES(origin),
+                        fit_weight    = VALUES(fit_weight),
+                        fit_t         = VALUES(fit_t),
+                        fit_x         = VALUES(fit_x),
+                        fit_tt        = VALUES(fit_tt),
+                        fit_tx        = VALUES(fit_tx),
+                        slope         = VALUES(slope),
+                        step          = VALUES(step),
+                        drifting      = VALUES(drifting),
+                        forecast_days = VALUES(forecast_days),
+                        updated       = VALUES(updated)
+`
+
+type UpsertTrendParams struct {
+	DeviceID     uint32
+	Interface    string
+	Metric       string
+	Samples      uint32
+	Mean         float64
+	Variance     float64
+	Origin       time.Time
+	FitWeight    float64
+	FitT         float64
+	FitX         float64
+	FitTt        float64
+	FitTx        float64
+	Slope        float64
+	Step         bool
+	Drifting     bool
+	ForecastDays sql.NullFloat64
+	Updated      time.Time
+}
+
+func (q *Queries) UpsertTrend(ctx context.Context, arg UpsertTrendParams) error {
+	_, err := q.db.ExecContext(ctx, upsertTrend,
+		arg.DeviceID,
+		arg.Interface,
+		arg.Metric,
+		arg.Samples,
+		arg.Mean,
+		arg.Variance,
+		arg.Origin,
+		arg.FitWeight,
+		arg.FitT,
+		arg.FitX,
+		arg.FitTt,
+		arg.FitTx,
+		arg.Slope,
+		arg.Step,
+		arg.Drifting,
+		arg.ForecastDays,
+		arg.Updated,
+	)
+	return err
+}
@@ -0,0 +1,20 @@
+-- +goose UP
+-- +goose StatementBegin
+CREATE TABLE audit_log
+(
+  id          INT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+  actor       VARCHAR(100) NOT NULL,
+  action      VARCHAR(20) NOT NULL,
+  target_type VARCHAR(50) NOT NULL,
+  target_id   INT UNSIGNED NOT NULL,
+  target_name VARCHAR(100) NOT NULL,
+  changes     TEXT NOT NULL,
+  created     DATETIME NOT NULL,
+  INDEX audit_log_created (created)
+) COLLATE = utf8mb4_unicode_ci CHARSET = utf8mb4 COMMENT 'Who changed what in EMS configuration';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE audit_log;
+-- +goose StatementEnd
@@ -0,0 +1,13 @@
+-- +goose UP
+-- +goose StatementBegin
+ALTER TABLE devices
+  ADD COLUMN port SMALLINT UNSIGNED NOT NULL DEFAULT 22,
+  ADD COLUMN tags VARCHAR(255) NOT NULL DEFAULT '' COMMENT 'Comma-separated device tags';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE devices
+  DROP COLUMN tags,
+  DROP COLUMN port;
+-- +goose StatementEnd
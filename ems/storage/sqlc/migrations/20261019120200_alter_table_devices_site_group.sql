@@ -0,0 +1,15 @@
+-- +goose UP
+-- +goose StatementBegin
+ALTER TABLE devices
+  ADD COLUMN site VARCHAR(100) NOT NULL DEFAULT '',
+  ADD COLUMN device_group VARCHAR(100) NOT NULL DEFAULT '',
+  ADD INDEX devices_site (site);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE devices
+  DROP INDEX devices_site,
+  DROP COLUMN device_group,
+  DROP COLUMN site;
+-- +goose StatementEnd
@@ -0,0 +1,31 @@
+-- +goose UP
+-- +goose StatementBegin
+CREATE TABLE credentials
+(
+  id         INT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+  name       VARCHAR(100) NOT NULL,
+  kind       VARCHAR(20) NOT NULL COMMENT 'password, key or agent',
+  login      VARCHAR(100) NOT NULL,
+  passwd     VARCHAR(100) DEFAULT NULL,
+  keyfile    BLOB DEFAULT NULL,
+  passphrase VARCHAR(200) DEFAULT NULL,
+  UNIQUE INDEX credentials_name (name)
+) COLLATE = utf8mb4_unicode_ci CHARSET = utf8mb4 COMMENT 'Credential profiles shared by devices';
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE devices
+  ADD COLUMN credential_id INT UNSIGNED DEFAULT NULL,
+  ADD CONSTRAINT devices_credential FOREIGN KEY (credential_id) REFERENCES credentials (id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE devices
+  DROP FOREIGN KEY devices_credential,
+  DROP COLUMN credential_id;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+DROP TABLE credentials;
+-- +goose StatementEnd
@@ -0,0 +1,23 @@
+-- +goose UP
+-- +goose StatementBegin
+ALTER TABLE devices
+  ADD COLUMN passphrase  VARCHAR(200) DEFAULT NULL COMMENT 'Passphrase of encrypted keyfile',
+  ADD COLUMN certificate BLOB DEFAULT NULL COMMENT 'OpenSSH user certificate of keyfile';
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE credentials
+  ADD COLUMN certificate BLOB DEFAULT NULL COMMENT 'OpenSSH user certificate of keyfile';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE credentials
+  DROP COLUMN certificate;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE devices
+  DROP COLUMN certificate,
+  DROP COLUMN passphrase;
+-- +goose StatementEnd
@@ -0,0 +1,32 @@
+-- +goose UP
+-- +goose StatementBegin
+CREATE TABLE jump_hosts
+(
+  id            INT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+  name          VARCHAR(100) NOT NULL,
+  address       VARCHAR(255) NOT NULL,
+  port          SMALLINT UNSIGNED NOT NULL DEFAULT 22,
+  credential_id INT UNSIGNED NOT NULL,
+  via_id        INT UNSIGNED DEFAULT NULL COMMENT 'Jump host this one is reached through',
+  UNIQUE INDEX jump_hosts_name (name),
+  CONSTRAINT jump_hosts_credential FOREIGN KEY (credential_id) REFERENCES credentials (id),
+  CONSTRAINT jump_hosts_via FOREIGN KEY (via_id) REFERENCES jump_hosts (id)
+) COLLATE = utf8mb4_unicode_ci CHARSET = utf8mb4 COMMENT 'SSH jump hosts (bastions) in front of devices';
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE devices
+  ADD COLUMN jump_host_id INT UNSIGNED DEFAULT NULL,
+  ADD CONSTRAINT devices_jump_host FOREIGN KEY (jump_host_id) REFERENCES jump_hosts (id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE devices
+  DROP FOREIGN KEY devices_jump_host,
+  DROP COLUMN jump_host_id;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+DROP TABLE jump_hosts;
+-- +goose StatementEnd
@@ -0,0 +1,27 @@
+-- +goose UP
+-- +goose StatementBegin
+ALTER TABLE devices
+  ADD COLUMN collector          VARCHAR(16) NOT NULL DEFAULT 'ssh' COMMENT 'Method of collecting transceiver data',
+  ADD COLUMN snmp_version       VARCHAR(2) NOT NULL DEFAULT '2c',
+  ADD COLUMN snmp_port          SMALLINT UNSIGNED NOT NULL DEFAULT 161,
+  ADD COLUMN snmp_community     VARCHAR(200) DEFAULT NULL COMMENT 'SNMPv2c community',
+  ADD COLUMN snmp_user          VARCHAR(64) NOT NULL DEFAULT '' COMMENT 'SNMPv3 user name',
+  ADD COLUMN snmp_auth_protocol VARCHAR(16) NOT NULL DEFAULT '',
+  ADD COLUMN snmp_auth_password VARCHAR(200) DEFAULT NULL,
+  ADD COLUMN snmp_priv_protocol VARCHAR(16) NOT NULL DEFAULT '',
+  ADD COLUMN snmp_priv_password VARCHAR(200) DEFAULT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE devices
+  DROP COLUMN snmp_priv_password,
+  DROP COLUMN snmp_priv_protocol,
+  DROP COLUMN snmp_auth_password,
+  DROP COLUMN snmp_auth_protocol,
+  DROP COLUMN snmp_user,
+  DROP COLUMN snmp_community,
+  DROP COLUMN snmp_port,
+  DROP COLUMN snmp_version,
+  DROP COLUMN collector;
+-- +goose StatementEnd
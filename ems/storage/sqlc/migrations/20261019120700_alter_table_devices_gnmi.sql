@@ -0,0 +1,19 @@
+-- +goose UP
+-- +goose StatementBegin
+ALTER TABLE devices
+  ADD COLUMN gnmi_port            SMALLINT UNSIGNED NOT NULL DEFAULT 9339,
+  ADD COLUMN gnmi_mode            VARCHAR(16) NOT NULL DEFAULT 'sample' COMMENT 'gNMI subscription mode, sample or on-change',
+  ADD COLUMN gnmi_sample_interval INT UNSIGNED NOT NULL DEFAULT 10 COMMENT 'gNMI sample interval in seconds',
+  ADD COLUMN gnmi_tls             BOOLEAN NOT NULL DEFAULT TRUE,
+  ADD COLUMN gnmi_skip_verify     BOOLEAN NOT NULL DEFAULT FALSE COMMENT 'Accept any gNMI target certificate';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE devices
+  DROP COLUMN gnmi_skip_verify,
+  DROP COLUMN gnmi_tls,
+  DROP COLUMN gnmi_sample_interval,
+  DROP COLUMN gnmi_mode,
+  DROP COLUMN gnmi_port;
+-- +goose StatementEnd
@@ -0,0 +1,15 @@
+-- +goose UP
+-- +goose StatementBegin
+ALTER TABLE devices
+  ADD COLUMN netconf_port     SMALLINT UNSIGNED NOT NULL DEFAULT 830,
+  ADD COLUMN netconf_platform VARCHAR(32) NOT NULL DEFAULT 'junos' COMMENT 'Mapping of NETCONF replies to measurements',
+  ADD COLUMN netconf_filter   TEXT NOT NULL COMMENT 'Subtree filter of <get>, empty uses the platform one';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE devices
+  DROP COLUMN netconf_filter,
+  DROP COLUMN netconf_platform,
+  DROP COLUMN netconf_port;
+-- +goose StatementEnd
@@ -0,0 +1,13 @@
+-- +goose UP
+-- +goose StatementBegin
+ALTER TABLE devices
+  ADD COLUMN local_interfaces VARCHAR(512) NOT NULL DEFAULT '' COMMENT 'Comma-separated host interfaces, empty reads all of them',
+  ADD COLUMN local_path       VARCHAR(255) NOT NULL DEFAULT '' COMMENT 'Directory of EEPROM dumps read instead of the modules';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE devices
+  DROP COLUMN local_path,
+  DROP COLUMN local_interfaces;
+-- +goose StatementEnd
@@ -0,0 +1,18 @@
+-- +goose UP
+-- +goose StatementBegin
+CREATE TABLE eeprom_captures
+(
+  id        INT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+  device_id INT UNSIGNED NOT NULL,
+  interface VARCHAR(100) NOT NULL,
+  data      MEDIUMBLOB NOT NULL COMMENT 'Gzip-compressed decoder input',
+  created   DATETIME NOT NULL,
+  INDEX eeprom_captures_interface (device_id, interface),
+  CONSTRAINT eeprom_captures_device FOREIGN KEY (device_id) REFERENCES devices (id) ON DELETE CASCADE
+) COLLATE = utf8mb4_unicode_ci CHARSET = utf8mb4 COMMENT 'Last raw EEPROM dumps of device interfaces';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE eeprom_captures;
+-- +goose StatementEnd
@@ -0,0 +1,39 @@
+-- +goose UP
+-- +goose StatementBegin
+CREATE TABLE interfaces
+(
+  device_id    INT UNSIGNED NOT NULL,
+  name         VARCHAR(100) NOT NULL,
+  frequency    DOUBLE NOT NULL DEFAULT 0 COMMENT 'Current laser frequency in THz, 0 when not tunable',
+  channel      SMALLINT NOT NULL DEFAULT 0,
+  grid_spacing DOUBLE NOT NULL DEFAULT 0 COMMENT 'GHz',
+  target_power DOUBLE NOT NULL DEFAULT 0 COMMENT 'dBm',
+  updated      DATETIME NOT NULL,
+  PRIMARY KEY (device_id, name),
+  CONSTRAINT interfaces_device FOREIGN KEY (device_id) REFERENCES devices (id) ON DELETE CASCADE
+) COLLATE = utf8mb4_unicode_ci CHARSET = utf8mb4 COMMENT 'Last decoded state of device interfaces';
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TABLE events
+(
+  id        INT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+  device_id INT UNSIGNED NOT NULL,
+  hostname  VARCHAR(100) NOT NULL,
+  interface VARCHAR(100) NOT NULL,
+  kind      VARCHAR(50) NOT NULL,
+  message   VARCHAR(255) NOT NULL,
+  created   DATETIME NOT NULL,
+  INDEX events_created (created),
+  CONSTRAINT events_device FOREIGN KEY (device_id) REFERENCES devices (id) ON DELETE CASCADE
+) COLLATE = utf8mb4_unicode_ci CHARSET = utf8mb4 COMMENT 'State changes of device interfaces seen by the monitor';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE events;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+DROP TABLE interfaces;
+-- +goose StatementEnd
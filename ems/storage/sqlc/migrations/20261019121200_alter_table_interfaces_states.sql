@@ -0,0 +1,13 @@
+-- +goose UP
+-- +goose StatementBegin
+ALTER TABLE interfaces
+  ADD COLUMN module_state    TINYINT UNSIGNED NOT NULL DEFAULT 0 COMMENT 'CMIS module state, 0 when not known',
+  ADD COLUMN datapath_states VARBINARY(8) NOT NULL DEFAULT '' COMMENT 'CMIS data path states of host lanes, a byte each';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE interfaces
+  DROP COLUMN datapath_states,
+  DROP COLUMN module_state;
+-- +goose StatementEnd
@@ -0,0 +1,15 @@
+-- +goose UP
+-- +goose StatementBegin
+ALTER TABLE interfaces
+  ADD COLUMN tx_power     DOUBLE NOT NULL DEFAULT 0 COMMENT 'dBm',
+  ADD COLUMN rx_power     DOUBLE NOT NULL DEFAULT 0 COMMENT 'dBm',
+  ADD COLUMN rx_low_alarm DOUBLE NOT NULL DEFAULT 0 COMMENT 'Rx power low alarm threshold of page 02h in dBm, 0 when not known';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE interfaces
+  DROP COLUMN rx_low_alarm,
+  DROP COLUMN rx_power,
+  DROP COLUMN tx_power;
+-- +goose StatementEnd
@@ -0,0 +1,25 @@
+-- +goose UP
+-- +goose StatementBegin
+CREATE TABLE links
+(
+  id          INT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+  name        VARCHAR(100) NOT NULL UNIQUE,
+  a_device_id INT UNSIGNED NOT NULL,
+  a_interface VARCHAR(100) NOT NULL,
+  b_device_id INT UNSIGNED NOT NULL,
+  b_interface VARCHAR(100) NOT NULL,
+  alarm_delta DOUBLE NOT NULL COMMENT 'Span loss increase over the baseline raising an alarm, in dB',
+  baseline    DOUBLE NULL COMMENT 'Span loss of the healthy link in dB, set by the first reading',
+  loss        DOUBLE NULL COMMENT 'Last span loss in dB',
+  margin      DOUBLE NULL COMMENT 'Last margin over the Rx low alarm threshold of B in dB',
+  degraded    BOOLEAN NOT NULL DEFAULT FALSE,
+  updated     DATETIME NULL,
+  CONSTRAINT links_a_device FOREIGN KEY (a_device_id) REFERENCES devices (id) ON DELETE CASCADE,
+  CONSTRAINT links_b_device FOREIGN KEY (b_device_id) REFERENCES devices (id) ON DELETE CASCADE
+) COLLATE = utf8mb4_unicode_ci CHARSET = utf8mb4 COMMENT 'Fiber spans from the Tx of interface A to the Rx of interface B';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE links;
+-- +goose StatementEnd
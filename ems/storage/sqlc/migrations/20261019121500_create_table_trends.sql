@@ -0,0 +1,30 @@
+-- +goose UP
+-- +goose StatementBegin
+CREATE TABLE trends
+(
+  device_id     INT UNSIGNED NOT NULL,
+  interface     VARCHAR(100) NOT NULL,
+  metric        VARCHAR(50) NOT NULL,
+  samples       INT UNSIGNED NOT NULL,
+  mean          DOUBLE NOT NULL COMMENT 'Rolling baseline',
+  variance      DOUBLE NOT NULL,
+  origin        DATETIME NOT NULL COMMENT 'Time 0 of the drift fit',
+  fit_weight    DOUBLE NOT NULL COMMENT 'Exponentially weighted sums of the drift fit, time in days since origin',
+  fit_t         DOUBLE NOT NULL,
+  fit_x         DOUBLE NOT NULL,
+  fit_tt        DOUBLE NOT NULL,
+  fit_tx        DOUBLE NOT NULL,
+  slope         DOUBLE NOT NULL COMMENT 'Drift per day, 0 until the fit spans enough time',
+  step          BOOLEAN NOT NULL DEFAULT FALSE,
+  drifting      BOOLEAN NOT NULL DEFAULT FALSE,
+  forecast_days DOUBLE NULL COMMENT 'Days until the fit reaches the alarm threshold, set within the horizon only',
+  updated       DATETIME NOT NULL,
+  PRIMARY KEY (device_id, interface, metric),
+  CONSTRAINT trends_device FOREIGN KEY (device_id) REFERENCES devices (id) ON DELETE CASCADE
+) COLLATE = utf8mb4_unicode_ci CHARSET = utf8mb4 COMMENT 'Rolling baselines and drift fits of interface readings';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE trends;
+-- +goose StatementEnd
@@ -0,0 +1,50 @@
+-- +goose UP
+-- +goose StatementBegin
+CREATE TABLE rules
+(
+  id             INT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+  name           VARCHAR(100) NOT NULL UNIQUE,
+  enabled        BOOLEAN NOT NULL DEFAULT TRUE,
+  metric         VARCHAR(100) NOT NULL COMMENT 'Influx field, e.g. rx_pwr or vdm_osnr',
+  comparison     VARCHAR(10) NOT NULL COMMENT 'below, above, rises or falls',
+  threshold      DOUBLE NOT NULL,
+  hysteresis     DOUBLE NOT NULL DEFAULT 0 COMMENT 'Margin past the threshold clearing the alert',
+  window_seconds INT UNSIGNED NOT NULL DEFAULT 0 COMMENT 'Time a rise or fall is measured over',
+  for_polls      INT UNSIGNED NOT NULL DEFAULT 1 COMMENT 'Consecutive breaching readings raising the alert',
+  for_seconds    INT UNSIGNED NOT NULL DEFAULT 0 COMMENT 'Time the breach has to last raising the alert',
+  site           VARCHAR(100) NOT NULL DEFAULT '',
+  device_group   VARCHAR(100) NOT NULL DEFAULT '',
+  tag            VARCHAR(64) NOT NULL DEFAULT '',
+  device_id      INT UNSIGNED NULL,
+  interface      VARCHAR(100) NOT NULL DEFAULT '',
+  silenced_until DATETIME NULL,
+  CONSTRAINT rules_device FOREIGN KEY (device_id) REFERENCES devices (id) ON DELETE CASCADE
+) COLLATE = utf8mb4_unicode_ci CHARSET = utf8mb4 COMMENT 'User-defined alert rules on measurements';
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TABLE alerts
+(
+  rule_id   INT UNSIGNED NOT NULL,
+  device_id INT UNSIGNED NOT NULL,
+  interface VARCHAR(100) NOT NULL,
+  breaches  INT UNSIGNED NOT NULL DEFAULT 0,
+  since     DATETIME NULL COMMENT 'First reading of the current breach',
+  firing    BOOLEAN NOT NULL DEFAULT FALSE,
+  value     DOUBLE NOT NULL DEFAULT 0 COMMENT 'Last value compared with the threshold',
+  samples   TEXT NOT NULL COMMENT 'JSON readings within the window of rises and falls',
+  updated   DATETIME NOT NULL,
+  PRIMARY KEY (rule_id, device_id, interface),
+  CONSTRAINT alerts_rule FOREIGN KEY (rule_id) REFERENCES rules (id) ON DELETE CASCADE,
+  CONSTRAINT alerts_device FOREIGN KEY (device_id) REFERENCES devices (id) ON DELETE CASCADE
+) COLLATE = utf8mb4_unicode_ci CHARSET = utf8mb4 COMMENT 'State of rules per interface';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE alerts;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+DROP TABLE rules;
+-- +goose StatementEnd
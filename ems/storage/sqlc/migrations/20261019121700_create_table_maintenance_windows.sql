@@ -0,0 +1,21 @@
+-- +goose UP
+-- +goose StatementBegin
+CREATE TABLE maintenance_windows
+(
+  id               INT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+  name             VARCHAR(100) NOT NULL UNIQUE,
+  site             VARCHAR(100) NOT NULL DEFAULT '',
+  device_group     VARCHAR(100) NOT NULL DEFAULT '',
+  device_id        INT UNSIGNED NULL,
+  starts           DATETIME NOT NULL COMMENT 'Start of a one-off window, or the time a recurring one takes effect',
+  duration_minutes INT UNSIGNED NOT NULL,
+  schedule         VARCHAR(100) NOT NULL DEFAULT '' COMMENT 'Cron expression of recurring starts, empty for a one-off window',
+  skip_polling     BOOLEAN NOT NULL DEFAULT FALSE,
+  CONSTRAINT maintenance_windows_device FOREIGN KEY (device_id) REFERENCES devices (id) ON DELETE CASCADE
+) COLLATE = utf8mb4_unicode_ci CHARSET = utf8mb4 COMMENT 'Planned work on devices, groups or sites';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE maintenance_windows;
+-- +goose StatementEnd
@@ -1,6 +1,12 @@
--- name: CreateDevice :exec
-INSERT INTO devices (hostname, ip, login, passwd, keyfile, connected)
-VALUES (sqlc.arg(hostname), sqlc.arg(ip), sqlc.arg(login), sqlc.arg(passwd), sqlc.arg(keyfile), sqlc.arg(connected));
+-- name: CreateDevice :execlastid
+INSERT INTO devices (hostname, ip, port, login, passwd, keyfile, passphrase, certificate, credential_id, jump_host_id, site, device_group, tags, connected,
+                     collector, snmp_version, snmp_port, snmp_community, snmp_user, snmp_auth_protocol, snmp_auth_password, snmp_priv_protocol, snmp_priv_password,
+                     gnmi_port, gnmi_mode, gnmi_sample_interval, gnmi_tls, gnmi_skip_verify,
+                     netconf_port, netconf_platform, netconf_filter, local_interfaces, local_path)
+VALUES (sqlc.arg(hostname), sqlc.arg(ip), sqlc.arg(port), sqlc.arg(login), sqlc.arg(passwd), sqlc.arg(keyfile), sqlc.arg(passphrase), sqlc.arg(certificate), sqlc.arg(credential_id), sqlc.arg(jump_host_id), sqlc.arg(site), sqlc.arg(device_group), sqlc.arg(tags), sqlc.arg(connected),
+        sqlc.arg(collector), sqlc.arg(snmp_version), sqlc.arg(snmp_port), sqlc.arg(snmp_community), sqlc.arg(snmp_user), sqlc.arg(snmp_auth_protocol), sqlc.arg(snmp_auth_password), sqlc.arg(snmp_priv_protocol), sqlc.arg(snmp_priv_password),
+        sqlc.arg(gnmi_port), sqlc.arg(gnmi_mode), sqlc.arg(gnmi_sample_interval), sqlc.arg(gnmi_tls), sqlc.arg(gnmi_skip_verify),
+        sqlc.arg(netconf_port), sqlc.arg(netconf_platform), sqlc.arg(netconf_filter), sqlc.arg(local_interfaces), sqlc.arg(local_path));
 
 -- name: Device :one
 SELECT * FROM devices
@@ -11,13 +17,40 @@ SELECT * FROM devices;
 
 -- name: UpdateDevice :exec
 UPDATE devices
-SET hostname    = sqlc.arg(hostname),
-    ip          = sqlc.arg(ip),
-    login       = sqlc.arg(login),
-    passwd      = sqlc.arg(passwd),
-    keyfile     = sqlc.arg(keyfile),
-    last_status = sqlc.arg(last_status),
-    connected   = sqlc.arg(connected)
+SET hostname      = sqlc.arg(hostname),
+    ip            = sqlc.arg(ip),
+    port          = sqlc.arg(port),
+    login         = sqlc.arg(login),
+    passwd        = sqlc.arg(passwd),
+    keyfile       = sqlc.arg(keyfile),
+    passphrase    = sqlc.arg(passphrase),
+    certificate   = sqlc.arg(certificate),
+    credential_id = sqlc.arg(credential_id),
+    jump_host_id  = sqlc.arg(jump_host_id),
+    site          = sqlc.arg(site),
+    device_group  = sqlc.arg(device_group),
+    tags          = sqlc.arg(tags),
+    last_status   = sqlc.arg(last_status),
+    connected     = sqlc.arg(connected),
+    collector          = sqlc.arg(collector),
+    snmp_version       = sqlc.arg(snmp_version),
+    snmp_port          = sqlc.arg(snmp_port),
+    snmp_community     = sqlc.arg(snmp_community),
+    snmp_user          = sqlc.arg(snmp_user),
+    snmp_auth_protocol = sqlc.arg(snmp_auth_protocol),
+    snmp_auth_password = sqlc.arg(snmp_auth_password),
+    snmp_priv_protocol = sqlc.arg(snmp_priv_protocol),
+    snmp_priv_password = sqlc.arg(snmp_priv_password),
+    gnmi_port            = sqlc.arg(gnmi_port),
+    gnmi_mode            = sqlc.arg(gnmi_mode),
+    gnmi_sample_interval = sqlc.arg(gnmi_sample_interval),
+    gnmi_tls             = sqlc.arg(gnmi_tls),
+    gnmi_skip_verify     = sqlc.arg(gnmi_skip_verify),
+    netconf_port     = sqlc.arg(netconf_port),
+    netconf_platform = sqlc.arg(netconf_platform),
+    netconf_filter   = sqlc.arg(netconf_filter),
+    local_interfaces = sqlc.arg(local_interfaces),
+    local_path       = sqlc.arg(local_path)
 WHERE devices.id = sqlc.arg(id);
 
 -- name: UpdateDeviceStatus :exec
@@ -28,4 +61,294 @@ WHERE devices.id = sqlc.arg(id);
 
 -- name: DeleteDevice :exec
 DELETE FROM devices
-WHERE devices.id = sqlc.arg(id);
\ No newline at end of file
+WHERE devices.id = sqlc.arg(id);
+
+-- name: CreateAuditEntry :exec
+INSERT INTO audit_log (actor, action, target_type, target_id, target_name, changes, created)
+VALUES (sqlc.arg(actor), sqlc.arg(action), sqlc.arg(target_type), sqlc.arg(target_id), sqlc.arg(target_name), sqlc.arg(changes), sqlc.arg(created));
+
+-- name: AuditEntries :many
+SELECT * FROM audit_log
+WHERE (sqlc.arg(actor) = '' OR audit_log.actor = sqlc.arg(actor))
+  AND (sqlc.arg(action) = '' OR audit_log.action = sqlc.arg(action))
+  AND audit_log.target_name LIKE sqlc.arg(target_pattern)
+ORDER BY audit_log.created DESC, audit_log.id DESC
+LIMIT ?;
+
+-- name: CreateCredential :execlastid
+INSERT INTO credentials (name, kind, login, passwd, keyfile, passphrase, certificate)
+VALUES (sqlc.arg(name), sqlc.arg(kind), sqlc.arg(login), sqlc.arg(passwd), sqlc.arg(keyfile), sqlc.arg(passphrase), sqlc.arg(certificate));
+
+-- name: Credential :one
+SELECT * FROM credentials
+WHERE credentials.id = sqlc.arg(id);
+
+-- name: Credentials :many
+SELECT * FROM credentials
+ORDER BY credentials.name;
+
+-- name: UpdateCredential :exec
+UPDATE credentials
+SET name        = sqlc.arg(name),
+    kind        = sqlc.arg(kind),
+    login       = sqlc.arg(login),
+    passwd      = sqlc.arg(passwd),
+    keyfile     = sqlc.arg(keyfile),
+    passphrase  = sqlc.arg(passphrase),
+    certificate = sqlc.arg(certificate)
+WHERE credentials.id = sqlc.arg(id);
+
+-- name: DeleteCredential :exec
+DELETE FROM credentials
+WHERE credentials.id = sqlc.arg(id);
+
+-- name: CredentialUsage :one
+SELECT COUNT(*) FROM devices
+WHERE devices.credential_id = sqlc.arg(credential_id);
+
+-- name: CredentialJumpHostUsage :one
+SELECT COUNT(*) FROM jump_hosts
+WHERE jump_hosts.credential_id = sqlc.arg(credential_id);
+
+-- name: CreateJumpHost :execlastid
+INSERT INTO jump_hosts (name, address, port, credential_id, via_id)
+VALUES (sqlc.arg(name), sqlc.arg(address), sqlc.arg(port), sqlc.arg(credential_id), sqlc.arg(via_id));
+
+-- name: JumpHost :one
+SELECT * FROM jump_hosts
+WHERE jump_hosts.id = sqlc.arg(id);
+
+-- name: JumpHosts :many
+SELECT * FROM jump_hosts
+ORDER BY jump_hosts.name;
+
+-- name: UpdateJumpHost :exec
+UPDATE jump_hosts
+SET name          = sqlc.arg(name),
+    address       = sqlc.arg(address),
+    port          = sqlc.arg(port),
+    credential_id = sqlc.arg(credential_id),
+    via_id        = sqlc.arg(via_id)
+WHERE jump_hosts.id = sqlc.arg(id);
+
+-- name: DeleteJumpHost :exec
+DELETE FROM jump_hosts
+WHERE jump_hosts.id = sqlc.arg(id);
+
+-- name: JumpHostUsage :one
+SELECT COUNT(*) FROM devices
+WHERE devices.jump_host_id = sqlc.arg(jump_host_id);
+
+-- name: JumpHostViaUsage :one
+SELECT COUNT(*) FROM jump_hosts
+WHERE jump_hosts.via_id = sqlc.arg(via_id);
+
+-- name: CreateCapture :exec
+INSERT INTO eeprom_captures (device_id, interface, data, created)
+VALUES (sqlc.arg(device_id), sqlc.arg(interface), sqlc.arg(data), sqlc.arg(created));
+
+-- name: PruneCaptures :exec
+DELETE FROM eeprom_captures
+WHERE eeprom_captures.device_id = sqlc.arg(device_id)
+  AND eeprom_captures.interface = sqlc.arg(interface)
+  AND eeprom_captures.id NOT IN (
+    SELECT recent.id FROM (
+      SELECT c.id FROM eeprom_captures AS c
+      WHERE c.device_id = sqlc.arg(device_id) AND c.interface = sqlc.arg(interface)
+      ORDER BY c.id DESC
+      LIMIT ?
+    ) AS recent
+  );
+
+-- name: Captures :many
+SELECT * FROM eeprom_captures
+WHERE eeprom_captures.device_id = sqlc.arg(device_id)
+ORDER BY eeprom_captures.interface, eeprom_captures.id DESC;
+
+-- name: UpsertInterface :exec
+INSERT INTO interfaces (device_id, name, frequency, channel, grid_spacing, target_power, module_state, datapath_states, tx_power, rx_power, rx_low_alarm, updated)
+VALUES (sqlc.arg(device_id), sqlc.arg(name), sqlc.arg(frequency), sqlc.arg(channel), sqlc.arg(grid_spacing), sqlc.arg(target_power), sqlc.arg(module_state), sqlc.arg(datapath_states), sqlc.arg(tx_power), sqlc.arg(rx_power), sqlc.arg(rx_low_alarm), sqlc.arg(updated))
+ON DUPLICATE KEY UPDATE frequency       = VALUES(frequency),
+                        channel         = VALUES(channel),
+                        grid_spacing    = VALUES(grid_spacing),
+                        target_power    = VALUES(target_power),
+                        module_state    = VALUES(module_state),
+                        datapath_states = VALUES(datapath_states),
+                        tx_power        = VALUES(tx_power),
+                        rx_power        = VALUES(rx_power),
+                        rx_low_alarm    = VALUES(rx_low_alarm),
+                        updated         = VALUES(updated);
+
+-- name: Interfaces :many
+SELECT * FROM interfaces
+ORDER BY interfaces.device_id, interfaces.name;
+
+-- name: DeviceInterfaces :many
+SELECT * FROM interfaces
+WHERE interfaces.device_id = sqlc.arg(device_id)
+ORDER BY interfaces.name;
+
+-- name: CreateEvent :exec
+INSERT INTO events (device_id, hostname, interface, kind, message, created)
+VALUES (sqlc.arg(device_id), sqlc.arg(hostname), sqlc.arg(interface), sqlc.arg(kind), sqlc.arg(message), sqlc.arg(created));
+
+-- name: Events :many
+SELECT * FROM events
+WHERE (sqlc.arg(device_id) = 0 OR events.device_id = sqlc.arg(device_id))
+  AND (sqlc.arg(kind) = '' OR events.kind = sqlc.arg(kind))
+ORDER BY events.created DESC, events.id DESC
+LIMIT ?;
+
+-- name: CreateLink :execlastid
+INSERT INTO links (name, a_device_id, a_interface, b_device_id, b_interface, alarm_delta)
+VALUES (sqlc.arg(name), sqlc.arg(a_device_id), sqlc.arg(a_interface), sqlc.arg(b_device_id), sqlc.arg(b_interface), sqlc.arg(alarm_delta));
+
+-- name: Link :one
+SELECT * FROM links
+WHERE links.id = sqlc.arg(id);
+
+-- name: Links :many
+SELECT * FROM links
+ORDER BY links.name;
+
+-- name: UpdateLink :exec
+UPDATE links
+SET name        = sqlc.arg(name),
+    a_device_id = sqlc.arg(a_device_id),
+    a_interface = sqlc.arg(a_interface),
+    b_device_id = sqlc.arg(b_device_id),
+    b_interface = sqlc.arg(b_interface),
+    alarm_delta = sqlc.arg(alarm_delta)
+WHERE links.id = sqlc.arg(id);
+
+-- name: UpdateLinkSpan :exec
+UPDATE links
+SET baseline = sqlc.arg(baseline),
+    loss     = sqlc.arg(loss),
+    margin   = sqlc.arg(margin),
+    degraded = sqlc.arg(degraded),
+    updated  = sqlc.arg(updated)
+WHERE links.id = sqlc.arg(id);
+
+-- name: ResetLinkBaseline :exec
+UPDATE links
+SET baseline = NULL,
+    degraded = FALSE
+WHERE links.id = sqlc.arg(id);
+
+-- name: DeleteLink :exec
+DELETE FROM links
+WHERE links.id = sqlc.arg(id);
+
+-- name: UpsertTrend :exec
+INSERT INTO trends (device_id, interface, metric, samples, mean, variance, origin, fit_weight, fit_t, fit_x, fit_tt, fit_tx, slope, step, drifting, forecast_days, updated)
+VALUES (sqlc.arg(device_id), sqlc.arg(interface), sqlc.arg(metric), sqlc.arg(samples), sqlc.arg(mean), sqlc.arg(variance), sqlc.arg(origin), sqlc.arg(fit_weight), sqlc.arg(fit_t), sqlc.arg(fit_x), sqlc.arg(fit_tt), sqlc.arg(fit_tx), sqlc.arg(slope), sqlc.arg(step), sqlc.arg(drifting), sqlc.arg(forecast_days), sqlc.arg(updated))
+ON DUPLICATE KEY UPDATE samples       = VALUES(samples),
+                        mean          = VALUES(mean),
+                        variance      = VALUES(variance),
+                        origin        = VALUES(origin),
+                        fit_weight    = VALUES(fit_weight),
+                        fit_t         = VALUES(fit_t),
+                        fit_x         = VALUES(fit_x),
+                        fit_tt        = VALUES(fit_tt),
+                        fit_tx        = VALUES(fit_tx),
+                        slope         = VALUES(slope),
+                        step          = VALUES(step),
+                        drifting      = VALUES(drifting),
+                        forecast_days = VALUES(forecast_days),
+                        updated       = VALUES(updated);
+
+-- name: DeviceTrends :many
+SELECT * FROM trends
+WHERE trends.device_id = sqlc.arg(device_id);
+
+-- name: FlaggedTrends :many
+SELECT * FROM trends
+WHERE trends.step OR trends.drifting OR trends.forecast_days IS NOT NULL
+ORDER BY trends.device_id, trends.interface, trends.metric;
+
+-- name: CreateRule :execlastid
+INSERT INTO rules (name, enabled, metric, comparison, threshold, hysteresis, window_seconds, for_polls, for_seconds, site, device_group, tag, device_id, interface)
+VALUES (sqlc.arg(name), sqlc.arg(enabled), sqlc.arg(metric), sqlc.arg(comparison), sqlc.arg(threshold), sqlc.arg(hysteresis), sqlc.arg(window_seconds), sqlc.arg(for_polls), sqlc.arg(for_seconds), sqlc.arg(site), sqlc.arg(device_group), sqlc.arg(tag), sqlc.arg(device_id), sqlc.arg(interface));
+
+-- name: Rule :one
+SELECT * FROM rules
+WHERE rules.id = sqlc.arg(id);
+
+-- name: Rules :many
+SELECT * FROM rules
+ORDER BY rules.name;
+
+-- name: UpdateRule :exec
+UPDATE rules
+SET name           = sqlc.arg(name),
+    enabled        = sqlc.arg(enabled),
+    metric         = sqlc.arg(metric),
+    comparison     = sqlc.arg(comparison),
+    threshold      = sqlc.arg(threshold),
+    hysteresis     = sqlc.arg(hysteresis),
+    window_seconds = sqlc.arg(window_seconds),
+    for_polls      = sqlc.arg(for_polls),
+    for_seconds    = sqlc.arg(for_seconds),
+    site           = sqlc.arg(site),
+    device_group   = sqlc.arg(device_group),
+    tag            = sqlc.arg(tag),
+    device_id      = sqlc.arg(device_id),
+    interface      = sqlc.arg(interface)
+WHERE rules.id = sqlc.arg(id);
+
+-- name: SilenceRule :exec
+UPDATE rules
+SET silenced_until = sqlc.arg(silenced_until)
+WHERE rules.id = sqlc.arg(id);
+
+-- name: DeleteRule :exec
+DELETE FROM rules
+WHERE rules.id = sqlc.arg(id);
+
+-- name: UpsertAlert :exec
+INSERT INTO alerts (rule_id, device_id, interface, breaches, since, firing, value, samples, updated)
+VALUES (sqlc.arg(rule_id), sqlc.arg(device_id), sqlc.arg(interface), sqlc.arg(breaches), sqlc.arg(since), sqlc.arg(firing), sqlc.arg(value), sqlc.arg(samples), sqlc.arg(updated))
+ON DUPLICATE KEY UPDATE breaches = VALUES(breaches),
+                        since    = VALUES(since),
+                        firing   = VALUES(firing),
+                        value    = VALUES(value),
+                        samples  = VALUES(samples),
+                        updated  = VALUES(updated);
+
+-- name: DeviceAlerts :many
+SELECT * FROM alerts
+WHERE alerts.device_id = sqlc.arg(device_id);
+
+-- name: FiringAlerts :many
+SELECT * FROM alerts
+WHERE alerts.firing
+ORDER BY alerts.rule_id, alerts.device_id, alerts.interface;
+
+-- name: CreateMaintenanceWindow :execlastid
+INSERT INTO maintenance_windows (name, site, device_group, device_id, starts, duration_minutes, schedule, skip_polling)
+VALUES (sqlc.arg(name), sqlc.arg(site), sqlc.arg(device_group), sqlc.arg(device_id), sqlc.arg(starts), sqlc.arg(duration_minutes), sqlc.arg(schedule), sqlc.arg(skip_polling));
+
+-- name: MaintenanceWindow :one
+SELECT * FROM maintenance_windows
+WHERE maintenance_windows.id = sqlc.arg(id);
+
+-- name: MaintenanceWindows :many
+SELECT * FROM maintenance_windows
+ORDER BY maintenance_windows.starts, maintenance_windows.name;
+
+-- name: UpdateMaintenanceWindow :exec
+UPDATE maintenance_windows
+SET name             = sqlc.arg(name),
+    site             = sqlc.arg(site),
+    device_group     = sqlc.arg(device_group),
+    device_id        = sqlc.arg(device_id),
+    starts           = sqlc.arg(starts),
+    duration_minutes = sqlc.arg(duration_minutes),
+    schedule         = sqlc.arg(schedule),
+    skip_polling     = sqlc.arg(skip_polling)
+WHERE maintenance_windows.id = sqlc.arg(id);
+
+-- name: DeleteMaintenanceWindow :exec
+DELETE FROM maintenance_windows
+WHERE maintenance_windows.id = sqlc.arg(id);
@@ -0,0 +1,43 @@
+package storage
+
+import "time"
+
+const (
+	EventStepChange        = "step-change"
+	EventDrift             = "drift"
+	EventThresholdForecast = "threshold-forecast"
+)
+
+// Trend is the rolling state of one metric of an interface, e.g. rx_pwr,
+// kept by the monitor to spot anomalies between polls.
+type Trend struct {
+	DeviceID  uint
+	Interface string
+	Metric    string
+	Samples   int
+	// Mean and Variance are the rolling baseline.
+	Mean     float64
+	Variance float64
+	// Fit are sums of a weighted least squares line through recent
+	// readings, time in days since Origin.
+	Origin time.Time
+	Fit    TrendFit
+	// Slope is the drift per day, 0 until the fit spans enough time.
+	Slope    float64
+	Step     bool
+	Drifting bool
+	// ForecastDays is the time left until the fit reaches the alarm
+	// threshold, nil when it is beyond the forecast horizon.
+	ForecastDays *float64
+	Updated      time.Time
+}
+
+// TrendFit are exponentially weighted sums of weights, times, values,
+// squared times and products of times and values.
+type TrendFit struct {
+	W  float64
+	T  float64
+	X  float64
+	TT float64
+	TX float64
+}
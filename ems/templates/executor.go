@@ -11,6 +11,18 @@ const (
 	PageIndex   = "index.html"
 	PageSignIn  = "signin.html"
 	PageNewEdit = "new.html"
+	PageAudit   = "audit.html"
+	PageImport  = "import.html"
+	PageInspect = "inspect.html"
+	PageEvents  = "events.html"
+
+	PageCredentials = "credentials.html"
+	PageJumpHosts   = "jump-hosts.html"
+	PageLinks       = "links.html"
+	PageRules       = "rules.html"
+	PageMaintenance = "maintenance.html"
+
+	PartialNav = "nav.html"
 )
 
 type Executor struct {
@@ -44,14 +56,107 @@ func (e *Executor) ExecuteNewEdit(data NewEdit) (*bytes.Buffer, error) {
 	return &buf, nil
 }
 
+func (e *Executor) ExecuteAudit(data Audit) (*bytes.Buffer, error) {
+	var buf bytes.Buffer
+	if err := e.templates.ExecuteTemplate(&buf, PageAudit, data); err != nil {
+		return nil, err
+	}
+
+	return &buf, nil
+}
+
+func (e *Executor) ExecuteImport(data Import) (*bytes.Buffer, error) {
+	var buf bytes.Buffer
+	if err := e.templates.ExecuteTemplate(&buf, PageImport, data); err != nil {
+		return nil, err
+	}
+
+	return &buf, nil
+}
+
+func (e *Executor) ExecuteInspect(data Inspect) (*bytes.Buffer, error) {
+	var buf bytes.Buffer
+	if err := e.templates.ExecuteTemplate(&buf, PageInspect, data); err != nil {
+		return nil, err
+	}
+
+	return &buf, nil
+}
+
+func (e *Executor) ExecuteCredentials(data Credentials) (*bytes.Buffer, error) {
+	var buf bytes.Buffer
+	if err := e.templates.ExecuteTemplate(&buf, PageCredentials, data); err != nil {
+		return nil, err
+	}
+
+	return &buf, nil
+}
+
+func (e *Executor) ExecuteJumpHosts(data JumpHosts) (*bytes.Buffer, error) {
+	var buf bytes.Buffer
+	if err := e.templates.ExecuteTemplate(&buf, PageJumpHosts, data); err != nil {
+		return nil, err
+	}
+
+	return &buf, nil
+}
+
+func (e *Executor) ExecuteEvents(data Events) (*bytes.Buffer, error) {
+	var buf bytes.Buffer
+	if err := e.templates.ExecuteTemplate(&buf, PageEvents, data); err != nil {
+		return nil, err
+	}
+
+	return &buf, nil
+}
+
+func (e *Executor) ExecuteLinks(data Links) (*bytes.Buffer, error) {
+	var buf bytes.Buffer
+	if err := e.templates.ExecuteTemplate(&buf, PageLinks, data); err != nil {
+		return nil, err
+	}
+
+	return &buf, nil
+}
+
+func (e *Executor) ExecuteRules(data Rules) (*bytes.Buffer, error) {
+	var buf bytes.Buffer
+	if err := e.templates.ExecuteTemplate(&buf, PageRules, data); err != nil {
+		return nil, err
+	}
+
+	return &buf, nil
+}
+
+func (e *Executor) ExecuteMaintenance(data Maintenance) (*bytes.Buffer, error) {
+	var buf bytes.Buffer
+	if err := e.templates.ExecuteTemplate(&buf, PageMaintenance, data); err != nil {
+		return nil, err
+	}
+
+	return &buf, nil
+}
+
 func NewExecutor(dir string) (*Executor, error) {
 	templates, err := template.New("ems").Funcs(template.FuncMap{
 		"ToUpper": strings.ToUpper,
 		"ToLower": strings.ToLower,
+		"Join":    strings.Join,
+		"Add":     func(a, b int) int { return a + b },
 	}).ParseFiles(
 		path.Join(dir, PageSignIn),
 		path.Join(dir, PageIndex),
 		path.Join(dir, PageNewEdit),
+		path.Join(dir, PageAudit),
+		path.Join(dir, PageImport),
+		path.Join(dir, PageInspect),
+		path.Join(dir, PageEvents),
+		path.Join(dir, PageCredentials),
+		path.Join(dir, PageJumpHosts),
+		path.Join(dir, PageLinks),
+		path.Join(dir, PageRules),
+		path.Join(dir, PageMaintenance),
+		path.Join(dir, PartialNav),
 	)
 	if err != nil {
 		return nil, err
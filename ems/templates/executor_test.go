@@ -0,0 +1,114 @@
+package templates
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"pi-wegrzyn/ems/storage"
+)
+
+func TestExecutor(t *testing.T) {
+	executor, err := NewExecutor("html")
+	if err != nil {
+		t.Fatalf("cannot parse templates: %v", err)
+	}
+
+	devices := []storage.Device{{ID: 1, Hostname: "hostname", IPAddress: "10.0.0.1", Login: "login"}}
+
+	pages := map[string]func() error{
+		PageSignIn: func() error {
+			_, err := executor.ExecuteSignIn(SignIn{ErrorMessage: "error", SSOEnabled: true})
+			return err
+		},
+		PageIndex: func() error {
+			interfaces := []storage.Interface{{DeviceID: 1, Name: "eth0", Frequency: 193.1, GridSpacing: 100, TargetPower: -10, ModuleState: 3, DataPathStates: []uint8{4, 4, 4, 4}}}
+			days := 12.3
+			trends := []storage.Trend{{DeviceID: devices[0].ID, Interface: "eth0", Metric: "rx_pwr", Step: true, Drifting: true, Slope: -0.1, ForecastDays: &days}}
+			_, err := executor.ExecuteIndex(IndexPageContent(devices, interfaces, storage.DeviceQuery{Sort: storage.SortSite}).WithTrends(trends))
+			return err
+		},
+		PageNewEdit: func() error {
+			_, err := executor.ExecuteNewEdit(EditPageContent(devices[0], "").WithCredentials([]storage.Credential{{ID: 1, Name: "noc"}}).WithJumpHosts([]storage.JumpHost{{ID: 1, Name: "bastion"}}))
+			return err
+		},
+		PageAudit: func() error {
+			_, err := executor.ExecuteAudit(AuditPageContent(storage.AuditFilter{Action: storage.AuditActionUpdate}, []storage.AuditEntry{{
+				ID:         1,
+				Actor:      "admin",
+				Action:     storage.AuditActionUpdate,
+				TargetType: storage.AuditTargetDevice,
+				TargetName: "hostname",
+				Changes:    []storage.Change{{Field: "ip", Before: "10.0.0.1", After: "10.0.0.2"}},
+				Created:    time.Now(),
+			}}))
+			return err
+		},
+		PageEvents: func() error {
+			_, err := executor.ExecuteEvents(EventsPageContent(storage.EventFilter{DeviceID: 1}, []storage.Event{{
+				ID:        1,
+				DeviceID:  1,
+				Hostname:  "hostname",
+				Interface: "eth0",
+				Kind:      storage.EventLaserFrequency,
+				Message:   "laser frequency changed",
+				Created:   time.Now(),
+			}}, devices))
+			return err
+		},
+		PageImport: func() error {
+			_, err := executor.ExecuteImport(ImportPageContent("csv", "hostname,ip,login", []ImportRow{
+				{Line: 2, Action: storage.AuditActionCreate, Hostname: "hostname", Changes: []storage.Change{{Field: "ip", After: "10.0.0.1"}}},
+				{Line: 3, Error: "wrong IP address"},
+			}, ""))
+			return err
+		},
+		PageCredentials: func() error {
+			credentials := []storage.Credential{{ID: 1, Name: "noc", Kind: storage.CredentialKey, Login: "noc", Keyfile: []byte("key")}}
+			_, err := executor.ExecuteCredentials(CredentialsPageContent(credentials, devices, credentials[0], "error"))
+			return err
+		},
+		PageJumpHosts: func() error {
+			credentials := []storage.Credential{{ID: 1, Name: "noc", Kind: storage.CredentialAgent, Login: "noc"}}
+			hosts := []storage.JumpHost{
+				{ID: 1, Name: "outer", Address: "192.0.2.1", Port: 22, CredentialID: 1},
+				{ID: 2, Name: "inner", Address: "10.0.0.1", Port: 22, CredentialID: 1, ViaID: 1},
+			}
+			_, err := executor.ExecuteJumpHosts(JumpHostsPageContent(hosts, credentials, devices, hosts[1], "error"))
+			return err
+		},
+		PageLinks: func() error {
+			loss := 4.5
+			links := []storage.Link{{ID: 1, Name: "a-b", ADeviceID: devices[0].ID, AInterface: "eth1", BDeviceID: 2, BInterface: "eth1", AlarmDelta: 3, Baseline: &loss, Loss: &loss, Degraded: true}}
+			_, err := executor.ExecuteLinks(LinksPageContent(links, devices, links[0], "error"))
+			return err
+		},
+		PageRules: func() error {
+			rules := []storage.Rule{{ID: 1, Name: "rx-low", Enabled: true, Metric: "rx_pwr", Comparison: storage.ComparisonBelow, Threshold: -18, ForPolls: 3, Site: "dc1", SilencedUntil: time.Now().Add(time.Hour)}}
+			alerts := []storage.Alert{{RuleID: 1, DeviceID: devices[0].ID, Interface: "eth0", Firing: true, Value: -19}}
+			_, err := executor.ExecuteRules(RulesPageContent(rules, alerts, devices, rules[0], "error", time.Now()))
+			return err
+		},
+		PageMaintenance: func() error {
+			windows := []storage.MaintenanceWindow{
+				{ID: 1, Name: "waw-nightly", Site: "WAW", Starts: time.Now().Add(-time.Hour), Duration: time.Hour, Schedule: "0 2 * * *"},
+				{ID: 2, Name: "fiber-repair", DeviceID: devices[0].ID, Starts: time.Now(), Duration: 2 * time.Hour, SkipPolling: true},
+			}
+			_, err := executor.ExecuteMaintenance(MaintenancePageContent(windows, devices, windows[1], "error", time.Now()))
+			return err
+		},
+		PageInspect: func() error {
+			captures := []storage.Capture{{ID: 1, DeviceID: 1, Interface: "eth0", Data: []byte(strings.Repeat(strings.Repeat("0", 32)+"\n", 64)), Created: time.Now()}}
+			_, err := executor.ExecuteInspect(InspectPageContent(devices[0], captures, ""))
+			return err
+		},
+	}
+
+	for page, execute := range pages {
+		t.Run(page, func(t *testing.T) {
+			if err := execute(); err != nil {
+				t.Errorf("cannot execute %s: %v", page, err)
+			}
+		})
+	}
+}
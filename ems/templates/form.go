@@ -2,23 +2,66 @@ package templates
 
 import (
 	"bytes"
+	"encoding/xml"
 	"errors"
+	"fmt"
+	"io"
 	"log/slog"
 	"mime/multipart"
 	"net"
+	"path/filepath"
 	"regexp"
 	"strconv"
+	"strings"
+	"time"
+
+	"pi-wegrzyn/ems/storage"
 )
 
-const LoginPattern string = `^[a-zA-Z][\-a-zA-Z0-9_\.]*[a-zA-Z0-9]$`
+const (
+	LoginPattern string = `^[a-zA-Z][\-a-zA-Z0-9_\.]*[a-zA-Z0-9]$`
+	TagPattern   string = `^[a-zA-Z0-9][\-a-zA-Z0-9_\.:/]*$`
+	// InterfacePattern matches interface names as collectors report them,
+	// e.g. eth0, 1/1/c1/1 or et-0/0/0.
+	InterfacePattern string = `^[a-zA-Z0-9][\-a-zA-Z0-9_\.:/@]{0,99}$`
+	// MetricPattern matches Influx fields of interface measurements, e.g.
+	// rx_pwr or vdm_pre_fec_ber.
+	MetricPattern string = `^[a-z][a-z0-9_]{0,63}$`
+	// StartsLayout is the layout of datetime-local inputs, in local time of
+	// the server.
+	StartsLayout = "2006-01-02T15:04"
+	// HostPattern matches DNS names, addresses are checked separately.
+	HostPattern string = `^[a-zA-Z0-9]([\-a-zA-Z0-9]{0,61}[a-zA-Z0-9])?(\.[a-zA-Z0-9]([\-a-zA-Z0-9]{0,61}[a-zA-Z0-9])?)*$`
+)
 
 type Form struct {
 	Hostname string
 	Ip       string
 	IPType   int
+	Port     int
 	Login    string
 	Password *string
 	Key      []byte
+	// Passphrase and Certificate belong to Key. On edit they are replaced
+	// together with it, or only when set if the key is kept.
+	Passphrase  string
+	Certificate []byte
+	// CredentialID selects a credential profile, device login and secrets
+	// then only override it.
+	CredentialID uint
+	// JumpHostID selects the jump host the device is reached through.
+	JumpHostID uint
+	// Collector selects how transceiver data is read, SNMP, GNMI, NETCONF
+	// and Local are used only by their collectors. On edit, SNMP secrets
+	// left empty keep their values.
+	Collector string
+	SNMP      storage.SNMP
+	GNMI      storage.GNMI
+	NETCONF   storage.NETCONF
+	Local     storage.Local
+	Site      string
+	Group     string
+	Tags      []string
 
 	EditId        uint
 	PasswordClear *string
@@ -31,11 +74,22 @@ func (f *Form) Validate() error {
 		return errors.New("wrong IP type provided")
 	}
 
-	if f.Hostname == "" || f.Ip == "" || f.Login == "" {
+	if f.Collector == "" {
+		f.Collector = storage.CollectorSSH
+	}
+
+	if !storage.ValidCollector(f.Collector) {
+		f.Collector = storage.CollectorSSH
+		return errors.New("wrong collector")
+	}
+
+	// Only SSH and NETCONF devices log in over SSH.
+	overSSH := f.Collector == storage.CollectorSSH || f.Collector == storage.CollectorNETCONF
+	if f.Hostname == "" || f.Ip == "" || (f.Login == "" && f.CredentialID == 0 && overSSH) {
 		return errors.New("empty fields")
 	}
 
-	if res, err := regexp.MatchString(LoginPattern, f.Login); err != nil || !res {
+	if !validLogin(f.Login) {
 		f.Login = ""
 		return errors.New("wrong login")
 	}
@@ -46,14 +100,204 @@ func (f *Form) Validate() error {
 		return errors.New("wrong IP address")
 	}
 
+	if f.Port == 0 {
+		f.Port = int(storage.DefaultPort)
+	}
+
+	if f.Port < 1 || f.Port > 65535 {
+		f.Port = int(storage.DefaultPort)
+		return errors.New("wrong port")
+	}
+
+	if f.Site != "" && !validTag(f.Site) {
+		f.Site = ""
+		return errors.New("wrong site")
+	}
+
+	if f.Group != "" && !validTag(f.Group) {
+		f.Group = ""
+		return errors.New("wrong group")
+	}
+
+	for _, tag := range f.Tags {
+		if !validTag(tag) {
+			f.Tags = nil
+			return errors.New("wrong tag")
+		}
+	}
+
 	if len(f.Key) > 20480 {
 		f.Key = nil
 		return errors.New("wrong key size")
 	}
 
+	if len(f.Certificate) > 20480 {
+		f.Certificate = nil
+		return errors.New("wrong certificate size")
+	}
+
+	switch f.Collector {
+	case storage.CollectorSNMP:
+		return f.validateSNMP()
+	case storage.CollectorGNMI:
+		return f.validateGNMI()
+	case storage.CollectorNETCONF:
+		return f.validateNETCONF()
+	case storage.CollectorLocal:
+		return f.validateLocal()
+	}
+
+	return nil
+}
+
+func (f *Form) validateLocal() error {
+	for _, name := range f.Local.Interfaces {
+		if !storage.ValidInterfaceName(name) {
+			f.Local.Interfaces = nil
+			return errors.New("wrong local interface")
+		}
+	}
+
+	if f.Local.Path != "" && (!filepath.IsAbs(f.Local.Path) || len(f.Local.Path) > 255) {
+		f.Local.Path = ""
+		return errors.New("wrong local EEPROM path")
+	}
+
 	return nil
 }
 
+func (f *Form) validateNETCONF() error {
+	if f.NETCONF.Port == 0 {
+		f.NETCONF.Port = storage.DefaultNETCONFPort
+	}
+
+	if f.NETCONF.Platform == "" {
+		f.NETCONF.Platform = storage.NETCONFPlatformJunos
+	}
+
+	if !storage.ValidNETCONFPlatform(f.NETCONF.Platform) {
+		f.NETCONF.Platform = storage.NETCONFPlatformJunos
+		return errors.New("wrong NETCONF platform")
+	}
+
+	if len(f.NETCONF.Filter) > 4096 || !validXML(f.NETCONF.Filter) {
+		f.NETCONF.Filter = ""
+		return errors.New("wrong NETCONF filter")
+	}
+
+	return nil
+}
+
+// validXML tells whether s is well-formed XML content, empty included.
+func validXML(s string) bool {
+	decoder := xml.NewDecoder(strings.NewReader("<filter>" + s + "</filter>"))
+	for {
+		if _, err := decoder.Token(); err != nil {
+			return err == io.EOF
+		}
+	}
+}
+
+func (f *Form) validateGNMI() error {
+	if f.GNMI.Port == 0 {
+		f.GNMI.Port = storage.DefaultGNMIPort
+	}
+
+	if f.GNMI.Mode == "" {
+		f.GNMI.Mode = storage.GNMIModeSample
+	}
+
+	if !storage.ValidGNMIMode(f.GNMI.Mode) {
+		f.GNMI.Mode = storage.GNMIModeSample
+		return errors.New("wrong gNMI mode")
+	}
+
+	if f.GNMI.SampleInterval == 0 {
+		f.GNMI.SampleInterval = storage.DefaultGNMISampleInterval
+	}
+
+	if f.GNMI.SampleInterval > 3600 {
+		f.GNMI.SampleInterval = storage.DefaultGNMISampleInterval
+		return errors.New("wrong gNMI sample interval")
+	}
+
+	if f.GNMI.SkipVerify && !f.GNMI.TLS {
+		f.GNMI.SkipVerify = false
+		return errors.New("gNMI certificate verification can only be skipped with TLS")
+	}
+
+	return nil
+}
+
+func (f *Form) validateSNMP() error {
+	if f.SNMP.Version == "" {
+		f.SNMP.Version = storage.SNMPVersion2c
+	}
+
+	if !storage.ValidSNMPVersion(f.SNMP.Version) {
+		f.SNMP.Version = storage.SNMPVersion2c
+		return errors.New("wrong SNMP version")
+	}
+
+	if f.SNMP.Port == 0 {
+		f.SNMP.Port = storage.DefaultSNMPPort
+	}
+
+	if f.SNMP.Version == storage.SNMPVersion2c {
+		if f.SNMP.Community == "" && f.EditId == 0 {
+			return errors.New("SNMP community is required")
+		}
+
+		return nil
+	}
+
+	if f.SNMP.User == "" || !validLogin(f.SNMP.User) {
+		f.SNMP.User = ""
+		return errors.New("wrong SNMP user")
+	}
+
+	if !storage.ValidSNMPAuthProtocol(f.SNMP.AuthProtocol) {
+		f.SNMP.AuthProtocol = ""
+		return errors.New("wrong SNMP auth protocol")
+	}
+
+	if !storage.ValidSNMPPrivProtocol(f.SNMP.PrivProtocol) {
+		f.SNMP.PrivProtocol = ""
+		return errors.New("wrong SNMP privacy protocol")
+	}
+
+	// USM has no privacy without authentication.
+	if f.SNMP.PrivProtocol != "" && f.SNMP.AuthProtocol == "" {
+		return errors.New("SNMP privacy requires authentication")
+	}
+
+	// Passwords may be left empty when editing to keep the stored ones.
+	if f.EditId == 0 {
+		if f.SNMP.AuthProtocol != "" && f.SNMP.AuthPassword == "" {
+			return errors.New("SNMP auth password is required")
+		}
+		if f.SNMP.PrivProtocol != "" && f.SNMP.PrivPassword == "" {
+			return errors.New("SNMP privacy password is required")
+		}
+	}
+
+	return nil
+}
+
+func validLogin(login string) bool {
+	if login == "" {
+		return true
+	}
+
+	res, err := regexp.MatchString(LoginPattern, login)
+	return err == nil && res
+}
+
+func validTag(tag string) bool {
+	res, err := regexp.MatchString(TagPattern, tag)
+	return err == nil && res && len(tag) <= 64
+}
+
 func ParseForm(formReader *multipart.Reader) (*Form, error) {
 	form := Form{}
 
@@ -83,6 +327,11 @@ func ParseForm(formReader *multipart.Reader) (*Form, error) {
 			if err != nil {
 				return &Form{}, err
 			}
+		case "port":
+			form.Port, err = strconv.Atoi(buf.String())
+			if err != nil {
+				return &Form{}, err
+			}
 		case "login":
 			form.Login = buf.String()
 		case "password":
@@ -90,6 +339,100 @@ func ParseForm(formReader *multipart.Reader) (*Form, error) {
 			form.Password = &password
 		case "key":
 			form.Key = buf.Bytes()
+		case "passphrase":
+			form.Passphrase = buf.String()
+		case "certificate":
+			form.Certificate = buf.Bytes()
+		case "credential-id":
+			if buf.Len() == 0 {
+				continue
+			}
+			credentialID, err := strconv.ParseUint(buf.String(), 10, 32)
+			if err != nil {
+				return &Form{}, err
+			}
+			form.CredentialID = uint(credentialID)
+		case "jump-host-id":
+			if buf.Len() == 0 {
+				continue
+			}
+			jumpHostID, err := strconv.ParseUint(buf.String(), 10, 32)
+			if err != nil {
+				return &Form{}, err
+			}
+			form.JumpHostID = uint(jumpHostID)
+		case "collector":
+			form.Collector = buf.String()
+		case "snmp-version":
+			form.SNMP.Version = buf.String()
+		case "snmp-port":
+			if buf.Len() == 0 {
+				continue
+			}
+			port, err := strconv.ParseUint(buf.String(), 10, 16)
+			if err != nil {
+				return &Form{}, err
+			}
+			form.SNMP.Port = uint16(port)
+		case "snmp-community":
+			form.SNMP.Community = buf.String()
+		case "snmp-user":
+			form.SNMP.User = buf.String()
+		case "snmp-auth-protocol":
+			form.SNMP.AuthProtocol = buf.String()
+		case "snmp-auth-password":
+			form.SNMP.AuthPassword = buf.String()
+		case "snmp-priv-protocol":
+			form.SNMP.PrivProtocol = buf.String()
+		case "snmp-priv-password":
+			form.SNMP.PrivPassword = buf.String()
+		case "gnmi-port":
+			if buf.Len() == 0 {
+				continue
+			}
+			port, err := strconv.ParseUint(buf.String(), 10, 16)
+			if err != nil {
+				return &Form{}, err
+			}
+			form.GNMI.Port = uint16(port)
+		case "gnmi-mode":
+			form.GNMI.Mode = buf.String()
+		case "gnmi-sample-interval":
+			if buf.Len() == 0 {
+				continue
+			}
+			interval, err := strconv.ParseUint(buf.String(), 10, 32)
+			if err != nil {
+				return &Form{}, err
+			}
+			form.GNMI.SampleInterval = uint(interval)
+		case "gnmi-tls":
+			form.GNMI.TLS = buf.String() != ""
+		case "gnmi-skip-verify":
+			form.GNMI.SkipVerify = buf.String() != ""
+		case "netconf-port":
+			if buf.Len() == 0 {
+				continue
+			}
+			port, err := strconv.ParseUint(buf.String(), 10, 16)
+			if err != nil {
+				return &Form{}, err
+			}
+			form.NETCONF.Port = uint16(port)
+		case "netconf-platform":
+			form.NETCONF.Platform = strings.TrimSpace(buf.String())
+		case "netconf-filter":
+			form.NETCONF.Filter = strings.TrimSpace(buf.String())
+		case "local-interfaces":
+			form.Local.Interfaces = storage.ParseTags(buf.String())
+		case "local-path":
+			form.Local.Path = strings.TrimSpace(buf.String())
+		case "site":
+			form.Site = strings.TrimSpace(buf.String())
+		case "group":
+			form.Group = strings.TrimSpace(buf.String())
+		case "tags":
+			form.Tags = storage.ParseTags(buf.String())
 		case "edit-id":
 			editId, err := strconv.ParseUint(buf.String(), 10, 32)
 			if err != nil {
@@ -109,3 +452,378 @@ func ParseForm(formReader *multipart.Reader) (*Form, error) {
 
 	return &form, nil
 }
+
+type ImportForm struct {
+	Filename string
+	File     []byte
+	Content  string
+	Format   string
+	Apply    bool
+}
+
+func ParseImportForm(formReader *multipart.Reader) (*ImportForm, error) {
+	form := ImportForm{}
+
+	for {
+		part, err := formReader.NextPart()
+		if err != nil {
+			break
+		}
+		defer func() {
+			if err := part.Close(); err != nil {
+				slog.Error("cannot close part", slog.Any("error", err))
+			}
+		}()
+
+		buf := new(bytes.Buffer)
+		if _, err = buf.ReadFrom(part); err != nil {
+			return &ImportForm{}, err
+		}
+
+		switch part.FormName() {
+		case "file":
+			form.Filename = part.FileName()
+			form.File = buf.Bytes()
+		case "content":
+			form.Content = buf.String()
+		case "format":
+			form.Format = buf.String()
+		case "apply":
+			form.Apply = buf.String() == "on"
+		default:
+			slog.Warn("unknown form field, skipping", slog.Any("formName", part.FormName()), slog.Any("fileName", part.FileName()))
+		}
+	}
+
+	return &form, nil
+}
+
+type CredentialForm struct {
+	Name       string
+	Kind       string
+	Login      string
+	Password   string
+	Key        []byte
+	Passphrase string
+	// Certificate is an OpenSSH user certificate of Key.
+	Certificate []byte
+
+	EditId uint
+}
+
+func (f *CredentialForm) Validate() error {
+	if f.Name == "" || f.Login == "" {
+		return errors.New("empty fields")
+	}
+
+	if !validTag(f.Name) {
+		f.Name = ""
+		return errors.New("wrong name")
+	}
+
+	if !storage.ValidCredentialKind(f.Kind) {
+		f.Kind = storage.CredentialPassword
+		return errors.New("wrong credential type")
+	}
+
+	if !validLogin(f.Login) {
+		f.Login = ""
+		return errors.New("wrong login")
+	}
+
+	// Secrets may be left empty when editing to keep the stored ones.
+	if f.EditId == 0 {
+		if f.Kind == storage.CredentialPassword && f.Password == "" {
+			return errors.New("password is required")
+		}
+		if f.Kind == storage.CredentialKey && len(f.Key) == 0 {
+			return errors.New("key is required")
+		}
+	}
+
+	if len(f.Key) > 20480 {
+		f.Key = nil
+		return errors.New("wrong key size")
+	}
+
+	if len(f.Certificate) > 20480 {
+		f.Certificate = nil
+		return errors.New("wrong certificate size")
+	}
+
+	return nil
+}
+
+func ParseCredentialForm(formReader *multipart.Reader) (*CredentialForm, error) {
+	form := CredentialForm{}
+
+	for {
+		part, err := formReader.NextPart()
+		if err != nil {
+			break
+		}
+		defer func() {
+			if err := part.Close(); err != nil {
+				slog.Error("cannot close part", slog.Any("error", err))
+			}
+		}()
+
+		buf := new(bytes.Buffer)
+		if _, err = buf.ReadFrom(part); err != nil {
+			return &CredentialForm{}, err
+		}
+
+		switch part.FormName() {
+		case "name":
+			form.Name = strings.TrimSpace(buf.String())
+		case "kind":
+			form.Kind = buf.String()
+		case "login":
+			form.Login = buf.String()
+		case "password":
+			form.Password = buf.String()
+		case "key":
+			form.Key = buf.Bytes()
+		case "passphrase":
+			form.Passphrase = buf.String()
+		case "certificate":
+			form.Certificate = buf.Bytes()
+		case "edit-id":
+			editId, err := strconv.ParseUint(buf.String(), 10, 32)
+			if err != nil {
+				return &CredentialForm{}, err
+			}
+			form.EditId = uint(editId)
+		default:
+			slog.Warn("unknown form field, skipping", slog.Any("formName", part.FormName()), slog.Any("fileName", part.FileName()))
+		}
+	}
+
+	return &form, nil
+}
+
+type JumpHostForm struct {
+	Name         string
+	Address      string
+	Port         int
+	CredentialID uint
+	ViaID        uint
+
+	EditId uint
+}
+
+func (f *JumpHostForm) Validate() error {
+	if f.Name == "" || f.Address == "" {
+		return errors.New("empty fields")
+	}
+
+	if !validTag(f.Name) {
+		f.Name = ""
+		return errors.New("wrong name")
+	}
+
+	if net.ParseIP(f.Address) == nil && !validHost(f.Address) {
+		f.Address = ""
+		return errors.New("wrong address")
+	}
+
+	if f.Port == 0 {
+		f.Port = int(storage.DefaultPort)
+	}
+
+	if f.Port < 1 || f.Port > 65535 {
+		f.Port = int(storage.DefaultPort)
+		return errors.New("wrong port")
+	}
+
+	if f.CredentialID == 0 {
+		return errors.New("credential profile is required")
+	}
+
+	if f.ViaID != 0 && f.ViaID == f.EditId {
+		f.ViaID = 0
+		return errors.New("jump host cannot be reached through itself")
+	}
+
+	return nil
+}
+
+type LinkForm struct {
+	Name       string
+	ADeviceID  uint
+	AInterface string
+	BDeviceID  uint
+	BInterface string
+	AlarmDelta float64
+
+	EditId uint
+}
+
+func (f *LinkForm) Validate() error {
+	if f.Name == "" || f.AInterface == "" || f.BInterface == "" {
+		return errors.New("empty fields")
+	}
+
+	if !validTag(f.Name) {
+		f.Name = ""
+		return errors.New("wrong name")
+	}
+
+	if f.ADeviceID == 0 || f.BDeviceID == 0 {
+		return errors.New("devices of both ends are required")
+	}
+
+	if !validInterface(f.AInterface) || !validInterface(f.BInterface) {
+		return errors.New("wrong interface")
+	}
+
+	if f.ADeviceID == f.BDeviceID && f.AInterface == f.BInterface {
+		return errors.New("link cannot loop back to the same interface")
+	}
+
+	if f.AlarmDelta == 0 {
+		f.AlarmDelta = storage.DefaultLinkAlarmDelta
+	}
+
+	if f.AlarmDelta < 0 {
+		f.AlarmDelta = storage.DefaultLinkAlarmDelta
+		return errors.New("wrong alarm delta")
+	}
+
+	return nil
+}
+
+type RuleForm struct {
+	Name          string
+	Enabled       bool
+	Metric        string
+	Comparison    string
+	Threshold     float64
+	Hysteresis    float64
+	WindowMinutes int
+	ForPolls      int
+	ForSeconds    int
+	Site          string
+	Group         string
+	Tag           string
+	DeviceID      uint
+	Interface     string
+
+	EditId uint
+}
+
+func (f *RuleForm) Validate() error {
+	if f.Name == "" || f.Metric == "" {
+		return errors.New("empty fields")
+	}
+
+	if !validTag(f.Name) {
+		f.Name = ""
+		return errors.New("wrong name")
+	}
+
+	if res, err := regexp.MatchString(MetricPattern, f.Metric); err != nil || !res {
+		f.Metric = ""
+		return errors.New("wrong metric")
+	}
+
+	if !storage.ValidComparison(f.Comparison) {
+		return errors.New("wrong comparison")
+	}
+
+	rate := f.Comparison == storage.ComparisonRises || f.Comparison == storage.ComparisonFalls
+	if rate && f.WindowMinutes <= 0 || f.WindowMinutes < 0 {
+		return errors.New("rises and falls need a window")
+	}
+
+	if f.Hysteresis < 0 {
+		return errors.New("wrong hysteresis")
+	}
+
+	if f.ForPolls == 0 {
+		f.ForPolls = 1
+	}
+
+	if f.ForPolls < 0 || f.ForSeconds < 0 {
+		return errors.New("wrong duration")
+	}
+
+	for _, scope := range []string{f.Site, f.Group, f.Tag} {
+		if scope != "" && !validTag(scope) {
+			return errors.New("wrong scope")
+		}
+	}
+
+	if f.Interface != "" && !validInterface(f.Interface) {
+		return errors.New("wrong interface")
+	}
+
+	return nil
+}
+
+// maxMaintenance is the longest maintenance window, in minutes.
+const maxMaintenance = 30 * 24 * 60
+
+type MaintenanceForm struct {
+	Name            string
+	Site            string
+	Group           string
+	DeviceID        uint
+	Starts          string
+	DurationMinutes int
+	Schedule        string
+	SkipPolling     bool
+
+	// StartsAt is Starts parsed by Validate.
+	StartsAt time.Time
+	EditId   uint
+}
+
+func (f *MaintenanceForm) Validate() error {
+	if f.Name == "" || f.Starts == "" {
+		return errors.New("empty fields")
+	}
+
+	if !validTag(f.Name) {
+		f.Name = ""
+		return errors.New("wrong name")
+	}
+
+	if f.Site == "" && f.Group == "" && f.DeviceID == 0 {
+		return errors.New("site, group or device is required")
+	}
+
+	if f.Site != "" && !validTag(f.Site) || f.Group != "" && !validTag(f.Group) {
+		return errors.New("wrong scope")
+	}
+
+	starts, err := time.ParseInLocation(StartsLayout, f.Starts, time.Local)
+	if err != nil {
+		f.Starts = ""
+		return errors.New("wrong start")
+	}
+	f.StartsAt = starts
+
+	if f.DurationMinutes <= 0 || f.DurationMinutes > maxMaintenance {
+		return errors.New("wrong duration")
+	}
+
+	f.Schedule = strings.Join(strings.Fields(f.Schedule), " ")
+	if f.Schedule != "" {
+		if _, err := storage.ParseSchedule(f.Schedule); err != nil {
+			return fmt.Errorf("wrong schedule: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func validInterface(name string) bool {
+	res, err := regexp.MatchString(InterfacePattern, name)
+	return err == nil && res
+}
+
+func validHost(host string) bool {
+	res, err := regexp.MatchString(HostPattern, host)
+	return err == nil && res && len(host) <= 253
+}
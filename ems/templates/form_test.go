@@ -3,6 +3,8 @@ package templates
 import (
 	"errors"
 	"testing"
+
+	"pi-wegrzyn/ems/storage"
 )
 
 func TestForm_Validate(t *testing.T) {
@@ -38,6 +40,16 @@ func TestForm_Validate(t *testing.T) {
 			},
 			err: errors.New("empty fields"),
 		},
+		{
+			name: "login from credential profile",
+			form: Form{
+				Hostname:     "hostname",
+				Ip:           "127.0.0.1",
+				IPType:       4,
+				CredentialID: 1,
+			},
+			err: nil,
+		},
 		{
 			name: "wrong login",
 			form: Form{
@@ -58,6 +70,39 @@ func TestForm_Validate(t *testing.T) {
 			},
 			err: errors.New("wrong IP address"),
 		},
+		{
+			name: "wrong port",
+			form: Form{
+				Hostname: "hostname",
+				Ip:       "127.0.0.1",
+				Login:    "login",
+				IPType:   4,
+				Port:     65536,
+			},
+			err: errors.New("wrong port"),
+		},
+		{
+			name: "wrong site",
+			form: Form{
+				Hostname: "hostname",
+				Ip:       "127.0.0.1",
+				Login:    "login",
+				IPType:   4,
+				Site:     "-waw",
+			},
+			err: errors.New("wrong site"),
+		},
+		{
+			name: "wrong tag",
+			form: Form{
+				Hostname: "hostname",
+				Ip:       "127.0.0.1",
+				Login:    "login",
+				IPType:   4,
+				Tags:     []string{"core", "site waw"},
+			},
+			err: errors.New("wrong tag"),
+		},
 		{
 			name: "wrong key size",
 			form: Form{
@@ -69,6 +114,159 @@ func TestForm_Validate(t *testing.T) {
 			},
 			err: errors.New("wrong key size"),
 		},
+		{
+			name: "SNMP without login",
+			form: Form{
+				Hostname:  "hostname",
+				Ip:        "127.0.0.1",
+				IPType:    4,
+				Collector: "snmp",
+				SNMP:      storage.SNMP{Community: "public"},
+			},
+			err: nil,
+		},
+		{
+			name: "wrong collector",
+			form: Form{
+				Hostname:  "hostname",
+				Ip:        "127.0.0.1",
+				Login:     "login",
+				IPType:    4,
+				Collector: "telnet",
+			},
+			err: errors.New("wrong collector"),
+		},
+		{
+			name: "SNMP without community",
+			form: Form{
+				Hostname:  "hostname",
+				Ip:        "127.0.0.1",
+				IPType:    4,
+				Collector: "snmp",
+			},
+			err: errors.New("SNMP community is required"),
+		},
+		{
+			name: "SNMPv3 privacy without authentication",
+			form: Form{
+				Hostname:  "hostname",
+				Ip:        "127.0.0.1",
+				IPType:    4,
+				Collector: "snmp",
+				SNMP:      storage.SNMP{Version: "3", User: "noc", PrivProtocol: "AES", PrivPassword: "secret12"},
+			},
+			err: errors.New("SNMP privacy requires authentication"),
+		},
+		{
+			name: "SNMPv3 edit keeps passwords",
+			form: Form{
+				Hostname:  "hostname",
+				Ip:        "127.0.0.1",
+				IPType:    4,
+				Collector: "snmp",
+				SNMP:      storage.SNMP{Version: "3", User: "noc", AuthProtocol: "SHA256", PrivProtocol: "AES"},
+				EditId:    1,
+			},
+			err: nil,
+		}, {
+			name: "gNMI without login",
+			form: Form{
+				Hostname:  "hostname",
+				Ip:        "127.0.0.1",
+				IPType:    4,
+				Collector: "gnmi",
+			},
+			err: nil,
+		},
+		{
+			name: "wrong gNMI mode",
+			form: Form{
+				Hostname:  "hostname",
+				Ip:        "127.0.0.1",
+				IPType:    4,
+				Collector: "gnmi",
+				GNMI:      storage.GNMI{Mode: "poll"},
+			},
+			err: errors.New("wrong gNMI mode"),
+		},
+		{
+			name: "gNMI skip verify without TLS",
+			form: Form{
+				Hostname:  "hostname",
+				Ip:        "127.0.0.1",
+				IPType:    4,
+				Collector: "gnmi",
+				GNMI:      storage.GNMI{SkipVerify: true},
+			},
+			err: errors.New("gNMI certificate verification can only be skipped with TLS"),
+		},
+		{
+			name: "NETCONF without login",
+			form: Form{
+				Hostname:  "hostname",
+				Ip:        "127.0.0.1",
+				IPType:    4,
+				Collector: "netconf",
+			},
+			err: errors.New("empty fields"),
+		},
+		{
+			name: "NETCONF with custom filter",
+			form: Form{
+				Hostname:  "hostname",
+				Ip:        "127.0.0.1",
+				Login:     "login",
+				IPType:    4,
+				Collector: "netconf",
+				NETCONF:   storage.NETCONF{Platform: "eos-custom", Filter: `<state xmlns="urn:example"><port/></state>`},
+			},
+			err: nil,
+		},
+		{
+			name: "NETCONF with malformed filter",
+			form: Form{
+				Hostname:  "hostname",
+				Ip:        "127.0.0.1",
+				Login:     "login",
+				IPType:    4,
+				Collector: "netconf",
+				NETCONF:   storage.NETCONF{Filter: `<state><port></state>`},
+			},
+			err: errors.New("wrong NETCONF filter"),
+		},
+		{
+			name: "local without login",
+			form: Form{
+				Hostname:  "hostname",
+				Ip:        "127.0.0.1",
+				IPType:    4,
+				Collector: "local",
+				Local:     storage.Local{Interfaces: []string{"eth0", "enp1s0f1"}},
+			},
+			err: nil,
+		},
+		{
+			name: "local with relative path",
+			form: Form{
+				Hostname:  "hostname",
+				Ip:        "127.0.0.1",
+				IPType:    4,
+				Collector: "local",
+				Local:     storage.Local{Path: "dumps"},
+			},
+			err: errors.New("wrong local EEPROM path"),
+		},
+		{
+			name: "local with wrong interface",
+			form: Form{
+				Hostname:  "hostname",
+				Ip:        "127.0.0.1",
+				IPType:    4,
+				Collector: "local",
+				Local:     storage.Local{Interfaces: []string{"../eth0"}},
+			},
+			err: errors.New("wrong local interface"),
+		},
 	}
 
 	for _, tc := range tcs {
@@ -89,3 +287,307 @@ func TestForm_Validate(t *testing.T) {
 		})
 	}
 }
+
+func TestCredentialForm_Validate(t *testing.T) {
+	tcs := []struct {
+		name string
+		form CredentialForm
+		err  error
+	}{
+		{
+			name: "valid password profile",
+			form: CredentialForm{Name: "noc", Kind: "password", Login: "noc", Password: "secret"},
+			err:  nil,
+		},
+		{
+			name: "valid agent profile",
+			form: CredentialForm{Name: "noc-agent", Kind: "agent", Login: "noc"},
+			err:  nil,
+		},
+		{
+			name: "edit keeps stored key",
+			form: CredentialForm{Name: "noc-key", Kind: "key", Login: "noc", EditId: 1},
+			err:  nil,
+		},
+		{
+			name: "empty fields",
+			form: CredentialForm{Kind: "password"},
+			err:  errors.New("empty fields"),
+		},
+		{
+			name: "wrong kind",
+			form: CredentialForm{Name: "noc", Kind: "token", Login: "noc"},
+			err:  errors.New("wrong credential type"),
+		},
+		{
+			name: "missing key",
+			form: CredentialForm{Name: "noc", Kind: "key", Login: "noc"},
+			err:  errors.New("key is required"),
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.form.Validate()
+
+			if err == nil {
+				if tc.err != nil {
+					t.Errorf("expected error %v, got nil", tc.err)
+				}
+
+				return
+			}
+
+			if tc.err == nil || err.Error() != tc.err.Error() {
+				t.Errorf("expected error %v, got %v", tc.err, err)
+			}
+		})
+	}
+}
+
+func TestJumpHostForm_Validate(t *testing.T) {
+	tcs := []struct {
+		name string
+		form JumpHostForm
+		err  error
+	}{
+		{
+			name: "valid address",
+			form: JumpHostForm{Name: "bastion", Address: "10.0.0.1", CredentialID: 1},
+			err:  nil,
+		},
+		{
+			name: "valid hostname",
+			form: JumpHostForm{Name: "bastion", Address: "bastion.dc1.example.net", Port: 2222, CredentialID: 1, ViaID: 2},
+			err:  nil,
+		},
+		{
+			name: "empty fields",
+			form: JumpHostForm{CredentialID: 1},
+			err:  errors.New("empty fields"),
+		},
+		{
+			name: "wrong address",
+			form: JumpHostForm{Name: "bastion", Address: "bastion_1;", CredentialID: 1},
+			err:  errors.New("wrong address"),
+		},
+		{
+			name: "wrong port",
+			form: JumpHostForm{Name: "bastion", Address: "10.0.0.1", Port: 70000, CredentialID: 1},
+			err:  errors.New("wrong port"),
+		},
+		{
+			name: "missing profile",
+			form: JumpHostForm{Name: "bastion", Address: "10.0.0.1"},
+			err:  errors.New("credential profile is required"),
+		},
+		{
+			name: "through itself",
+			form: JumpHostForm{Name: "bastion", Address: "10.0.0.1", CredentialID: 1, ViaID: 3, EditId: 3},
+			err:  errors.New("jump host cannot be reached through itself"),
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.form.Validate()
+
+			if err == nil {
+				if tc.err != nil {
+					t.Errorf("expected error %v, got nil", tc.err)
+				}
+
+				return
+			}
+
+			if tc.err == nil || err.Error() != tc.err.Error() {
+				t.Errorf("expected error %v, got %v", tc.err, err)
+			}
+		})
+	}
+}
+
+func TestLinkForm_Validate(t *testing.T) {
+	tcs := []struct {
+		name string
+		form LinkForm
+		err  error
+	}{
+		{
+			name: "valid with default alarm delta",
+			form: LinkForm{Name: "a-b", ADeviceID: 1, AInterface: "eth1", BDeviceID: 2, BInterface: "eth1"},
+		},
+		{
+			name: "valid loopback on another interface",
+			form: LinkForm{Name: "loop", ADeviceID: 1, AInterface: "eth1", BDeviceID: 1, BInterface: "eth2", AlarmDelta: 1.5},
+		},
+		{
+			name: "empty fields",
+			form: LinkForm{ADeviceID: 1, BDeviceID: 2},
+			err:  errors.New("empty fields"),
+		},
+		{
+			name: "missing device",
+			form: LinkForm{Name: "a-b", ADeviceID: 1, AInterface: "eth1", BInterface: "eth1"},
+			err:  errors.New("devices of both ends are required"),
+		},
+		{
+			name: "wrong interface",
+			form: LinkForm{Name: "a-b", ADeviceID: 1, AInterface: "eth1;", BDeviceID: 2, BInterface: "eth1"},
+			err:  errors.New("wrong interface"),
+		},
+		{
+			name: "same interface",
+			form: LinkForm{Name: "a-b", ADeviceID: 1, AInterface: "eth1", BDeviceID: 1, BInterface: "eth1"},
+			err:  errors.New("link cannot loop back to the same interface"),
+		},
+		{
+			name: "negative alarm delta",
+			form: LinkForm{Name: "a-b", ADeviceID: 1, AInterface: "eth1", BDeviceID: 2, BInterface: "eth1", AlarmDelta: -1},
+			err:  errors.New("wrong alarm delta"),
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.form.Validate()
+
+			if err == nil {
+				if tc.err != nil {
+					t.Errorf("expected error %v, got nil", tc.err)
+				}
+
+				return
+			}
+
+			if tc.err == nil || err.Error() != tc.err.Error() {
+				t.Errorf("expected error %v, got %v", tc.err, err)
+			}
+		})
+	}
+}
+
+func TestRuleForm_Validate(t *testing.T) {
+	tcs := []struct {
+		name string
+		form RuleForm
+		err  error
+	}{
+		{
+			name: "valid below with default polls",
+			form: RuleForm{Name: "rx-low", Metric: "rx_pwr", Comparison: storage.ComparisonBelow, Threshold: -18},
+		},
+		{
+			name: "valid rises scoped to interface",
+			form: RuleForm{Name: "temp-jump", Metric: "temp", Comparison: storage.ComparisonRises, Threshold: 5, WindowMinutes: 10, Site: "dc1", Interface: "1/1/c1/1"},
+		},
+		{
+			name: "empty fields",
+			form: RuleForm{Comparison: storage.ComparisonBelow},
+			err:  errors.New("empty fields"),
+		},
+		{
+			name: "wrong metric",
+			form: RuleForm{Name: "rx-low", Metric: "rx pwr", Comparison: storage.ComparisonBelow},
+			err:  errors.New("wrong metric"),
+		},
+		{
+			name: "wrong comparison",
+			form: RuleForm{Name: "rx-low", Metric: "rx_pwr", Comparison: "equals"},
+			err:  errors.New("wrong comparison"),
+		},
+		{
+			name: "rises without window",
+			form: RuleForm{Name: "temp-jump", Metric: "temp", Comparison: storage.ComparisonRises, Threshold: 5},
+			err:  errors.New("rises and falls need a window"),
+		},
+		{
+			name: "negative hysteresis",
+			form: RuleForm{Name: "rx-low", Metric: "rx_pwr", Comparison: storage.ComparisonBelow, Hysteresis: -1},
+			err:  errors.New("wrong hysteresis"),
+		},
+		{
+			name: "wrong scope",
+			form: RuleForm{Name: "rx-low", Metric: "rx_pwr", Comparison: storage.ComparisonBelow, Tag: "core tag"},
+			err:  errors.New("wrong scope"),
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.form.Validate()
+
+			if err == nil {
+				if tc.err != nil {
+					t.Errorf("expected error %v, got nil", tc.err)
+				}
+
+				return
+			}
+
+			if tc.err == nil || err.Error() != tc.err.Error() {
+				t.Errorf("expected error %v, got %v", tc.err, err)
+			}
+		})
+	}
+}
+
+func TestMaintenanceForm_Validate(t *testing.T) {
+	tcs := []struct {
+		name string
+		form MaintenanceForm
+		err  error
+	}{
+		{
+			name: "valid one-off of device",
+			form: MaintenanceForm{Name: "fiber-repair", DeviceID: 1, Starts: "2026-10-19T22:00", DurationMinutes: 90, SkipPolling: true},
+		},
+		{
+			name: "valid recurring of site",
+			form: MaintenanceForm{Name: "waw-nightly", Site: "WAW", Starts: "2026-10-19T00:00", DurationMinutes: 60, Schedule: " 0 2  * * 0 "},
+		},
+		{
+			name: "empty fields",
+			form: MaintenanceForm{Site: "WAW"},
+			err:  errors.New("empty fields"),
+		},
+		{
+			name: "no scope",
+			form: MaintenanceForm{Name: "all", Starts: "2026-10-19T22:00", DurationMinutes: 60},
+			err:  errors.New("site, group or device is required"),
+		},
+		{
+			name: "wrong start",
+			form: MaintenanceForm{Name: "waw", Site: "WAW", Starts: "19.10.2026 22:00", DurationMinutes: 60},
+			err:  errors.New("wrong start"),
+		},
+		{
+			name: "wrong duration",
+			form: MaintenanceForm{Name: "waw", Site: "WAW", Starts: "2026-10-19T22:00"},
+			err:  errors.New("wrong duration"),
+		},
+		{
+			name: "wrong schedule",
+			form: MaintenanceForm{Name: "waw", Site: "WAW", Starts: "2026-10-19T22:00", DurationMinutes: 60, Schedule: "0 25 * * *"},
+			err:  errors.New(`wrong schedule: wrong hour "25": out of range 0-23`),
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.form.Validate()
+
+			if err == nil {
+				if tc.err != nil {
+					t.Errorf("expected error %v, got nil", tc.err)
+				}
+
+				return
+			}
+
+			if tc.err == nil || err.Error() != tc.err.Error() {
+				t.Errorf("expected error %v, got %v", tc.err, err)
+			}
+		})
+	}
+}
@@ -1,6 +1,17 @@
 package templates
 
-import "pi-wegrzyn/ems/storage"
+import (
+	"fmt"
+	"math"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"pi-wegrzyn/ems/monitor"
+	"pi-wegrzyn/ems/storage"
+)
 
 const (
 	IPv4Pattern string = `^((25[0-5]|(2[0-4]|1{0,1}[0-9]){0,1}[0-9])\.){3,3}(25[0-5]|(2[0-4]|1{0,1}[0-9]){0,1}[0-9])$`
@@ -10,25 +21,308 @@ const (
 	EditAction = "Edit"
 )
 
-type SignIn = string
+type SignIn struct {
+	ErrorMessage string
+	SSOEnabled   bool
+}
+
+// Statuses are offered in the dashboard filter, worst last.
+var Statuses = []string{
+	storage.StatusNames[storage.StatusOK],
+	storage.StatusNames[storage.StatusUndefined],
+	storage.StatusNames[storage.StatusMaintenance],
+	storage.StatusNames[storage.StatusWarning],
+	storage.StatusNames[storage.StatusErrorSSH],
+	storage.StatusNames[storage.StatusErrorCollector],
+	storage.StatusNames[storage.StatusErrorKeyfile],
+}
+
+type Index struct {
+	Devices []storage.Device
+	// Interfaces are states of interfaces by device ID.
+	Interfaces map[uint][]InterfaceState
+	// Badges are anomalies, trends and alerts of interfaces by device ID.
+	Badges   map[uint][]Badge
+	Query    storage.DeviceQuery
+	Total    int
+	Pages    int
+	Sites    []string
+	Groups   []string
+	Tags     []string
+	Statuses []string
+}
+
+// IndexPageContent lists the requested page of devices. Filter options are
+// collected from all devices, not only the matching ones.
+func IndexPageContent(devices []storage.Device, interfaces []storage.Interface, query storage.DeviceQuery) Index {
+	var sites, groups, tags []string
+	for _, d := range devices {
+		sites = appendUnique(sites, d.Site)
+		groups = appendUnique(groups, d.Group)
+		for _, t := range d.Tags {
+			tags = appendUnique(tags, t)
+		}
+	}
+	slices.Sort(sites)
+	slices.Sort(groups)
+	slices.Sort(tags)
+
+	page, total := query.Apply(devices)
+
+	// Interfaces of modules not read from EEPROM have no state to show.
+	byDevice := make(map[uint][]InterfaceState)
+	for _, i := range interfaces {
+		if i.Frequency == 0 && i.ModuleState == 0 {
+			continue
+		}
+
+		byDevice[i.DeviceID] = append(byDevice[i.DeviceID], interfaceState(i))
+	}
+
+	return Index{
+		Devices:    page,
+		Interfaces: byDevice,
+		Query:      query,
+		Total:      total,
+		Pages:      query.Pages(total),
+		Sites:      sites,
+		Groups:     groups,
+		Tags:       tags,
+		Statuses:   Statuses,
+	}
+}
+
+// InterfaceState names CMIS states of an interface. Data paths lists states
+// of used host lanes, lanes in the same state are grouped.
+type InterfaceState struct {
+	storage.Interface
+	Module    string
+	DataPaths string
+	Fault     bool
+}
+
+func interfaceState(i storage.Interface) InterfaceState {
+	state := InterfaceState{Interface: i, Fault: monitor.ModuleState(i.ModuleState) == monitor.ModuleFault}
+	if i.ModuleState != 0 {
+		state.Module = monitor.ModuleState(i.ModuleState).String()
+	}
+
+	var groups []string
+	for lane := 0; lane < len(i.DataPathStates); {
+		dp := i.DataPathStates[lane]
+		if dp == 0 {
+			lane++
+			continue
+		}
+
+		first := lane
+		for lane < len(i.DataPathStates) && i.DataPathStates[lane] == dp {
+			lane++
+		}
+
+		lanes := fmt.Sprintf("lane %d", first+1)
+		if lane-first > 1 {
+			lanes = fmt.Sprintf("lanes %d-%d", first+1, lane)
+		}
+		groups = append(groups, fmt.Sprintf("%s %s", lanes, monitor.DataPathState(dp)))
+	}
+	state.DataPaths = strings.Join(groups, ", ")
+
+	return state
+}
+
+// Badge is an anomaly or trend of an interface reading shown on the
+// dashboard.
+type Badge struct {
+	Text string
+	// Title explains the badge on hover.
+	Title string
+}
+
+// WithTrends sets badges of steps, drifts and threshold forecasts.
+func (i Index) WithTrends(trends []storage.Trend) Index {
+	i.Badges = make(map[uint][]Badge)
+	for _, t := range trends {
+		if t.Step {
+			i.Badges[t.DeviceID] = append(i.Badges[t.DeviceID], Badge{
+				Text:  fmt.Sprintf("%s %s STEP", t.Interface, t.Metric),
+				Title: fmt.Sprintf("stepped away from the baseline of %.2f", t.Mean),
+			})
+		}
+		if t.Drifting {
+			i.Badges[t.DeviceID] = append(i.Badges[t.DeviceID], Badge{
+				Text:  fmt.Sprintf("%s %s DRIFT %+.2f dB/WEEK", t.Interface, t.Metric, t.Slope*7),
+				Title: "slow drift of the reading",
+			})
+		}
+		if t.ForecastDays != nil {
+			i.Badges[t.DeviceID] = append(i.Badges[t.DeviceID], Badge{
+				Text:  fmt.Sprintf("%s %s ALARM IN %.0f DAYS", t.Interface, t.Metric, math.Ceil(*t.ForecastDays)),
+				Title: "low alarm threshold reached at the current drift",
+			})
+		}
+	}
+
+	return i
+}
+
+// WithAlerts adds badges of alerts firing on rules that are not silenced at
+// now.
+func (i Index) WithAlerts(rules []storage.Rule, alerts []storage.Alert, now time.Time) Index {
+	byID := make(map[uint]storage.Rule, len(rules))
+	for _, r := range rules {
+		byID[r.ID] = r
+	}
+
+	if i.Badges == nil {
+		i.Badges = make(map[uint][]Badge)
+	}
+	for _, a := range alerts {
+		rule, ok := byID[a.RuleID]
+		if !ok || !a.Firing || rule.Silenced(now) {
+			continue
+		}
+
+		i.Badges[a.DeviceID] = append(i.Badges[a.DeviceID], Badge{
+			Text:  fmt.Sprintf("%s %s ALERT", a.Interface, rule.Name),
+			Title: fmt.Sprintf("%s %s %g, last %.2f", rule.Metric, rule.Comparison, rule.Threshold, a.Value),
+		})
+	}
+
+	return i
+}
+
+// WithMaintenance adds badges of maintenance windows open at now.
+func (i Index) WithMaintenance(windows []storage.MaintenanceWindow, now time.Time) Index {
+	if i.Badges == nil {
+		i.Badges = make(map[uint][]Badge)
+	}
+	for _, d := range i.Devices {
+		w, ok := storage.Maintenance(windows, d, now)
+		if !ok {
+			continue
+		}
+
+		title := "alerts suppressed, availability not counted"
+		if w.SkipPolling {
+			title += ", not polled"
+		}
+		i.Badges[d.ID] = append(i.Badges[d.ID], Badge{Text: "MAINTENANCE " + w.Name, Title: title})
+	}
+
+	return i
+}
+
+// Link returns the dashboard URL with the current query, overridden by the
+// given key-value pairs.
+func (i Index) Link(pairs ...any) string {
+	query := url.Values{}
+	set := func(key, value string) {
+		if value != "" {
+			query.Set(key, value)
+		}
+	}
 
-type Index = []storage.Device
+	set("q", i.Query.Search)
+	set("site", i.Query.Site)
+	set("group", i.Query.Group)
+	set("tag", i.Query.Tag)
+	set("status", i.Query.Status)
+	set("sort", i.Query.Sort)
+	if i.Query.Desc {
+		set("order", "desc")
+	}
+	if i.Query.Page > 1 {
+		set("page", strconv.Itoa(i.Query.Page))
+	}
+
+	for n := 0; n+1 < len(pairs); n += 2 {
+		key, value := fmt.Sprint(pairs[n]), fmt.Sprint(pairs[n+1])
+		query.Del(key)
+		set(key, value)
+	}
+
+	if len(query) == 0 {
+		return "/"
+	}
+
+	return "/?" + query.Encode()
+}
+
+// SortLink toggles the order when the dashboard is already sorted by field.
+func (i Index) SortLink(field string) string {
+	order := "asc"
+	if i.Query.Sort == field && !i.Query.Desc {
+		order = "desc"
+	}
+
+	return i.Link("sort", field, "order", order, "page", "")
+}
+
+func appendUnique(list []string, value string) []string {
+	if value == "" || slices.Contains(list, value) {
+		return list
+	}
+
+	return append(list, value)
+}
 
 type NewEdit struct {
 	Action       string
 	Device       storage.Device
 	IPVersion    int
 	IPPattern    string
+	Credentials  []storage.Credential
+	JumpHosts    []storage.JumpHost
 	ErrorMessage string
+
+	Collectors        []string
+	SNMPVersions      []string
+	SNMPAuthProtocols []string
+	SNMPPrivProtocols []string
+	GNMIModes         []string
+	NETCONFPlatforms  []string
+}
+
+// WithCredentials sets the profiles offered in the credential picker.
+func (n NewEdit) WithCredentials(credentials []storage.Credential) NewEdit {
+	n.Credentials = credentials
+
+	return n
+}
+
+// WithJumpHosts sets the jump hosts offered in the jump host picker.
+func (n NewEdit) WithJumpHosts(hosts []storage.JumpHost) NewEdit {
+	n.JumpHosts = hosts
+
+	return n
 }
 
 func NewPageContent() NewEdit {
 	return NewEdit{
-		Action:       NewAction,
-		Device:       storage.Device{},
+		Action: NewAction,
+		Device: storage.Device{
+			Port:      storage.DefaultPort,
+			Collector: storage.CollectorSSH,
+			SNMP:      storage.SNMP{Version: storage.SNMPVersion2c, Port: storage.DefaultSNMPPort},
+			GNMI: storage.GNMI{
+				Port:           storage.DefaultGNMIPort,
+				Mode:           storage.GNMIModeSample,
+				SampleInterval: storage.DefaultGNMISampleInterval,
+				TLS:            true,
+			},
+			NETCONF: storage.NETCONF{Port: storage.DefaultNETCONFPort, Platform: storage.NETCONFPlatformJunos},
+		},
 		IPVersion:    4,
 		IPPattern:    IPv4Pattern,
 		ErrorMessage: "",
+
+		Collectors:        storage.Collectors,
+		SNMPVersions:      storage.SNMPVersions,
+		SNMPAuthProtocols: storage.SNMPAuthProtocols,
+		SNMPPrivProtocols: storage.SNMPPrivProtocols,
+		GNMIModes:         storage.GNMIModes,
+		NETCONFPlatforms:  storage.NETCONFPlatforms,
 	}
 }
 
@@ -52,5 +346,441 @@ func EditPageContent(device storage.Device, errMsg string) NewEdit {
 		IPVersion:    ipVersion,
 		IPPattern:    pattern,
 		ErrorMessage: errMsg,
+
+		Collectors:        storage.Collectors,
+		SNMPVersions:      storage.SNMPVersions,
+		SNMPAuthProtocols: storage.SNMPAuthProtocols,
+		SNMPPrivProtocols: storage.SNMPPrivProtocols,
+		GNMIModes:         storage.GNMIModes,
+		NETCONFPlatforms:  storage.NETCONFPlatforms,
+	}
+}
+
+type Audit struct {
+	Filter      storage.AuditFilter
+	Entries     []storage.AuditEntry
+	Actions     []string
+	ExportQuery string
+}
+
+func AuditPageContent(filter storage.AuditFilter, entries []storage.AuditEntry) Audit {
+	query := url.Values{}
+	query.Set("actor", filter.Actor)
+	query.Set("action", filter.Action)
+	query.Set("target", filter.TargetName)
+
+	return Audit{
+		Filter:      filter,
+		Entries:     entries,
+		Actions:     []string{storage.AuditActionCreate, storage.AuditActionUpdate, storage.AuditActionDelete},
+		ExportQuery: query.Encode(),
+	}
+}
+
+type Events struct {
+	Filter  storage.EventFilter
+	Events  []storage.Event
+	Devices []storage.Device
+	Kinds   []string
+}
+
+func EventsPageContent(filter storage.EventFilter, events []storage.Event, devices []storage.Device) Events {
+	return Events{
+		Filter:  filter,
+		Events:  events,
+		Devices: devices,
+		Kinds: []string{storage.EventLaserFrequency, storage.EventModuleFault, storage.EventDataPathDeactivated,
+			storage.EventSpanDegraded, storage.EventSpanRecovered, storage.EventStepChange, storage.EventDrift, storage.EventThresholdForecast,
+			storage.EventAlert, storage.EventAlertCleared},
+	}
+}
+
+type ImportRow struct {
+	Line     int
+	Action   string
+	Hostname string
+	Changes  []storage.Change
+	Error    string
+}
+
+type Import struct {
+	Format       string
+	Content      string
+	Rows         []ImportRow
+	Valid        bool
+	ErrorMessage string
+}
+
+// ImportPageContent shows the dry-run result. Content is sent back with the
+// apply request, so that exactly the previewed file gets imported.
+func ImportPageContent(format string, content string, rows []ImportRow, errMsg string) Import {
+	valid := len(rows) != 0 && errMsg == ""
+	for _, r := range rows {
+		if r.Error != "" {
+			valid = false
+		}
+	}
+
+	return Import{
+		Format:       format,
+		Content:      content,
+		Rows:         rows,
+		Valid:        valid,
+		ErrorMessage: errMsg,
+	}
+}
+
+type Inspect struct {
+	Device       storage.Device
+	Interfaces   []string
+	Interface    string
+	Captured     time.Time
+	Pages        []monitor.InspectedPage
+	ErrorMessage string
+}
+
+// InspectPageContent decodes the latest capture of iface, or of the first
+// captured interface when iface is empty. Captures are expected by interface,
+// newest first.
+func InspectPageContent(device storage.Device, captures []storage.Capture, iface string) Inspect {
+	page := Inspect{Device: device, Interface: iface}
+
+	latest := make(map[string]storage.Capture)
+	for _, c := range captures {
+		if _, ok := latest[c.Interface]; !ok {
+			latest[c.Interface] = c
+			page.Interfaces = append(page.Interfaces, c.Interface)
+		}
+	}
+
+	if len(page.Interfaces) == 0 {
+		page.ErrorMessage = "NO EEPROM CAPTURED YET, IT IS READ OVER SSH OR FROM LOCAL MODULES WITH MONITOR_RAW_CAPTURES ABOVE 0"
+		return page
+	}
+	if page.Interface == "" {
+		page.Interface = page.Interfaces[0]
+	}
+
+	capture, ok := latest[page.Interface]
+	if !ok {
+		page.ErrorMessage = fmt.Sprintf("NO EEPROM CAPTURED FOR %s", page.Interface)
+		return page
+	}
+	page.Captured = capture.Created
+
+	pages, err := monitor.InspectDump(monitor.DefaultDecoder(), capture.Data)
+	if err != nil {
+		page.ErrorMessage = fmt.Sprintf("CANNOT DECODE EEPROM OF %s: %v", page.Interface, err)
+		return page
 	}
+	page.Pages = pages
+
+	return page
+}
+
+type CredentialProfile struct {
+	storage.Credential
+
+	Devices int
+}
+
+type Credentials struct {
+	Profiles     []CredentialProfile
+	Edit         storage.Credential
+	Kinds        []string
+	ErrorMessage string
+}
+
+// CredentialsPageContent lists profiles with the number of devices using
+// each one. Edit is the profile loaded into the form, zero value for a new one.
+func CredentialsPageContent(credentials []storage.Credential, devices []storage.Device, edit storage.Credential, errMsg string) Credentials {
+	usage := make(map[uint]int)
+	for _, d := range devices {
+		if d.CredentialID != 0 {
+			usage[d.CredentialID]++
+		}
+	}
+
+	profiles := make([]CredentialProfile, 0, len(credentials))
+	for _, c := range credentials {
+		profiles = append(profiles, CredentialProfile{Credential: c, Devices: usage[c.ID]})
+	}
+
+	if edit.Kind == "" {
+		edit.Kind = storage.CredentialPassword
+	}
+
+	return Credentials{
+		Profiles:     profiles,
+		Edit:         edit,
+		Kinds:        storage.CredentialKinds,
+		ErrorMessage: errMsg,
+	}
+}
+
+type JumpHostEntry struct {
+	storage.JumpHost
+
+	Profile string
+	Via     string
+	// Usage counts devices and jump hosts reached through this one.
+	Usage int
+}
+
+type JumpHosts struct {
+	Hosts        []JumpHostEntry
+	Edit         storage.JumpHost
+	Credentials  []storage.Credential
+	ErrorMessage string
+}
+
+// JumpHostsPageContent lists jump hosts with their profile, the jump host
+// they are reached through and their usage. Edit is the jump host loaded into
+// the form, zero value for a new one.
+func JumpHostsPageContent(hosts []storage.JumpHost, credentials []storage.Credential, devices []storage.Device, edit storage.JumpHost, errMsg string) JumpHosts {
+	usage := make(map[uint]int)
+	for _, d := range devices {
+		if d.JumpHostID != 0 {
+			usage[d.JumpHostID]++
+		}
+	}
+
+	names := make(map[uint]string)
+	for _, h := range hosts {
+		names[h.ID] = h.Name
+		if h.ViaID != 0 {
+			usage[h.ViaID]++
+		}
+	}
+
+	profiles := make(map[uint]string)
+	for _, c := range credentials {
+		profiles[c.ID] = c.Name
+	}
+
+	entries := make([]JumpHostEntry, 0, len(hosts))
+	for _, h := range hosts {
+		entries = append(entries, JumpHostEntry{
+			JumpHost: h,
+			Profile:  profiles[h.CredentialID],
+			Via:      names[h.ViaID],
+			Usage:    usage[h.ID],
+		})
+	}
+
+	if edit.Port == 0 {
+		edit.Port = storage.DefaultPort
+	}
+
+	return JumpHosts{
+		Hosts:        entries,
+		Edit:         edit,
+		Credentials:  credentials,
+		ErrorMessage: errMsg,
+	}
+}
+
+type LinkEntry struct {
+	storage.Link
+
+	AHost string
+	BHost string
+	// Span sums up the last reading, empty before the first one.
+	Span string
+}
+
+type Links struct {
+	Links        []LinkEntry
+	Edit         storage.Link
+	Devices      []storage.Device
+	ErrorMessage string
+}
+
+// LinksPageContent lists links with hostnames of their ends. Edit is the
+// link loaded into the form, zero value for a new one.
+func LinksPageContent(links []storage.Link, devices []storage.Device, edit storage.Link, errMsg string) Links {
+	hostnames := make(map[uint]string, len(devices))
+	for _, d := range devices {
+		hostnames[d.ID] = d.Hostname
+	}
+
+	entries := make([]LinkEntry, 0, len(links))
+	for _, l := range links {
+		entries = append(entries, LinkEntry{Link: l, AHost: hostnames[l.ADeviceID], BHost: hostnames[l.BDeviceID], Span: linkSpan(l)})
+	}
+
+	if edit.AlarmDelta == 0 {
+		edit.AlarmDelta = storage.DefaultLinkAlarmDelta
+	}
+
+	return Links{
+		Links:        entries,
+		Edit:         edit,
+		Devices:      devices,
+		ErrorMessage: errMsg,
+	}
+}
+
+func linkSpan(l storage.Link) string {
+	if l.Loss == nil {
+		return ""
+	}
+
+	span := fmt.Sprintf("loss %.2f dB", *l.Loss)
+	if l.Baseline != nil {
+		span += fmt.Sprintf(" (baseline %.2f dB)", *l.Baseline)
+	}
+	if l.Margin != nil {
+		span += fmt.Sprintf(", margin %.2f dB", *l.Margin)
+	}
+
+	return span
+}
+
+type RuleEntry struct {
+	storage.Rule
+
+	// Scope sums up what the rule matches, Silenced until when it is quiet.
+	Scope    string
+	Silenced string
+	// Firing lists interfaces the rule is firing on.
+	Firing []string
+}
+
+type Rules struct {
+	Rules        []RuleEntry
+	Edit         storage.Rule
+	Comparisons  []string
+	Devices      []storage.Device
+	ErrorMessage string
+}
+
+// RulesPageContent lists rules with interfaces they fire on. Edit is the rule
+// loaded into the form, zero value for a new one.
+func RulesPageContent(rules []storage.Rule, alerts []storage.Alert, devices []storage.Device, edit storage.Rule, errMsg string, now time.Time) Rules {
+	hostnames := make(map[uint]string, len(devices))
+	for _, d := range devices {
+		hostnames[d.ID] = d.Hostname
+	}
+
+	firing := make(map[uint][]string)
+	for _, a := range alerts {
+		if a.Firing {
+			firing[a.RuleID] = append(firing[a.RuleID], fmt.Sprintf("%s %s (%.2f)", hostnames[a.DeviceID], a.Interface, a.Value))
+		}
+	}
+
+	entries := make([]RuleEntry, 0, len(rules))
+	for _, r := range rules {
+		entry := RuleEntry{Rule: r, Scope: ruleScope(r, hostnames), Firing: firing[r.ID]}
+		if r.Silenced(now) {
+			entry.Silenced = r.SilencedUntil.Format(time.DateTime)
+		}
+		entries = append(entries, entry)
+	}
+
+	if edit.ID == 0 && edit.Name == "" {
+		edit.Enabled = true
+		edit.ForPolls = 1
+	}
+
+	return Rules{
+		Rules:        entries,
+		Edit:         edit,
+		Comparisons:  storage.Comparisons,
+		Devices:      devices,
+		ErrorMessage: errMsg,
+	}
+}
+
+func ruleScope(r storage.Rule, hostnames map[uint]string) string {
+	var scope []string
+	if r.Site != "" {
+		scope = append(scope, "site "+r.Site)
+	}
+	if r.Group != "" {
+		scope = append(scope, "group "+r.Group)
+	}
+	if r.Tag != "" {
+		scope = append(scope, "tag "+r.Tag)
+	}
+	if r.DeviceID != 0 {
+		scope = append(scope, "device "+hostnames[r.DeviceID])
+	}
+	if r.Interface != "" {
+		scope = append(scope, "interface "+r.Interface)
+	}
+	if len(scope) == 0 {
+		return "all interfaces"
+	}
+
+	return strings.Join(scope, ", ")
+}
+
+type MaintenanceEntry struct {
+	storage.MaintenanceWindow
+
+	Scope string
+	// Active tells whether the window is open, Next is its next start, empty
+	// when there is none.
+	Active bool
+	Next   string
+}
+
+type Maintenance struct {
+	Windows []MaintenanceEntry
+	Edit    storage.MaintenanceWindow
+	// Starts is the start of Edit in the layout of the form.
+	Starts       string
+	Devices      []storage.Device
+	ErrorMessage string
+}
+
+// MaintenancePageContent lists maintenance windows with their next starts.
+// Edit is the window loaded into the form, zero value for a new one starting
+// now.
+func MaintenancePageContent(windows []storage.MaintenanceWindow, devices []storage.Device, edit storage.MaintenanceWindow, errMsg string, now time.Time) Maintenance {
+	hostnames := make(map[uint]string, len(devices))
+	for _, d := range devices {
+		hostnames[d.ID] = d.Hostname
+	}
+
+	entries := make([]MaintenanceEntry, 0, len(windows))
+	for _, w := range windows {
+		entry := MaintenanceEntry{MaintenanceWindow: w, Scope: maintenanceScope(w, hostnames), Active: w.Active(now)}
+		if next, ok := w.NextStart(now); ok {
+			entry.Next = next.Local().Format(time.DateTime)
+		}
+		entries = append(entries, entry)
+	}
+
+	if edit.Starts.IsZero() {
+		edit.Starts = now.Truncate(time.Minute)
+	}
+	if edit.Duration == 0 {
+		edit.Duration = time.Hour
+	}
+
+	return Maintenance{
+		Windows:      entries,
+		Edit:         edit,
+		Starts:       edit.Starts.Local().Format(StartsLayout),
+		Devices:      devices,
+		ErrorMessage: errMsg,
+	}
+}
+
+func maintenanceScope(w storage.MaintenanceWindow, hostnames map[uint]string) string {
+	var scope []string
+	if w.Site != "" {
+		scope = append(scope, "site "+w.Site)
+	}
+	if w.Group != "" {
+		scope = append(scope, "group "+w.Group)
+	}
+	if w.DeviceID != 0 {
+		scope = append(scope, "device "+hostnames[w.DeviceID])
+	}
+
+	return strings.Join(scope, ", ")
 }
@@ -1,7 +1,9 @@
 package templates
 
 import (
+	"strings"
 	"testing"
+	"time"
 
 	"pi-wegrzyn/ems/storage"
 )
@@ -65,3 +67,159 @@ func TestNewPageContentWithError(t *testing.T) {
 		t.Errorf("ErrorMessage should be %s, but got %s", errMsg, newEdit.ErrorMessage)
 	}
 }
+
+func TestIndex_Link(t *testing.T) {
+	index := IndexPageContent(
+		[]storage.Device{{Hostname: "r1", Site: "WAW", Tags: []string{"core"}}},
+		nil,
+		storage.DeviceQuery{Site: "WAW", Sort: storage.SortStatus, Page: 1},
+	)
+
+	tcs := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{name: "keeps query", got: index.Link(), want: "/?site=WAW&sort=status"},
+		{name: "overrides value", got: index.Link("site", "KRK", "page", 2), want: "/?page=2&site=KRK&sort=status"},
+		{name: "removes empty value", got: index.Link("site", ""), want: "/?sort=status"},
+		{name: "toggles order", got: index.SortLink(storage.SortStatus), want: "/?order=desc&site=WAW&sort=status"},
+		{name: "sorts by new field ascending", got: index.SortLink(storage.SortHostname), want: "/?order=asc&site=WAW&sort=hostname"},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.got != tc.want {
+				t.Errorf("expected %s, got %s", tc.want, tc.got)
+			}
+		})
+	}
+}
+
+func TestIndexPageContent_interfaces(t *testing.T) {
+	index := IndexPageContent(
+		[]storage.Device{{ID: 1, Hostname: "r1"}, {ID: 2, Hostname: "r2"}},
+		[]storage.Interface{
+			{DeviceID: 1, Name: "eth0", Frequency: 193.1},
+			{DeviceID: 1, Name: "eth1"},
+			{DeviceID: 1, Name: "eth2", ModuleState: 5, DataPathStates: []uint8{4, 4, 3, 0, 1, 1, 1, 1}},
+			{DeviceID: 2, Name: "eth0"},
+		},
+		storage.DeviceQuery{Page: 1},
+	)
+
+	got := index.Interfaces[1]
+	if len(got) != 2 || got[0].Name != "eth0" || got[1].Name != "eth2" {
+		t.Fatalf("expected interfaces of r1 with a state, got %+v", got)
+	}
+	if got[0].Module != "" || got[0].Fault {
+		t.Errorf("expected no module state of eth0, got %+v", got[0])
+	}
+	if got[1].Module != "ModuleFault" || !got[1].Fault || got[1].DataPaths != "lanes 1-2 DPActivated, lane 3 DPDeinit, lanes 5-8 DPDeactivated" {
+		t.Errorf("unexpected states of eth2: %+v", got[1])
+	}
+	if got := index.Interfaces[2]; len(got) != 0 {
+		t.Errorf("expected no interfaces of r2, got %+v", got)
+	}
+}
+
+func TestIndex_WithTrends(t *testing.T) {
+	days := 12.3
+	index := IndexPageContent([]storage.Device{{ID: 1, Hostname: "r1"}}, nil, storage.DeviceQuery{Page: 1}).WithTrends([]storage.Trend{
+		{DeviceID: 1, Interface: "eth0", Metric: "rx_pwr", Mean: -11, Step: true},
+		{DeviceID: 1, Interface: "eth0", Metric: "tx_pwr", Slope: -0.12, Drifting: true, ForecastDays: &days},
+	})
+
+	var got []string
+	for _, b := range index.Badges[1] {
+		got = append(got, b.Text)
+	}
+	want := "eth0 rx_pwr STEP; eth0 tx_pwr DRIFT -0.84 dB/WEEK; eth0 tx_pwr ALARM IN 13 DAYS"
+	if strings.Join(got, "; ") != want {
+		t.Errorf("expected badges %q, got %q", want, strings.Join(got, "; "))
+	}
+}
+
+func TestIndex_WithAlerts(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	rules := []storage.Rule{
+		{ID: 1, Name: "rx-low", Metric: "rx_pwr", Comparison: storage.ComparisonBelow, Threshold: -18},
+		{ID: 2, Name: "hot", Metric: "temp", Comparison: storage.ComparisonAbove, Threshold: 70, SilencedUntil: now.Add(time.Hour)},
+	}
+	index := IndexPageContent([]storage.Device{{ID: 1, Hostname: "r1"}}, nil, storage.DeviceQuery{Page: 1}).WithAlerts(rules, []storage.Alert{
+		{RuleID: 1, DeviceID: 1, Interface: "eth0", Firing: true, Value: -19},
+		{RuleID: 1, DeviceID: 1, Interface: "eth1", Value: -19},
+		{RuleID: 2, DeviceID: 1, Interface: "eth0", Firing: true, Value: 75},
+	}, now)
+
+	if len(index.Badges[1]) != 1 || index.Badges[1][0].Text != "eth0 rx-low ALERT" {
+		t.Errorf("expected a badge of rx-low on eth0, got %v", index.Badges[1])
+	}
+}
+
+func TestIndex_WithMaintenance(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.Local)
+	devices := []storage.Device{{ID: 1, Hostname: "r1", Site: "WAW"}, {ID: 2, Hostname: "r2", Site: "KRK"}}
+	index := IndexPageContent(devices, nil, storage.DeviceQuery{Page: 1}).WithMaintenance([]storage.MaintenanceWindow{
+		{Name: "waw", Site: "WAW", Starts: now.Add(-time.Minute), Duration: time.Hour, SkipPolling: true},
+		{Name: "krk", Site: "KRK", Starts: now.Add(time.Minute), Duration: time.Hour},
+	}, now)
+
+	if len(index.Badges[1]) != 1 || index.Badges[1][0].Text != "MAINTENANCE waw" || !strings.HasSuffix(index.Badges[1][0].Title, "not polled") {
+		t.Errorf("expected a maintenance badge of r1, got %v", index.Badges[1])
+	}
+	if len(index.Badges[2]) != 0 {
+		t.Errorf("expected no badge of r2, got %v", index.Badges[2])
+	}
+}
+
+func TestInspectPageContent(t *testing.T) {
+	dump := func(temp string) []byte {
+		return []byte("0000000000000000000000000000" + temp + "\n" + strings.Repeat(strings.Repeat("0", 32)+"\n", 63))
+	}
+
+	device := storage.Device{ID: 1, Hostname: "hostname"}
+	captures := []storage.Capture{
+		{ID: 3, Interface: "eth0", Data: dump("0380")},
+		{ID: 1, Interface: "eth0", Data: dump("0000")},
+		{ID: 2, Interface: "eth1", Data: []byte("00\n")},
+	}
+
+	tests := []struct {
+		name     string
+		captures []storage.Capture
+		iface    string
+		wantIf   string
+		wantTemp string
+		wantErr  string
+	}{
+		{name: "first interface by default", captures: captures, wantIf: "eth0", wantTemp: "35.00 °C"},
+		{name: "undecodable capture", captures: captures, iface: "eth1", wantIf: "eth1", wantErr: "CANNOT DECODE EEPROM OF eth1"},
+		{name: "unknown interface", captures: captures, iface: "eth2", wantIf: "eth2", wantErr: "NO EEPROM CAPTURED FOR eth2"},
+		{name: "no captures", wantErr: "NO EEPROM CAPTURED YET"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			page := InspectPageContent(device, tc.captures, tc.iface)
+
+			if page.Interface != tc.wantIf {
+				t.Errorf("expected interface %q, got %q", tc.wantIf, page.Interface)
+			}
+			if !strings.HasPrefix(page.ErrorMessage, tc.wantErr) || (tc.wantErr == "") != (page.ErrorMessage == "") {
+				t.Errorf("expected error %q, got %q", tc.wantErr, page.ErrorMessage)
+			}
+
+			if tc.wantTemp != "" {
+				if len(page.Pages) == 0 {
+					t.Fatal("expected pages")
+				}
+				for _, f := range page.Pages[0].Fields {
+					if f.Name == "Temperature monitor" && f.Value != tc.wantTemp {
+						t.Errorf("expected temperature %q, got %q", tc.wantTemp, f.Value)
+					}
+				}
+			}
+		})
+	}
+}
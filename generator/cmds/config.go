@@ -1,11 +1,23 @@
 package cmds
 
+import (
+	"fmt"
+	"slices"
+
+	"gopkg.in/yaml.v3"
+)
+
 type Config struct {
-	Duration int      `yaml:"Duration"`
-	Modules  []Module `yaml:"Modules"`
+	Duration int `yaml:"Duration"`
+	// Seed makes noise and flaps of runs with the same seed identical, 0
+	// seeds runs with the current time.
+	Seed    int64    `yaml:"Seed"`
+	Modules []Module `yaml:"Modules"`
 }
 type Module struct {
 	Interface string `yaml:"Interface"`
+	// Lanes is the number of media lanes, 1-8, 1 when not set.
+	Lanes int `yaml:"Lanes"`
 
 	// CMIS parameters
 	SFF8024Identifier                  int     `yaml:"SFF8024Identifier"`
@@ -41,9 +53,166 @@ type Scenario struct {
 	TxPower     []Step `yaml:"TxPower"`
 	RxPower     []Step `yaml:"RxPower"`
 	Osnr        []Step `yaml:"Osnr"`
+
+	// LaserBias in mA and laser Frequency in MHz, the module's
+	// CurrentLaserFrequencyTxx when not set. Both go without noise.
+	LaserBias []Step `yaml:"LaserBias"`
+	Frequency []Step `yaml:"Frequency"`
+	// ModuleState and DataPathState of lanes without one of their own are
+	// CMIS state machine states, ModuleReady and DPActivated when not set.
+	ModuleState   []State `yaml:"ModuleState"`
+	DataPathState []State `yaml:"DataPathState"`
+	// Vdm are VDM observables besides OSNR.
+	Vdm []VdmSignal `yaml:"Vdm"`
+	// Lanes other than the first one follow the signals above, with noise
+	// of their own, unless a lane scenario of theirs overrides them.
+	Lanes []LaneScenario `yaml:"Lanes"`
+	// Insertions remove the module or swap it for one with another serial
+	// number, the module stays inserted when not set.
+	Insertions []Insertion `yaml:"Insertions"`
+
+	Noise ScenarioNoise `yaml:"Noise"`
+	// Latch keeps flags raised until the module is inserted again, as
+	// latched flags nobody reads, instead of following the signals.
+	Latch bool `yaml:"Latch"`
+	// Fit holds the last value of signals whose steps end before Duration
+	// and cuts the ones running past it, instead of failing.
+	Fit bool `yaml:"Fit"`
 }
 
+// Step shapes a signal for Duration seconds, from the value the previous
+// step ended at towards Endval. Type is one of the Step* shapes, linear by
+// default; the first step starts at its own Endval.
 type Step struct {
+	Type     string  `yaml:"type"`
 	Endval   float64 `yaml:"endval"`
 	Duration int     `yaml:"duration"`
+	// Tau is the time constant of exponential steps in seconds, a fifth of
+	// Duration by default.
+	Tau float64 `yaml:"tau"`
+	// Amplitude and Period in seconds shape sine steps around Endval, one
+	// period takes Duration by default.
+	Amplitude float64 `yaml:"amplitude"`
+	Period    int     `yaml:"period"`
+	// Altval is the value flap steps flip to and back from Endval, with
+	// Probability every second.
+	Altval      float64 `yaml:"altval"`
+	Probability float64 `yaml:"probability"`
+	// Times repeats Steps of repeat steps, which take their duration from
+	// them.
+	Times int    `yaml:"times"`
+	Steps []Step `yaml:"steps"`
+
+	// line is the line of the step in the YAML file, 0 when not known.
+	line int
+}
+
+func (s *Step) UnmarshalYAML(value *yaml.Node) error {
+	type plain Step
+	if err := decodeKnown(value, (*plain)(s), "type", "endval", "duration", "tau", "amplitude", "period", "altval", "probability", "times", "steps"); err != nil {
+		return err
+	}
+	s.line = value.Line
+
+	return nil
+}
+
+// State holds a state of a CMIS state machine, named as in the
+// specification, e.g. ModuleFault or DPDeactivated.
+type State struct {
+	State    string `yaml:"state"`
+	Duration int    `yaml:"duration"`
+
+	line int
+}
+
+func (s *State) UnmarshalYAML(value *yaml.Node) error {
+	type plain State
+	if err := decodeKnown(value, (*plain)(s), "state", "duration"); err != nil {
+		return err
+	}
+	s.line = value.Line
+
+	return nil
+}
+
+// Insertion is the module inserted for Duration seconds, under Serial
+// instead of its own serial number when set, or no module when Removed.
+type Insertion struct {
+	Serial   string `yaml:"serial"`
+	Removed  bool   `yaml:"removed"`
+	Duration int    `yaml:"duration"`
+
+	line int
+}
+
+func (i *Insertion) UnmarshalYAML(value *yaml.Node) error {
+	type plain Insertion
+	if err := decodeKnown(value, (*plain)(i), "serial", "removed", "duration"); err != nil {
+		return err
+	}
+	i.line = value.Line
+
+	return nil
+}
+
+// LaneScenario overrides signals of a lane, 2 to the lane count of the
+// module. TxPower, RxPower and LaserBias steps replace the ones of lane 1,
+// TxOffset and RxOffset in dB are added to either.
+type LaneScenario struct {
+	Lane          int     `yaml:"Lane"`
+	TxPower       []Step  `yaml:"TxPower"`
+	RxPower       []Step  `yaml:"RxPower"`
+	LaserBias     []Step  `yaml:"LaserBias"`
+	TxOffset      float64 `yaml:"TxOffset"`
+	RxOffset      float64 `yaml:"RxOffset"`
+	DataPathState []State `yaml:"DataPathState"`
+
+	line int
+}
+
+func (l *LaneScenario) UnmarshalYAML(value *yaml.Node) error {
+	type plain LaneScenario
+	if err := decodeKnown(value, (*plain)(l), "Lane", "TxPower", "RxPower", "LaserBias", "TxOffset", "RxOffset", "DataPathState"); err != nil {
+		return err
+	}
+	l.line = value.Line
+
+	return nil
+}
+
+// VdmSignal is a VDM observable of a CMIS or C-CMIS type ID on a lane, 1 by
+// default, with values in the unit of the type.
+type VdmSignal struct {
+	Type  int    `yaml:"type"`
+	Lane  int    `yaml:"lane"`
+	Steps []Step `yaml:"steps"`
+
+	line int
+}
+
+func (v *VdmSignal) UnmarshalYAML(value *yaml.Node) error {
+	type plain VdmSignal
+	if err := decodeKnown(value, (*plain)(v), "type", "lane", "steps"); err != nil {
+		return err
+	}
+	v.line = value.Line
+
+	return nil
+}
+
+// decodeKnown decodes the node into out, failing on keys other than the
+// known ones. Decoding a node does not report unknown keys by itself, so
+// misspelled ones are looked for here.
+func decodeKnown(value *yaml.Node, out any, known ...string) error {
+	if value.Kind == yaml.MappingNode {
+		for i := 0; i < len(value.Content); i += 2 {
+			key := value.Content[i]
+			if !slices.Contains(known, key.Value) {
+				return fmt.Errorf("line %d: unknown key %q", key.Line, key.Value)
+			}
+		}
+	}
+
+	return value.Decode(out)
 }
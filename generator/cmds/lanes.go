@@ -0,0 +1,84 @@
+package cmds
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"slices"
+)
+
+// lanes is the number of media lanes of the module.
+func (m *Module) lanes() int {
+	return max(m.Lanes, 1)
+}
+
+// lane are signals of a media lane for every second of a scenario.
+type lane struct {
+	txPower, rxPower, laserBias []float64
+	dataPathState               []byte
+}
+
+// generateLanes returns signals of lanes 2 to the lane count of the module.
+// Lanes follow the base signals of lane 1, before noise, unless their lane
+// scenario overrides them, and get noise of their own.
+func (m *Module) generateLanes(base lane, txNoise, rxNoise Noise, duration int, rng *rand.Rand) ([]lane, error) {
+	if m.Lanes < 0 || m.Lanes > 8 {
+		return nil, fmt.Errorf("%d lanes out of 1-8", m.Lanes)
+	}
+
+	fit := m.Scenario.Fit
+	scenarios := make(map[int]LaneScenario)
+	for _, ls := range m.Scenario.Lanes {
+		at := lineAt(ls.line, fmt.Sprintf("lane %d", ls.Lane))
+		if ls.Lane < 2 || ls.Lane > m.lanes() {
+			return nil, fmt.Errorf("%s: lane %d is not one of lanes 2-%d, lane 1 is the scenario itself", at, ls.Lane, m.lanes())
+		}
+		if _, ok := scenarios[ls.Lane]; ok {
+			return nil, fmt.Errorf("%s: lane %d has another scenario", at, ls.Lane)
+		}
+		scenarios[ls.Lane] = ls
+	}
+
+	lanes := make([]lane, 0, m.lanes()-1)
+	for n := 2; n <= m.lanes(); n++ {
+		ls := scenarios[n]
+		l := lane{
+			txPower:       slices.Clone(base.txPower),
+			rxPower:       slices.Clone(base.rxPower),
+			laserBias:     base.laserBias,
+			dataPathState: base.dataPathState,
+		}
+
+		var err error
+		if len(ls.TxPower) != 0 {
+			if l.txPower, err = generateSteps(ls.TxPower, duration, fit, rng); err != nil {
+				return nil, fmt.Errorf("lane %d: TxPower: %w", n, err)
+			}
+		}
+		if len(ls.RxPower) != 0 {
+			if l.rxPower, err = generateSteps(ls.RxPower, duration, fit, rng); err != nil {
+				return nil, fmt.Errorf("lane %d: RxPower: %w", n, err)
+			}
+		}
+		if len(ls.LaserBias) != 0 {
+			if l.laserBias, err = generateSteps(ls.LaserBias, duration, fit, rng); err != nil {
+				return nil, fmt.Errorf("lane %d: LaserBias: %w", n, err)
+			}
+		}
+		if len(ls.DataPathState) != 0 {
+			if l.dataPathState, err = generateStates(ls.DataPathState, dataPathStates, 0, duration, fit); err != nil {
+				return nil, fmt.Errorf("lane %d: DataPathState: %w", n, err)
+			}
+		}
+
+		for i := range duration {
+			l.txPower[i] += ls.TxOffset
+			l.rxPower[i] += ls.RxOffset
+		}
+		txNoise.apply(l.txPower, rng)
+		rxNoise.apply(l.rxPower, rng)
+
+		lanes = append(lanes, l)
+	}
+
+	return lanes, nil
+}
@@ -0,0 +1,90 @@
+package cmds
+
+import (
+	"math"
+	"math/rand/v2"
+	"strings"
+	"testing"
+)
+
+func TestCreateTimelapse_lanes(t *testing.T) {
+	module := testModule(ScenarioNoise{TxPower: &Noise{Model: NoiseNone}, RxPower: &Noise{Model: NoiseNone}})
+	module.Lanes = 4
+	module.OpticalPowerRxLowWarningThreshold = -20
+	module.Scenario.Lanes = []LaneScenario{
+		{Lane: 2, RxOffset: -1},
+		{
+			Lane:          3,
+			RxPower:       []Step{{Endval: -11, Duration: 5}, {Type: StepHold, Endval: -40, Duration: 5}},
+			DataPathState: []State{{State: "DPActivated", Duration: 5}, {State: "DPDeactivated", Duration: 5}},
+		},
+	}
+
+	timelapse, err := CreateTimelapse(module, 10, rand.New(rand.NewPCG(1, 0)))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := timelapse[0][128+82]; got != 0xF0 {
+		t.Errorf("expected lanes 5-8 unsupported, got media lane information %08b", got)
+	}
+
+	rxPower := func(dump []byte, lane int) float64 {
+		offset := 5*128 + 58 + 2*lane
+		raw := uint16(dump[offset])<<8 | uint16(dump[offset+1])
+		if raw == 0 {
+			return 0
+		}
+
+		return math.Round(10*math.Log10(float64(raw)/10000)*10) / 10
+	}
+
+	for i, dump := range timelapse {
+		failed := i >= 5
+		want := []float64{-11, -12, -11, -11, 0, 0, 0, 0}
+		var lowAlarm, dataPathStates byte = 0, 0x44
+		if failed {
+			want[2] = -40
+			lowAlarm, dataPathStates = 0b0100, 0x41
+		}
+
+		for lane, w := range want {
+			if got := rxPower(dump, lane); got != w {
+				t.Errorf("second %d, lane %d: expected Rx power %v, got %v", i, lane+1, w, got)
+			}
+		}
+		if got := dump[5*128+22]; got != lowAlarm {
+			t.Errorf("second %d: expected Rx power low alarm %08b, got %08b", i, lowAlarm, got)
+		}
+		if got := dump[5*128+1]; got != dataPathStates {
+			t.Errorf("second %d: expected data path states of lanes 3-4 %#x, got %#x", i, dataPathStates, got)
+		}
+	}
+}
+
+func TestCreateTimelapse_wrongLanes(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		lanes int
+		lane  LaneScenario
+		want  string
+	}{
+		{"too many lanes", 9, LaneScenario{}, "Lanes: 9 lanes out of 1-8"},
+		{"first lane", 4, LaneScenario{Lane: 1, line: 12}, "Lanes: line 12: lane 1 is not one of lanes 2-4"},
+		{"missing lane", 2, LaneScenario{Lane: 3, line: 12}, "Lanes: line 12: lane 3 is not one of lanes 2-2"},
+		{"wrong steps", 2, LaneScenario{Lane: 2, TxPower: []Step{{Endval: -10, Duration: 3, line: 14}}}, "Lanes: lane 2: TxPower: line 14: steps take 3 s"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			module := testModule(ScenarioNoise{})
+			module.Lanes = tc.lanes
+			if tc.lane.Lane != 0 {
+				module.Scenario.Lanes = []LaneScenario{tc.lane}
+			}
+
+			_, err := CreateTimelapse(module, 10, rand.New(rand.NewPCG(1, 0)))
+			if err == nil || !strings.HasPrefix(err.Error(), tc.want) {
+				t.Errorf("expected error %q, got %v", tc.want, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package cmds
+
+import (
+	"fmt"
+	"math/rand/v2"
+)
+
+// Noise models of scenario signals.
+const (
+	NoiseNone       = "none"
+	NoiseUniform    = "uniform"
+	NoiseGaussian   = "gaussian"
+	NoiseRandomWalk = "random-walk"
+)
+
+// Noise is added to a signal on every second, Amplitude is in the unit of
+// the signal. Model is uniform when not set. Uniform noise is drawn from [-Amplitude, Amplitude), gaussian
+// noise has a standard deviation of Amplitude and a random walk moves by
+// gaussian steps of it, so it wanders off the scenario over time.
+type Noise struct {
+	Model     string  `yaml:"model"`
+	Amplitude float64 `yaml:"amplitude"`
+}
+
+// ScenarioNoise models noise of scenario signals. Signals without a model
+// get uniform noise of 0.001 V, 1 °C, 0.01 dB of powers and 1 dB of OSNR,
+// unlike the powers jittered in 0.1 µW units and the OSNR jittered with
+// temperature before noise models.
+type ScenarioNoise struct {
+	Voltage     *Noise `yaml:"Voltage"`
+	Temperature *Noise `yaml:"Temperature"`
+	TxPower     *Noise `yaml:"TxPower"`
+	RxPower     *Noise `yaml:"RxPower"`
+	Osnr        *Noise `yaml:"Osnr"`
+}
+
+func orDefault(n *Noise, def Noise) Noise {
+	if n == nil {
+		return def
+	}
+
+	return *n
+}
+
+func (n Noise) validate() error {
+	switch n.Model {
+	case "", NoiseNone, NoiseUniform, NoiseGaussian, NoiseRandomWalk:
+	default:
+		return fmt.Errorf("unknown noise model %q", n.Model)
+	}
+
+	if n.Amplitude < 0 {
+		return fmt.Errorf("negative noise amplitude %v", n.Amplitude)
+	}
+
+	return nil
+}
+
+// apply adds noise drawn from rng to values in place.
+func (n Noise) apply(values []float64, rng *rand.Rand) {
+	var walk float64
+	for i := range values {
+		switch n.Model {
+		case NoiseUniform, "":
+			values[i] += n.Amplitude * (2*rng.Float64() - 1)
+		case NoiseGaussian:
+			values[i] += n.Amplitude * rng.NormFloat64()
+		case NoiseRandomWalk:
+			walk += n.Amplitude * rng.NormFloat64()
+			values[i] += walk
+		}
+	}
+}
@@ -1,57 +1,125 @@
 package cmds
 
 import (
-	"crypto/md5"
+	"fmt"
 	"math"
-	"math/rand"
 )
 
 const (
 	TempMonAlarmThreshold     float64 = 10.0 // in Celsius degrees, added/substracted as High/Low Alarm
 	VccMonAlarmThreshold      float64 = 0.3  // in V, -||-
 	OpticalTxRxAlarmThreshold float64 = 1.33 // multiplicable factor for Optics Alarms
+
+	VdmTypeOSNR byte = 139 // C-CMIS observable type of OSNR in 0.1 dB
 )
 
-func (m *Module) PageLow(temperature float64, vcc float64) (page []byte) {
-	temperature = temperature + 2*rand.Float64() - 1
-	vcc = vcc + rand.Float64()/500 - 0.001
+// Sample is the module in a second of a scenario.
+type Sample struct {
+	Temperature float64
+	Voltage     float64
+	Osnr        float64
+	Frequency   int // in MHz
+	// TxPower, RxPower and LaserBias in mA are of every lane of the module.
+	TxPower   []float64
+	RxPower   []float64
+	LaserBias []float64
+	// ModuleState and DataPathState of host lanes 1-8 are state codes of
+	// the CMIS state machines.
+	ModuleState   byte
+	DataPathState [8]byte
+	// Serial is the vendor serial number, the module's own one when empty.
+	Serial string
+	// Vdm are values of observables of the scenario.
+	Vdm   []float64
+	Flags Flags
+}
+
+// Flags of the module: Vcc and temperature ones of the lower page, Tx and
+// Rx power ones of page 11h as high alarm, low alarm, high warning and low
+// warning, a bit of every lane.
+type Flags struct {
+	Module byte
+	Tx, Rx [4]byte
+}
+
+// flags returns the flags the signals of the sample raise.
+func (m *Module) flags(s Sample) (f Flags) {
+	if s.Voltage < m.VccMonLowWarningThreshold {
+		f.Module |= (0x1 << 7)
+	}
+	if s.Voltage > m.VccMonHighWarningThreshold {
+		f.Module |= (0x1 << 6)
+	}
+	if s.Voltage < (m.VccMonLowWarningThreshold - VccMonAlarmThreshold) {
+		f.Module |= (0x1 << 5)
+	}
+	if s.Voltage > (m.VccMonHighWarningThreshold + VccMonAlarmThreshold) {
+		f.Module |= (0x1 << 4)
+	}
+	if s.Temperature < m.TempMonLowWarningThreshold {
+		f.Module |= (0x1 << 3)
+	}
+	if s.Temperature > m.TempMonHighWarningThreshold {
+		f.Module |= (0x1 << 2)
+	}
+	if s.Temperature < m.TempMonLowWarningThreshold-TempMonAlarmThreshold {
+		f.Module |= (0x1 << 1)
+	}
+	if s.Temperature > m.TempMonHighWarningThreshold+TempMonAlarmThreshold {
+		f.Module |= 0x1
+	}
+
+	for lane, tx := range s.TxPower {
+		raiseLane(&f.Tx, lane, [4]bool{
+			tx > m.OpticalPowerTxHighWarningThreshold*OpticalTxRxAlarmThreshold,
+			tx < m.OpticalPowerTxLowWarningThreshold/OpticalTxRxAlarmThreshold,
+			tx > m.OpticalPowerTxHighWarningThreshold,
+			tx < m.OpticalPowerTxLowWarningThreshold,
+		})
+	}
+	for lane, rx := range s.RxPower {
+		raiseLane(&f.Rx, lane, [4]bool{
+			rx > m.OpticalPowerRxHighWarningThreshold*OpticalTxRxAlarmThreshold,
+			rx < m.OpticalPowerRxLowWarningThreshold/OpticalTxRxAlarmThreshold,
+			rx > m.OpticalPowerRxHighWarningThreshold,
+			rx < m.OpticalPowerRxLowWarningThreshold,
+		})
+	}
+
+	return
+}
+
+// raiseLane sets the bit of the lane in flags raised.
+func raiseLane(flags *[4]byte, lane int, raised [4]bool) {
+	for i, r := range raised {
+		if r {
+			flags[i] |= 1 << lane
+		}
+	}
+}
+
+// latch returns the flags with ones raised in g kept raised.
+func (f Flags) latch(g Flags) Flags {
+	f.Module |= g.Module
+	for i := range 4 {
+		f.Tx[i] |= g.Tx[i]
+		f.Rx[i] |= g.Rx[i]
+	}
+
+	return f
+}
+
+func (m *Module) PageLow(s Sample) (page []byte) {
 	page = append(page, byte(m.SFF8024Identifier)) // SFF8024Identifier
 	page = append(page, byte(m.CmisRevision))      // CmisRevision
 	page = append(page, 0x04)                      // MemoryModel + SteppedConfigOnly + MciMaxSpeed
-	page = append(page, 0b0101)                    // ModuleState
+	page = append(page, s.ModuleState<<1|1)        // ModuleState + InterruptDeasserted
 	page = append(page, make([]byte, 5)...)        // FlagsSummary (Banks and others)
-	var flagsIndicator byte = 0
-	{
-		if vcc < m.VccMonLowWarningThreshold {
-			flagsIndicator = flagsIndicator | (0x1 << 7)
-		}
-		if vcc > m.VccMonHighWarningThreshold {
-			flagsIndicator = flagsIndicator | (0x1 << 6)
-		}
-		if vcc < (m.VccMonLowWarningThreshold - VccMonAlarmThreshold) {
-			flagsIndicator = flagsIndicator | (0x1 << 5)
-		}
-		if vcc > (m.VccMonHighWarningThreshold + VccMonAlarmThreshold) {
-			flagsIndicator = flagsIndicator | (0x1 << 4)
-		}
-		if temperature < m.TempMonLowWarningThreshold {
-			flagsIndicator = flagsIndicator | (0x1 << 3)
-		}
-		if temperature > m.TempMonHighWarningThreshold {
-			flagsIndicator = flagsIndicator | (0x1 << 2)
-		}
-		if temperature < m.TempMonLowWarningThreshold-TempMonAlarmThreshold {
-			flagsIndicator = flagsIndicator | (0x1 << 1)
-		}
-		if temperature > m.TempMonHighWarningThreshold+TempMonAlarmThreshold {
-			flagsIndicator = flagsIndicator | 0x1
-		}
-	}
-	page = append(page, flagsIndicator)     // Latched Flags
-	page = append(page, make([]byte, 4)...) // Aux and Custom Flags
-	tempMonValue := int16(temperature * 256 / 10)
+	page = append(page, s.Flags.Module)            // Latched Flags
+	page = append(page, make([]byte, 4)...)        // Aux and Custom Flags
+	tempMonValue := int16(s.Temperature * 256 / 10)
 	page = append(page, byte(tempMonValue>>8), byte(tempMonValue&0xFF)) // TempMonValue
-	vccMonValue := uint16(vcc * 10000)
+	vccMonValue := uint16(s.Voltage * 10000)
 	page = append(page, byte(vccMonValue>>8), byte(vccMonValue&0xFF))           // VccMonVoltage
 	page = append(page, make([]byte, 14)...)                                    // Aux + Custom + Global Controls
 	page = append(page, 0xFF)                                                   // Module Level Masks (Vcc + Temp)
@@ -67,23 +135,27 @@ func (m *Module) PageLow(temperature float64, vcc float64) (page []byte) {
 	return
 }
 
-func (m *Module) Page00h() (page []byte) {
+func (m *Module) Page00h(s Sample) (page []byte) {
 	page = append(page, byte(m.SFF8024Identifier))
 	vendorName := (append(make([]byte, 0, 16), m.VendorName...))[0:16]
 	page = append(page, vendorName...)                                         // VendorName
 	page = append(page, 0xCC, 0xFA, 0xCE)                                      // VendorOUI
 	page = append(page, append(vendorName[0:4], []byte("xx1234567890")...)...) // VendorPN
 	page = append(page, 0x01, 0x23)                                            // VendorRev
-	page = append(page, append(vendorName[0:4], []byte("xx1234567890")...)...) // VendorSN
-	page = append(page, append([]byte(m.DateCode)[2:8], 0x00, 0x00)...)        // DateCode
-	page = append(page, []byte("BEST_MEMES")...)                               // CLEI
-	page = append(page, 0b11100000, byte(m.MaxPower))                          // ModulePowerCharacteristics
-	page = append(page, 0x00, 0x07)                                            // CableAssemblyLinkLength + ConnectorType
-	page = append(page, make([]byte, 6)...)                                    // Copper Cable Attenuation
-	page = append(page, 0xfe, 0x00, 0x10)                                      // MediaLaneInformation + Cable Assembly Information + MediaInterfaceTechnology
-	page = append(page, make([]byte, 9)...)                                    // Reserved+Custom
-	page = append(page, checksum(page[0:93]))                                  // PageChecksum
-	page = append(page, make([]byte, 33)...)                                   // Custom
+	if s.Serial == "" {
+		page = append(page, append(vendorName[0:4], []byte("xx1234567890")...)...) // VendorSN
+	} else {
+		page = append(page, fmt.Sprintf("%-16s", s.Serial)...) // VendorSN
+	}
+	page = append(page, append([]byte(m.DateCode)[2:8], 0x00, 0x00)...) // DateCode
+	page = append(page, []byte("BEST_MEMES")...)                        // CLEI
+	page = append(page, 0b11100000, byte(m.MaxPower))                   // ModulePowerCharacteristics
+	page = append(page, 0x00, 0x07)                                     // CableAssemblyLinkLength + ConnectorType
+	page = append(page, make([]byte, 6)...)                             // Copper Cable Attenuation
+	page = append(page, byte(0xFF<<m.lanes()), 0x00, 0x10)              // MediaLaneInformation (unsupported lanes) + Cable Assembly Information + MediaInterfaceTechnology
+	page = append(page, make([]byte, 9)...)                             // Reserved+Custom
+	page = append(page, checksum(page[0:94]))                           // PageChecksum
+	page = append(page, make([]byte, 33)...)                            // Custom
 
 	return
 }
@@ -98,17 +170,21 @@ func (m *Module) Page01h() (page []byte) {
 	}
 	page = append(page, make([]byte, 5)...)                           // LengthOMs + Reserved
 	page = append(page, 0x77, 0xDD, 0x00, 0x2F)                       // NominalWavelength + WavelengthTolerance
-	page = append(page, 0b1000000)                                    // Supported Pages Advertising
+	page = append(page, 0b1000000)                                    // Supported Pages Advertising (VdmSupported)
 	page = append(page, 0x04, 0x79, 0x00)                             // Durations Advertising + Module Characteristics Advertising
 	page = append(page, byte(m.ModuleTempMax), byte(m.ModuleTempMin)) // ModuleTemp
 	page = append(page, make([]byte, 7)...)                           // PropagationDelay + OperatingVoltageMin + Others
 	page = append(page, 0b1000000)                                    // TransmitterIsTunable
 	page = append(page, make([]byte, 3)...)                           // Others
-	page = append(page, 0b11, 0b110)                                  // VccMonSupported + TempMonSupported + RxTxOpticalPowerMonSupported
-	page = append(page, make([]byte, 6)...)                           // ???
-	page = append(page, 0x79, 0x14)                                   // MaxDurationModulePwr + MaxDurationDPTxTurn
-	page = append(page, make([]byte, 86)...)                          // MediaLaneAssignment + Custom + Reserved
-	page = append(page, checksum(page[2:126]))                        // checksum
+	var laneMonitors byte = 0b110                                     // RxTxOpticalPowerMonSupported
+	if len(m.Scenario.LaserBias) != 0 {
+		laneMonitors |= 0b1 // TxBiasMonSupported
+	}
+	page = append(page, 0b11, laneMonitors)    // VccMonSupported + TempMonSupported + lane monitors
+	page = append(page, make([]byte, 6)...)    // ???
+	page = append(page, 0x79, 0x14)            // MaxDurationModulePwr + MaxDurationDPTxTurn
+	page = append(page, make([]byte, 86)...)   // MediaLaneAssignment + Custom + Reserved
+	page = append(page, checksum(page[2:127])) // checksum
 
 	return
 }
@@ -159,7 +235,7 @@ func (m *Module) Page02h() (page []byte) {
 	}
 
 	page = append(page, make([]byte, 55)...)   // Reserved + Custom
-	page = append(page, checksum(page[0:126])) // Page checksum
+	page = append(page, checksum(page[0:127])) // Page checksum
 
 	return
 }
@@ -173,70 +249,25 @@ func (m *Module) Page04h() (page []byte) {
 	page = append(page, byte(minPwr>>8), byte(minPwr&0xFF)) // ProgOutputPowerMin
 	page = append(page, byte(maxPwr>>8), byte(maxPwr&0xFF)) // ProgOutputPowerMax
 	page = append(page, make([]byte, 53)...)                // Reserved
-	page = append(page, checksum(page[0:126]))              // Page checksum
+	page = append(page, checksum(page[0:127]))              // Page checksum
 
 	return
 }
 
-func (m *Module) Page11h(txPower float64, rxPower float64) (page []byte) {
-	for i := 0; i < 4; i++ {
-		page = append(page, 0x44) // DPStateHostLane
+func (m *Module) Page11h(s Sample) (page []byte) {
+	for lane := 0; lane < 8; lane += 2 {
+		page = append(page, s.DataPathState[lane+1]<<4|s.DataPathState[lane]) // DPStateHostLane
 	}
 	page = append(page, 0xFF)               // OutputStatusRx
 	page = append(page, make([]byte, 6)...) // OutputStatusTx + Lane-Specific State Changed Flags
-
-	// Tx Flags
-	if txPower > m.OpticalPowerTxHighWarningThreshold*OpticalTxRxAlarmThreshold {
-		page = append(page, 0x01)
-	} else {
-		page = append(page, 0x00)
-	}
-	if txPower < m.OpticalPowerTxLowWarningThreshold/OpticalTxRxAlarmThreshold {
-		page = append(page, 0x01)
-	} else {
-		page = append(page, 0x00)
-	}
-	if txPower > m.OpticalPowerTxHighWarningThreshold {
-		page = append(page, 0x01)
-	} else {
-		page = append(page, 0x00)
-	}
-	if txPower < m.OpticalPowerTxLowWarningThreshold {
-		page = append(page, 0x01)
-	} else {
-		page = append(page, 0x00)
-	}
+	page = append(page, s.Flags.Tx[:]...)   // Tx Power Flags
 	page = append(page, make([]byte, 6)...) // LaserBias + LOS + CDRLOL
+	page = append(page, s.Flags.Rx[:]...)   // Rx Power Flags
 
-	// Rx Flags
-	if rxPower > m.OpticalPowerRxHighWarningThreshold*OpticalTxRxAlarmThreshold {
-		page = append(page, 0x01)
-	} else {
-		page = append(page, 0x00)
-	}
-	if rxPower < m.OpticalPowerRxLowWarningThreshold/OpticalTxRxAlarmThreshold {
-		page = append(page, 0x01)
-	} else {
-		page = append(page, 0x00)
-	}
-	if rxPower > m.OpticalPowerRxHighWarningThreshold {
-		page = append(page, 0x01)
-	} else {
-		page = append(page, 0x00)
-	}
-	if rxPower < m.OpticalPowerRxLowWarningThreshold {
-		page = append(page, 0x01)
-	} else {
-		page = append(page, 0x00)
-	}
-
-	page = append(page, 0x00) // OutputStatusChangedFlagRx
-	txPower01microW := uint16(dbmTo01MicroWatt(txPower) + 2*rand.Float64() - 1)
-	page = append(page, byte(txPower01microW>>8), byte(txPower01microW&0xFF)) // OpticalPowerTx1
-	page = append(page, make([]byte, 30)...)                                  // OpticalPowerTx2-8 + LaserBiasTx
-	rxPower01microW := uint16(dbmTo01MicroWatt(rxPower) + 2*rand.Float64() - 1)
-	page = append(page, byte(rxPower01microW>>8), byte(rxPower01microW&0xFF)) // OpticalPowerRx1
-	page = append(page, make([]byte, 14)...)                                  // OpticalPowerRx2-8
+	page = append(page, 0x00)                                                                   // OutputStatusChangedFlagRx
+	page = append(page, lanesU16(s.TxPower, dbmTo01MicroWatt)...)                               // OpticalPowerTx1-8
+	page = append(page, lanesU16(s.LaserBias, func(mA float64) float64 { return mA * 500 })...) // LaserBiasTx1-8 (2 uA)
+	page = append(page, lanesU16(s.RxPower, dbmTo01MicroWatt)...)                               // OpticalPowerRx1-8
 	for i := 0; i < 4; i++ {
 		page = append(page, 0x11) // ConfigStatusLane
 	}
@@ -250,28 +281,28 @@ func (m *Module) Page11h(txPower float64, rxPower float64) (page []byte) {
 	return
 }
 
-func (m *Module) Page12h() (page []byte) {
-	page = append(page, 0b0101<<4)          // GridSpacingTx1
-	page = append(page, make([]byte, 7)...) // GridSpacingTx2-8
-	channelNumber := int16((m.CurrentLaserFrequencyTxx - 193100000) / 100)
+func (m *Module) Page12h(s Sample) (page []byte) {
+	page = append(page, byte(m.GridSpacingTxx)) // GridSpacingTx1
+	page = append(page, make([]byte, 7)...)     // GridSpacingTx2-8
+	channelNumber := int16(float64(s.Frequency-193100000) / gridSpacingMHz[byte(m.GridSpacingTxx)>>4])
 	page = append(page, byte(channelNumber>>8), byte(channelNumber&0xFF)) // ChannelNumberTx1
 	page = append(page, make([]byte, 30)...)
-	freq := uint32(m.CurrentLaserFrequencyTxx)                                                               // ChannelNumberTx2-8 + FineTuningOffsetTx
+	freq := uint32(s.Frequency)                                                                              // ChannelNumberTx2-8 + FineTuningOffsetTx
 	page = append(page, byte((freq>>24)&0xFF), byte((freq>>16)&0xFF), byte((freq>>8)&0xFF), byte(freq&0xFF)) // CurrentLaserFrequencyTx1
 	page = append(page, make([]byte, 28)...)
-	pwr := int16(m.TargetOutputPowerTxx)
-	page = append(page, byte(pwr>>8), byte(pwr&0xFF)) // TargetOutputPowerTxx1
+	pwr := int16(m.TargetOutputPowerTxx * 100)
+	page = append(page, byte(pwr>>8), byte(pwr&0xFF)) // TargetOutputPowerTxx1 (0.01 dBm)
 	page = append(page, make([]byte, 54)...)          // TargetOutputPowerTxx2-8
 
 	return
 }
 
-func (m *Module) Page25h(osnr float64, temperature float64) (page []byte) {
+func (m *Module) Page25h(s Sample) (page []byte) {
 	page = append(page, make([]byte, 22)...)
-	if osnr == 0.0 { // VDM real-time OSNR
+	if s.Osnr <= 0.0 { // VDM real-time OSNR
 		page = append(page, 0x00, 0x00)
 	} else {
-		modOsnr := uint16(10*osnr + (float64(rand.Intn(2*int(temperature))-int(temperature)) / 3))
+		modOsnr := uint16(10 * s.Osnr)
 		page = append(page, []byte{byte(modOsnr >> 8), byte(modOsnr & 0xFF)}...)
 	}
 	page = append(page, make([]byte, 104)...)
@@ -279,9 +310,56 @@ func (m *Module) Page25h(osnr float64, temperature float64) (page []byte) {
 	return
 }
 
-func checksum(data []byte) byte {
-	cs := md5.Sum(data)
-	return cs[len(cs)-1]
+// VdmPages returns VDM pages 20h-24h, 26h and 27h, which follow page 25h in
+// the dump. Real-time OSNR of lane 1 is descriptor 12 of page 21h, so its
+// sample is the one written by Page25h. Observables of the scenario are
+// descriptors of page 20h with samples on page 24h.
+func (m *Module) VdmPages(s Sample) (pages []byte) {
+	page20h, page24h := make([]byte, 128), make([]byte, 128)
+	for i, v := range m.Scenario.Vdm {
+		page20h[2*i], page20h[2*i+1] = byte(v.lane()-1), byte(v.Type) // ThresholdSetID + Lane, ObservableType
+		sample := vdmEncodings[v.Type](s.Vdm[i])
+		page24h[2*i], page24h[2*i+1] = byte(sample>>8), byte(sample&0xFF)
+	}
+
+	page21h := make([]byte, 128)
+	page21h[22], page21h[23] = 0x00, VdmTypeOSNR // ThresholdSetID + Lane, ObservableType
+
+	pages = append(pages, page20h...)
+	pages = append(pages, page21h...)
+	pages = append(pages, make([]byte, 2*128)...) // Pages 22h, 23h
+	pages = append(pages, page24h...)
+	pages = append(pages, make([]byte, 2*128)...) // Pages 26h, 27h
+
+	return
+}
+
+// gridSpacingMHz maps GridSpacingTx codes (bits 7-4) to channel spacing,
+// channels count from 193.1 THz.
+var gridSpacingMHz = map[byte]float64{
+	0: 3125, 1: 6250, 2: 12500, 3: 25000, 4: 50000, 5: 100000, 6: 33000, 7: 75000,
+}
+
+// checksum is the CMIS page checksum, the low-order 8 bits of the sum of
+// all bytes it covers.
+func checksum(data []byte) (cs byte) {
+	for _, b := range data {
+		cs += b
+	}
+
+	return cs
+}
+
+// lanesU16 returns 16-bit monitors of lanes 1-8, values encoded by the
+// function and 0 of lanes without a value.
+func lanesU16(values []float64, encode func(float64) float64) []byte {
+	monitors := make([]byte, 16)
+	for lane, v := range values {
+		u := uint16(encode(v))
+		monitors[2*lane], monitors[2*lane+1] = byte(u>>8), byte(u&0xFF)
+	}
+
+	return monitors
 }
 
 func dbmTo01MicroWatt(dbm float64) float64 {
@@ -0,0 +1,87 @@
+package cmds
+
+import "fmt"
+
+// States of CMIS state machines by name, as encoded in the lower page and
+// page 11h.
+var (
+	moduleStates = map[string]byte{
+		"ModuleLowPwr": 1,
+		"ModulePwrUp":  2,
+		"ModuleReady":  3,
+		"ModulePwrDn":  4,
+		"ModuleFault":  5,
+	}
+	dataPathStates = map[string]byte{
+		"DPDeactivated": 1,
+		"DPInit":        2,
+		"DPDeinit":      3,
+		"DPActivated":   4,
+		"DPTxTurnOn":    5,
+		"DPTxTurnOff":   6,
+		"DPInitialized": 7,
+	}
+)
+
+// generateStates returns the code of the state of every second of duration,
+// def without states. Fit is as of fitValues.
+func generateStates(states []State, codes map[string]byte, def byte, duration int, fit bool) ([]byte, error) {
+	if len(states) == 0 {
+		return constant(def, duration), nil
+	}
+
+	values := make([]byte, 0, duration)
+	for _, s := range states {
+		code, ok := codes[s.State]
+		if !ok {
+			return nil, fmt.Errorf("%s: unknown state %q", lineAt(s.line, "state"), s.State)
+		}
+		if s.Duration < 0 {
+			return nil, fmt.Errorf("%s: negative duration %d", lineAt(s.line, s.State), s.Duration)
+		}
+
+		for range s.Duration {
+			values = append(values, code)
+		}
+	}
+
+	last := states[len(states)-1]
+
+	return fitValues(values, duration, fit, lineAt(last.line, last.State))
+}
+
+// generateInsertions returns the index of the insertion of every second of
+// duration, all 0 without insertions. Fit is as of fitValues.
+func generateInsertions(insertions []Insertion, duration int, fit bool) ([]int, error) {
+	if len(insertions) == 0 {
+		return constant(0, duration), nil
+	}
+
+	values := make([]int, 0, duration)
+	for i, ins := range insertions {
+		at := lineAt(ins.line, fmt.Sprintf("insertion %d", i+1))
+		if ins.Duration < 0 {
+			return nil, fmt.Errorf("%s: negative duration %d", at, ins.Duration)
+		}
+		if len(ins.Serial) > 16 {
+			return nil, fmt.Errorf("%s: serial number %q longer than 16 characters", at, ins.Serial)
+		}
+
+		for range ins.Duration {
+			values = append(values, i)
+		}
+	}
+
+	last := len(insertions) - 1
+
+	return fitValues(values, duration, fit, lineAt(insertions[last].line, fmt.Sprintf("insertion %d", last+1)))
+}
+
+func constant[T any](v T, duration int) []T {
+	values := make([]T, duration)
+	for i := range values {
+		values[i] = v
+	}
+
+	return values
+}
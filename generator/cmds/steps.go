@@ -0,0 +1,165 @@
+package cmds
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand/v2"
+)
+
+// Shapes of scenario steps.
+const (
+	StepHold   = "hold"
+	StepLinear = "linear"
+	StepExp    = "exp"
+	StepSine   = "sine"
+	StepFlap   = "flap"
+	StepRepeat = "repeat"
+)
+
+// generateSteps returns a value of the signal for every second of duration.
+// Flap steps draw from rng, fit is as of fitValues.
+func generateSteps(steps []Step, duration int, fit bool, rng *rand.Rand) ([]float64, error) {
+	if len(steps) == 0 {
+		return nil, errors.New("no steps")
+	}
+
+	values, err := appendSteps(make([]float64, 0, duration), steps, steps[0].Endval, rng)
+	if err != nil {
+		return nil, err
+	}
+
+	return fitValues(values, duration, fit, stepAt(steps[len(steps)-1]))
+}
+
+// fitValues returns values of a signal lasting duration. With fit, signals
+// ending early hold their last value and ones running late are cut,
+// otherwise both are errors at the location of the last item.
+func fitValues[T any](values []T, duration int, fit bool, at string) ([]T, error) {
+	switch {
+	case len(values) == duration:
+		return values, nil
+	case !fit:
+		return nil, fmt.Errorf("%s: steps take %d s, Duration is %d s, set Fit to fill or truncate them", at, len(values), duration)
+	case len(values) > duration:
+		return values[:duration], nil
+	case len(values) == 0:
+		return nil, fmt.Errorf("%s: steps take no time", at)
+	}
+
+	for hold := values[len(values)-1]; len(values) < duration; {
+		values = append(values, hold)
+	}
+
+	return values, nil
+}
+
+// appendSteps appends values of steps starting from the value from.
+func appendSteps(values []float64, steps []Step, from float64, rng *rand.Rand) ([]float64, error) {
+	for _, step := range steps {
+		if err := step.validate(); err != nil {
+			return nil, fmt.Errorf("%s: %w", stepAt(step), err)
+		}
+
+		if step.Type == StepRepeat {
+			for range step.Times {
+				var err error
+				if values, err = appendSteps(values, step.Steps, from, rng); err != nil {
+					return nil, err
+				}
+				if len(values) != 0 {
+					from = values[len(values)-1]
+				}
+			}
+
+			continue
+		}
+
+		flapped := false
+		for j := range step.Duration {
+			values = append(values, step.value(from, j, &flapped, rng))
+		}
+		if step.Duration != 0 {
+			from = values[len(values)-1]
+		}
+	}
+
+	return values, nil
+}
+
+// value returns the value of the step in second j of it. Flap steps keep
+// whether they are flapped between seconds.
+func (s Step) value(from float64, j int, flapped *bool, rng *rand.Rand) float64 {
+	t := float64(j + 1)
+	switch s.Type {
+	case StepHold:
+		return s.Endval
+	case StepExp:
+		tau := s.Tau
+		if tau == 0 {
+			tau = float64(s.Duration) / 5
+		}
+
+		return s.Endval + (from-s.Endval)*math.Exp(-t/tau)
+	case StepSine:
+		period := s.Period
+		if period == 0 {
+			period = s.Duration
+		}
+
+		return s.Endval + s.Amplitude*math.Sin(2*math.Pi*float64(j)/float64(period))
+	case StepFlap:
+		if rng.Float64() < s.Probability {
+			*flapped = !*flapped
+		}
+		if *flapped {
+			return s.Altval
+		}
+
+		return s.Endval
+	default:
+		return from + (s.Endval-from)*t/float64(s.Duration)
+	}
+}
+
+func (s Step) validate() error {
+	switch s.Type {
+	case "", StepHold, StepLinear, StepExp, StepSine, StepFlap:
+		if s.Duration < 0 {
+			return fmt.Errorf("negative duration %d", s.Duration)
+		}
+	case StepRepeat:
+		if s.Times < 1 || len(s.Steps) == 0 {
+			return errors.New("repeat needs times and steps")
+		}
+		if s.Duration != 0 {
+			return errors.New("repeat takes its duration from its steps")
+		}
+	default:
+		return fmt.Errorf("unknown step type %q", s.Type)
+	}
+
+	if s.Tau < 0 || s.Period < 0 {
+		return errors.New("negative tau or period")
+	}
+	if s.Probability < 0 || s.Probability > 1 {
+		return fmt.Errorf("probability %v out of 0-1", s.Probability)
+	}
+
+	return nil
+}
+
+// stepAt names the step by its line in the YAML file.
+func stepAt(s Step) string {
+	return lineAt(s.line, fmt.Sprintf("step with endval %v", s.Endval))
+}
+
+// lineAt names an item of the YAML file by its line, or by name when the
+// line is not known.
+func lineAt(line int, name string) string {
+	if line == 0 {
+		return name
+	}
+
+	return fmt.Sprintf("line %d", line)
+}
@@ -0,0 +1,162 @@
+package cmds
+
+import (
+	"math"
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestGenerateSteps(t *testing.T) {
+	round := func(values []float64) []float64 {
+		out := make([]float64, len(values))
+		for i, v := range values {
+			out[i] = math.Round(v*1000) / 1000
+		}
+
+		return out
+	}
+
+	for _, tc := range []struct {
+		name     string
+		steps    []Step
+		duration int
+		fit      bool
+		want     []float64
+	}{
+		{
+			name:     "linear",
+			steps:    []Step{{Endval: 1, Duration: 2}, {Endval: 3, Duration: 4}},
+			duration: 6,
+			want:     []float64{1, 1, 1.5, 2, 2.5, 3},
+		},
+		{
+			name:     "hold",
+			steps:    []Step{{Endval: 1, Duration: 2}, {Type: StepHold, Endval: 5, Duration: 2}},
+			duration: 4,
+			want:     []float64{1, 1, 5, 5},
+		},
+		{
+			name:     "exp",
+			steps:    []Step{{Endval: 10, Duration: 1}, {Type: StepExp, Endval: 0, Duration: 3, Tau: 1}},
+			duration: 4,
+			want:     []float64{10, 3.679, 1.353, 0.498},
+		},
+		{
+			name:     "sine",
+			steps:    []Step{{Type: StepSine, Endval: 20, Duration: 4, Amplitude: 5}},
+			duration: 4,
+			want:     []float64{20, 25, 20, 15},
+		},
+		{
+			name:     "repeat",
+			steps:    []Step{{Endval: 0, Duration: 1}, {Type: StepRepeat, Times: 2, Steps: []Step{{Endval: 2, Duration: 2}, {Type: StepHold, Endval: 0, Duration: 1}}}},
+			duration: 7,
+			want:     []float64{0, 1, 2, 0, 1, 2, 0},
+		},
+		{
+			name:     "fill",
+			steps:    []Step{{Endval: 0, Duration: 1}, {Endval: 2, Duration: 2}},
+			duration: 5,
+			fit:      true,
+			want:     []float64{0, 1, 2, 2, 2},
+		},
+		{
+			name:     "truncate",
+			steps:    []Step{{Endval: 0, Duration: 1}, {Endval: 2, Duration: 2}},
+			duration: 2,
+			fit:      true,
+			want:     []float64{0, 1},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := generateSteps(tc.steps, tc.duration, tc.fit, rand.New(rand.NewPCG(1, 0)))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got = round(got); !slices.Equal(got, tc.want) {
+				t.Errorf("expected %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestGenerateSteps_flap(t *testing.T) {
+	steps := []Step{{Type: StepFlap, Endval: -10, Altval: -40, Probability: 0.3, Duration: 100}}
+
+	got, err := generateSteps(steps, 100, false, rand.New(rand.NewPCG(1, 0)))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	flaps := 0
+	for i, v := range got {
+		if v != -10 && v != -40 {
+			t.Fatalf("second %d: unexpected value %v", i, v)
+		}
+		if i > 0 && v != got[i-1] {
+			flaps++
+		}
+	}
+	if flaps == 0 {
+		t.Error("expected the signal to flap")
+	}
+
+	again, _ := generateSteps(steps, 100, false, rand.New(rand.NewPCG(1, 0)))
+	if !slices.Equal(got, again) {
+		t.Error("expected flaps of the same seed to be identical")
+	}
+}
+
+func TestGenerateSteps_errors(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		yaml string
+		want string
+	}{
+		{
+			name: "mismatch",
+			yaml: "- endval: 1\n  duration: 5\n- endval: 2\n  duration: 4\n",
+			want: "line 3: steps take 9 s, Duration is 10 s",
+		},
+		{
+			name: "unknown type",
+			yaml: "- endval: 1\n  duration: 5\n- type: square\n  duration: 5\n",
+			want: `line 3: unknown step type "square"`,
+		},
+		{
+			name: "nested",
+			yaml: "- type: repeat\n  times: 2\n  steps:\n    - endval: 1\n      duration: 5\n    - type: flap\n      probability: 2\n",
+			want: "line 6: probability 2 out of 0-1",
+		},
+		{
+			name: "unknown key",
+			yaml: "- endval: 1\n  duration: 5\n- endval: 2\n  duraton: 5\n",
+			want: `line 4: unknown key "duraton"`,
+		},
+		{
+			name: "repeat with duration",
+			yaml: "- type: repeat\n  duration: 10\n  times: 2\n  steps:\n    - endval: 1\n      duration: 5\n",
+			want: "line 1: repeat takes its duration from its steps",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var steps []Step
+			if err := yaml.Unmarshal([]byte(tc.yaml), &steps); err != nil {
+				if !strings.HasPrefix(err.Error(), tc.want) {
+					t.Errorf("expected error %q, got %v", tc.want, err)
+				}
+
+				return
+			}
+
+			_, err := generateSteps(steps, 10, false, rand.New(rand.NewPCG(1, 0)))
+			if err == nil || !strings.HasPrefix(err.Error(), tc.want) {
+				t.Errorf("expected error %q, got %v", tc.want, err)
+			}
+		})
+	}
+}
@@ -2,71 +2,142 @@ package cmds
 
 import (
 	"fmt"
+	"math"
+	"math/rand/v2"
 	"os"
 	"path"
+	"slices"
 )
 
-func generateSteps(steps []Step, duration int) ([]float64, error) {
-	newSteps := make([]float64, 0, duration)
+// CreateTimelapse returns EEPROM dumps of the module for every second of the
+// scenario, with flaps and noise of signals drawn from rng.
+func CreateTimelapse(module Module, duration int, rng *rand.Rand) (timelapse [][]byte, err error) {
+	scenario := module.Scenario
+	signals := []struct {
+		name  string
+		steps []Step
+		noise Noise
+	}{
+		{"Voltage", scenario.Voltage, orDefault(scenario.Noise.Voltage, Noise{Model: NoiseUniform, Amplitude: 0.001})},
+		{"Temperature", scenario.Temperature, orDefault(scenario.Noise.Temperature, Noise{Model: NoiseUniform, Amplitude: 1})},
+		{"TxPower", scenario.TxPower, orDefault(scenario.Noise.TxPower, Noise{Model: NoiseUniform, Amplitude: 0.01})},
+		{"RxPower", scenario.RxPower, orDefault(scenario.Noise.RxPower, Noise{Model: NoiseUniform, Amplitude: 0.01})},
+		{"Osnr", scenario.Osnr, orDefault(scenario.Noise.Osnr, Noise{Model: NoiseUniform, Amplitude: 1})},
+	}
 
-	for i := 0; i < len(steps); i++ {
-		if i == 0 {
-			for j := 0; j < steps[i].Duration; j++ {
-				newSteps = append(newSteps, steps[i].Endval)
-			}
-		} else {
-			f := func(x float64) float64 {
-				return (steps[i].Endval-steps[i-1].Endval)*(x+1)/float64(steps[i].Duration) + steps[i-1].Endval
-			}
-			for j := 0; j < steps[i].Duration; j++ {
-				newSteps = append(newSteps, f(float64(j)))
-			}
+	lists := make([][]float64, len(signals))
+	for i, s := range signals {
+		if err := s.noise.validate(); err != nil {
+			return nil, fmt.Errorf("%s: %w", s.name, err)
 		}
-	}
 
-	if len(newSteps) != duration {
-		return nil, fmt.Errorf("mismatch between Duration (%d) and steps durations (%d) near %f endval", duration, len(newSteps), newSteps[len(newSteps)-1])
+		if lists[i], err = generateSteps(s.steps, duration, scenario.Fit, rng); err != nil {
+			return nil, fmt.Errorf("%s: %w", s.name, err)
+		}
 	}
+	listVcc, listTemp, listTxPower, listRxPower, listOsnr := lists[0], lists[1], lists[2], lists[3], lists[4]
 
-	return newSteps, nil
-}
+	// Other lanes follow powers of lane 1 before noise.
+	base := lane{txPower: slices.Clone(listTxPower), rxPower: slices.Clone(listRxPower)}
 
-func CreateTimelapse(module Module, duration int) (timelapse [][]byte, err error) {
-	listVcc, err := generateSteps(module.Scenario.Voltage, duration)
-	if err != nil {
-		return nil, err
+	// OSNR of 0 is not reported by the module, noise does not bring it
+	// back.
+	osnr := slices.Clone(listOsnr)
+	for i, s := range signals {
+		s.noise.apply(lists[i], rng)
+	}
+	for i, v := range osnr {
+		if v == 0 {
+			listOsnr[i] = 0
+		}
 	}
 
-	listTemp, err := generateSteps(module.Scenario.Temperature, duration)
+	bias, err := optionalSteps(scenario.LaserBias, 0, duration, scenario.Fit, rng)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("LaserBias: %w", err)
 	}
-
-	listTxPower, err := generateSteps(module.Scenario.TxPower, duration)
+	frequency, err := optionalSteps(scenario.Frequency, float64(module.CurrentLaserFrequencyTxx), duration, scenario.Fit, rng)
+	if err != nil {
+		return nil, fmt.Errorf("Frequency: %w", err)
+	}
+	vdm, err := generateVdm(scenario.Vdm, duration, scenario.Fit, rng)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("Vdm: %w", err)
 	}
 
-	listRxPower, err := generateSteps(module.Scenario.RxPower, duration)
+	moduleState, err := generateStates(scenario.ModuleState, moduleStates, moduleStates["ModuleReady"], duration, scenario.Fit)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("ModuleState: %w", err)
+	}
+	dataPathState, err := generateStates(scenario.DataPathState, dataPathStates, dataPathStates["DPActivated"], duration, scenario.Fit)
+	if err != nil {
+		return nil, fmt.Errorf("DataPathState: %w", err)
+	}
+	insertions, err := generateInsertions(scenario.Insertions, duration, scenario.Fit)
+	if err != nil {
+		return nil, fmt.Errorf("Insertions: %w", err)
 	}
 
-	listOsnr, err := generateSteps(module.Scenario.Osnr, duration)
+	base.laserBias, base.dataPathState = bias, dataPathState
+	lanes, err := module.generateLanes(base, signals[2].noise, signals[3].noise, duration, rng)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("Lanes: %w", err)
 	}
 
+	var latched Flags
 	for i := 0; i < duration; i++ {
+		var insertion Insertion
+		if len(scenario.Insertions) != 0 {
+			insertion = scenario.Insertions[insertions[i]]
+		}
+		if i > 0 && insertions[i] != insertions[i-1] {
+			latched = Flags{}
+		}
+		// A removed module has no EEPROM to read, its dump is empty.
+		if insertion.Removed {
+			timelapse = append(timelapse, []byte{})
+			continue
+		}
+
+		sample := Sample{
+			Temperature: listTemp[i],
+			Voltage:     listVcc[i],
+			Osnr:        listOsnr[i],
+			Frequency:   int(math.Round(frequency[i])),
+			TxPower:     []float64{listTxPower[i]},
+			RxPower:     []float64{listRxPower[i]},
+			LaserBias:   []float64{bias[i]},
+			ModuleState: moduleState[i],
+			Serial:      insertion.Serial,
+		}
+		for j := range sample.DataPathState {
+			sample.DataPathState[j] = dataPathState[i]
+		}
+		for j, l := range lanes {
+			sample.TxPower = append(sample.TxPower, l.txPower[i])
+			sample.RxPower = append(sample.RxPower, l.rxPower[i])
+			sample.LaserBias = append(sample.LaserBias, l.laserBias[i])
+			sample.DataPathState[j+1] = l.dataPathState[i]
+		}
+		for _, values := range vdm {
+			sample.Vdm = append(sample.Vdm, values[i])
+		}
+		sample.Flags = module.flags(sample)
+		if scenario.Latch {
+			sample.Flags = sample.Flags.latch(latched)
+			latched = sample.Flags
+		}
+
 		step := make([]byte, 0)
-		step = append(step, module.PageLow(listTemp[i], listVcc[i])...)
-		step = append(step, module.Page00h()...)
+		step = append(step, module.PageLow(sample)...)
+		step = append(step, module.Page00h(sample)...)
 		step = append(step, module.Page01h()...)
 		step = append(step, module.Page02h()...)
 		step = append(step, module.Page04h()...)
-		step = append(step, module.Page11h(listTxPower[i], listRxPower[i])...)
-		step = append(step, module.Page12h()...)
-		step = append(step, module.Page25h(listOsnr[i], listTemp[i])...)
+		step = append(step, module.Page11h(sample)...)
+		step = append(step, module.Page12h(sample)...)
+		step = append(step, module.Page25h(sample)...)
+		step = append(step, module.VdmPages(sample)...)
 
 		timelapse = append(timelapse, step)
 	}
@@ -74,6 +145,43 @@ func CreateTimelapse(module Module, duration int) (timelapse [][]byte, err error
 	return
 }
 
+// optionalSteps generates steps of a signal, def all the time without them.
+func optionalSteps(steps []Step, def float64, duration int, fit bool, rng *rand.Rand) ([]float64, error) {
+	if len(steps) == 0 {
+		return constant(def, duration), nil
+	}
+
+	return generateSteps(steps, duration, fit, rng)
+}
+
+// generateVdm generates values of every VDM observable.
+func generateVdm(signals []VdmSignal, duration int, fit bool, rng *rand.Rand) ([][]float64, error) {
+	if len(signals) > vdmLength {
+		return nil, fmt.Errorf("%d observables, at most %d fit page 20h", len(signals), vdmLength)
+	}
+
+	values := make([][]float64, len(signals))
+	for i, v := range signals {
+		at := lineAt(v.line, fmt.Sprintf("observable %d", i+1))
+		if _, ok := vdmEncodings[v.Type]; !ok {
+			return nil, fmt.Errorf("%s: unknown observable type %d", at, v.Type)
+		}
+		if v.Lane < 0 || v.Lane > 8 {
+			return nil, fmt.Errorf("%s: lane %d out of 1-8", at, v.Lane)
+		}
+		if v.Type == int(VdmTypeOSNR) && v.lane() == 1 {
+			return nil, fmt.Errorf("%s: OSNR of lane 1 is the Osnr signal", at)
+		}
+
+		var err error
+		if values[i], err = generateSteps(v.Steps, duration, fit, rng); err != nil {
+			return nil, fmt.Errorf("%s: %w", at, err)
+		}
+	}
+
+	return values, nil
+}
+
 func SaveToFile(outputPath string, moduleName string, data [][]byte) error {
 	if err := os.MkdirAll(outputPath, os.ModePerm); err != nil {
 		return err
@@ -0,0 +1,188 @@
+package cmds
+
+import (
+	"bytes"
+	"math"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+func testModule(noise ScenarioNoise) Module {
+	return Module{
+		Interface:                 "eth0",
+		VendorName:                "FibreFiberLtd",
+		DateCode:                  "20220825",
+		ModuleRevision:            0x1000,
+		GridSpacingTxx:            0x50,
+		VccMonLowWarningThreshold: 3.168,
+		Scenario: Scenario{
+			Voltage:     []Step{{Endval: 3.3, Duration: 10}},
+			Temperature: []Step{{Endval: 33, Duration: 5}, {Endval: 38, Duration: 5}},
+			TxPower:     []Step{{Endval: -10, Duration: 10}},
+			RxPower:     []Step{{Endval: -11, Duration: 10}},
+			Osnr:        []Step{{Endval: 23, Duration: 5}, {Endval: 0, Duration: 1}, {Endval: 0, Duration: 4}},
+			Noise:       noise,
+		},
+	}
+}
+
+func TestCreateTimelapse_seed(t *testing.T) {
+	noise := ScenarioNoise{RxPower: &Noise{Model: NoiseRandomWalk, Amplitude: 0.1}, Temperature: &Noise{Model: NoiseGaussian, Amplitude: 0.5}}
+
+	run := func(seed uint64) []byte {
+		timelapse, err := CreateTimelapse(testModule(noise), 10, rand.New(rand.NewPCG(seed, 0)))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		return bytes.Join(timelapse, nil)
+	}
+
+	if !bytes.Equal(run(42), run(42)) {
+		t.Error("expected identical timelapses of the same seed")
+	}
+	if bytes.Equal(run(42), run(43)) {
+		t.Error("expected different timelapses of different seeds")
+	}
+}
+
+func TestCreateTimelapse_noNoise(t *testing.T) {
+	none := &Noise{Model: NoiseNone}
+	timelapse, err := CreateTimelapse(testModule(ScenarioNoise{Voltage: none, Temperature: none, TxPower: none, RxPower: none, Osnr: none}), 10, rand.New(rand.NewPCG(1, 0)))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// TempMonValue of the lower page, PageLow writes it in 10/256 °C.
+	for i, want := range []float64{33, 33, 33, 33, 33, 34, 35, 36, 37, 38} {
+		raw := int16(timelapse[i][14])<<8 | int16(timelapse[i][15])
+		if got := float64(raw) * 10 / 256; math.Abs(got-want) > 0.05 {
+			t.Errorf("second %d: expected temperature %v, got %v", i, want, got)
+		}
+	}
+}
+
+func TestCreateTimelapse_osnrOff(t *testing.T) {
+	timelapse, err := CreateTimelapse(testModule(ScenarioNoise{Osnr: &Noise{Model: NoiseGaussian, Amplitude: 2}}), 10, rand.New(rand.NewPCG(1, 0)))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// OSNR sample of page 25h follows the lower page and pages 00h-02h,
+	// 04h, 11h and 12h.
+	offset := 7*128 + 22
+	for i := range 10 {
+		got := timelapse[i][offset : offset+2]
+		if off := bytes.Equal(got, []byte{0, 0}); off != (i >= 5) {
+			t.Errorf("second %d: unexpected OSNR %x", i, got)
+		}
+	}
+}
+
+func TestCreateTimelapse_wrongNoise(t *testing.T) {
+	_, err := CreateTimelapse(testModule(ScenarioNoise{Voltage: &Noise{Model: "pink"}}), 10, rand.New(rand.NewPCG(1, 0)))
+	if err == nil || err.Error() != `Voltage: unknown noise model "pink"` {
+		t.Errorf("expected unknown noise model error, got %v", err)
+	}
+}
+
+func TestNoise_apply(t *testing.T) {
+	values := func() []float64 { return []float64{1, 1, 1, 1, 1, 1, 1, 1} }
+	rng := rand.New(rand.NewPCG(7, 0))
+
+	got := values()
+	Noise{Model: NoiseNone, Amplitude: 5}.apply(got, rng)
+	for _, v := range got {
+		if v != 1 {
+			t.Errorf("expected no noise, got %v", got)
+			break
+		}
+	}
+
+	got = values()
+	Noise{Model: NoiseUniform, Amplitude: 0.5}.apply(got, rng)
+	for _, v := range got {
+		if v < 0.5 || v >= 1.5 {
+			t.Errorf("expected uniform noise within amplitude, got %v", got)
+			break
+		}
+	}
+
+	// An amplitude without a model is uniform noise.
+	got = values()
+	Noise{Amplitude: 0.5}.apply(got, rng)
+	if slices.Equal(got, values()) {
+		t.Errorf("expected noise without a model, got %v", got)
+	}
+	for _, v := range got {
+		if v < 0.5 || v >= 1.5 {
+			t.Errorf("expected uniform noise within amplitude, got %v", got)
+			break
+		}
+	}
+}
+
+func TestCreateTimelapse_states(t *testing.T) {
+	module := testModule(ScenarioNoise{})
+	module.Scenario.ModuleState = []State{{State: "ModuleReady", Duration: 4}, {State: "ModuleFault", Duration: 6}}
+	module.Scenario.DataPathState = []State{{State: "DPDeactivated", Duration: 10}}
+
+	timelapse, err := CreateTimelapse(module, 10, rand.New(rand.NewPCG(1, 0)))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for i, dump := range timelapse {
+		want := byte(3)
+		if i >= 4 {
+			want = 5
+		}
+		if got := dump[3] >> 1; got != want {
+			t.Errorf("second %d: expected module state %d, got %d", i, want, got)
+		}
+		if got := dump[5*128]; got != 0x11 {
+			t.Errorf("second %d: expected data path states 0x11, got %#x", i, got)
+		}
+	}
+
+	module.Scenario.ModuleState = []State{{State: "ModuleBroken", Duration: 10, line: 7}}
+	if _, err := CreateTimelapse(module, 10, rand.New(rand.NewPCG(1, 0))); err == nil || err.Error() != `ModuleState: line 7: unknown state "ModuleBroken"` {
+		t.Errorf("expected unknown state error, got %v", err)
+	}
+}
+
+func TestCreateTimelapse_insertions(t *testing.T) {
+	module := testModule(ScenarioNoise{RxPower: &Noise{Model: NoiseNone}})
+	module.OpticalPowerRxLowWarningThreshold = -20
+	module.Scenario.RxPower = []Step{{Endval: -11, Duration: 1}, {Type: StepHold, Endval: -30, Duration: 1}, {Type: StepHold, Endval: -11, Duration: 8}}
+	module.Scenario.Insertions = []Insertion{{Duration: 5}, {Removed: true, Duration: 2}, {Serial: "SWAPPED", Duration: 3}}
+	module.Scenario.Latch = true
+
+	timelapse, err := CreateTimelapse(module, 10, rand.New(rand.NewPCG(1, 0)))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Rx power low warning of lane 1, latched until the module is swapped.
+	rxLowWarning := 5*128 + 24
+	for i, dump := range timelapse {
+		switch {
+		case i == 5 || i == 6:
+			if len(dump) != 0 {
+				t.Errorf("second %d: expected no EEPROM of a removed module, got %d bytes", i, len(dump))
+			}
+		case i >= 7:
+			if serial := string(dump[128+38 : 128+54]); serial != "SWAPPED         " {
+				t.Errorf("second %d: unexpected serial number %q", i, serial)
+			}
+			if dump[rxLowWarning] != 0 {
+				t.Errorf("second %d: expected flags cleared by the swap", i)
+			}
+		default:
+			if latched := dump[rxLowWarning] != 0; latched != (i >= 1) {
+				t.Errorf("second %d: unexpected Rx power low warning %x", i, dump[rxLowWarning])
+			}
+		}
+	}
+}
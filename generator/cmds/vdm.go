@@ -0,0 +1,93 @@
+package cmds
+
+import "math"
+
+// vdmEncoding encodes a value of an observable, in the unit of its type, as
+// a 16-bit sample.
+type vdmEncoding func(v float64) uint16
+
+func vdmU16(scale float64) vdmEncoding {
+	return func(v float64) uint16 { return uint16(max(0, min(math.Round(v/scale), math.MaxUint16))) }
+}
+
+func vdmS16(scale float64) vdmEncoding {
+	return func(v float64) uint16 {
+		return uint16(int16(max(math.MinInt16, min(math.Round(v/scale), math.MaxInt16))))
+	}
+}
+
+// vdmF16 is the CMIS floating point format, a 5-bit exponent biased by 24
+// and an 11-bit mantissa: m * 10^(e-24). The smallest exponent keeps the
+// most digits.
+func vdmF16(v float64) uint16 {
+	if v <= 0 {
+		return 0
+	}
+
+	for e := range 32 {
+		if m := math.Round(v / math.Pow10(e-24)); m <= 0x07FF {
+			return uint16(e)<<11 | uint16(m)
+		}
+	}
+
+	return math.MaxUint16
+}
+
+// vdmEncodings of observable types of CMIS 5.2 (1-24) and OIF C-CMIS
+// (128-148) by type ID.
+var vdmEncodings = map[int]vdmEncoding{
+	1:   vdmU16(1),             // laser age, %
+	2:   vdmS16(100.0 / 32767), // TEC current, %
+	3:   vdmS16(10),            // laser frequency error, MHz
+	4:   vdmS16(1.0 / 256),     // laser temperature, °C
+	5:   vdmU16(1.0 / 256),     // eSNR media input, dB
+	6:   vdmU16(1.0 / 256),     // eSNR host input, dB
+	7:   vdmU16(1.0 / 256),     // PAM4 level transition media input, dB
+	8:   vdmU16(1.0 / 256),     // PAM4 level transition host input, dB
+	9:   vdmF16,                // pre-FEC BER minimum media input
+	10:  vdmF16,                // pre-FEC BER minimum host input
+	11:  vdmF16,                // pre-FEC BER maximum media input
+	12:  vdmF16,                // pre-FEC BER maximum host input
+	13:  vdmF16,                // pre-FEC BER average media input
+	14:  vdmF16,                // pre-FEC BER average host input
+	15:  vdmF16,                // pre-FEC BER current media input
+	16:  vdmF16,                // pre-FEC BER current host input
+	17:  vdmF16,                // frame error ratio minimum media input
+	18:  vdmF16,                // frame error ratio minimum host input
+	19:  vdmF16,                // frame error ratio maximum media input
+	20:  vdmF16,                // frame error ratio maximum host input
+	21:  vdmF16,                // frame error ratio average media input
+	22:  vdmF16,                // frame error ratio average host input
+	23:  vdmF16,                // frame error ratio current media input
+	24:  vdmF16,                // frame error ratio current host input
+	128: vdmU16(100.0 / 65535), // modulator bias X/I, %
+	129: vdmU16(100.0 / 65535), // modulator bias X/Q, %
+	130: vdmU16(100.0 / 65535), // modulator bias Y/I, %
+	131: vdmU16(100.0 / 65535), // modulator bias Y/Q, %
+	132: vdmU16(100.0 / 65535), // modulator bias X phase, %
+	133: vdmU16(100.0 / 65535), // modulator bias Y phase, %
+	134: vdmS16(1),             // CD short link, ps/nm
+	135: vdmS16(20),            // CD long link, ps/nm
+	136: vdmU16(0.01),          // DGD, ps
+	137: vdmU16(0.01),          // SOPMD, ps²
+	138: vdmU16(0.1),           // PDL, dB
+	139: vdmU16(0.1),           // OSNR, dB
+	140: vdmU16(0.1),           // eSNR, dB
+	141: vdmS16(1),             // CFO, MHz
+	142: vdmU16(100.0 / 65535), // EVM, %
+	143: vdmS16(0.01),          // Tx power, dBm
+	144: vdmS16(0.01),          // Rx total power, dBm
+	145: vdmS16(0.01),          // Rx signal power, dBm
+	146: vdmU16(1),             // SOP rate of change, krad/s
+	147: vdmU16(0.1),           // MER, dB
+	148: vdmS16(100.0 / 32767), // clock recovery loop, %
+}
+
+// vdmLength is the number of observables of page 20h, their samples are on
+// page 24h.
+const vdmLength = 64
+
+// lane is the 1-based lane of the observable.
+func (v VdmSignal) lane() int {
+	return max(v.Lane, 1)
+}
@@ -0,0 +1,20 @@
+package cmds
+
+import "testing"
+
+func TestVdmEncodings(t *testing.T) {
+	for _, tc := range []struct {
+		typ   int
+		value float64
+		want  uint16
+	}{
+		{139, 23.4, 234},
+		{143, -10.5, 0xFBE6},
+		{15, 1.5e-4, 17<<11 | 1500},
+		{15, 0, 0},
+	} {
+		if got := vdmEncodings[tc.typ](tc.value); got != tc.want {
+			t.Errorf("type %d of %v: expected %#x, got %#x", tc.typ, tc.value, tc.want, got)
+		}
+	}
+}
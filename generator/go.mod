@@ -2,7 +2,7 @@ module pi-wegrzyn/generator
 
 go 1.26.1
 
-require gopkg.in/yaml.v2 v2.4.0
+require gopkg.in/yaml.v3 v3.0.1
 
 require (
 	github.com/kr/pretty v0.3.1 // indirect
@@ -4,13 +4,16 @@ import (
 	"context"
 	"flag"
 	"fmt"
+	"hash/fnv"
 	"log/slog"
+	"math/rand/v2"
 	"os"
 	"path"
+	"time"
 
 	"pi-wegrzyn/generator/cmds"
 
-	"gopkg.in/yaml.v2"
+	"gopkg.in/yaml.v3"
 )
 
 type ctxKey string
@@ -38,9 +41,17 @@ func main() {
 		os.Exit(1)
 	}
 
+	if cfg.Seed == 0 {
+		cfg.Seed = time.Now().UnixNano()
+	}
+	slog.InfoContext(appCtx, "generating timelapses", slog.Int64("seed", cfg.Seed))
+
 	for i := range len(cfg.Modules) {
 		out := path.Join(*outputPath, cfg.Modules[i].Interface)
-		timelapse, err := cmds.CreateTimelapse(cfg.Modules[i], cfg.Duration)
+		// Every module has its own stream keyed by its interface, so adding
+		// or reordering modules does not change noise of the others.
+		rng := rand.New(rand.NewPCG(uint64(cfg.Seed), streamID(cfg.Modules[i].Interface)))
+		timelapse, err := cmds.CreateTimelapse(cfg.Modules[i], cfg.Duration, rng)
 		if err != nil {
 			slog.ErrorContext(appCtx, "cannot generate timelapse", slog.Any("error", err))
 			os.Exit(1)
@@ -53,15 +64,25 @@ func main() {
 	}
 }
 
+// streamID returns the random stream of the module of iface.
+func streamID(iface string) uint64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(iface))
+
+	return h.Sum64()
+}
+
 func readConfig(filename string, out any) error {
-	cfg, err := os.ReadFile(filename)
+	f, err := os.Open(filename)
 	if err != nil {
 		return err
 	}
+	defer f.Close()
 
-	if err = yaml.Unmarshal(cfg, out); err != nil {
-		return err
-	}
+	// Misspelled keys would silently fall back to defaults, they are
+	// reported with their line instead.
+	decoder := yaml.NewDecoder(f)
+	decoder.KnownFields(true)
 
-	return nil
+	return decoder.Decode(out)
 }